@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	accountDelete "github.com/dipress/blog/internal/account/delete"
+	accountExport "github.com/dipress/blog/internal/account/export"
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/storage/postgres"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+)
+
+func TestAccount(t *testing.T) {
+	t.Log("with prepared server")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		ctx, cancel := context.WithTimeout(context.Background(), caseTimeout)
+		defer cancel()
+
+		repo := postgres.NewRepository(db)
+
+		// signup creates a verified user and returns its token.
+		signup := func(username string) string {
+			nu := user.NewUser{
+				Username:     username,
+				Email:        username + "@example.com",
+				PasswordHash: "$2y$12$e4.VBLqKAanAZs10dRL65O8.b0kHBC34pcGCN1HdJIchCi9im40Ei",
+			}
+			var u user.User
+			if _, _, err := repo.CreateUser(ctx, &nu, &u); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := repo.VerifyUser(ctx, u.ID); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			token, err := authenticator.GenerateToken(ctx, auth.NewClaims(u.Username, time.Now(), time.Hour))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return token
+		}
+
+		serve := func(policy string) *http.Server {
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				log.Fatalf("failed to listen: %v", err)
+			}
+			cfg := config(db)
+			cfg.DeletePolicy = policy
+			s := setupServer(lis.Addr().String(), db, authenticator, cfg)
+			go s.Serve(lis)
+			return s
+		}
+
+		do := func(s *http.Server, method, path, body, token string) (*http.Response, []byte) {
+			req, err := http.NewRequest(method, fmt.Sprintf("http://%s%s", s.Addr, path), strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if token != "" {
+				req.Header.Add("Authorization", "Bearer "+token)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			data, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return resp, data
+		}
+
+		anonymize := serve(accountDelete.PolicyAnonymize)
+		defer anonymize.Close()
+
+		token := signup("username89")
+		postStr := `{"title": "my awesome title", "body": "my awesome body"}`
+
+		var p post.Post
+		resp, data := do(anonymize, "POST", "/posts", postStr, token)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+		}
+		if err := p.UnmarshalJSON(data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould export the account data.")
+		{
+			resp, data := do(anonymize, "GET", "/me/export", "", token)
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+			if resp.Header.Get("Content-Disposition") == "" {
+				t.Error("expected attachment")
+			}
+			body := string(data)
+			if !strings.Contains(body, "username89@example.com") || !strings.Contains(body, "my awesome title") {
+				t.Errorf("expected account data in export: %s", body)
+			}
+			if strings.Contains(body, "password_hash") {
+				t.Errorf("unexpected password hash in export: %s", body)
+			}
+
+			var a accountExport.Archive
+			if err := a.UnmarshalJSON(data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(a.Posts) != 1 {
+				t.Errorf("unexpected posts in export: %d expected: %d", len(a.Posts), 1)
+			}
+			for _, section := range []string{`"identities":`, `"personal_tokens":`, `"sessions":`} {
+				if !strings.Contains(body, section) {
+					t.Errorf("expected %s in export: %s", section, body)
+				}
+			}
+		}
+
+		t.Log("\ttest:1\tshould anonymize the account.")
+		{
+			resp, _ := do(anonymize, "DELETE", "/me", "", token)
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:2\tshould not find the anonymized account.")
+		{
+			resp, _ := do(anonymize, "GET", "/users/username89", "", "")
+			if resp.StatusCode != http.StatusNotFound {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusNotFound)
+			}
+		}
+
+		t.Log("\ttest:3\tshould keep posts of the anonymized account.")
+		{
+			resp, _ := do(anonymize, "GET", fmt.Sprintf("/posts/%d", p.ID), "", "")
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		cascade := serve(accountDelete.PolicyCascade)
+		defer cascade.Close()
+
+		token = signup("username90")
+
+		resp, data = do(cascade, "POST", "/posts", postStr, token)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+		}
+		if err := p.UnmarshalJSON(data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:4\tshould delete the account with its content.")
+		{
+			resp, _ := do(cascade, "DELETE", "/me", "", token)
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:5\tshould not find posts of the deleted account.")
+		{
+			resp, _ := do(cascade, "GET", fmt.Sprintf("/posts/%d", p.ID), "", "")
+			if resp.StatusCode != http.StatusNotFound {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusNotFound)
+			}
+		}
+	}
+}
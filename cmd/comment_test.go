@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dipress/blog/internal/comment"
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/storage/postgres"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+)
+
+func TestComments(t *testing.T) {
+	t.Log("with prepared server")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		ctx, cancel := context.WithTimeout(context.Background(), caseTimeout)
+		defer cancel()
+
+		repo := postgres.NewRepository(db)
+
+		nu := user.NewUser{
+			Username:     "username80",
+			Email:        "username80@example.com",
+			PasswordHash: "$2y$12$e4.VBLqKAanAZs10dRL65O8.b0kHBC34pcGCN1HdJIchCi9im40Ei",
+		}
+		var u user.User
+		_, _, err := repo.CreateUser(ctx, &nu, &u)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		np := post.NewPost{
+			UserID: 1,
+			Title:  "my title",
+			Body:   "my body",
+		}
+		var p post.Post
+		if err := repo.CreatePost(ctx, &np, &p); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		nd := post.NewPost{
+			UserID: 1,
+			Title:  "my draft title",
+			Body:   "my body",
+			Status: post.StatusDraft,
+		}
+		var d post.Post
+		if err := repo.CreatePost(ctx, &nd, &d); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		nc := comment.NewComment{
+			PostID: p.ID,
+			UserID: u.ID,
+			Body:   "my comment",
+		}
+		var c comment.Comment
+		if err := repo.CreateComment(ctx, &nc, &c); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		claims := auth.NewClaims(u.Username, time.Now(), time.Hour)
+
+		token, err := authenticator.GenerateToken(ctx, claims)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		token = "Bearer " + token
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
+		go s.Serve(lis)
+		defer s.Close()
+
+		t.Log("\ttest:0\tshould reply to a comment.")
+		{
+			commentStr := fmt.Sprintf(`{"parent_id": %d, "body": "my reply"}`, c.ID)
+			req, err := http.NewRequest("POST", fmt.Sprintf("http://%s/posts/%d/comments", s.Addr, p.ID), strings.NewReader(commentStr))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Add("Authorization", token)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:1\tshould show post comments.")
+		{
+			req, err := http.NewRequest("GET", fmt.Sprintf("http://%s/posts/%d/comments", s.Addr, p.ID), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:2\tshould update a comment.")
+		{
+			commentStr := `{"body": "my updated comment"}`
+			req, err := http.NewRequest("PUT", fmt.Sprintf("http://%s/posts/%d/comments/%d", s.Addr, p.ID, c.ID), strings.NewReader(commentStr))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Add("Authorization", token)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:3\tshould delete a comment.")
+		{
+			req, err := http.NewRequest("DELETE", fmt.Sprintf("http://%s/posts/%d/comments/%d", s.Addr, p.ID, c.ID), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			req.Header.Add("Authorization", token)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:4\tshould not show comments of a draft of another user.")
+		{
+			req, err := http.NewRequest("GET", fmt.Sprintf("http://%s/posts/%d/comments", s.Addr, d.ID), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			req.Header.Add("Authorization", token)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusNotFound {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusNotFound)
+			}
+		}
+
+		t.Log("\ttest:5\tshould not comment a draft of another user.")
+		{
+			commentStr := `{"body": "my comment"}`
+			req, err := http.NewRequest("POST", fmt.Sprintf("http://%s/posts/%d/comments", s.Addr, d.ID), strings.NewReader(commentStr))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Add("Authorization", token)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusNotFound {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusNotFound)
+			}
+		}
+	}
+}
@@ -37,6 +37,10 @@ func TestCreatePost(t *testing.T) {
 			t.Errorf("unexpected error: %v", err)
 		}
 
+		if err := repo.VerifyUser(ctx, u.ID); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
 		claims := auth.NewClaims(u.Username, time.Now(), time.Hour)
 
 		token, err := authenticator.GenerateToken(ctx, claims)
@@ -51,7 +55,7 @@ func TestCreatePost(t *testing.T) {
 			log.Fatalf("failed to listen: %v", err)
 		}
 
-		s := setupServer(lis.Addr().String(), db, authenticator)
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
 		go s.Serve(lis)
 		defer s.Close()
 
@@ -62,7 +62,7 @@ func TestDeletePost(t *testing.T) {
 			log.Fatalf("failed to listen: %v", err)
 		}
 
-		s := setupServer(lis.Addr().String(), db, authenticator)
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
 		go s.Serve(lis)
 		defer s.Close()
 
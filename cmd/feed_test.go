@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/storage/postgres"
+	"github.com/dipress/blog/internal/user"
+)
+
+func TestFeeds(t *testing.T) {
+	t.Log("with prepared server")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		ctx, cancel := context.WithTimeout(context.Background(), caseTimeout)
+		defer cancel()
+
+		repo := postgres.NewRepository(db)
+
+		nu := user.NewUser{
+			Username:     "username97",
+			Email:        "username97@example.com",
+			PasswordHash: "$2y$12$e4.VBLqKAanAZs10dRL65O8.b0kHBC34pcGCN1HdJIchCi9im40Ei",
+		}
+		var u user.User
+		if _, _, err := repo.CreateUser(ctx, &nu, &u); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		np := post.NewPost{
+			UserID: u.ID,
+			Title:  "my feed title",
+			Slug:   post.Slug("my feed title"),
+			Body:   "my body",
+			Tags:   []string{"feeds"},
+		}
+		var p post.Post
+		if err := repo.CreatePost(ctx, &np, &p); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
+		go s.Serve(lis)
+		defer s.Close()
+
+		get := func(path, etag string) *http.Response {
+			req, err := http.NewRequest("GET", fmt.Sprintf("http://%s%s", s.Addr, path), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if etag != "" {
+				req.Header.Set("If-None-Match", etag)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+			return resp
+		}
+
+		t.Log("\ttest:0\tshould serve feeds in all formats.")
+		{
+			for _, path := range []string{
+				"/feed.rss",
+				"/feed.atom",
+				"/feed.json",
+				"/users/username97/feed.atom",
+				"/tags/feeds/feed.rss",
+			} {
+				resp := get(path, "")
+				if resp.StatusCode != http.StatusOK {
+					t.Errorf("unexpected status code of %s: %d expected: %d", path, resp.StatusCode, http.StatusOK)
+				}
+			}
+		}
+
+		t.Log("\ttest:1\tshould not send unchanged feeds again.")
+		{
+			resp := get("/feed.json", "")
+			resp = get("/feed.json", resp.Header.Get("ETag"))
+			if resp.StatusCode != http.StatusNotModified {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusNotModified)
+			}
+		}
+
+		t.Log("\ttest:2\tshould not find feeds of unknown authors.")
+		{
+			resp := get("/users/unknown97/feed.rss", "")
+			if resp.StatusCode != http.StatusNotFound {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusNotFound)
+			}
+		}
+	}
+}
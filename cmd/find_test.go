@@ -33,12 +33,23 @@ func TestFindPost(t *testing.T) {
 			t.Errorf("unexpected error: %v", err)
 		}
 
+		draft := post.NewPost{
+			UserID: 10,
+			Title:  "my draft",
+			Body:   "my body",
+			Status: post.StatusDraft,
+		}
+		var d post.Post
+		if err := repo.CreatePost(ctx, &draft, &d); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
 		lis, err := net.Listen("tcp", "127.0.0.1:0")
 		if err != nil {
 			log.Fatalf("failed to listen: %v", err)
 		}
 
-		s := setupServer(lis.Addr().String(), db, authenticator)
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
 		go s.Serve(lis)
 		defer s.Close()
 
@@ -57,5 +68,95 @@ func TestFindPost(t *testing.T) {
 				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
 			}
 		}
+
+		t.Log("\ttest:1\tshould hide a draft from anonymous readers.")
+		{
+			req, err := http.NewRequest("GET", fmt.Sprintf("http://%s/posts/%d", s.Addr, d.ID), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusNotFound {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusNotFound)
+			}
+		}
+	}
+}
+
+func TestFindPostBySlug(t *testing.T) {
+	t.Log("with prepared server")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		ctx, cancel := context.WithTimeout(context.Background(), caseTimeout)
+		defer cancel()
+
+		repo := postgres.NewRepository(db)
+
+		np := post.NewPost{
+			UserID: 11,
+			Title:  "my former title",
+			Slug:   post.Slug("my former title"),
+			Body:   "my body",
+		}
+		var p post.Post
+		if err := repo.CreatePost(ctx, &np, &p); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		p.Title = "my current title"
+		p.Slug = post.Slug(p.Title)
+		if err := repo.UpdatePost(ctx, p.ID, &p, nil); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
+		go s.Serve(lis)
+		defer s.Close()
+
+		client := http.Client{
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+
+		t.Log("\ttest:0\tshould find a post by the slug.")
+		{
+			resp, err := client.Get(fmt.Sprintf("http://%s/posts/by-slug/my-current-title", s.Addr))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:1\tshould redirect from the former slug.")
+		{
+			resp, err := client.Get(fmt.Sprintf("http://%s/posts/by-slug/my-former-title", s.Addr))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusMovedPermanently {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusMovedPermanently)
+			}
+			if location := resp.Header.Get("Location"); location != "/posts/by-slug/my-current-title" {
+				t.Errorf("unexpected location: %s", location)
+			}
+		}
 	}
 }
@@ -37,7 +37,7 @@ func TestListPost(t *testing.T) {
 		if err != nil {
 			log.Fatalf("failed to listen: %v", err)
 		}
-		s := setupServer(lis.Addr().String(), db, authenticator)
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
 		go s.Serve(lis)
 		defer s.Close()
 
@@ -56,5 +56,37 @@ func TestListPost(t *testing.T) {
 				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
 			}
 		}
+
+		t.Log("\ttest:1\tshould show a page of user posts.")
+		{
+			req, err := http.NewRequest("GET", fmt.Sprintf("http://%s/posts?limit=1&user_id=%d", s.Addr, p.UserID), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:2\tshould reject a broken cursor.")
+		{
+			req, err := http.NewRequest("GET", fmt.Sprintf("http://%s/posts?after=broken", s.Addr), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusBadRequest)
+			}
+		}
 	}
 }
@@ -1,31 +1,69 @@
 package main
 
 import (
-	"crypto/rsa"
+	"context"
 	"database/sql"
 	"flag"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	jwt "github.com/dgrijalva/jwt-go"
+	accountDelete "github.com/dipress/blog/internal/account/delete"
 	httpBroker "github.com/dipress/blog/internal/broker/http"
+	"github.com/dipress/blog/internal/notify"
+	"github.com/dipress/blog/internal/oidc"
+	"github.com/dipress/blog/internal/publish"
+	"github.com/dipress/blog/internal/storage/postgres"
 	"github.com/dipress/blog/internal/storage/postgres/schema"
+	"github.com/dipress/blog/internal/trash/purge"
+	"github.com/dipress/blog/internal/user"
 	authEng "github.com/dipress/blog/kit/auth"
+	oidcEng "github.com/dipress/blog/kit/oidc"
+	"github.com/dipress/blog/kit/ratelimit"
 	"github.com/mattes/migrate"
 	"github.com/pkg/errors"
 )
 
 const (
-	alg = "RS256"
+	revocationsSize = 10000
+	attemptsSize    = 10000
 )
 
 func main() {
 	var (
 		addr           = flag.String("addr", ":8080", "address of http server")
 		dsn            = flag.String("dsn", "", "postgres database DSN")
-		privateKeyFile = flag.String("key", "./kit/keys/demo.rsa", "private key file path")
-		keyID          = flag.String("id", "123456", "private key id")
+		keysDir        = flag.String("keys", "./kit/keys", "directory of <kid>.rsa or <kid>.pem private keys, reloaded on SIGHUP")
+		keysGrace      = flag.Duration("keys-grace", time.Hour, "how long superseded keys verify tokens")
+		privateKeyFile = flag.String("key", "./kit/keys/demo.rsa", "RSA, ECDSA P-256 or Ed25519 private key file path, used when keys directory is empty")
+		keyID          = flag.String("id", "123456", "private key id, used when keys directory is empty")
+		publishEvery   = flag.Duration("publish", time.Minute, "interval of scheduled posts publishing")
+		purgeEvery     = flag.Duration("purge", time.Hour, "interval of trash purging")
+		retention      = flag.Duration("retention", 30*24*time.Hour, "how long deleted posts stay in trash")
+		admin          = flag.String("admin", "", "username to promote to administrator")
+		revocations    = flag.String("revocations", "postgres", "revoked tokens store: postgres or memory")
+		attempts       = flag.String("attempts", "memory", "failed sign in attempts store: memory or postgres for multiple instances")
+		smtpAddr       = flag.String("smtp-addr", "", "address of smtp server, emails are written to stdout when empty")
+		smtpFrom       = flag.String("smtp-from", "blog@localhost", "sender address of emails")
+		smtpUser       = flag.String("smtp-user", "", "smtp username")
+		smtpPassword   = flag.String("smtp-password", "", "smtp password")
+		rateReads      = flag.String("rate-reads", httpBroker.DefaultRateLimits.Reads.String(), "reading requests per client as <requests>/<period>, 0 disables")
+		rateWrites     = flag.String("rate-writes", httpBroker.DefaultRateLimits.Writes.String(), "changing requests per client as <requests>/<period>, 0 disables")
+		rateAuth       = flag.String("rate-auth", httpBroker.DefaultRateLimits.Auth.String(), "sign up and sign in requests per client as <requests>/<period>, 0 disables")
+		rateIP         = flag.String("rate-ip", httpBroker.DefaultRateLimits.IP.String(), "all requests per address before authentication as <requests>/<period>, 0 disables")
+		deletePolicy   = flag.String("delete-policy", accountDelete.PolicyAnonymize, "what happens to content of deleted accounts: anonymize or cascade")
+		oidcName       = flag.String("oidc-name", "oidc", "name of the OpenID Connect provider in login URLs")
+		oidcIssuer     = flag.String("oidc-issuer", "", "issuer URL of the OpenID Connect provider, login with it is disabled when empty")
+		oidcClientID   = flag.String("oidc-client-id", "", "client id registered at the OpenID Connect provider")
+		oidcSecret     = flag.String("oidc-client-secret", "", "client secret registered at the OpenID Connect provider")
+		oidcRedirect   = flag.String("oidc-redirect-url", "", "URL of /oidc/<name>/callback registered at the OpenID Connect provider")
+		frontend       = flag.Bool("frontend", false, "serve HTML pages to read and write posts in the browser")
+		baseURL        = flag.String("base-url", "http://localhost:8080", "scheme and host of the site in absolute URLs of feeds, sitemaps and pages")
+		trustedProxies = flag.String("trusted-proxies", "", "comma separated addresses or CIDR networks of proxies whose X-Forwarded-For is trusted")
 	)
 	flag.Parse()
 
@@ -44,28 +82,174 @@ func main() {
 	}
 
 	// Authentication setup.
-	keyContents, err := ioutil.ReadFile(*privateKeyFile)
-	if err != nil {
-		log.Fatalf("reading auth private key: %v", err)
+	var authenticator *authEng.Authenticator
+	if *keysDir != "" {
+		ring, err := authEng.NewKeyRing(*keysDir, *keysGrace)
+		if err != nil {
+			log.Fatalf("loading auth key ring: %v", err)
+		}
+
+		authenticator, err = authEng.NewRingAuthenticator(ring)
+		if err != nil {
+			log.Fatalf("constructing authenticator: %v", err)
+		}
+
+		// Rotate keys on SIGHUP.
+		go reloadKeys(ring)
+	} else {
+		keyContents, err := ioutil.ReadFile(*privateKeyFile)
+		if err != nil {
+			log.Fatalf("reading auth private key: %v", err)
+		}
+
+		key, err := authEng.ParsePrivateKey(keyContents)
+		if err != nil {
+			log.Fatalf("parsing auth private key: %v", err)
+		}
+		alg, err := authEng.AlgorithmForKey(key.Public())
+		if err != nil {
+			log.Fatalf("detecting auth key algorithm: %v", err)
+		}
+		publicKeyLookup := authEng.NewSingleKeyFunc(*keyID, key.Public())
+		authenticator, err = authEng.NewAuthenticator(key, *keyID, alg, publicKeyLookup)
+		if err != nil {
+			log.Fatalf("constructing authenticator: %v", err)
+		}
 	}
 
-	key, err := jwt.ParseRSAPrivateKeyFromPEM(keyContents)
+	// Background jobs setup.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	repo := postgres.NewRepository(db)
+
+	// Promote the administrator if requested.
+	if *admin != "" {
+		var u user.User
+		if err := repo.FindByUsername(ctx, *admin, &u); err != nil {
+			log.Fatalf("finding admin user: %v", err)
+		}
+		if err := repo.UpdateRole(ctx, u.ID, user.RoleAdmin); err != nil {
+			log.Fatalf("promoting admin user: %v", err)
+		}
+	}
+
+	// Give the posts left by the slugs migration the slugs of their titles.
+	if n, err := repo.BackfillPostSlugs(ctx); err != nil {
+		log.Printf("backfill post slugs: %v\n", err)
+	} else if n > 0 {
+		log.Printf("backfilled %d post slugs\n", n)
+	}
+
+	// Publish scheduled posts in background.
+	publisher := publish.NewService(repo)
+	go publisher.Run(ctx, *publishEvery)
+
+	// Purge the trash in background.
+	purger := purge.NewService(repo, *retention)
+	go purger.Run(ctx, *purgeEvery)
+
+	// Revoked tokens store setup.
+	var store authEng.RevocationStore = repo
+	switch *revocations {
+	case "postgres":
+	case "memory":
+		store = authEng.NewMemoryStore(revocationsSize)
+	default:
+		log.Fatalf("unknown revocations store %q", *revocations)
+	}
+
+	// Failed sign in attempts store setup.
+	var attemptStore authEng.AttemptStore = repo
+	switch *attempts {
+	case "postgres":
+	case "memory":
+		attemptStore = authEng.NewMemoryAttempts(attemptsSize)
+	default:
+		log.Fatalf("unknown attempts store %q", *attempts)
+	}
+
+	// Notifier setup.
+	var notifier notify.Notifier = notify.NewLog(os.Stdout)
+	if *smtpAddr != "" {
+		notifier, err = notify.NewSMTP(*smtpAddr, *smtpFrom, *smtpUser, *smtpPassword)
+		if err != nil {
+			log.Fatalf("constructing smtp notifier: %v", err)
+		}
+	}
+
+	// Account deletion policy setup.
+	switch *deletePolicy {
+	case accountDelete.PolicyAnonymize, accountDelete.PolicyCascade:
+	default:
+		log.Fatalf("unknown delete policy %q", *deletePolicy)
+	}
+
+	// Rate limits setup.
+	var limits httpBroker.RateLimits
+	for _, l := range []struct {
+		rate  *ratelimit.Rate
+		value string
+	}{
+		{rate: &limits.Reads, value: *rateReads},
+		{rate: &limits.Writes, value: *rateWrites},
+		{rate: &limits.Auth, value: *rateAuth},
+		{rate: &limits.IP, value: *rateIP},
+	} {
+		if *l.rate, err = ratelimit.ParseRate(l.value); err != nil {
+			log.Fatalf("parsing rate limit: %v", err)
+		}
+	}
+
+	// OpenID Connect providers setup.
+	providers := make(map[string]oidc.Provider)
+	if *oidcIssuer != "" {
+		providers[*oidcName] = oidcEng.NewProvider(*oidcIssuer, *oidcClientID, *oidcSecret, *oidcRedirect)
+	}
+
+	// Trusted proxies setup.
+	proxies, err := httpBroker.ParseProxies(*trustedProxies)
 	if err != nil {
-		log.Fatalf("parsing auth private key: %v", err)
+		log.Fatalf("parsing trusted proxies: %v", err)
 	}
-	publicKeyLookup := authEng.NewSingleKeyFunc(*keyID, key.Public().(*rsa.PublicKey))
-	authenticator, err := authEng.NewAuthenticator(key, *keyID, alg, publicKeyLookup)
+
+	// Site base URL setup.
+	base, err := httpBroker.ParseBaseURL(*baseURL)
 	if err != nil {
-		log.Fatalf("constructing authenticator: %v", err)
+		log.Fatalf("parsing base url: %v", err)
 	}
 
 	// Setup handlers.
-	srv := setupServer(*addr, db, authenticator)
+	srv := setupServer(*addr, db, authenticator, httpBroker.Config{
+		Revocations:    store,
+		Attempts:       attemptStore,
+		Notifier:       notifier,
+		DeletePolicy:   *deletePolicy,
+		RateLimits:     limits,
+		Providers:      providers,
+		Frontend:       *frontend,
+		TrustedProxies: proxies,
+		BaseURL:        base,
+	})
 	if err := srv.ListenAndServe(); err != nil {
 		errors.Wrap(err, "filed to serve http")
 	}
 }
 
-func setupServer(addr string, db *sql.DB, authenticator *authEng.Authenticator) *http.Server {
-	return httpBroker.NewServer(addr, db, authenticator)
+// reloadKeys reloads the key ring every time the process gets SIGHUP.
+func reloadKeys(ring *authEng.KeyRing) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	for range hup {
+		if err := ring.Load(); err != nil {
+			log.Printf("reloading auth keys: %v", err)
+			continue
+		}
+		kid, _ := ring.SigningKey()
+		log.Printf("auth keys reloaded, signing with %q", kid)
+	}
+}
+
+func setupServer(addr string, db *sql.DB, authenticator *authEng.Authenticator, cfg httpBroker.Config) *http.Server {
+	return httpBroker.NewServer(addr, db, authenticator, cfg)
 }
@@ -1,18 +1,25 @@
 package main
 
 import (
-	"crypto/rsa"
+	"bytes"
 	"database/sql"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
+	"regexp"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	txdb "github.com/DATA-DOG/go-txdb"
-	jwt "github.com/dgrijalva/jwt-go"
+	accountDelete "github.com/dipress/blog/internal/account/delete"
+	httpBroker "github.com/dipress/blog/internal/broker/http"
+	"github.com/dipress/blog/internal/notify"
+	"github.com/dipress/blog/internal/oidc"
+	"github.com/dipress/blog/internal/storage/postgres"
 	"github.com/dipress/blog/internal/storage/postgres/schema"
 	"github.com/dipress/blog/kit/auth"
 	"github.com/dipress/blog/kit/docker"
@@ -26,8 +33,58 @@ const (
 var (
 	db            *sql.DB
 	authenticator *auth.Authenticator
+	mails         mailbox
+	notifier      = notify.NewLog(&mails)
+	deletePolicy  = accountDelete.PolicyAnonymize
+	attempts      = auth.NewMemoryAttempts(attemptsSize)
+	rateLimits    = httpBroker.DefaultRateLimits
+	providers     map[string]oidc.Provider
+	baseURL       = "https://blog.example.com"
 )
 
+// mailbox keeps emails written by the log notifier.
+type mailbox struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Write implements io.Writer interface.
+func (m *mailbox) Write(p []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.buf.Write(p)
+}
+
+var tokenRegexp = regexp.MustCompile(`[\w-]+\.[\w-]+\.[\w-]+`)
+
+// token returns the last token sent to the email.
+func (m *mailbox) token(to string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var tkn string
+	for _, msg := range strings.Split(m.buf.String(), "To: ")[1:] {
+		if strings.HasPrefix(msg, to+"\n") {
+			tkn = tokenRegexp.FindString(msg[len(to):])
+		}
+	}
+	return tkn
+}
+
+// config returns settings of the server shared by tests.
+// Revoked tokens are kept in the database of the test.
+func config(db *sql.DB) httpBroker.Config {
+	return httpBroker.Config{
+		Revocations:  postgres.NewRepository(db),
+		Attempts:     attempts,
+		Notifier:     notifier,
+		DeletePolicy: deletePolicy,
+		RateLimits:   rateLimits,
+		Providers:    providers,
+		BaseURL:      baseURL,
+	}
+}
+
 func TestMain(m *testing.M) {
 	flag.Parse()
 
@@ -58,11 +115,15 @@ func TestMain(m *testing.M) {
 		log.Fatalf("reading auth private key: %v", err)
 	}
 
-	key, err := jwt.ParseRSAPrivateKeyFromPEM(keyContents)
+	key, err := auth.ParsePrivateKey(keyContents)
 	if err != nil {
 		log.Fatalf("parsing auth private key: %v", err)
 	}
-	publicKeyLookup := auth.NewSingleKeyFunc("12345", key.Public().(*rsa.PublicKey))
+	alg, err := auth.AlgorithmForKey(key.Public())
+	if err != nil {
+		log.Fatalf("detecting auth key algorithm: %v", err)
+	}
+	publicKeyLookup := auth.NewSingleKeyFunc("12345", key.Public())
 	ac, err := auth.NewAuthenticator(key, "12345", alg, publicKeyLookup)
 	if err != nil {
 		log.Fatalf("constructing authenticator: %v", err)
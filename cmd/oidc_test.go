@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"testing"
+	"time"
+
+	authService "github.com/dipress/blog/internal/auth"
+	"github.com/dipress/blog/internal/oidc"
+	"github.com/dipress/blog/internal/storage/postgres"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	oidcEng "github.com/dipress/blog/kit/oidc"
+	"github.com/dipress/blog/kit/oidc/oidctest"
+)
+
+func TestOIDCLogin(t *testing.T) {
+	t.Log("with prepared server and provider")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		ctx, cancel := context.WithTimeout(context.Background(), caseTimeout)
+		defer cancel()
+
+		repo := postgres.NewRepository(db)
+
+		provider := oidctest.NewServer("blog", "secret")
+		defer provider.Close()
+
+		provider.SignIn(oidctest.User{
+			Subject:           "94",
+			Email:             "username94@example.com",
+			EmailVerified:     true,
+			PreferredUsername: "username94",
+		})
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+
+		redirectURL := fmt.Sprintf("http://%s/oidc/example/callback", lis.Addr())
+		cfg := config(db)
+		cfg.Providers = map[string]oidc.Provider{
+			"example": oidcEng.NewProvider(provider.URL, "blog", "secret", redirectURL),
+		}
+		s := setupServer(lis.Addr().String(), db, authenticator, cfg)
+		go s.Serve(lis)
+		defer s.Close()
+
+		// The browser follows redirects to the provider and back
+		// and keeps the state cookie.
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		browser := &http.Client{Jar: jar}
+
+		get := func(client *http.Client, url string) ([]byte, int) {
+			resp, err := client.Get(url)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			data, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return data, resp.StatusCode
+		}
+
+		t.Log("\ttest:0\tshould create a user of the identity.")
+		{
+			data, code := get(browser, fmt.Sprintf("http://%s/oidc/example/login", s.Addr))
+			if code != http.StatusOK {
+				t.Fatalf("unexpected status code: %d expected: %d", code, http.StatusOK)
+			}
+
+			var tkn authService.Token
+			if err := tkn.UnmarshalJSON(data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tkn.Token == "" || tkn.RefreshToken == "" {
+				t.Error("expected tokens")
+			}
+
+			var u user.User
+			if err := repo.FindByUsername(ctx, "username94", &u); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if u.VerifiedAt == nil {
+				t.Error("expected verified user")
+			}
+		}
+
+		t.Log("\ttest:1\tshould sign in the user of the identity.")
+		{
+			_, code := get(browser, fmt.Sprintf("http://%s/oidc/example/login", s.Addr))
+			if code != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", code, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:2\tshould not link the identity to another user.")
+		{
+			nu := user.NewUser{
+				Username:     "username95",
+				Email:        "username95@example.com",
+				PasswordHash: "$2y$12$e4.VBLqKAanAZs10dRL65O8.b0kHBC34pcGCN1HdJIchCi9im40Ei",
+			}
+			var u user.User
+			if _, _, err := repo.CreateUser(ctx, &nu, &u); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			token, err := authenticator.GenerateToken(ctx, auth.NewClaims(u.Username, time.Now(), time.Hour))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			req, err := http.NewRequest("POST", fmt.Sprintf("http://%s/me/identities/example", s.Addr), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			req.Header.Add("Authorization", "Bearer "+token)
+
+			resp, err := browser.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			data, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var r oidc.Redirect
+			if err := r.UnmarshalJSON(data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			_, code := get(browser, r.URL)
+			if code != http.StatusConflict {
+				t.Errorf("unexpected status code: %d expected: %d", code, http.StatusConflict)
+			}
+		}
+
+		t.Log("\ttest:3\tshould reject an unknown state.")
+		{
+			_, code := get(browser, fmt.Sprintf("http://%s/oidc/example/callback?code=code&state=unknown", s.Addr))
+			if code != http.StatusUnauthorized {
+				t.Errorf("unexpected status code: %d expected: %d", code, http.StatusUnauthorized)
+			}
+		}
+
+		t.Log("\ttest:4\tshould not complete the sign in started in another browser.")
+		{
+			_, code := get(http.DefaultClient, fmt.Sprintf("http://%s/oidc/example/login", s.Addr))
+			if code != http.StatusUnauthorized {
+				t.Errorf("unexpected status code: %d expected: %d", code, http.StatusUnauthorized)
+			}
+		}
+	}
+}
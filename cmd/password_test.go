@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dipress/blog/internal/reg"
+)
+
+func TestResetPassword(t *testing.T) {
+	t.Log("with prepared server")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
+		go s.Serve(lis)
+		defer s.Close()
+
+		do := func(method, path, body string) (*http.Response, []byte) {
+			req, err := http.NewRequest(method, fmt.Sprintf("http://%s%s", s.Addr, path), strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			data, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return resp, data
+		}
+
+		var signed reg.Token
+		resp, data := do("POST", "/signup", `{"username": "username87", "email": "username87@example.com", "password": "password123"}`)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+		}
+		if err := signed.UnmarshalJSON(data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould accept unknown emails.")
+		{
+			resp, _ := do("POST", "/password/forgot", `{"email": "unknown@example.com"}`)
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:1\tshould send the reset token.")
+		{
+			resp, _ := do("POST", "/password/forgot", `{"email": "username87@example.com"}`)
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		reset := mails.token("username87@example.com")
+		if reset == "" {
+			t.Fatal("expected reset email")
+		}
+		resetStr := fmt.Sprintf(`{"token": %q, "password": "newpassword123"}`, reset)
+
+		t.Log("\ttest:2\tshould reset the password.")
+		{
+			resp, _ := do("POST", "/password/reset", resetStr)
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:3\tshould reject the used token.")
+		{
+			resp, _ := do("POST", "/password/reset", resetStr)
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusUnauthorized)
+			}
+		}
+
+		t.Log("\ttest:4\tshould revoke refresh tokens.")
+		{
+			resp, _ := do("POST", "/token/refresh", fmt.Sprintf(`{"refresh_token": %q}`, signed.RefreshToken))
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusUnauthorized)
+			}
+		}
+
+		t.Log("\ttest:5\tshould authenticate with the new password.")
+		{
+			resp, _ := do("POST", "/signin", `{"email": "username87@example.com", "password": "newpassword123"}`)
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dipress/blog/internal/storage/postgres"
+	"github.com/dipress/blog/internal/token/personal"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+)
+
+func TestPersonalTokens(t *testing.T) {
+	t.Log("with prepared server")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		ctx, cancel := context.WithTimeout(context.Background(), caseTimeout)
+		defer cancel()
+
+		repo := postgres.NewRepository(db)
+
+		nu := user.NewUser{
+			Username:     "username96",
+			Email:        "username96@example.com",
+			PasswordHash: "$2y$12$e4.VBLqKAanAZs10dRL65O8.b0kHBC34pcGCN1HdJIchCi9im40Ei",
+		}
+		var u user.User
+		_, _, err := repo.CreateUser(ctx, &nu, &u)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		if err := repo.VerifyUser(ctx, u.ID); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		session, err := authenticator.GenerateToken(ctx, auth.NewClaims(u.Username, time.Now(), time.Hour))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
+		go s.Serve(lis)
+		defer s.Close()
+
+		do := func(method, path, body, token string) (*http.Response, []byte) {
+			req, err := http.NewRequest(method, fmt.Sprintf("http://%s%s", s.Addr, path), strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			req.Header.Add("Authorization", "Bearer "+token)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			data, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return resp, data
+		}
+
+		var tkn personal.Token
+
+		t.Log("\ttest:0\tshould create a personal token.")
+		{
+			resp, data := do("POST", "/me/tokens", `{"name": "deploy", "scopes": ["posts:write"]}`, session)
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+			if err := tkn.UnmarshalJSON(data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !personal.IsPersonal(tkn.Secret) {
+				t.Errorf("unexpected token: %s", tkn.Secret)
+			}
+		}
+
+		t.Log("\ttest:1\tshould create a post with the personal token.")
+		{
+			resp, _ := do("POST", "/posts", `{"title": "my awesome title", "body": "my awesome body"}`, tkn.Secret)
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:2\tshould not allow changes out of the scopes.")
+		{
+			resp, _ := do("PATCH", "/me", `{"display_name": "John"}`, tkn.Secret)
+			if resp.StatusCode != http.StatusForbidden {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusForbidden)
+			}
+
+			resp, _ = do("POST", "/me/tokens", `{"name": "another", "scopes": ["posts:write"]}`, tkn.Secret)
+			if resp.StatusCode != http.StatusForbidden {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusForbidden)
+			}
+		}
+
+		t.Log("\ttest:3\tshould list personal tokens without secrets.")
+		{
+			resp, data := do("GET", "/me/tokens", "", session)
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+
+			var ts personal.Tokens
+			if err := ts.UnmarshalJSON(data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(ts.Tokens) != 1 || ts.Tokens[0].Secret != "" || ts.Tokens[0].LastUsedAt == nil {
+				t.Errorf("unexpected tokens: %s", data)
+			}
+		}
+
+		t.Log("\ttest:4\tshould revoke the personal token.")
+		{
+			resp, _ := do("DELETE", fmt.Sprintf("/me/tokens/%d", tkn.ID), "", session)
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+
+			resp, _ = do("POST", "/posts", `{"title": "my awesome title", "body": "my awesome body"}`, tkn.Secret)
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusUnauthorized)
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dipress/blog/internal/reg"
+)
+
+func TestProfile(t *testing.T) {
+	t.Log("with prepared server")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
+		go s.Serve(lis)
+		defer s.Close()
+
+		do := func(method, path, body, token string) (*http.Response, []byte) {
+			req, err := http.NewRequest(method, fmt.Sprintf("http://%s%s", s.Addr, path), strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if token != "" {
+				req.Header.Add("Authorization", "Bearer "+token)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			data, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return resp, data
+		}
+
+		var signed reg.Token
+		resp, data := do("POST", "/signup", `{"username": "username88", "email": "username88@example.com", "password": "password123"}`, "")
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+		}
+		if err := signed.UnmarshalJSON(data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould update the profile.")
+		{
+			resp, _ := do("PATCH", "/me", `{"display_name": "John", "bio": "About me"}`, signed.Token)
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:1\tshould reject an invalid avatar url.")
+		{
+			resp, _ := do("PATCH", "/me", `{"avatar_url": "ftp://example.com/avatar.png"}`, signed.Token)
+			if resp.StatusCode != http.StatusUnprocessableEntity {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusUnprocessableEntity)
+			}
+		}
+
+		t.Log("\ttest:2\tshould show the public profile only.")
+		{
+			resp, data := do("GET", "/users/username88", "", "")
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+			body := string(data)
+			if !strings.Contains(body, `"display_name":"John"`) {
+				t.Errorf("expected display name in profile: %s", body)
+			}
+			if strings.Contains(body, "password_hash") || strings.Contains(body, "email") {
+				t.Errorf("unexpected private fields in profile: %s", body)
+			}
+		}
+
+		t.Log("\ttest:3\tshould list posts of the author.")
+		{
+			resp, _ := do("GET", "/users/username88/posts", "", "")
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:4\tshould not find an unknown user.")
+		{
+			resp, _ := do("GET", "/users/unknown", "", "")
+			if resp.StatusCode != http.StatusNotFound {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusNotFound)
+			}
+		}
+	}
+}
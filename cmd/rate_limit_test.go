@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dipress/blog/kit/ratelimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	t.Log("with prepared server")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+
+		cfg := config(db)
+		cfg.RateLimits.Auth = ratelimit.Rate{Requests: 2, Per: time.Minute}
+		s := setupServer(lis.Addr().String(), db, authenticator, cfg)
+		go s.Serve(lis)
+		defer s.Close()
+
+		signin := func() *http.Response {
+			authStr := `{"email": "username92@example.com", "password": "password123"}`
+			req, err := http.NewRequest("POST", fmt.Sprintf("http://%s/signin", s.Addr), strings.NewReader(authStr))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+			return resp
+		}
+
+		t.Log("\ttest:0\tshould report the rate limit.")
+		{
+			resp := signin()
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusUnauthorized)
+			}
+			if got := resp.Header.Get("RateLimit-Limit"); got != "2" {
+				t.Errorf("unexpected rate limit: %q expected: %q", got, "2")
+			}
+			if got := resp.Header.Get("RateLimit-Remaining"); got != "1" {
+				t.Errorf("unexpected remaining requests: %q expected: %q", got, "1")
+			}
+		}
+
+		t.Log("\ttest:1\tshould reject requests over the limit.")
+		{
+			signin()
+			resp := signin()
+			if resp.StatusCode != http.StatusTooManyRequests {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusTooManyRequests)
+			}
+			if resp.Header.Get("Retry-After") == "" {
+				t.Error("expected retry after header")
+			}
+		}
+
+		t.Log("\ttest:2\tshould not limit other route groups.")
+		{
+			resp, err := http.Get(fmt.Sprintf("http://%s/tags", s.Addr))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+	}
+}
+
+func TestRateLimitBeforeAuth(t *testing.T) {
+	t.Log("with prepared server")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+
+		cfg := config(db)
+		cfg.RateLimits.IP = ratelimit.Rate{Requests: 2, Per: time.Minute}
+		s := setupServer(lis.Addr().String(), db, authenticator, cfg)
+		go s.Serve(lis)
+		defer s.Close()
+
+		me := func() *http.Response {
+			req, err := http.NewRequest("GET", fmt.Sprintf("http://%s/me/tokens", s.Addr), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			req.Header.Add("Authorization", "Bearer invalid")
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+			return resp
+		}
+
+		t.Log("\ttest:0\tshould limit requests with invalid tokens.")
+		{
+			for i := 0; i < 2; i++ {
+				resp := me()
+				if resp.StatusCode != http.StatusUnauthorized {
+					t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusUnauthorized)
+				}
+			}
+
+			resp := me()
+			if resp.StatusCode != http.StatusTooManyRequests {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusTooManyRequests)
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dipress/blog/internal/reg"
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/internal/token/refresh"
+)
+
+func TestRefreshToken(t *testing.T) {
+	t.Log("with prepared server")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
+		go s.Serve(lis)
+		defer s.Close()
+
+		do := func(method, path, body, token string) (*http.Response, []byte) {
+			req, err := http.NewRequest(method, fmt.Sprintf("http://%s%s", s.Addr, path), strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if token != "" {
+				req.Header.Add("Authorization", "Bearer "+token)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			data, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return resp, data
+		}
+
+		var signed reg.Token
+		t.Log("\ttest:0\tshould issue a refresh token on sign up.")
+		{
+			resp, data := do("POST", "/signup", `{"username": "username85", "email": "username85@example.com", "password": "password123"}`, "")
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+
+			if err := signed.UnmarshalJSON(data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if signed.RefreshToken == "" {
+				t.Error("expected refresh token")
+			}
+		}
+
+		var rotated refresh.Token
+		t.Log("\ttest:1\tshould rotate the refresh token.")
+		{
+			resp, data := do("POST", "/token/refresh", fmt.Sprintf(`{"refresh_token": %q}`, signed.RefreshToken), "")
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+
+			if err := rotated.UnmarshalJSON(data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if rotated.RefreshToken == "" || rotated.RefreshToken == signed.RefreshToken {
+				t.Error("expected a new refresh token")
+			}
+		}
+
+		t.Log("\ttest:2\tshould exchange the rotated token again within the grace period.")
+		{
+			resp, _ := do("POST", "/token/refresh", fmt.Sprintf(`{"refresh_token": %q}`, signed.RefreshToken), "")
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		// The grace period of the rotated token passes.
+		if _, err := db.Exec(`UPDATE refresh_tokens SET revoked_at = revoked_at - interval '1 minute' WHERE hash = $1`, token.Hash(signed.RefreshToken)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:3\tshould reject a reused refresh token.")
+		{
+			resp, _ := do("POST", "/token/refresh", fmt.Sprintf(`{"refresh_token": %q}`, signed.RefreshToken), "")
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusUnauthorized)
+			}
+		}
+
+		t.Log("\ttest:4\tshould revoke the whole family after reuse.")
+		{
+			resp, _ := do("POST", "/token/refresh", fmt.Sprintf(`{"refresh_token": %q}`, rotated.RefreshToken), "")
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusUnauthorized)
+			}
+		}
+
+		t.Log("\ttest:5\tshould sign out with a known refresh token.")
+		{
+			resp, _ := do("POST", "/signout", fmt.Sprintf(`{"refresh_token": %q}`, rotated.RefreshToken), rotated.Token)
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:6\tshould reject the access token after sign out.")
+		{
+			resp, _ := do("GET", "/me/trash", "", rotated.Token)
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusUnauthorized)
+			}
+		}
+
+		t.Log("\ttest:7\tshould reject sign out with an unknown refresh token.")
+		{
+			resp, _ := do("POST", "/signout", `{"refresh_token": "unknown"}`, "")
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusUnauthorized)
+			}
+		}
+	}
+}
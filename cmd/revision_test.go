@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/storage/postgres"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+)
+
+func TestRevisions(t *testing.T) {
+	t.Log("with prepared server")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		ctx, cancel := context.WithTimeout(context.Background(), caseTimeout)
+		defer cancel()
+
+		repo := postgres.NewRepository(db)
+
+		nu := user.NewUser{
+			Username:     "username81",
+			Email:        "username81@example.com",
+			PasswordHash: "$2y$12$e4.VBLqKAanAZs10dRL65O8.b0kHBC34pcGCN1HdJIchCi9im40Ei",
+		}
+		var u user.User
+		_, _, err := repo.CreateUser(ctx, &nu, &u)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		np := post.NewPost{
+			UserID: u.ID,
+			Title:  "my title",
+			Body:   "my body",
+		}
+		var p post.Post
+		if err := repo.CreatePost(ctx, &np, &p); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		claims := auth.NewClaims(u.Username, time.Now(), time.Hour)
+
+		token, err := authenticator.GenerateToken(ctx, claims)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		token = "Bearer " + token
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
+		go s.Serve(lis)
+		defer s.Close()
+
+		t.Log("\ttest:0\tshould keep a revision on update.")
+		{
+			postStr := `{"title": "my new title", "body": "my new body"}`
+			req, err := http.NewRequest("PUT", fmt.Sprintf("http://%s/posts/%d", s.Addr, p.ID), strings.NewReader(postStr))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Add("Authorization", token)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:1\tshould show revisions of the post.")
+		{
+			req, err := http.NewRequest("GET", fmt.Sprintf("http://%s/posts/%d/revisions", s.Addr, p.ID), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			req.Header.Add("Authorization", token)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:2\tshould show a revision with diff.")
+		{
+			req, err := http.NewRequest("GET", fmt.Sprintf("http://%s/posts/%d/revisions/1", s.Addr, p.ID), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			req.Header.Add("Authorization", token)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:3\tshould restore a revision.")
+		{
+			req, err := http.NewRequest("POST", fmt.Sprintf("http://%s/posts/%d/revisions/1/restore", s.Addr, p.ID), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			req.Header.Add("Authorization", token)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+	}
+}
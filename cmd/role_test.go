@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dipress/blog/internal/storage/postgres"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+)
+
+func TestAssignRole(t *testing.T) {
+	t.Log("with prepared server")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		ctx, cancel := context.WithTimeout(context.Background(), caseTimeout)
+		defer cancel()
+
+		repo := postgres.NewRepository(db)
+
+		na := user.NewUser{
+			Username:     "username83",
+			Email:        "username83@example.com",
+			PasswordHash: "$2y$12$e4.VBLqKAanAZs10dRL65O8.b0kHBC34pcGCN1HdJIchCi9im40Ei",
+		}
+		var a user.User
+		if _, _, err := repo.CreateUser(ctx, &na, &a); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		if err := repo.UpdateRole(ctx, a.ID, user.RoleAdmin); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		nu := user.NewUser{
+			Username:     "username84",
+			Email:        "username84@example.com",
+			PasswordHash: "$2y$12$e4.VBLqKAanAZs10dRL65O8.b0kHBC34pcGCN1HdJIchCi9im40Ei",
+		}
+		var u user.User
+		if _, _, err := repo.CreateUser(ctx, &nu, &u); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		if err := repo.VerifyUser(ctx, u.ID); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		adminToken, err := authenticator.GenerateToken(ctx, auth.NewClaims(a.Username, time.Now(), time.Hour))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		userToken, err := authenticator.GenerateToken(ctx, auth.NewClaims(u.Username, time.Now(), time.Hour))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
+		go s.Serve(lis)
+		defer s.Close()
+
+		t.Log("\ttest:0\tshould forbid role assignment for authors.")
+		{
+			req, err := http.NewRequest("PUT", fmt.Sprintf("http://%s/users/%s/role", s.Addr, a.Username), strings.NewReader(`{"role":"reader"}`))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			req.Header.Add("Authorization", "Bearer "+userToken)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusForbidden {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusForbidden)
+			}
+		}
+
+		t.Log("\ttest:1\tshould assign a role by admin.")
+		{
+			req, err := http.NewRequest("PUT", fmt.Sprintf("http://%s/users/%s/role", s.Addr, u.Username), strings.NewReader(`{"role":"reader"}`))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			req.Header.Add("Authorization", "Bearer "+adminToken)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:2\tshould forbid post creation for readers.")
+		{
+			req, err := http.NewRequest("POST", fmt.Sprintf("http://%s/posts", s.Addr), strings.NewReader(`{"title":"my title","body":"my body"}`))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			req.Header.Add("Authorization", "Bearer "+userToken)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusForbidden {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusForbidden)
+			}
+		}
+	}
+}
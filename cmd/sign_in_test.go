@@ -40,7 +40,7 @@ func TestSignIn(t *testing.T) {
 			log.Fatalf("failed to listen: %v", err)
 		}
 
-		s := setupServer(lis.Addr().String(), db, authenticator)
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
 		go s.Serve(lis)
 		defer s.Close()
 
@@ -63,3 +63,74 @@ func TestSignIn(t *testing.T) {
 		}
 	}
 }
+
+func TestSignInLockout(t *testing.T) {
+	t.Log("with prepared server")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		ctx, cancel := context.WithTimeout(context.Background(), caseTimeout)
+		defer cancel()
+
+		repo := postgres.NewRepository(db)
+
+		nu := user.NewUser{
+			Username:     "username91",
+			Email:        "username91@example.com",
+			PasswordHash: "$2y$12$e4.VBLqKAanAZs10dRL65O8.b0kHBC34pcGCN1HdJIchCi9im40Ei",
+		}
+		var u user.User
+		_, _, err := repo.CreateUser(ctx, &nu, &u)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+
+		cfg := config(db)
+		cfg.Attempts = repo
+		s := setupServer(lis.Addr().String(), db, authenticator, cfg)
+		go s.Serve(lis)
+		defer s.Close()
+
+		signin := func(password string) *http.Response {
+			authStr := fmt.Sprintf(`{"email": "username91@example.com", "password": %q}`, password)
+			req, err := http.NewRequest("POST", fmt.Sprintf("http://%s/signin", s.Addr), strings.NewReader(authStr))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+			return resp
+		}
+
+		t.Log("\ttest:0\tshould reject wrong passwords.")
+		{
+			for i := 0; i < 5; i++ {
+				resp := signin("wrong password")
+				if resp.StatusCode != http.StatusUnauthorized {
+					t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusUnauthorized)
+				}
+			}
+		}
+
+		t.Log("\ttest:1\tshould lock the account.")
+		{
+			resp := signin("password123")
+			if resp.StatusCode != http.StatusLocked {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusLocked)
+			}
+			if resp.Header.Get("Retry-After") == "" {
+				t.Error("expected retry after header")
+			}
+		}
+	}
+}
@@ -19,7 +19,7 @@ func TestSignUp(t *testing.T) {
 		if err != nil {
 			log.Fatalf("failed to listen: %v", err)
 		}
-		s := setupServer(lis.Addr().String(), db, authenticator)
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
 		go s.Serve(lis)
 		defer s.Close()
 
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/storage/postgres"
+	"github.com/dipress/blog/internal/user"
+)
+
+func TestSitemap(t *testing.T) {
+	t.Log("with prepared server")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		ctx, cancel := context.WithTimeout(context.Background(), caseTimeout)
+		defer cancel()
+
+		repo := postgres.NewRepository(db)
+
+		nu := user.NewUser{
+			Username:     "username99",
+			Email:        "username99@example.com",
+			PasswordHash: "$2y$12$e4.VBLqKAanAZs10dRL65O8.b0kHBC34pcGCN1HdJIchCi9im40Ei",
+		}
+		var u user.User
+		if _, _, err := repo.CreateUser(ctx, &nu, &u); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		for _, np := range []post.NewPost{
+			{UserID: u.ID, Title: "my sitemap title", Slug: "my-sitemap-title", Body: "my body"},
+			{UserID: u.ID, Title: "my syndicated title", Slug: "my-syndicated-title", Body: "my body", CanonicalURL: "https://example.com/my-syndicated-title"},
+			{UserID: u.ID, Title: "my draft title", Slug: "my-draft-title", Body: "my body", Status: post.StatusDraft},
+		} {
+			var p post.Post
+			if err := repo.CreatePost(ctx, &np, &p); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
+		go s.Serve(lis)
+		defer s.Close()
+
+		get := func(path string) (*http.Response, string) {
+			resp, err := http.Get(fmt.Sprintf("http://%s%s", s.Addr, path))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			data, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return resp, string(data)
+		}
+
+		t.Log("\ttest:0\tshould list published posts in the sitemap.")
+		{
+			resp, body := get("/sitemap.xml")
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+			if !strings.Contains(body, "<loc>"+baseURL+"/posts/by-slug/my-sitemap-title</loc>") {
+				t.Errorf("expected the published post in the sitemap: %s", body)
+			}
+			for _, slug := range []string{"my-syndicated-title", "my-draft-title"} {
+				if strings.Contains(body, slug) {
+					t.Errorf("unexpected post %s in the sitemap", slug)
+				}
+			}
+		}
+
+		t.Log("\ttest:1\tshould not find pages past the last one.")
+		{
+			resp, _ := get("/sitemap-1000.xml")
+			if resp.StatusCode != http.StatusNotFound {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusNotFound)
+			}
+		}
+	}
+}
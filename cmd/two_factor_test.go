@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	authService "github.com/dipress/blog/internal/auth"
+	"github.com/dipress/blog/internal/storage/postgres"
+	"github.com/dipress/blog/internal/twofactor"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/dipress/blog/kit/totp"
+)
+
+func TestTwoFactor(t *testing.T) {
+	t.Log("with prepared server")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		ctx, cancel := context.WithTimeout(context.Background(), caseTimeout)
+		defer cancel()
+
+		repo := postgres.NewRepository(db)
+
+		nu := user.NewUser{
+			Username:     "username93",
+			Email:        "username93@example.com",
+			PasswordHash: "$2y$12$e4.VBLqKAanAZs10dRL65O8.b0kHBC34pcGCN1HdJIchCi9im40Ei",
+		}
+		var u user.User
+		_, _, err := repo.CreateUser(ctx, &nu, &u)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		claims := auth.NewClaims(u.Username, time.Now(), time.Hour)
+
+		token, err := authenticator.GenerateToken(ctx, claims)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		token = "Bearer " + token
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
+		go s.Serve(lis)
+		defer s.Close()
+
+		do := func(method, path, body, token string) ([]byte, int) {
+			req, err := http.NewRequest(method, fmt.Sprintf("http://%s%s", s.Addr, path), strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if token != "" {
+				req.Header.Add("Authorization", token)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			data, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return data, resp.StatusCode
+		}
+
+		signin := func() string {
+			data, code := do("POST", "/signin", `{"email": "username93@example.com", "password": "password123"}`, "")
+			if code != http.StatusOK {
+				t.Fatalf("unexpected status code: %d expected: %d", code, http.StatusOK)
+			}
+
+			var tkn authService.Token
+			if err := tkn.UnmarshalJSON(data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return tkn.Challenge
+		}
+
+		var e twofactor.Enrollment
+
+		t.Log("\ttest:0\tshould enroll two-factor authentication.")
+		{
+			data, code := do("POST", "/me/2fa", "", token)
+			if code != http.StatusOK {
+				t.Fatalf("unexpected status code: %d expected: %d", code, http.StatusOK)
+			}
+
+			if err := e.UnmarshalJSON(data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if challenge := signin(); challenge != "" {
+				t.Error("unexpected challenge before enabling")
+			}
+		}
+
+		t.Log("\ttest:1\tshould enable two-factor authentication.")
+		{
+			_, code := do("POST", "/me/2fa/verify", `{"code": "000000"}`, token)
+			if code != http.StatusUnprocessableEntity {
+				t.Errorf("unexpected status code: %d expected: %d", code, http.StatusUnprocessableEntity)
+			}
+
+			totpCode, err := totp.Code(e.Secret, totp.Step(time.Now()))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			_, code = do("POST", "/me/2fa/verify", fmt.Sprintf(`{"code": %q}`, totpCode), token)
+			if code != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", code, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:2\tshould sign in with a recovery code.")
+		{
+			challenge := signin()
+			if challenge == "" {
+				t.Fatal("expected challenge")
+			}
+
+			body := fmt.Sprintf(`{"challenge": %q, "code": %q}`, challenge, e.RecoveryCodes[0])
+			_, code := do("POST", "/signin/2fa", body, "")
+			if code != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", code, http.StatusOK)
+			}
+
+			_, code = do("POST", "/signin/2fa", body, "")
+			if code != http.StatusUnauthorized {
+				t.Errorf("unexpected status code: %d expected: %d", code, http.StatusUnauthorized)
+			}
+		}
+
+		t.Log("\ttest:3\tshould reject a used recovery code.")
+		{
+			body := fmt.Sprintf(`{"challenge": %q, "code": %q}`, signin(), e.RecoveryCodes[0])
+			_, code := do("POST", "/signin/2fa", body, "")
+			if code != http.StatusUnauthorized {
+				t.Errorf("unexpected status code: %d expected: %d", code, http.StatusUnauthorized)
+			}
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dipress/blog/internal/reg"
+)
+
+func TestVerifyEmail(t *testing.T) {
+	t.Log("with prepared server")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
+		go s.Serve(lis)
+		defer s.Close()
+
+		do := func(method, path, body, token string) (*http.Response, []byte) {
+			req, err := http.NewRequest(method, fmt.Sprintf("http://%s%s", s.Addr, path), strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if token != "" {
+				req.Header.Add("Authorization", "Bearer "+token)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			data, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return resp, data
+		}
+
+		var signed reg.Token
+		resp, data := do("POST", "/signup", `{"username": "username86", "email": "username86@example.com", "password": "password123"}`, "")
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+		}
+		if err := signed.UnmarshalJSON(data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		postStr := `{"title": "my awesome title", "body": "my awesome body"}`
+
+		t.Log("\ttest:0\tshould not create posts before verification.")
+		{
+			resp, _ := do("POST", "/posts", postStr, signed.Token)
+			if resp.StatusCode != http.StatusForbidden {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusForbidden)
+			}
+		}
+
+		verification := mails.token("username86@example.com")
+		if verification == "" {
+			t.Fatal("expected verification email")
+		}
+
+		t.Log("\ttest:1\tshould not authenticate with the verification token.")
+		{
+			resp, _ := do("POST", "/posts", postStr, verification)
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusUnauthorized)
+			}
+		}
+
+		t.Log("\ttest:2\tshould resend the verification email.")
+		{
+			resp, _ := do("POST", "/verify-email/resend", "", signed.Token)
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+
+			verification = mails.token("username86@example.com")
+			if verification == "" {
+				t.Fatal("expected verification email")
+			}
+		}
+
+		t.Log("\ttest:3\tshould verify the email.")
+		{
+			resp, _ := do("POST", "/verify-email", fmt.Sprintf(`{"token": %q}`, verification), "")
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:4\tshould reject the used token.")
+		{
+			resp, _ := do("POST", "/verify-email", fmt.Sprintf(`{"token": %q}`, verification), "")
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusUnauthorized)
+			}
+		}
+
+		t.Log("\ttest:5\tshould create posts after verification.")
+		{
+			resp, _ := do("POST", "/posts", postStr, signed.Token)
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:6\tshould not resend the email after verification.")
+		{
+			resp, _ := do("POST", "/verify-email/resend", "", signed.Token)
+			if resp.StatusCode != http.StatusConflict {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusConflict)
+			}
+		}
+	}
+}
+
+func TestVerifyRightAfterSignUp(t *testing.T) {
+	t.Log("with prepared server")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+		s := setupServer(lis.Addr().String(), db, authenticator, config(db))
+		go s.Serve(lis)
+		defer s.Close()
+
+		do := func(method, path, body, token string) (*http.Response, []byte) {
+			req, err := http.NewRequest(method, fmt.Sprintf("http://%s%s", s.Addr, path), strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if token != "" {
+				req.Header.Add("Authorization", "Bearer "+token)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			data, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return resp, data
+		}
+
+		// Tokens issued in the second the account is created are valid.
+		var signed reg.Token
+		resp, data := do("POST", "/signup", `{"username": "username100", "email": "username100@example.com", "password": "password123"}`, "")
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+		}
+		if err := signed.UnmarshalJSON(data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould authenticate with the token of the sign up.")
+		{
+			resp, _ := do("GET", "/me/trash", "", signed.Token)
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:1\tshould verify the email with the link sent on sign up.")
+		{
+			verification := mails.token("username100@example.com")
+			if verification == "" {
+				t.Fatal("expected verification email")
+			}
+
+			resp, _ := do("POST", "/verify-email", fmt.Sprintf(`{"token": %q}`, verification), "")
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+	}
+}
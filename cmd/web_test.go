@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/dipress/blog/internal/storage/postgres"
+	"github.com/dipress/blog/internal/user"
+)
+
+func TestFrontend(t *testing.T) {
+	t.Log("with prepared server")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+
+		ctx, cancel := context.WithTimeout(context.Background(), caseTimeout)
+		defer cancel()
+
+		repo := postgres.NewRepository(db)
+
+		nu := user.NewUser{
+			Username:     "username98",
+			Email:        "username98@example.com",
+			PasswordHash: "$2y$12$e4.VBLqKAanAZs10dRL65O8.b0kHBC34pcGCN1HdJIchCi9im40Ei",
+		}
+		var u user.User
+		if _, _, err := repo.CreateUser(ctx, &nu, &u); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		if err := repo.VerifyUser(ctx, u.ID); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+		cfg := config(db)
+		cfg.Frontend = true
+		s := setupServer(lis.Addr().String(), db, authenticator, cfg)
+		go s.Serve(lis)
+		defer s.Close()
+
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		client := http.Client{Jar: jar}
+		csrfRe := regexp.MustCompile(`name="csrf_token" value="([^"]+)"`)
+
+		get := func(path string) (*http.Response, string) {
+			resp, err := client.Get(fmt.Sprintf("http://%s%s", s.Addr, path))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+			body, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return resp, string(body)
+		}
+
+		post := func(path, csrf string, form url.Values) (*http.Response, string) {
+			form.Set("csrf_token", csrf)
+			resp, err := client.PostForm(fmt.Sprintf("http://%s%s", s.Addr, path), form)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+			body, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return resp, string(body)
+		}
+
+		t.Log("\ttest:0\tshould show the index page.")
+		{
+			resp, _ := get("/")
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+		}
+
+		t.Log("\ttest:1\tshould reject forms without csrf token.")
+		{
+			resp, _ := post("/login", "", url.Values{"email": {nu.Email}, "password": {"password123"}})
+			if resp.StatusCode != http.StatusForbidden {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusForbidden)
+			}
+		}
+
+		t.Log("\ttest:2\tshould sign in and write a post.")
+		{
+			_, body := get("/login")
+			m := csrfRe.FindStringSubmatch(body)
+			if m == nil {
+				t.Fatalf("csrf token is not found: %s", body)
+			}
+			csrf := m[1]
+
+			resp, body := post("/login", csrf, url.Values{"email": {nu.Email}, "password": {"password123"}})
+			if resp.StatusCode != http.StatusOK || !strings.Contains(body, "/u/username98") {
+				t.Fatalf("unexpected status code: %d expected signed in index page: %s", resp.StatusCode, body)
+			}
+
+			resp, body = post("/editor", csrf, url.Values{
+				"title":  {"my web title"},
+				"body":   {"my *web* body"},
+				"format": {"markdown"},
+				"status": {"published"},
+			})
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusOK)
+			}
+			if resp.Request.URL.Path != "/p/my-web-title" {
+				t.Errorf("unexpected path: %s expected: %s", resp.Request.URL.Path, "/p/my-web-title")
+			}
+			if !strings.Contains(body, "<em>web</em>") {
+				t.Errorf("unexpected body: %s", body)
+			}
+
+			_, body = get("/u/username98")
+			if !strings.Contains(body, "my web title") {
+				t.Errorf("unexpected author page: %s", body)
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package ability
+
+import (
+	"github.com/dipress/blog/internal/user"
+)
+
+// Action is an operation which can be performed by the user.
+type Action string
+
+// Actions which can be granted to roles.
+const (
+	ActionCreate     Action = "create"
+	ActionView       Action = "view"
+	ActionUpdate     Action = "update"
+	ActionDelete     Action = "delete"
+	ActionRestore    Action = "restore"
+	ActionAssignRole Action = "assign_role"
+)
+
+// Grant holds actions allowed to the role
+// on own resources and on resources of anyone.
+type Grant struct {
+	Own []Action
+	Any []Action
+}
+
+// Policy is a role based policy engine.
+// It maps roles to their grants.
+type Policy map[string]Grant
+
+// DefaultPolicy allows authors to manage their own posts,
+// editors to moderate posts of anyone and admins
+// to do everything including roles assignment.
+var DefaultPolicy = Policy{
+	user.RoleReader: {},
+	user.RoleAuthor: {
+		Own: []Action{ActionCreate, ActionView, ActionUpdate, ActionDelete, ActionRestore},
+	},
+	user.RoleEditor: {
+		Own: []Action{ActionCreate},
+		Any: []Action{ActionView, ActionUpdate, ActionDelete, ActionRestore},
+	},
+	user.RoleAdmin: {
+		Own: []Action{ActionCreate},
+		Any: []Action{ActionView, ActionUpdate, ActionDelete, ActionRestore, ActionAssignRole},
+	},
+}
+
+// Allowed checks that the user can perform the action
+// on a resource which belongs to the owner.
+func (p Policy) Allowed(u *user.User, a Action, ownerID int) bool {
+	if u == nil {
+		return false
+	}
+
+	g, ok := p[u.Role]
+	if !ok {
+		return false
+	}
+
+	if contains(g.Any, a) {
+		return true
+	}
+
+	return ownerID != 0 && ownerID == u.ID && contains(g.Own, a)
+}
+
+func contains(actions []Action, a Action) bool {
+	for _, action := range actions {
+		if action == a {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package ability
+
+import (
+	"testing"
+
+	"github.com/dipress/blog/internal/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    *user.User
+		action  Action
+		ownerID int
+		expect  bool
+	}{
+		{
+			name:    "anonymous",
+			action:  ActionView,
+			ownerID: 1,
+		},
+		{
+			name:    "unknown role",
+			user:    &user.User{ID: 1, Role: "guest"},
+			action:  ActionUpdate,
+			ownerID: 1,
+		},
+		{
+			name:    "reader can't create",
+			user:    &user.User{ID: 1, Role: user.RoleReader},
+			action:  ActionCreate,
+			ownerID: 1,
+		},
+		{
+			name:    "author updates own post",
+			user:    &user.User{ID: 1, Role: user.RoleAuthor},
+			action:  ActionUpdate,
+			ownerID: 1,
+			expect:  true,
+		},
+		{
+			name:    "author can't update post of another user",
+			user:    &user.User{ID: 1, Role: user.RoleAuthor},
+			action:  ActionUpdate,
+			ownerID: 2,
+		},
+		{
+			name:    "editor deletes post of another user",
+			user:    &user.User{ID: 1, Role: user.RoleEditor},
+			action:  ActionDelete,
+			ownerID: 2,
+			expect:  true,
+		},
+		{
+			name:   "editor can't assign roles",
+			user:   &user.User{ID: 1, Role: user.RoleEditor},
+			action: ActionAssignRole,
+		},
+		{
+			name:   "admin assigns roles",
+			user:   &user.User{ID: 1, Role: user.RoleAdmin},
+			action: ActionAssignRole,
+			expect: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expect, DefaultPolicy.Allowed(tc.user, tc.action, tc.ownerID))
+		})
+	}
+}
@@ -1,18 +1,61 @@
 package ability
 
 import (
+	"github.com/dipress/blog/internal/comment"
 	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/user"
 )
 
 // PostAbillity allows checking ability to view post.
+// It consults the default role based policy.
 type PostAbillity struct{}
 
+// CanCreate checks permission to create posts.
+func (a PostAbillity) CanCreate(u *user.User) bool {
+	return u != nil && DefaultPolicy.Allowed(u, ActionCreate, u.ID)
+}
+
+// CanView checks permission to view the post.
+// Published posts are public, the rest are visible
+// to the owner and moderators only.
+func (a PostAbillity) CanView(u *user.User, p *post.Post) bool {
+	return p.Status == post.StatusPublished || DefaultPolicy.Allowed(u, ActionView, p.UserID)
+}
+
 // CanUpdate checks permission to update the post.
-func (p PostAbillity) CanUpdate(userID int, post *post.Post) bool {
-	return userID == post.UserID
+func (a PostAbillity) CanUpdate(u *user.User, p *post.Post) bool {
+	return DefaultPolicy.Allowed(u, ActionUpdate, p.UserID)
 }
 
 // CanDelete checks permission to delete the post.
-func (p PostAbillity) CanDelete(userID int, post *post.Post) bool {
-	return userID == post.UserID
+func (a PostAbillity) CanDelete(u *user.User, p *post.Post) bool {
+	return DefaultPolicy.Allowed(u, ActionDelete, p.UserID)
+}
+
+// CanRestore checks permission to restore the post from the trash.
+func (a PostAbillity) CanRestore(u *user.User, p *post.Post) bool {
+	return DefaultPolicy.Allowed(u, ActionRestore, p.UserID)
+}
+
+// RoleAbillity allows checking ability to manage user roles.
+type RoleAbillity struct{}
+
+// CanAssign checks permission to assign roles to users.
+func (r RoleAbillity) CanAssign(u *user.User) bool {
+	return DefaultPolicy.Allowed(u, ActionAssignRole, 0)
+}
+
+// CommentAbillity allows checking ability to moderate comments.
+type CommentAbillity struct{}
+
+// CanUpdate checks permission to update the comment.
+// Only the author can edit the comment.
+func (c CommentAbillity) CanUpdate(userID int, comment *comment.Comment) bool {
+	return userID == comment.UserID
+}
+
+// CanDelete checks permission to delete the comment.
+// The author and the owner of the post can delete it.
+func (c CommentAbillity) CanDelete(userID int, comment *comment.Comment, post *post.Post) bool {
+	return userID == comment.UserID || userID == post.UserID
 }
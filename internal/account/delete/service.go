@@ -0,0 +1,86 @@
+package delete
+
+import (
+	"context"
+	"time"
+
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=service.go -package=delete -destination=service.mock.go
+
+// Deletion policies.
+const (
+	// PolicyAnonymize keeps the content of the user and
+	// erases the personal data of the account.
+	PolicyAnonymize = "anonymize"
+	// PolicyCascade deletes the account with all content.
+	PolicyCascade = "cascade"
+)
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	AnonymizeUser(ctx context.Context, userID int) error
+	DeleteUser(ctx context.Context, userID int) error
+}
+
+// SubjectRevoker revokes all access tokens of the user.
+type SubjectRevoker interface {
+	RevokeSubject(ctx context.Context, subject string, after time.Time) error
+}
+
+// Service is a use case for account deleting.
+type Service struct {
+	Repository
+	SubjectRevoker
+	Policy string
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, sr SubjectRevoker, policy string) *Service {
+	s := Service{
+		Repository:     r,
+		SubjectRevoker: sr,
+		Policy:         policy,
+	}
+
+	return &s
+}
+
+// Delete deletes the account of the current user according
+// to the policy. All sessions of the user are revoked first,
+// so they can't outlive the account.
+func (s *Service) Delete(ctx context.Context) error {
+	if err := auth.RequireSession(ctx); err != nil {
+		return errors.Wrap(err, "require session")
+	}
+
+	claims, _ := auth.FromContext(ctx)
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+		return errors.Wrap(err, "repository find user")
+	}
+
+	if err := s.SubjectRevoker.RevokeSubject(ctx, u.Username, time.Now()); err != nil {
+		return errors.Wrap(err, "revoke subject")
+	}
+
+	switch s.Policy {
+	case PolicyAnonymize:
+		if err := s.Repository.AnonymizeUser(ctx, u.ID); err != nil {
+			return errors.Wrap(err, "repository anonymize user")
+		}
+	case PolicyCascade:
+		if err := s.Repository.DeleteUser(ctx, u.ID); err != nil {
+			return errors.Wrap(err, "repository delete user")
+		}
+	default:
+		return errors.Errorf("unknown deletion policy %q", s.Policy)
+	}
+
+	return nil
+}
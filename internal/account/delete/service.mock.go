@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package delete is a generated GoMock package.
+package delete
+
+import (
+	context "context"
+	user "github.com/dipress/blog/internal/user"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+	time "time"
+)
+
+// MockRepository is a mock of Repository interface
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByUsername mocks base method
+func (m *MockRepository) FindByUsername(ctx context.Context, username string, u *user.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUsername", ctx, username, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindByUsername indicates an expected call of FindByUsername
+func (mr *MockRepositoryMockRecorder) FindByUsername(ctx, username, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockRepository)(nil).FindByUsername), ctx, username, u)
+}
+
+// AnonymizeUser mocks base method
+func (m *MockRepository) AnonymizeUser(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnonymizeUser indicates an expected call of AnonymizeUser
+func (mr *MockRepositoryMockRecorder) AnonymizeUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUser", reflect.TypeOf((*MockRepository)(nil).AnonymizeUser), ctx, userID)
+}
+
+// DeleteUser mocks base method
+func (m *MockRepository) DeleteUser(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser
+func (mr *MockRepositoryMockRecorder) DeleteUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockRepository)(nil).DeleteUser), ctx, userID)
+}
+
+// MockSubjectRevoker is a mock of SubjectRevoker interface
+type MockSubjectRevoker struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubjectRevokerMockRecorder
+}
+
+// MockSubjectRevokerMockRecorder is the mock recorder for MockSubjectRevoker
+type MockSubjectRevokerMockRecorder struct {
+	mock *MockSubjectRevoker
+}
+
+// NewMockSubjectRevoker creates a new mock instance
+func NewMockSubjectRevoker(ctrl *gomock.Controller) *MockSubjectRevoker {
+	mock := &MockSubjectRevoker{ctrl: ctrl}
+	mock.recorder = &MockSubjectRevokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSubjectRevoker) EXPECT() *MockSubjectRevokerMockRecorder {
+	return m.recorder
+}
+
+// RevokeSubject mocks base method
+func (m *MockSubjectRevoker) RevokeSubject(ctx context.Context, subject string, after time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSubject", ctx, subject, after)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSubject indicates an expected call of RevokeSubject
+func (mr *MockSubjectRevokerMockRecorder) RevokeSubject(ctx, subject, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSubject", reflect.TypeOf((*MockSubjectRevoker)(nil).RevokeSubject), ctx, subject, after)
+}
@@ -0,0 +1,129 @@
+package delete
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dipress/blog/kit/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceDelete(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         string
+		repositoryFunc func(mock *MockRepository)
+		revokerFunc    func(mock *MockSubjectRevoker)
+		wantErr        bool
+	}{
+		{
+			name:   "anonymize",
+			policy: PolicyAnonymize,
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().AnonymizeUser(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			revokerFunc: func(m *MockSubjectRevoker) {
+				m.EXPECT().RevokeSubject(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:   "cascade",
+			policy: PolicyCascade,
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().DeleteUser(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			revokerFunc: func(m *MockSubjectRevoker) {
+				m.EXPECT().RevokeSubject(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:   "unknown policy",
+			policy: "unknown",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			revokerFunc: func(m *MockSubjectRevoker) {
+				m.EXPECT().RevokeSubject(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:   "find user error",
+			policy: PolicyAnonymize,
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			revokerFunc: func(m *MockSubjectRevoker) {},
+			wantErr:     true,
+		},
+		{
+			name:   "revoke subject error",
+			policy: PolicyAnonymize,
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			revokerFunc: func(m *MockSubjectRevoker) {
+				m.EXPECT().RevokeSubject(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+		{
+			name:   "anonymize user error",
+			policy: PolicyAnonymize,
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().AnonymizeUser(gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			revokerFunc: func(m *MockSubjectRevoker) {
+				m.EXPECT().RevokeSubject(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:   "delete user error",
+			policy: PolicyCascade,
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().DeleteUser(gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			revokerFunc: func(m *MockSubjectRevoker) {
+				m.EXPECT().RevokeSubject(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepository(ctrl)
+			revoker := NewMockSubjectRevoker(ctrl)
+			tc.repositoryFunc(repo)
+			tc.revokerFunc(revoker)
+
+			s := NewService(repo, revoker, tc.policy)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			claims := auth.Claims{}
+			newCtx := auth.ToContext(ctx, &claims)
+
+			err := s.Delete(newCtx)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package export
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/dipress/blog/internal/comment"
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/revision"
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+// easyjson service.go
+//go:generate mockgen -source=service.go -package=export -destination=service.mock.go
+
+// pageSize is the number of rows read from the database at once.
+const pageSize = 100
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	ListPost(ctx context.Context, f *post.Filter, pos *post.Posts) error
+	ListUserComments(ctx context.Context, userID, afterID, limit int, cs *comment.Comments) error
+	ListUserRevisions(ctx context.Context, userID, afterID, limit int, rs *revision.Revisions) error
+	ListUserIdentities(ctx context.Context, userID int) ([]user.Identity, error)
+	ListPersonalTokens(ctx context.Context, userID int) ([]token.Personal, error)
+	ListActiveRefreshTokens(ctx context.Context, userID int) ([]token.Refresh, error)
+}
+
+// Archive contains all data stored about the user. It describes
+// the document written by Export, which is never built in memory.
+//easyjson:json
+type Archive struct {
+	User           user.User           `json:"user"`
+	Posts          []post.Post         `json:"posts"`
+	Comments       []comment.Comment   `json:"comments"`
+	Revisions      []revision.Revision `json:"revisions"`
+	Identities     []Identity          `json:"identities"`
+	PersonalTokens []PersonalToken     `json:"personal_tokens"`
+	Sessions       []Session           `json:"sessions"`
+}
+
+// Identity is a linked account of the user at an OpenID Connect provider.
+//easyjson:json
+type Identity struct {
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PersonalToken describes a personal access token without its hash.
+//easyjson:json
+type PersonalToken struct {
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Session describes a signed in device by its refresh token.
+//easyjson:json
+type Session struct {
+	Family    string    `json:"family"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Service is a use case for account data exporting.
+type Service struct {
+	Repository
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository) *Service {
+	s := Service{
+		Repository: r,
+	}
+
+	return &s
+}
+
+// Export writes the account, posts including drafts and trash,
+// comments, revisions, linked identities, personal access tokens
+// and sessions of the current user as an Archive. Rows are read
+// page by page and written as soon as they are read. Nothing is
+// written when the user can't be found.
+func (s *Service) Export(ctx context.Context, w io.Writer) error {
+	if err := auth.RequireSession(ctx); err != nil {
+		return errors.Wrap(err, "require session")
+	}
+
+	claims, _ := auth.FromContext(ctx)
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+		return errors.Wrap(err, "repository find user")
+	}
+
+	aw := newArchiveWriter(w)
+	aw.field("user", &u)
+
+	aw.begin("posts")
+	for _, deleted := range []bool{false, true} {
+		filter := post.Filter{
+			UserID:  u.ID,
+			Deleted: deleted,
+			Limit:   pageSize,
+		}
+
+		for {
+			var posts post.Posts
+			if err := s.Repository.ListPost(ctx, &filter, &posts); err != nil {
+				return errors.Wrap(err, "list posts")
+			}
+			for i := range posts.Posts {
+				aw.item(&posts.Posts[i])
+			}
+			if len(posts.Posts) < pageSize {
+				break
+			}
+
+			last := posts.Posts[len(posts.Posts)-1]
+			filter.AfterCreatedAt = last.CreatedAt
+			filter.AfterID = last.ID
+		}
+	}
+	aw.end()
+
+	aw.begin("comments")
+	for afterID := 0; ; {
+		var comments comment.Comments
+		if err := s.Repository.ListUserComments(ctx, u.ID, afterID, pageSize, &comments); err != nil {
+			return errors.Wrap(err, "list comments")
+		}
+		for i := range comments.Comments {
+			aw.item(&comments.Comments[i])
+		}
+		if len(comments.Comments) < pageSize {
+			break
+		}
+		afterID = comments.Comments[len(comments.Comments)-1].ID
+	}
+	aw.end()
+
+	aw.begin("revisions")
+	for afterID := 0; ; {
+		var revisions revision.Revisions
+		if err := s.Repository.ListUserRevisions(ctx, u.ID, afterID, pageSize, &revisions); err != nil {
+			return errors.Wrap(err, "list revisions")
+		}
+		for i := range revisions.Revisions {
+			aw.item(&revisions.Revisions[i])
+		}
+		if len(revisions.Revisions) < pageSize {
+			break
+		}
+		afterID = revisions.Revisions[len(revisions.Revisions)-1].ID
+	}
+	aw.end()
+
+	identities, err := s.Repository.ListUserIdentities(ctx, u.ID)
+	if err != nil {
+		return errors.Wrap(err, "list identities")
+	}
+	aw.begin("identities")
+	for _, i := range identities {
+		aw.item(&Identity{
+			Provider:  i.Provider,
+			Subject:   i.Subject,
+			Email:     i.Email,
+			CreatedAt: i.CreatedAt,
+		})
+	}
+	aw.end()
+
+	tokens, err := s.Repository.ListPersonalTokens(ctx, u.ID)
+	if err != nil {
+		return errors.Wrap(err, "list personal tokens")
+	}
+	aw.begin("personal_tokens")
+	for _, t := range tokens {
+		aw.item(&PersonalToken{
+			Name:       t.Name,
+			Scopes:     t.Scopes,
+			LastUsedAt: t.LastUsedAt,
+			ExpiresAt:  t.ExpiresAt,
+			CreatedAt:  t.CreatedAt,
+		})
+	}
+	aw.end()
+
+	refreshes, err := s.Repository.ListActiveRefreshTokens(ctx, u.ID)
+	if err != nil {
+		return errors.Wrap(err, "list sessions")
+	}
+	aw.begin("sessions")
+	for _, t := range refreshes {
+		aw.item(&Session{
+			Family:    t.Family,
+			ExpiresAt: t.ExpiresAt,
+			CreatedAt: t.CreatedAt,
+		})
+	}
+	aw.end()
+
+	if err := aw.close(); err != nil {
+		return errors.Wrap(err, "write archive")
+	}
+
+	return nil
+}
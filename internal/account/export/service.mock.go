@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package export is a generated GoMock package.
+package export
+
+import (
+	context "context"
+	comment "github.com/dipress/blog/internal/comment"
+	post "github.com/dipress/blog/internal/post"
+	revision "github.com/dipress/blog/internal/revision"
+	token "github.com/dipress/blog/internal/token"
+	user "github.com/dipress/blog/internal/user"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockRepository is a mock of Repository interface
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByUsername mocks base method
+func (m *MockRepository) FindByUsername(ctx context.Context, username string, u *user.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUsername", ctx, username, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindByUsername indicates an expected call of FindByUsername
+func (mr *MockRepositoryMockRecorder) FindByUsername(ctx, username, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockRepository)(nil).FindByUsername), ctx, username, u)
+}
+
+// ListPost mocks base method
+func (m *MockRepository) ListPost(ctx context.Context, f *post.Filter, pos *post.Posts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPost", ctx, f, pos)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListPost indicates an expected call of ListPost
+func (mr *MockRepositoryMockRecorder) ListPost(ctx, f, pos interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPost", reflect.TypeOf((*MockRepository)(nil).ListPost), ctx, f, pos)
+}
+
+// ListUserComments mocks base method
+func (m *MockRepository) ListUserComments(ctx context.Context, userID, afterID, limit int, cs *comment.Comments) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserComments", ctx, userID, afterID, limit, cs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListUserComments indicates an expected call of ListUserComments
+func (mr *MockRepositoryMockRecorder) ListUserComments(ctx, userID, afterID, limit, cs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserComments", reflect.TypeOf((*MockRepository)(nil).ListUserComments), ctx, userID, afterID, limit, cs)
+}
+
+// ListUserRevisions mocks base method
+func (m *MockRepository) ListUserRevisions(ctx context.Context, userID, afterID, limit int, rs *revision.Revisions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserRevisions", ctx, userID, afterID, limit, rs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListUserRevisions indicates an expected call of ListUserRevisions
+func (mr *MockRepositoryMockRecorder) ListUserRevisions(ctx, userID, afterID, limit, rs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserRevisions", reflect.TypeOf((*MockRepository)(nil).ListUserRevisions), ctx, userID, afterID, limit, rs)
+}
+
+// ListUserIdentities mocks base method
+func (m *MockRepository) ListUserIdentities(ctx context.Context, userID int) ([]user.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserIdentities", ctx, userID)
+	ret0, _ := ret[0].([]user.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserIdentities indicates an expected call of ListUserIdentities
+func (mr *MockRepositoryMockRecorder) ListUserIdentities(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserIdentities", reflect.TypeOf((*MockRepository)(nil).ListUserIdentities), ctx, userID)
+}
+
+// ListPersonalTokens mocks base method
+func (m *MockRepository) ListPersonalTokens(ctx context.Context, userID int) ([]token.Personal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPersonalTokens", ctx, userID)
+	ret0, _ := ret[0].([]token.Personal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPersonalTokens indicates an expected call of ListPersonalTokens
+func (mr *MockRepositoryMockRecorder) ListPersonalTokens(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPersonalTokens", reflect.TypeOf((*MockRepository)(nil).ListPersonalTokens), ctx, userID)
+}
+
+// ListActiveRefreshTokens mocks base method
+func (m *MockRepository) ListActiveRefreshTokens(ctx context.Context, userID int) ([]token.Refresh, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveRefreshTokens", ctx, userID)
+	ret0, _ := ret[0].([]token.Refresh)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveRefreshTokens indicates an expected call of ListActiveRefreshTokens
+func (mr *MockRepositoryMockRecorder) ListActiveRefreshTokens(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveRefreshTokens", reflect.TypeOf((*MockRepository)(nil).ListActiveRefreshTokens), ctx, userID)
+}
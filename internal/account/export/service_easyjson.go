@@ -0,0 +1,747 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package export
+
+import (
+	json "encoding/json"
+	comment "github.com/dipress/blog/internal/comment"
+	post "github.com/dipress/blog/internal/post"
+	revision "github.com/dipress/blog/internal/revision"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonCd93bc43DecodeGithubComDipressBlogInternalAccountExport(in *jlexer.Lexer, out *Session) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "family":
+			out.Family = string(in.String())
+		case "expires_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ExpiresAt).UnmarshalJSON(data))
+			}
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCd93bc43EncodeGithubComDipressBlogInternalAccountExport(out *jwriter.Writer, in Session) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"family\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Family))
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.ExpiresAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Session) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonCd93bc43EncodeGithubComDipressBlogInternalAccountExport(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Session) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonCd93bc43EncodeGithubComDipressBlogInternalAccountExport(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Session) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonCd93bc43DecodeGithubComDipressBlogInternalAccountExport(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Session) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonCd93bc43DecodeGithubComDipressBlogInternalAccountExport(l, v)
+}
+func easyjsonCd93bc43DecodeGithubComDipressBlogInternalAccountExport1(in *jlexer.Lexer, out *PersonalToken) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "scopes":
+			if in.IsNull() {
+				in.Skip()
+				out.Scopes = nil
+			} else {
+				in.Delim('[')
+				if out.Scopes == nil {
+					if !in.IsDelim(']') {
+						out.Scopes = make([]string, 0, 4)
+					} else {
+						out.Scopes = []string{}
+					}
+				} else {
+					out.Scopes = (out.Scopes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.Scopes = append(out.Scopes, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "last_used_at":
+			if in.IsNull() {
+				in.Skip()
+				out.LastUsedAt = nil
+			} else {
+				if out.LastUsedAt == nil {
+					out.LastUsedAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.LastUsedAt).UnmarshalJSON(data))
+				}
+			}
+		case "expires_at":
+			if in.IsNull() {
+				in.Skip()
+				out.ExpiresAt = nil
+			} else {
+				if out.ExpiresAt == nil {
+					out.ExpiresAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ExpiresAt).UnmarshalJSON(data))
+				}
+			}
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCd93bc43EncodeGithubComDipressBlogInternalAccountExport1(out *jwriter.Writer, in PersonalToken) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"scopes\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Scopes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Scopes {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"last_used_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.LastUsedAt == nil {
+			out.RawString("null")
+		} else {
+			out.Raw((*in.LastUsedAt).MarshalJSON())
+		}
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.ExpiresAt == nil {
+			out.RawString("null")
+		} else {
+			out.Raw((*in.ExpiresAt).MarshalJSON())
+		}
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PersonalToken) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonCd93bc43EncodeGithubComDipressBlogInternalAccountExport1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PersonalToken) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonCd93bc43EncodeGithubComDipressBlogInternalAccountExport1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PersonalToken) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonCd93bc43DecodeGithubComDipressBlogInternalAccountExport1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PersonalToken) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonCd93bc43DecodeGithubComDipressBlogInternalAccountExport1(l, v)
+}
+func easyjsonCd93bc43DecodeGithubComDipressBlogInternalAccountExport2(in *jlexer.Lexer, out *Identity) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "provider":
+			out.Provider = string(in.String())
+		case "subject":
+			out.Subject = string(in.String())
+		case "email":
+			out.Email = string(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCd93bc43EncodeGithubComDipressBlogInternalAccountExport2(out *jwriter.Writer, in Identity) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"provider\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Provider))
+	}
+	{
+		const prefix string = ",\"subject\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Subject))
+	}
+	{
+		const prefix string = ",\"email\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Email))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Identity) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonCd93bc43EncodeGithubComDipressBlogInternalAccountExport2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Identity) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonCd93bc43EncodeGithubComDipressBlogInternalAccountExport2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Identity) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonCd93bc43DecodeGithubComDipressBlogInternalAccountExport2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Identity) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonCd93bc43DecodeGithubComDipressBlogInternalAccountExport2(l, v)
+}
+func easyjsonCd93bc43DecodeGithubComDipressBlogInternalAccountExport3(in *jlexer.Lexer, out *Archive) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "user":
+			(out.User).UnmarshalEasyJSON(in)
+		case "posts":
+			if in.IsNull() {
+				in.Skip()
+				out.Posts = nil
+			} else {
+				in.Delim('[')
+				if out.Posts == nil {
+					if !in.IsDelim(']') {
+						out.Posts = make([]post.Post, 0, 1)
+					} else {
+						out.Posts = []post.Post{}
+					}
+				} else {
+					out.Posts = (out.Posts)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 post.Post
+					(v4).UnmarshalEasyJSON(in)
+					out.Posts = append(out.Posts, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "comments":
+			if in.IsNull() {
+				in.Skip()
+				out.Comments = nil
+			} else {
+				in.Delim('[')
+				if out.Comments == nil {
+					if !in.IsDelim(']') {
+						out.Comments = make([]comment.Comment, 0, 1)
+					} else {
+						out.Comments = []comment.Comment{}
+					}
+				} else {
+					out.Comments = (out.Comments)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 comment.Comment
+					(v5).UnmarshalEasyJSON(in)
+					out.Comments = append(out.Comments, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "revisions":
+			if in.IsNull() {
+				in.Skip()
+				out.Revisions = nil
+			} else {
+				in.Delim('[')
+				if out.Revisions == nil {
+					if !in.IsDelim(']') {
+						out.Revisions = make([]revision.Revision, 0, 1)
+					} else {
+						out.Revisions = []revision.Revision{}
+					}
+				} else {
+					out.Revisions = (out.Revisions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v6 revision.Revision
+					(v6).UnmarshalEasyJSON(in)
+					out.Revisions = append(out.Revisions, v6)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "identities":
+			if in.IsNull() {
+				in.Skip()
+				out.Identities = nil
+			} else {
+				in.Delim('[')
+				if out.Identities == nil {
+					if !in.IsDelim(']') {
+						out.Identities = make([]Identity, 0, 1)
+					} else {
+						out.Identities = []Identity{}
+					}
+				} else {
+					out.Identities = (out.Identities)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 Identity
+					(v7).UnmarshalEasyJSON(in)
+					out.Identities = append(out.Identities, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "personal_tokens":
+			if in.IsNull() {
+				in.Skip()
+				out.PersonalTokens = nil
+			} else {
+				in.Delim('[')
+				if out.PersonalTokens == nil {
+					if !in.IsDelim(']') {
+						out.PersonalTokens = make([]PersonalToken, 0, 1)
+					} else {
+						out.PersonalTokens = []PersonalToken{}
+					}
+				} else {
+					out.PersonalTokens = (out.PersonalTokens)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v8 PersonalToken
+					(v8).UnmarshalEasyJSON(in)
+					out.PersonalTokens = append(out.PersonalTokens, v8)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "sessions":
+			if in.IsNull() {
+				in.Skip()
+				out.Sessions = nil
+			} else {
+				in.Delim('[')
+				if out.Sessions == nil {
+					if !in.IsDelim(']') {
+						out.Sessions = make([]Session, 0, 1)
+					} else {
+						out.Sessions = []Session{}
+					}
+				} else {
+					out.Sessions = (out.Sessions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v9 Session
+					(v9).UnmarshalEasyJSON(in)
+					out.Sessions = append(out.Sessions, v9)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCd93bc43EncodeGithubComDipressBlogInternalAccountExport3(out *jwriter.Writer, in Archive) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"user\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.User).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"posts\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Posts == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v10, v11 := range in.Posts {
+				if v10 > 0 {
+					out.RawByte(',')
+				}
+				(v11).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"comments\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Comments == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v12, v13 := range in.Comments {
+				if v12 > 0 {
+					out.RawByte(',')
+				}
+				(v13).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"revisions\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Revisions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v14, v15 := range in.Revisions {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				(v15).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"identities\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Identities == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v16, v17 := range in.Identities {
+				if v16 > 0 {
+					out.RawByte(',')
+				}
+				(v17).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"personal_tokens\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.PersonalTokens == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v18, v19 := range in.PersonalTokens {
+				if v18 > 0 {
+					out.RawByte(',')
+				}
+				(v19).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"sessions\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Sessions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v20, v21 := range in.Sessions {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				(v21).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Archive) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonCd93bc43EncodeGithubComDipressBlogInternalAccountExport3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Archive) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonCd93bc43EncodeGithubComDipressBlogInternalAccountExport3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Archive) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonCd93bc43DecodeGithubComDipressBlogInternalAccountExport3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Archive) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonCd93bc43DecodeGithubComDipressBlogInternalAccountExport3(l, v)
+}
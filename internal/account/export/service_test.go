@@ -0,0 +1,174 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dipress/blog/internal/comment"
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceExport(t *testing.T) {
+	tests := []struct {
+		name           string
+		repositoryFunc func(mock *MockRepository)
+		checkFunc      func(t *testing.T, a *Archive)
+		wantErr        bool
+		wantEmpty      bool
+	}{
+		{
+			name: "ok",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, username string, u *user.User) error {
+						u.Email = "username@example.com"
+						u.PasswordHash = "hash"
+						return nil
+					},
+				)
+				m.EXPECT().ListPost(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, f *post.Filter, pos *post.Posts) error {
+						pos.Posts = []post.Post{{Title: "title"}}
+						return nil
+					},
+				).Times(2)
+				m.EXPECT().ListUserComments(gomock.Any(), gomock.Any(), 0, pageSize, gomock.Any()).Return(nil)
+				m.EXPECT().ListUserRevisions(gomock.Any(), gomock.Any(), 0, pageSize, gomock.Any()).Return(nil)
+				m.EXPECT().ListUserIdentities(gomock.Any(), gomock.Any()).Return([]user.Identity{{Provider: "example", Email: "username@example.org"}}, nil)
+				m.EXPECT().ListPersonalTokens(gomock.Any(), gomock.Any()).Return([]token.Personal{{Name: "ci", Hash: "hash"}}, nil)
+				m.EXPECT().ListActiveRefreshTokens(gomock.Any(), gomock.Any()).Return([]token.Refresh{{Family: "family", Hash: "hash"}}, nil)
+			},
+			checkFunc: func(t *testing.T, a *Archive) {
+				assert.Equal(t, "username@example.com", a.User.Email)
+				assert.Len(t, a.Posts, 2)
+				assert.Equal(t, "username@example.org", a.Identities[0].Email)
+				assert.Equal(t, "ci", a.PersonalTokens[0].Name)
+				assert.Equal(t, "family", a.Sessions[0].Family)
+			},
+		},
+		{
+			name: "pages",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().ListPost(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, f *post.Filter, pos *post.Posts) error {
+						if !f.Deleted {
+							pos.Posts = []post.Post{{Title: "title"}}
+						}
+						return nil
+					},
+				).Times(2)
+				m.EXPECT().ListUserComments(gomock.Any(), gomock.Any(), 0, pageSize, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, userID, afterID, limit int, cs *comment.Comments) error {
+						cs.Comments = make([]comment.Comment, pageSize)
+						for i := range cs.Comments {
+							cs.Comments[i].ID = i + 1
+						}
+						return nil
+					},
+				)
+				m.EXPECT().ListUserComments(gomock.Any(), gomock.Any(), pageSize, pageSize, gomock.Any()).Return(nil)
+				m.EXPECT().ListUserRevisions(gomock.Any(), gomock.Any(), 0, pageSize, gomock.Any()).Return(nil)
+				m.EXPECT().ListUserIdentities(gomock.Any(), gomock.Any()).Return(nil, nil)
+				m.EXPECT().ListPersonalTokens(gomock.Any(), gomock.Any()).Return(nil, nil)
+				m.EXPECT().ListActiveRefreshTokens(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+			checkFunc: func(t *testing.T, a *Archive) {
+				assert.Len(t, a.Posts, 1)
+				assert.Len(t, a.Comments, pageSize)
+			},
+		},
+		{
+			name: "find user error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr:   true,
+			wantEmpty: true,
+		},
+		{
+			name: "list posts error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().ListPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "list comments error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().ListPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				m.EXPECT().ListUserComments(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "list revisions error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().ListPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				m.EXPECT().ListUserComments(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().ListUserRevisions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "list identities error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().ListPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				m.EXPECT().ListUserComments(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().ListUserRevisions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().ListUserIdentities(gomock.Any(), gomock.Any()).Return(nil, errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepository(ctrl)
+			tc.repositoryFunc(repo)
+
+			s := NewService(repo)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			claims := auth.Claims{}
+			newCtx := auth.ToContext(ctx, &claims)
+
+			var buf bytes.Buffer
+			err := s.Export(newCtx, &buf)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.wantEmpty {
+					assert.Zero(t, buf.Len())
+				}
+				return
+			}
+			assert.Nil(t, err)
+
+			var a Archive
+			if err := a.UnmarshalJSON(buf.Bytes()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assert.NotContains(t, buf.String(), "hash")
+			tc.checkFunc(t, &a)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// archiveWriter writes the archive as a JSON object section
+// by section. The first error stops all further writes and
+// is returned by close.
+type archiveWriter struct {
+	w     io.Writer
+	enc   *json.Encoder
+	sep   string
+	items int
+	err   error
+}
+
+// newArchiveWriter prepares the writer of the archive.
+func newArchiveWriter(w io.Writer) *archiveWriter {
+	aw := archiveWriter{
+		w:   w,
+		enc: json.NewEncoder(w),
+		sep: "{",
+	}
+
+	return &aw
+}
+
+// write writes the raw string.
+func (aw *archiveWriter) write(s string) {
+	if aw.err != nil {
+		return
+	}
+	_, aw.err = io.WriteString(aw.w, s)
+}
+
+// encode writes the value.
+func (aw *archiveWriter) encode(v interface{}) {
+	if aw.err != nil {
+		return
+	}
+	aw.err = aw.enc.Encode(v)
+}
+
+// key writes the name of the next section.
+func (aw *archiveWriter) key(name string) {
+	aw.write(aw.sep + strconv.Quote(name) + ":")
+	aw.sep = ","
+}
+
+// field writes the section holding a single value.
+func (aw *archiveWriter) field(name string, v interface{}) {
+	aw.key(name)
+	aw.encode(v)
+}
+
+// begin starts the section holding a list of values.
+func (aw *archiveWriter) begin(name string) {
+	aw.key(name)
+	aw.write("[")
+	aw.items = 0
+}
+
+// item writes the value to the list.
+func (aw *archiveWriter) item(v interface{}) {
+	if aw.items > 0 {
+		aw.write(",")
+	}
+	aw.encode(v)
+	aw.items++
+}
+
+// end finishes the list.
+func (aw *archiveWriter) end() {
+	aw.write("]")
+}
+
+// close finishes the archive.
+func (aw *archiveWriter) close() error {
+	aw.write("}")
+	return aw.err
+}
@@ -2,9 +2,13 @@ package auth
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/dipress/blog/internal/token/onetime"
+	"github.com/dipress/blog/internal/twofactor"
 	"github.com/dipress/blog/internal/user"
 	"github.com/dipress/blog/kit/auth"
 	"github.com/pkg/errors"
@@ -13,6 +17,13 @@ import (
 
 // easyjson service.go
 
+const (
+	// challengePurpose is the audience of two-factor challenge tokens.
+	challengePurpose = "signin-2fa"
+	// challengeTTL is how long the user has to send the code.
+	challengeTTL = 5 * time.Minute
+)
+
 var (
 	// ErrNotFound returns when given email is not
 	// found in database.
@@ -20,11 +31,65 @@ var (
 	// ErrWrongPassword returns when given password
 	// is not equal to it's hash in database.
 	ErrWrongPassword = errors.New("wrong password")
+	// ErrInvalidChallenge returns when two-factor challenge
+	// is malformed, expired or already used, or the code is wrong.
+	ErrInvalidChallenge = errors.New("invalid challenge")
+)
+
+// LockedError returns when too many failed attempts were made for
+// the account or from the address. Attempts are rejected until
+// RetryAfter passes.
+type LockedError struct {
+	RetryAfter time.Duration
+	// Address is true when the address is locked, not the account.
+	Address bool
+}
+
+// Error implements error interface.
+func (e *LockedError) Error() string {
+	if e.Address {
+		return fmt.Sprintf("too many attempts from the address, retry after %s", e.RetryAfter)
+	}
+	return fmt.Sprintf("account is locked, retry after %s", e.RetryAfter)
+}
+
+// Lockout limits failed sign in attempts. Free failures are allowed,
+// every next one locks for Base doubled per failure, up to Max.
+// Failures are forgotten after Window since the last one.
+type Lockout struct {
+	Free   int
+	Base   time.Duration
+	Max    time.Duration
+	Window time.Duration
+}
+
+// Default lockouts of accounts and addresses. An address gets more
+// free attempts, since many users may share it.
+var (
+	DefaultAccountLockout = Lockout{Free: 5, Base: time.Second, Max: 15 * time.Minute, Window: time.Hour}
+	DefaultAddressLockout = Lockout{Free: 20, Base: time.Second, Max: 15 * time.Minute, Window: time.Hour}
 )
 
+// backoff returns how long the key is locked after the failures.
+func (l Lockout) backoff(failures int) time.Duration {
+	if failures < l.Free {
+		return 0
+	}
+
+	d := l.Base
+	for i := l.Free; i < failures && d < l.Max; i++ {
+		d *= 2
+	}
+	if d > l.Max {
+		d = l.Max
+	}
+	return d
+}
+
 // Repository allows to work with database.
 type Repository interface {
 	FindByEmail(ctx context.Context, email string, user *user.User) error
+	FindByUsername(ctx context.Context, username string, user *user.User) error
 }
 
 // TokenGenerator is the behavior we need in our
@@ -33,12 +98,42 @@ type TokenGenerator interface {
 	GenerateToken(ctx context.Context, claims jwt.Claims) (string, error)
 }
 
+// Issuer issues refresh tokens.
+type Issuer interface {
+	Issue(ctx context.Context, userID int, family string) (string, error)
+}
+
+// Attempts counts failed sign in attempts.
+type Attempts interface {
+	FailAttempt(ctx context.Context, key string, now, expiresAt time.Time) (int, error)
+	Attempts(ctx context.Context, key string, now time.Time) (int, time.Time, error)
+	ResetAttempts(ctx context.Context, key string) error
+}
+
+// TwoFactor checks two-factor codes of users.
+type TwoFactor interface {
+	Enabled(ctx context.Context, userID int) (bool, error)
+	Check(ctx context.Context, userID int, code string) error
+}
+
+// Challenges issues and redeems single-use challenge tokens.
+type Challenges interface {
+	Issue(ctx context.Context, subject, purpose string, exp time.Duration) (string, error)
+	Redeem(ctx context.Context, tknStr, purpose string) (string, error)
+}
+
 // Service holds required data for user
 // authentication.
 type Service struct {
 	Repository
 	TokenGenerator
-	ExpireAfter time.Duration
+	Issuer
+	Attempts
+	TwoFactor
+	Challenges
+	ExpireAfter    time.Duration
+	AccountLockout Lockout
+	AddressLockout Lockout
 }
 
 // Form is a user auth form.
@@ -48,46 +143,228 @@ type Form struct {
 	Password string `json:"password"`
 }
 
-// Token holds token data.
+// ChallengeForm is a second step of
+// two-factor authentication form.
+//easyjson:json
+type ChallengeForm struct {
+	Challenge string `json:"challenge"`
+	Code      string `json:"code"`
+}
+
+// Token holds token data. When two-factor authentication is
+// enabled only Challenge is set, which is exchanged for tokens
+// with the code.
 //easyjson:json
 type Token struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	Challenge    string `json:"challenge,omitempty"`
 }
 
 // NewService factory created ready to service.
-func NewService(r Repository, t TokenGenerator, exp time.Duration) *Service {
+func NewService(r Repository, t TokenGenerator, i Issuer, a Attempts, tf TwoFactor, c Challenges, exp time.Duration) *Service {
 	s := Service{
 		Repository:     r,
 		TokenGenerator: t,
+		Issuer:         i,
+		Attempts:       a,
+		TwoFactor:      tf,
+		Challenges:     c,
 		ExpireAfter:    exp,
+		AccountLockout: DefaultAccountLockout,
+		AddressLockout: DefaultAddressLockout,
 	}
 
 	return &s
 }
 
 // Authenticate allows authenticating user by given email and password
-// and set t Token value as generated token.
-func (s *Service) Authenticate(ctx context.Context, email, password string, t *Token) error {
+// and set t Token value as generated token. Every sign in starts
+// a new refresh token family. Failed attempts are counted per
+// account and per address ip, which get locked out after too many.
+func (s *Service) Authenticate(ctx context.Context, email, password, ip string, t *Token) error {
+	now := time.Now()
+
+	locks := s.locks(email, ip)
+	if err := s.checkLocks(ctx, locks, now); err != nil {
+		return err
+	}
+
 	var user user.User
 	if err := s.Repository.FindByEmail(ctx, email, &user); err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			if err := s.fail(ctx, locks, now); err != nil {
+				return errors.Wrap(err, "fail attempt")
+			}
+		}
 		return errors.Wrap(err, "find user by email")
 	}
 
 	// Compare the provided password with the saved hash. Use the bcrypt
 	// comparison function so it is cryptographically secure.
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		if err := s.fail(ctx, locks, now); err != nil {
+			return errors.Wrap(err, "fail attempt")
+		}
 		return ErrWrongPassword
 	}
 
+	enabled, err := s.TwoFactor.Enabled(ctx, user.ID)
+	if err != nil {
+		return errors.Wrap(err, "two-factor enabled")
+	}
+	// Failures are kept until the code is checked,
+	// wrong codes count as well, see Challenge.
+	if enabled {
+		return s.challenge(ctx, &user, t)
+	}
+
+	// The address keeps its failures, so signing in to
+	// an own account doesn't allow guessing others.
+	if err := s.Attempts.ResetAttempts(ctx, locks[0].key); err != nil {
+		return errors.Wrap(err, "reset attempts")
+	}
+
+	return s.issue(ctx, &user, t)
+}
+
+// SignIn sets tokens of the user, who is authenticated by
+// other means. Users with two-factor authentication get
+// a challenge instead of tokens, see Challenge.
+func (s *Service) SignIn(ctx context.Context, u *user.User, t *Token) error {
+	enabled, err := s.TwoFactor.Enabled(ctx, u.ID)
+	if err != nil {
+		return errors.Wrap(err, "two-factor enabled")
+	}
+	if enabled {
+		return s.challenge(ctx, u, t)
+	}
+
+	return s.issue(ctx, u, t)
+}
+
+// Challenge completes two-factor authentication. The challenge
+// is single-use, so a wrong code requires signing in again.
+// Wrong codes are counted as failed sign in attempts of the
+// account and the address ip.
+func (s *Service) Challenge(ctx context.Context, f *ChallengeForm, ip string, t *Token) error {
+	now := time.Now()
+
+	username, err := s.Challenges.Redeem(ctx, f.Challenge, challengePurpose)
+	if err != nil {
+		if errors.Cause(err) == onetime.ErrInvalidToken {
+			return ErrInvalidChallenge
+		}
+		return errors.Wrap(err, "redeem challenge")
+	}
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, username, &u); err != nil {
+		if errors.Cause(err) == user.ErrNotFound {
+			return ErrInvalidChallenge
+		}
+		return errors.Wrap(err, "find user by username")
+	}
+
+	locks := s.locks(u.Email, ip)
+	if err := s.checkLocks(ctx, locks, now); err != nil {
+		return err
+	}
+
+	if err := s.TwoFactor.Check(ctx, u.ID, f.Code); err != nil {
+		if errors.Cause(err) == twofactor.ErrInvalidCode {
+			if err := s.fail(ctx, locks, now); err != nil {
+				return errors.Wrap(err, "fail attempt")
+			}
+			return ErrInvalidChallenge
+		}
+		return errors.Wrap(err, "check code")
+	}
+
+	if err := s.Attempts.ResetAttempts(ctx, locks[0].key); err != nil {
+		return errors.Wrap(err, "reset attempts")
+	}
+
+	return s.issue(ctx, &u, t)
+}
+
+// challenge sets the challenge which is exchanged
+// for tokens of the user with the two-factor code.
+func (s *Service) challenge(ctx context.Context, u *user.User, t *Token) error {
+	challenge, err := s.Challenges.Issue(ctx, u.Username, challengePurpose, challengeTTL)
+	if err != nil {
+		return errors.Wrap(err, "issue challenge")
+	}
+
+	t.Challenge = challenge
+	return nil
+}
+
+// issue sets access and refresh tokens of the user.
+func (s *Service) issue(ctx context.Context, user *user.User, t *Token) error {
 	// If we are this far the request is valid. Create some claims for the user
 	// and generate their token.
 	claims := auth.NewClaims(user.Username, time.Now(), s.ExpireAfter)
+	claims.Role = user.Role
 
 	tknStr, err := s.GenerateToken(ctx, claims)
 	if err != nil {
 		return errors.Wrap(err, "generate token")
 	}
+
+	refresh, err := s.Issuer.Issue(ctx, user.ID, "")
+	if err != nil {
+		return errors.Wrap(err, "issue refresh token")
+	}
+
 	t.Token = tknStr
+	t.RefreshToken = refresh
+
+	return nil
+}
+
+// lock is a key whose failed attempts are limited by the lockout.
+type lock struct {
+	key     string
+	lockout Lockout
+	address bool
+}
+
+// locks returns locks of the account with the email and
+// of the address ip. The lock of the account goes first.
+func (s *Service) locks(email, ip string) []lock {
+	locks := []lock{
+		{key: "account:" + strings.ToLower(email), lockout: s.AccountLockout},
+	}
+	if ip != "" {
+		locks = append(locks, lock{key: "address:" + ip, lockout: s.AddressLockout, address: true})
+	}
+
+	return locks
+}
+
+// checkLocks returns LockedError when any of locks is locked.
+func (s *Service) checkLocks(ctx context.Context, locks []lock, now time.Time) error {
+	for _, l := range locks {
+		failures, last, err := s.Attempts.Attempts(ctx, l.key, now)
+		if err != nil {
+			return errors.Wrap(err, "attempts")
+		}
+		if until := last.Add(l.lockout.backoff(failures)); until.After(now) {
+			return &LockedError{RetryAfter: until.Sub(now), Address: l.address}
+		}
+	}
+
+	return nil
+}
+
+// fail records the failed attempt for all locks.
+func (s *Service) fail(ctx context.Context, locks []lock, now time.Time) error {
+	for _, l := range locks {
+		if _, err := s.Attempts.FailAttempt(ctx, l.key, now, now.Add(l.lockout.Window)); err != nil {
+			return errors.Wrapf(err, "fail %s", l.key)
+		}
+	}
 
 	return nil
 }
@@ -38,6 +38,10 @@ func easyjsonCd93bc43DecodeGithubComDipressBlogInternalAuth(in *jlexer.Lexer, ou
 		switch key {
 		case "token":
 			out.Token = string(in.String())
+		case "refresh_token":
+			out.RefreshToken = string(in.String())
+		case "challenge":
+			out.Challenge = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -62,6 +66,26 @@ func easyjsonCd93bc43EncodeGithubComDipressBlogInternalAuth(out *jwriter.Writer,
 		}
 		out.String(string(in.Token))
 	}
+	{
+		const prefix string = ",\"refresh_token\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.RefreshToken))
+	}
+	if in.Challenge != "" {
+		const prefix string = ",\"challenge\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Challenge))
+	}
 	out.RawByte('}')
 }
 
@@ -171,3 +195,86 @@ func (v *Form) UnmarshalJSON(data []byte) error {
 func (v *Form) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonCd93bc43DecodeGithubComDipressBlogInternalAuth1(l, v)
 }
+func easyjsonCd93bc43DecodeGithubComDipressBlogInternalAuth2(in *jlexer.Lexer, out *ChallengeForm) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "challenge":
+			out.Challenge = string(in.String())
+		case "code":
+			out.Code = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCd93bc43EncodeGithubComDipressBlogInternalAuth2(out *jwriter.Writer, in ChallengeForm) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"challenge\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Challenge))
+	}
+	{
+		const prefix string = ",\"code\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Code))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChallengeForm) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonCd93bc43EncodeGithubComDipressBlogInternalAuth2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChallengeForm) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonCd93bc43EncodeGithubComDipressBlogInternalAuth2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChallengeForm) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonCd93bc43DecodeGithubComDipressBlogInternalAuth2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChallengeForm) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonCd93bc43DecodeGithubComDipressBlogInternalAuth2(l, v)
+}
@@ -6,7 +6,10 @@ import (
 	"time"
 
 	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/dipress/blog/internal/token/onetime"
+	"github.com/dipress/blog/internal/twofactor"
 	user "github.com/dipress/blog/internal/user"
+	authEng "github.com/dipress/blog/kit/auth"
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -23,7 +26,11 @@ func Test_Service(t *testing.T) {
 		name               string
 		repositoryFunc     func(ctx context.Context, email string, user *user.User) error
 		tokenGeneratorFunc func(ctx context.Context, claims jwt.Claims) (string, error)
+		issuerFunc         func(ctx context.Context, userID int, family string) (string, error)
+		twoFactor          twoFactorStub
+		failures           int
 		wantErr            bool
+		wantLocked         bool
 		expect             Token
 	}{
 		{
@@ -35,10 +42,34 @@ func Test_Service(t *testing.T) {
 			tokenGeneratorFunc: func(ctx context.Context, claims jwt.Claims) (string, error) {
 				return "token", nil
 			},
+			issuerFunc: func(ctx context.Context, userID int, family string) (string, error) {
+				return "refresh", nil
+			},
+			expect: Token{
+				Token:        "token",
+				RefreshToken: "refresh",
+			},
+		},
+		{
+			name: "two-factor",
+			repositoryFunc: func(ctx context.Context, email string, user *user.User) error {
+				user.PasswordHash = string(pw)
+				return nil
+			},
+			twoFactor: twoFactorStub{enabled: true},
 			expect: Token{
-				Token: "token",
+				Challenge: "challenge",
 			},
 		},
+		{
+			name: "two-factor enabled",
+			repositoryFunc: func(ctx context.Context, email string, user *user.User) error {
+				user.PasswordHash = string(pw)
+				return nil
+			},
+			twoFactor: twoFactorStub{err: errors.New("mock error")},
+			wantErr:   true,
+		},
 		{
 			name: "not found",
 			repositoryFunc: func(ctx context.Context, email string, user *user.User) error {
@@ -53,6 +84,16 @@ func Test_Service(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "locked account",
+			repositoryFunc: func(ctx context.Context, email string, user *user.User) error {
+				user.PasswordHash = string(pw)
+				return nil
+			},
+			failures:   5,
+			wantErr:    true,
+			wantLocked: true,
+		},
 		{
 			name: "token gen",
 			repositoryFunc: func(ctx context.Context, email string, user *user.User) error {
@@ -64,6 +105,20 @@ func Test_Service(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "refresh token issue",
+			repositoryFunc: func(ctx context.Context, email string, user *user.User) error {
+				user.PasswordHash = string(pw)
+				return nil
+			},
+			tokenGeneratorFunc: func(ctx context.Context, claims jwt.Claims) (string, error) {
+				return "token", nil
+			},
+			issuerFunc: func(ctx context.Context, userID int, family string) (string, error) {
+				return "", errors.New("mock error")
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -73,18 +128,28 @@ func Test_Service(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			s := NewService(repositoryFunc(tt.repositoryFunc), tokenGeneratorFunc(tt.tokenGeneratorFunc), time.Hour)
+			attempts := authEng.NewMemoryAttempts(10)
+			s := NewService(repositoryFunc(tt.repositoryFunc), tokenGeneratorFunc(tt.tokenGeneratorFunc), issuerFunc(tt.issuerFunc), attempts, &tt.twoFactor, &challengesStub{}, time.Hour)
 
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
+			now := time.Now()
+			for i := 0; i < tt.failures; i++ {
+				if _, err := attempts.FailAttempt(ctx, "account:username@example.com", now, now.Add(time.Hour)); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
 			var got Token
 			email := "username@example.com"
 			password := "password123"
-			err := s.Authenticate(ctx, email, password, &got)
+			err := s.Authenticate(ctx, email, password, "127.0.0.1", &got)
 
 			if tt.wantErr {
 				assert.Error(t, err)
+				_, locked := errors.Cause(err).(*LockedError)
+				assert.Equal(t, tt.wantLocked, locked)
 				return
 			}
 			assert.Nil(t, err)
@@ -93,14 +158,254 @@ func Test_Service(t *testing.T) {
 	}
 }
 
+func TestServiceLockout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := func(ctx context.Context, email string, user *user.User) error {
+		return ErrNotFound
+	}
+
+	s := NewService(repositoryFunc(repo), nil, nil, authEng.NewMemoryAttempts(10), nil, nil, time.Hour)
+	s.AccountLockout = Lockout{Free: 2, Base: time.Minute, Max: time.Hour, Window: time.Hour}
+	s.AddressLockout = Lockout{Free: 3, Base: time.Minute, Max: time.Hour, Window: time.Hour}
+
+	var tkn Token
+	for i := 0; i < 2; i++ {
+		err := s.Authenticate(ctx, "username@example.com", "password123", "127.0.0.1", &tkn)
+		assert.Equal(t, ErrNotFound, errors.Cause(err))
+	}
+
+	t.Log("\ttest:0\tshould lock the account.")
+	{
+		err := s.Authenticate(ctx, "USERNAME@example.com", "password123", "127.0.0.2", &tkn)
+		locked, ok := errors.Cause(err).(*LockedError)
+		assert.True(t, ok)
+		assert.False(t, locked.Address)
+		assert.InDelta(t, time.Minute.Seconds(), locked.RetryAfter.Seconds(), 1)
+	}
+
+	t.Log("\ttest:1\tshould lock the address.")
+	{
+		err := s.Authenticate(ctx, "other@example.com", "password123", "127.0.0.1", &tkn)
+		assert.Equal(t, ErrNotFound, errors.Cause(err))
+
+		err = s.Authenticate(ctx, "another@example.com", "password123", "127.0.0.1", &tkn)
+		locked, ok := errors.Cause(err).(*LockedError)
+		assert.True(t, ok)
+		assert.True(t, locked.Address)
+	}
+}
+
+func TestServiceChallenge(t *testing.T) {
+	tests := []struct {
+		name           string
+		repositoryFunc func(ctx context.Context, username string, user *user.User) error
+		challenges     challengesStub
+		twoFactor      twoFactorStub
+		wantErr        bool
+		wantInvalid    bool
+	}{
+		{
+			name: "ok",
+			repositoryFunc: func(ctx context.Context, username string, user *user.User) error {
+				return nil
+			},
+		},
+		{
+			name:        "invalid challenge",
+			challenges:  challengesStub{err: onetime.ErrInvalidToken},
+			wantErr:     true,
+			wantInvalid: true,
+		},
+		{
+			name:       "redeem challenge",
+			challenges: challengesStub{err: errors.New("mock error")},
+			wantErr:    true,
+		},
+		{
+			name: "user not found",
+			repositoryFunc: func(ctx context.Context, username string, u *user.User) error {
+				return user.ErrNotFound
+			},
+			wantErr:     true,
+			wantInvalid: true,
+		},
+		{
+			name: "invalid code",
+			repositoryFunc: func(ctx context.Context, username string, user *user.User) error {
+				return nil
+			},
+			twoFactor:   twoFactorStub{err: twofactor.ErrInvalidCode},
+			wantErr:     true,
+			wantInvalid: true,
+		},
+		{
+			name: "check code",
+			repositoryFunc: func(ctx context.Context, username string, user *user.User) error {
+				return nil
+			},
+			twoFactor: twoFactorStub{err: errors.New("mock error")},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tokenGenerator := func(ctx context.Context, claims jwt.Claims) (string, error) {
+				return "token", nil
+			}
+			issuer := func(ctx context.Context, userID int, family string) (string, error) {
+				return "refresh", nil
+			}
+
+			s := NewService(repositoryFunc(tt.repositoryFunc), tokenGeneratorFunc(tokenGenerator), issuerFunc(issuer), authEng.NewMemoryAttempts(10), &tt.twoFactor, &tt.challenges, time.Hour)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var got Token
+			err := s.Challenge(ctx, &ChallengeForm{Challenge: "challenge", Code: "123456"}, "127.0.0.1", &got)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantInvalid, errors.Cause(err) == ErrInvalidChallenge)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, Token{Token: "token", RefreshToken: "refresh"}, got)
+		})
+	}
+}
+
+func TestServiceChallengeLockout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pw, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("failed to generate password: %v", err)
+	}
+
+	repo := func(ctx context.Context, email string, user *user.User) error {
+		user.Email = "username@example.com"
+		user.PasswordHash = string(pw)
+		return nil
+	}
+
+	attempts := authEng.NewMemoryAttempts(10)
+	twoFactor := twoFactorStub{enabled: true}
+	s := NewService(repositoryFunc(repo), nil, nil, attempts, &twoFactor, &challengesStub{}, time.Hour)
+	s.AccountLockout = Lockout{Free: 2, Base: time.Minute, Max: time.Hour, Window: time.Hour}
+
+	now := time.Now()
+	if _, err := attempts.FailAttempt(ctx, "account:username@example.com", now, now.Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Log("\ttest:0\tshould keep failures until the code is checked.")
+	{
+		var tkn Token
+		err := s.Authenticate(ctx, "username@example.com", "password123", "127.0.0.1", &tkn)
+		assert.Nil(t, err)
+		assert.Equal(t, "challenge", tkn.Challenge)
+
+		failures, _, err := attempts.Attempts(ctx, "account:username@example.com", time.Now())
+		assert.Nil(t, err)
+		assert.Equal(t, 1, failures)
+	}
+
+	twoFactor.enabled = false
+	twoFactor.err = twofactor.ErrInvalidCode
+
+	t.Log("\ttest:1\tshould count wrong codes.")
+	{
+		var tkn Token
+		err := s.Challenge(ctx, &ChallengeForm{Challenge: "challenge", Code: "000000"}, "127.0.0.1", &tkn)
+		assert.Equal(t, ErrInvalidChallenge, errors.Cause(err))
+
+		failures, _, err := attempts.Attempts(ctx, "address:127.0.0.1", time.Now())
+		assert.Nil(t, err)
+		assert.Equal(t, 1, failures)
+	}
+
+	t.Log("\ttest:2\tshould lock the account.")
+	{
+		var tkn Token
+		err := s.Challenge(ctx, &ChallengeForm{Challenge: "challenge", Code: "000000"}, "127.0.0.2", &tkn)
+		locked, ok := errors.Cause(err).(*LockedError)
+		assert.True(t, ok)
+		assert.False(t, locked.Address)
+	}
+}
+
+func TestLockoutBackoff(t *testing.T) {
+	l := Lockout{Free: 2, Base: time.Second, Max: 10 * time.Second}
+
+	tests := []struct {
+		failures int
+		backoff  time.Duration
+	}{
+		{failures: 0, backoff: 0},
+		{failures: 1, backoff: 0},
+		{failures: 2, backoff: time.Second},
+		{failures: 3, backoff: 2 * time.Second},
+		{failures: 5, backoff: 8 * time.Second},
+		{failures: 6, backoff: 10 * time.Second},
+		{failures: 100, backoff: 10 * time.Second},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.backoff, l.backoff(tc.failures), tc.failures)
+	}
+}
+
 type repositoryFunc func(ctx context.Context, email string, user *user.User) error
 
 func (r repositoryFunc) FindByEmail(ctx context.Context, email string, user *user.User) error {
 	return r(ctx, email, user)
 }
 
+func (r repositoryFunc) FindByUsername(ctx context.Context, username string, user *user.User) error {
+	return r(ctx, username, user)
+}
+
 type tokenGeneratorFunc func(ctx context.Context, claims jwt.Claims) (string, error)
 
 func (t tokenGeneratorFunc) GenerateToken(ctx context.Context, claims jwt.Claims) (string, error) {
 	return t(ctx, claims)
 }
+
+type issuerFunc func(ctx context.Context, userID int, family string) (string, error)
+
+func (i issuerFunc) Issue(ctx context.Context, userID int, family string) (string, error) {
+	return i(ctx, userID, family)
+}
+
+type twoFactorStub struct {
+	enabled bool
+	err     error
+}
+
+func (tf *twoFactorStub) Enabled(ctx context.Context, userID int) (bool, error) {
+	return tf.enabled, tf.err
+}
+
+func (tf *twoFactorStub) Check(ctx context.Context, userID int, code string) error {
+	return tf.err
+}
+
+type challengesStub struct {
+	err error
+}
+
+func (c *challengesStub) Issue(ctx context.Context, subject, purpose string, exp time.Duration) (string, error) {
+	return "challenge", c.err
+}
+
+func (c *challengesStub) Redeem(ctx context.Context, tknStr, purpose string) (string, error) {
+	return "username", c.err
+}
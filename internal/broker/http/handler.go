@@ -1,18 +1,47 @@
 package http
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/dipress/blog/internal/auth"
+	"github.com/dipress/blog/internal/comment"
+	commentCreate "github.com/dipress/blog/internal/comment/create"
+	commentUpdate "github.com/dipress/blog/internal/comment/update"
 	"github.com/dipress/blog/internal/create"
+	"github.com/dipress/blog/internal/feed"
+	"github.com/dipress/blog/internal/list"
+	"github.com/dipress/blog/internal/oidc"
+	"github.com/dipress/blog/internal/password"
 	"github.com/dipress/blog/internal/post"
+	profileUpdate "github.com/dipress/blog/internal/profile/update"
 	"github.com/dipress/blog/internal/reg"
+	"github.com/dipress/blog/internal/revision"
+	"github.com/dipress/blog/internal/role"
+	"github.com/dipress/blog/internal/search"
+	"github.com/dipress/blog/internal/sitemap"
+	"github.com/dipress/blog/internal/tag"
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/internal/token/personal"
+	"github.com/dipress/blog/internal/token/refresh"
+	"github.com/dipress/blog/internal/token/revoke"
+	"github.com/dipress/blog/internal/twofactor"
 	"github.com/dipress/blog/internal/update"
+	"github.com/dipress/blog/internal/user"
 	"github.com/dipress/blog/internal/validation"
+	"github.com/dipress/blog/internal/verify"
+	authEng "github.com/dipress/blog/kit/auth"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 )
@@ -27,6 +56,11 @@ type Finder interface {
 	Find(ctx context.Context, id int) (*post.Post, error)
 }
 
+// SlugFinder abstraction for find by slug service.
+type SlugFinder interface {
+	FindBySlug(ctx context.Context, slug string) (*post.Post, error)
+}
+
 // Updater abstraction for update service.
 type Updater interface {
 	Update(ctx context.Context, id int, f *update.Form) (*post.Post, error)
@@ -39,9 +73,55 @@ type Deleter interface {
 
 // Lister abstraction for list service.
 type Lister interface {
+	List(ctx context.Context, f *list.Form) (*post.Posts, error)
+}
+
+// RevisionLister abstraction for revision list service.
+type RevisionLister interface {
+	List(ctx context.Context, postID int) (*revision.Revisions, error)
+}
+
+// RevisionFinder abstraction for revision find service.
+type RevisionFinder interface {
+	Find(ctx context.Context, postID, number int) (*revision.Diff, error)
+}
+
+// RevisionRestorer abstraction for revision restore service.
+type RevisionRestorer interface {
+	Restore(ctx context.Context, postID, number int) (*post.Post, error)
+}
+
+// TrashLister abstraction for trash list service.
+type TrashLister interface {
 	List(ctx context.Context) (*post.Posts, error)
 }
 
+// PostRestorer abstraction for trash restore service.
+type PostRestorer interface {
+	Restore(ctx context.Context, id int) (*post.Post, error)
+}
+
+// Searcher abstraction for search service.
+type Searcher interface {
+	Search(ctx context.Context, f *search.Form) (*post.Hits, error)
+}
+
+// Feeder abstraction for feed service.
+type Feeder interface {
+	Feed(ctx context.Context, f *feed.Form) (*feed.Feed, error)
+}
+
+// Sitemapper abstraction for sitemap service.
+type Sitemapper interface {
+	Root(ctx context.Context) (*sitemap.Sitemap, error)
+	Page(ctx context.Context, number int) (*sitemap.Sitemap, error)
+}
+
+// TagLister abstraction for tag list service.
+type TagLister interface {
+	List(ctx context.Context) (*tag.Tags, error)
+}
+
 // Registrater abstraction for registrate service.
 type Registrater interface {
 	Registrate(ctx context.Context, f *reg.Form, token *reg.Token) error
@@ -49,7 +129,127 @@ type Registrater interface {
 
 // Authenticater abstraction for authenticate service.
 type Authenticater interface {
-	Authenticate(ctx context.Context, email, password string, t *auth.Token) error
+	Authenticate(ctx context.Context, email, password, ip string, t *auth.Token) error
+}
+
+// Challenger abstraction for the second step of authenticate service.
+type Challenger interface {
+	Challenge(ctx context.Context, f *auth.ChallengeForm, ip string, t *auth.Token) error
+}
+
+// LoginStarter abstraction for starting oidc login service.
+type LoginStarter interface {
+	Start(ctx context.Context, provider string) (*oidc.Redirect, error)
+}
+
+// LoginCompleter abstraction for completing oidc login service.
+type LoginCompleter interface {
+	Callback(ctx context.Context, provider string, f *oidc.CallbackForm, t *auth.Token) error
+}
+
+// Refresher abstraction for token refresh service.
+type Refresher interface {
+	Refresh(ctx context.Context, f *refresh.Form, t *refresh.Token) error
+}
+
+// Revoker abstraction for token revoke service.
+type Revoker interface {
+	Revoke(ctx context.Context, f *revoke.Form) error
+}
+
+// EmailVerifier abstraction for email verify service.
+type EmailVerifier interface {
+	Verify(ctx context.Context, f *verify.Form) error
+}
+
+// VerificationResender abstraction for email verification resend service.
+type VerificationResender interface {
+	Resend(ctx context.Context) error
+}
+
+// PasswordForgetter abstraction for forgotten password service.
+type PasswordForgetter interface {
+	Forgot(ctx context.Context, f *password.ForgotForm) error
+}
+
+// PasswordResetter abstraction for password reset service.
+type PasswordResetter interface {
+	Reset(ctx context.Context, f *password.ResetForm) error
+}
+
+// KeySet abstraction for public keys of the authenticator.
+type KeySet interface {
+	JWKS() authEng.JWKS
+}
+
+// RoleAssigner abstraction for role assign service.
+type RoleAssigner interface {
+	Assign(ctx context.Context, username string, f *role.Form) (*role.Assignment, error)
+}
+
+// ProfileFinder abstraction for profile find service.
+type ProfileFinder interface {
+	Find(ctx context.Context, username string) (*user.Profile, error)
+}
+
+// ProfileUpdater abstraction for profile update service.
+type ProfileUpdater interface {
+	Update(ctx context.Context, f *profileUpdate.Form) (*user.Profile, error)
+}
+
+// AccountExporter abstraction for account export service.
+type AccountExporter interface {
+	Export(ctx context.Context, w io.Writer) error
+}
+
+// AccountDeleter abstraction for account delete service.
+type AccountDeleter interface {
+	Delete(ctx context.Context) error
+}
+
+// TwoFactorEnroller abstraction for two-factor enroll service.
+type TwoFactorEnroller interface {
+	Enroll(ctx context.Context) (*twofactor.Enrollment, error)
+}
+
+// TwoFactorEnabler abstraction for two-factor enable service.
+type TwoFactorEnabler interface {
+	Enable(ctx context.Context, f *twofactor.Form) error
+}
+
+// PersonalTokenCreater abstraction for personal token create service.
+type PersonalTokenCreater interface {
+	Create(ctx context.Context, f *personal.Form) (*personal.Token, error)
+}
+
+// PersonalTokenLister abstraction for personal token list service.
+type PersonalTokenLister interface {
+	List(ctx context.Context) (*personal.Tokens, error)
+}
+
+// PersonalTokenRevoker abstraction for personal token revoke service.
+type PersonalTokenRevoker interface {
+	Revoke(ctx context.Context, id int) error
+}
+
+// CommentCreater abstraction for comment create service.
+type CommentCreater interface {
+	Create(ctx context.Context, postID int, f *commentCreate.Form) (*comment.Comment, error)
+}
+
+// CommentLister abstraction for comment list service.
+type CommentLister interface {
+	List(ctx context.Context, postID int) (*comment.Comments, error)
+}
+
+// CommentUpdater abstraction for comment update service.
+type CommentUpdater interface {
+	Update(ctx context.Context, postID, id int, f *commentUpdate.Form) (*comment.Comment, error)
+}
+
+// CommentDeleter abstraction for comment delete service.
+type CommentDeleter interface {
+	Delete(ctx context.Context, postID, id int) error
 }
 
 // Handler allows to handle requests.
@@ -116,10 +316,18 @@ func (a AuthHandler) Handle(w http.ResponseWriter, r *http.Request) error {
 		return errors.Wrap(badRequestResponse(w), "unmarshal json")
 	}
 
-	if err := a.Authenticater.Authenticate(r.Context(), f.Email, f.Password, &t); err != nil {
+	if err := a.Authenticater.Authenticate(r.Context(), f.Email, f.Password, remoteIP(r), &t); err != nil {
 		switch err := errors.Cause(err); err {
 		case auth.ErrNotFound, auth.ErrWrongPassword:
 			return errors.Wrap(unauthorizedResponse(w), "find user")
+		}
+
+		switch v := errors.Cause(err).(type) {
+		case *auth.LockedError:
+			if v.Address {
+				return errors.Wrap(tooManyRequestsResponse(w, v.RetryAfter), "address locked")
+			}
+			return errors.Wrap(lockedResponse(w, v.RetryAfter), "account locked")
 		default:
 			return errors.Wrap(internalServerErrorResponse(w), "authenticate")
 		}
@@ -137,14 +345,15 @@ func (a AuthHandler) Handle(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
-// CreateHandler for create requests.
-type CreateHandler struct {
-	Creater
+// ChallengeHandler for the second step of authenticate requests.
+type ChallengeHandler struct {
+	Challenger
 }
 
 // Handle implements Handler interface.
-func (h *CreateHandler) Handle(w http.ResponseWriter, r *http.Request) error {
-	var f create.Form
+func (h *ChallengeHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var f auth.ChallengeForm
+	var t auth.Token
 
 	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
@@ -155,17 +364,23 @@ func (h *CreateHandler) Handle(w http.ResponseWriter, r *http.Request) error {
 		return errors.Wrap(badRequestResponse(w), "unmarshal json")
 	}
 
-	post, err := h.Creater.Create(r.Context(), &f)
-	if err != nil {
+	if err := h.Challenger.Challenge(r.Context(), &f, remoteIP(r), &t); err != nil {
+		if errors.Cause(err) == auth.ErrInvalidChallenge {
+			return errors.Wrap(unauthorizedResponse(w), "challenge")
+		}
+
 		switch v := errors.Cause(err).(type) {
-		case validation.Errors:
-			return errors.Wrap(unprocessabeEntityResponse(w, v), "validation response")
+		case *auth.LockedError:
+			if v.Address {
+				return errors.Wrap(tooManyRequestsResponse(w, v.RetryAfter), "address locked")
+			}
+			return errors.Wrap(lockedResponse(w, v.RetryAfter), "account locked")
 		default:
-			return errors.Wrap(internalServerErrorResponse(w), "create post")
+			return errors.Wrap(internalServerErrorResponse(w), "challenge")
 		}
 	}
 
-	data, err = post.MarshalJSON()
+	data, err = t.MarshalJSON()
 	if err != nil {
 		return errors.Wrap(err, "marshal json")
 	}
@@ -177,30 +392,53 @@ func (h *CreateHandler) Handle(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
-// FindHandler for find requests.
-type FindHandler struct {
-	Finder
+// StartLoginHandler for sign in with provider requests.
+type StartLoginHandler struct {
+	LoginStarter
 }
 
 // Handle implements Handler interface.
-func (f *FindHandler) Handle(w http.ResponseWriter, r *http.Request) error {
-	vars := mux.Vars(r)
-	id, err := strconv.Atoi(vars["id"])
+func (h *StartLoginHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	redirect, err := h.LoginStarter.Start(r.Context(), mux.Vars(r)["provider"])
 	if err != nil {
-		return errors.Wrapf(badRequestResponse(w), "convert id query param to int: %v", err)
+		switch errors.Cause(err) {
+		case oidc.ErrUnknownProvider:
+			return errors.Wrap(notFoundResponse(w), "start login")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "start login")
+		}
 	}
 
-	p, err := f.Finder.Find(r.Context(), id)
+	setStateCookie(w, r, redirect.State, int(oidc.StateTTL.Seconds()))
+	http.Redirect(w, r, redirect.URL, http.StatusFound)
+
+	return nil
+}
+
+// LinkIdentityHandler for identity link requests. It responds with
+// the URL of the provider, as the browser can't follow a redirect
+// of the request with the authorization header.
+type LinkIdentityHandler struct {
+	LoginStarter
+}
+
+// Handle implements Handler interface.
+func (h *LinkIdentityHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	redirect, err := h.LoginStarter.Start(r.Context(), mux.Vars(r)["provider"])
 	if err != nil {
 		switch errors.Cause(err) {
-		case post.ErrNotFound:
-			return errors.Wrap(notFoundResponse(w), "find")
+		case oidc.ErrUnknownProvider:
+			return errors.Wrap(notFoundResponse(w), "link identity")
+		case authEng.ErrInsufficientScope:
+			return errors.Wrap(forbiddenResponse(w), "link identity")
 		default:
-			return errors.Wrap(internalServerErrorResponse(w), "find")
+			return errors.Wrap(internalServerErrorResponse(w), "link identity")
 		}
 	}
 
-	data, err := p.MarshalJSON()
+	setStateCookie(w, r, redirect.State, int(oidc.StateTTL.Seconds()))
+
+	data, err := redirect.MarshalJSON()
 	if err != nil {
 		return errors.Wrap(err, "marshal json")
 	}
@@ -212,21 +450,84 @@ func (f *FindHandler) Handle(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
-// UpdateHandler for update requests.
-type UpdateHandler struct {
-	Updater
+// LoginCallbackHandler for requests the provider
+// redirects the user back with.
+type LoginCallbackHandler struct {
+	LoginCompleter
 }
 
 // Handle implements Handler interface.
-func (h *UpdateHandler) Handle(w http.ResponseWriter, r *http.Request) error {
-	var f update.Form
-	vars := mux.Vars(r)
+func (h *LoginCallbackHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+	f := oidc.CallbackForm{
+		Code:  q.Get("code"),
+		State: q.Get("state"),
+		Error: q.Get("error"),
+	}
+	if c, err := r.Cookie(stateCookie); err == nil {
+		f.BrowserState = c.Value
+	}
+	// The state is used once, so the cookie is not needed anymore.
+	setStateCookie(w, r, "", -1)
 
-	id, err := strconv.Atoi(vars["id"])
+	var t auth.Token
+	if err := h.LoginCompleter.Callback(r.Context(), mux.Vars(r)["provider"], &f, &t); err != nil {
+		switch errors.Cause(err) {
+		case oidc.ErrUnknownProvider:
+			return errors.Wrap(notFoundResponse(w), "login callback")
+		case oidc.ErrInvalidLogin:
+			return errors.Wrap(unauthorizedResponse(w), "login callback")
+		case oidc.ErrEmailRequired:
+			ers := validation.Errors{"email": oidc.ErrEmailRequired.Error()}
+			return errors.Wrap(unprocessabeEntityResponse(w, ers), "validation response")
+		case oidc.ErrEmailExists, oidc.ErrIdentityLinked:
+			return errors.Wrap(conflictResponse(w), "login callback")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "login callback")
+		}
+	}
+
+	data, err := t.MarshalJSON()
 	if err != nil {
-		return errors.Wrapf(badRequestResponse(w), "convert id query param to int: %v", err)
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
 	}
 
+	return nil
+}
+
+// stateCookie keeps the state of the sign in with the provider
+// in the browser which started it.
+const stateCookie = "oidc_state"
+
+// setStateCookie sets the state cookie for callbacks only. It's
+// sent with the redirect of the provider, which is a top-level
+// navigation, so it's lax.
+func setStateCookie(w http.ResponseWriter, r *http.Request, state string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     stateCookie,
+		Value:    state,
+		Path:     "/oidc/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// RefreshHandler for token refresh requests.
+type RefreshHandler struct {
+	Refresher
+}
+
+// Handle implements Handler interface.
+func (h *RefreshHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var f refresh.Form
+	var t refresh.Token
+
 	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		return errors.Wrap(badRequestResponse(w), "read body")
@@ -236,17 +537,16 @@ func (h *UpdateHandler) Handle(w http.ResponseWriter, r *http.Request) error {
 		return errors.Wrap(badRequestResponse(w), "unmarshal json")
 	}
 
-	p, err := h.Updater.Update(r.Context(), id, &f)
-	if err != nil {
-		switch v := errors.Cause(err).(type) {
-		case validation.Errors:
-			return errors.Wrap(unprocessabeEntityResponse(w, v), "validation response")
+	if err := h.Refresher.Refresh(r.Context(), &f, &t); err != nil {
+		switch errors.Cause(err) {
+		case refresh.ErrInvalidToken:
+			return errors.Wrap(unauthorizedResponse(w), "refresh token")
 		default:
-			return errors.Wrap(internalServerErrorResponse(w), "update")
+			return errors.Wrap(internalServerErrorResponse(w), "refresh token")
 		}
 	}
 
-	data, err = p.MarshalJSON()
+	data, err = t.MarshalJSON()
 	if err != nil {
 		return errors.Wrap(err, "marshal json")
 	}
@@ -258,41 +558,155 @@ func (h *UpdateHandler) Handle(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
-// DeleteHandler for delete requests.
-type DeleteHandler struct {
-	Deleter
+// SignoutHandler for signout requests.
+type SignoutHandler struct {
+	Revoker
 }
 
 // Handle implements Handler interface.
-func (h DeleteHandler) Handle(w http.ResponseWriter, r *http.Request) error {
-	vars := mux.Vars(r)
-	id, err := strconv.Atoi(vars["id"])
+func (h *SignoutHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var f revoke.Form
+
+	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		return errors.Wrapf(badRequestResponse(w), "convert id query param to int: %v", err)
+		return errors.Wrap(badRequestResponse(w), "read body")
 	}
-	// TODO:
-	// check if permission error from abillity
 
-	if err := h.Deleter.Delete(r.Context(), id); err != nil {
-		return errors.Wrap(internalServerErrorResponse(w), "delete")
+	if err := f.UnmarshalJSON(data); err != nil {
+		return errors.Wrap(badRequestResponse(w), "unmarshal json")
+	}
+
+	if err := h.Revoker.Revoke(r.Context(), &f); err != nil {
+		switch errors.Cause(err) {
+		case revoke.ErrInvalidToken:
+			return errors.Wrap(unauthorizedResponse(w), "revoke token")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "revoke token")
+		}
 	}
 
 	return nil
 }
 
-// ListHandler for list requests.
-type ListHandler struct {
-	Lister
+// VerifyEmailHandler for email verification requests.
+type VerifyEmailHandler struct {
+	EmailVerifier
 }
 
 // Handle implements Handler interface.
-func (h ListHandler) Handle(w http.ResponseWriter, r *http.Request) error {
-	p, err := h.Lister.List(r.Context())
+func (h *VerifyEmailHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var f verify.Form
+
+	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		return errors.Wrap(internalServerErrorResponse(w), "list")
+		return errors.Wrap(badRequestResponse(w), "read body")
 	}
 
-	data, err := p.MarshalJSON()
+	if err := f.UnmarshalJSON(data); err != nil {
+		return errors.Wrap(badRequestResponse(w), "unmarshal json")
+	}
+
+	if err := h.EmailVerifier.Verify(r.Context(), &f); err != nil {
+		switch errors.Cause(err) {
+		case verify.ErrInvalidToken:
+			return errors.Wrap(unauthorizedResponse(w), "verify email")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "verify email")
+		}
+	}
+
+	return nil
+}
+
+// ResendVerificationHandler for email verification resend requests.
+type ResendVerificationHandler struct {
+	VerificationResender
+}
+
+// Handle implements Handler interface.
+func (h *ResendVerificationHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	if err := h.VerificationResender.Resend(r.Context()); err != nil {
+		switch errors.Cause(err) {
+		case verify.ErrVerified:
+			return errors.Wrap(conflictResponse(w), "resend verification")
+		case user.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "resend verification")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "resend verification")
+		}
+	}
+
+	return nil
+}
+
+// ForgotPasswordHandler for forgotten password requests.
+type ForgotPasswordHandler struct {
+	PasswordForgetter
+}
+
+// Handle implements Handler interface.
+func (h *ForgotPasswordHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var f password.ForgotForm
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return errors.Wrap(badRequestResponse(w), "read body")
+	}
+
+	if err := f.UnmarshalJSON(data); err != nil {
+		return errors.Wrap(badRequestResponse(w), "unmarshal json")
+	}
+
+	if err := h.PasswordForgetter.Forgot(r.Context(), &f); err != nil {
+		return errors.Wrap(internalServerErrorResponse(w), "forgot password")
+	}
+
+	return nil
+}
+
+// ResetPasswordHandler for password reset requests.
+type ResetPasswordHandler struct {
+	PasswordResetter
+}
+
+// Handle implements Handler interface.
+func (h *ResetPasswordHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var f password.ResetForm
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return errors.Wrap(badRequestResponse(w), "read body")
+	}
+
+	if err := f.UnmarshalJSON(data); err != nil {
+		return errors.Wrap(badRequestResponse(w), "unmarshal json")
+	}
+
+	if err := h.PasswordResetter.Reset(r.Context(), &f); err != nil {
+		if errors.Cause(err) == password.ErrInvalidToken {
+			return errors.Wrap(unauthorizedResponse(w), "reset password")
+		}
+		switch v := errors.Cause(err).(type) {
+		case validation.Errors:
+			return errors.Wrap(unprocessabeEntityResponse(w, v), "validation response")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "reset password")
+		}
+	}
+
+	return nil
+}
+
+// JWKSHandler for public keys requests.
+type JWKSHandler struct {
+	KeySet
+}
+
+// Handle implements Handler interface.
+func (h *JWKSHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	set := h.KeySet.JWKS()
+
+	data, err := set.MarshalJSON()
 	if err != nil {
 		return errors.Wrap(err, "marshal json")
 	}
@@ -300,9 +714,1170 @@ func (h ListHandler) Handle(w http.ResponseWriter, r *http.Request) error {
 	if _, err := w.Write(data); err != nil {
 		return errors.Wrap(err, "write response")
 	}
+
 	return nil
 }
 
+// CreateHandler for create requests.
+type CreateHandler struct {
+	Creater
+}
+
+// Handle implements Handler interface.
+func (h *CreateHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var f create.Form
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return errors.Wrap(badRequestResponse(w), "read body")
+	}
+
+	if err := f.UnmarshalJSON(data); err != nil {
+		return errors.Wrap(badRequestResponse(w), "unmarshal json")
+	}
+
+	post, err := h.Creater.Create(r.Context(), &f)
+	if err != nil {
+		if cause := errors.Cause(err); cause == create.ErrForbidden || cause == create.ErrUnverified || cause == authEng.ErrInsufficientScope {
+			return errors.Wrap(forbiddenResponse(w), "forbidden response")
+		}
+		switch v := errors.Cause(err).(type) {
+		case validation.Errors:
+			return errors.Wrap(unprocessabeEntityResponse(w, v), "validation response")
+		case *tag.SlugError:
+			ers := validation.Errors{"tags": v.Error()}
+			return errors.Wrap(unprocessabeEntityResponse(w, ers), "validation response")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "create post")
+		}
+	}
+
+	data, err = post.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+
+	return nil
+}
+
+// FindHandler for find requests.
+type FindHandler struct {
+	Finder
+}
+
+// Handle implements Handler interface.
+func (f *FindHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		return errors.Wrapf(badRequestResponse(w), "convert id query param to int: %v", err)
+	}
+
+	p, err := f.Finder.Find(r.Context(), id)
+	if err != nil {
+		switch errors.Cause(err) {
+		case post.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "find")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "find")
+		}
+	}
+
+	data, err := p.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+
+	return nil
+}
+
+// FindBySlugHandler for find by slug requests. Former slugs
+// of the post are redirected to the current one.
+type FindBySlugHandler struct {
+	SlugFinder
+}
+
+// Handle implements Handler interface.
+func (h *FindBySlugHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	slug := mux.Vars(r)["slug"]
+
+	p, err := h.SlugFinder.FindBySlug(r.Context(), slug)
+	if err != nil {
+		switch errors.Cause(err) {
+		case post.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "find by slug")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "find by slug")
+		}
+	}
+
+	if p.Slug != slug {
+		http.Redirect(w, r, "/posts/by-slug/"+url.PathEscape(p.Slug), http.StatusMovedPermanently)
+		return nil
+	}
+
+	data, err := p.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+
+	return nil
+}
+
+// UpdateHandler for update requests.
+type UpdateHandler struct {
+	Updater
+}
+
+// Handle implements Handler interface.
+func (h *UpdateHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var f update.Form
+	vars := mux.Vars(r)
+
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		return errors.Wrapf(badRequestResponse(w), "convert id query param to int: %v", err)
+	}
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return errors.Wrap(badRequestResponse(w), "read body")
+	}
+
+	if err := f.UnmarshalJSON(data); err != nil {
+		return errors.Wrap(badRequestResponse(w), "unmarshal json")
+	}
+
+	p, err := h.Updater.Update(r.Context(), id, &f)
+	if err != nil {
+		switch errors.Cause(err) {
+		case post.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "update")
+		case authEng.ErrInsufficientScope:
+			return errors.Wrap(forbiddenResponse(w), "forbidden response")
+		}
+		switch v := errors.Cause(err).(type) {
+		case validation.Errors:
+			return errors.Wrap(unprocessabeEntityResponse(w, v), "validation response")
+		case *tag.SlugError:
+			ers := validation.Errors{"tags": v.Error()}
+			return errors.Wrap(unprocessabeEntityResponse(w, ers), "validation response")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "update")
+		}
+	}
+
+	data, err = p.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+
+	return nil
+}
+
+// DeleteHandler for delete requests.
+type DeleteHandler struct {
+	Deleter
+}
+
+// Handle implements Handler interface.
+func (h DeleteHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		return errors.Wrapf(badRequestResponse(w), "convert id query param to int: %v", err)
+	}
+
+	if err := h.Deleter.Delete(r.Context(), id); err != nil {
+		switch errors.Cause(err) {
+		case post.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "delete")
+		case authEng.ErrInsufficientScope:
+			return errors.Wrap(forbiddenResponse(w), "forbidden response")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "delete")
+		}
+	}
+
+	return nil
+}
+
+// ListHandler for list requests.
+type ListHandler struct {
+	Lister
+}
+
+// Handle implements Handler interface.
+func (h ListHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var f list.Form
+	if err := parseListForm(r.URL.Query(), &f); err != nil {
+		return errors.Wrapf(badRequestResponse(w), "parse query params: %v", err)
+	}
+	f.Tag = mux.Vars(r)["slug"]
+	f.Author = mux.Vars(r)["username"]
+
+	p, err := h.Lister.List(r.Context(), &f)
+	if err != nil {
+		switch errors.Cause(err) {
+		case list.ErrInvalidCursor:
+			return errors.Wrap(badRequestResponse(w), "list")
+		case user.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "list")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "list")
+		}
+	}
+
+	data, err := p.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+	return nil
+}
+
+// FeedHandler for feed requests. Responses have ETag and Last-Modified
+// headers, so readers can poll feeds with conditional requests.
+type FeedHandler struct {
+	Feeder
+	Encoder     feed.Encoder
+	ContentType string
+	BaseURL     string
+}
+
+// Handle implements Handler interface.
+func (h *FeedHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	f := feed.Form{
+		Author: mux.Vars(r)["username"],
+		Tag:    mux.Vars(r)["slug"],
+	}
+
+	fd, err := h.Feeder.Feed(r.Context(), &f)
+	if err != nil {
+		switch errors.Cause(err) {
+		case user.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "feed")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "feed")
+		}
+	}
+
+	data, err := h.Encoder(fd, h.BaseURL, h.BaseURL+r.URL.EscapedPath())
+	if err != nil {
+		return errors.Wrap(internalServerErrorResponse(w), "encode feed")
+	}
+
+	sum := sha256.Sum256(data)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	w.Header().Set("Content-Type", h.ContentType)
+
+	// ServeContent answers conditional requests with 304.
+	http.ServeContent(w, r, "", fd.Updated, bytes.NewReader(data))
+
+	return nil
+}
+
+// SitemapHandler for sitemap requests. The root sitemap becomes
+// the index of numbered pages when posts don't fit into one.
+type SitemapHandler struct {
+	Sitemapper
+	PostPath string
+	BaseURL  string
+}
+
+// Handle implements Handler interface.
+func (h *SitemapHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var sm *sitemap.Sitemap
+	var err error
+	if v, ok := mux.Vars(r)["page"]; ok {
+		number, convErr := strconv.Atoi(v)
+		if convErr != nil {
+			return errors.Wrapf(notFoundResponse(w), "convert page param to int: %v", convErr)
+		}
+		sm, err = h.Sitemapper.Page(r.Context(), number)
+	} else {
+		sm, err = h.Sitemapper.Root(r.Context())
+	}
+	if err != nil {
+		switch errors.Cause(err) {
+		case sitemap.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "sitemap")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "sitemap")
+		}
+	}
+
+	data, err := sitemap.Encode(sm, h.BaseURL, h.PostPath)
+	if err != nil {
+		return errors.Wrap(internalServerErrorResponse(w), "encode sitemap")
+	}
+
+	w.Header().Set("Content-Type", sitemap.ContentType)
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+
+	return nil
+}
+
+// ListRevisionsHandler for revision list requests.
+type ListRevisionsHandler struct {
+	RevisionLister
+}
+
+// Handle implements Handler interface.
+func (h ListRevisionsHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	vars := mux.Vars(r)
+	postID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		return errors.Wrapf(badRequestResponse(w), "convert id query param to int: %v", err)
+	}
+
+	rs, err := h.RevisionLister.List(r.Context(), postID)
+	if err != nil {
+		switch errors.Cause(err) {
+		case post.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "list revisions")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "list revisions")
+		}
+	}
+
+	data, err := rs.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+	return nil
+}
+
+// FindRevisionHandler for revision find requests.
+type FindRevisionHandler struct {
+	RevisionFinder
+}
+
+// Handle implements Handler interface.
+func (h FindRevisionHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	vars := mux.Vars(r)
+	postID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		return errors.Wrapf(badRequestResponse(w), "convert id query param to int: %v", err)
+	}
+
+	number, err := strconv.Atoi(vars["rev"])
+	if err != nil {
+		return errors.Wrapf(badRequestResponse(w), "convert rev query param to int: %v", err)
+	}
+
+	d, err := h.RevisionFinder.Find(r.Context(), postID, number)
+	if err != nil {
+		switch errors.Cause(err) {
+		case post.ErrNotFound, revision.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "find revision")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "find revision")
+		}
+	}
+
+	data, err := d.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+	return nil
+}
+
+// RestoreRevisionHandler for revision restore requests.
+type RestoreRevisionHandler struct {
+	RevisionRestorer
+}
+
+// Handle implements Handler interface.
+func (h RestoreRevisionHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	vars := mux.Vars(r)
+	postID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		return errors.Wrapf(badRequestResponse(w), "convert id query param to int: %v", err)
+	}
+
+	number, err := strconv.Atoi(vars["rev"])
+	if err != nil {
+		return errors.Wrapf(badRequestResponse(w), "convert rev query param to int: %v", err)
+	}
+
+	p, err := h.RevisionRestorer.Restore(r.Context(), postID, number)
+	if err != nil {
+		switch errors.Cause(err) {
+		case post.ErrNotFound, revision.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "restore revision")
+		case authEng.ErrInsufficientScope:
+			return errors.Wrap(forbiddenResponse(w), "restore revision")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "restore revision")
+		}
+	}
+
+	data, err := p.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+	return nil
+}
+
+// TrashHandler for trash requests.
+type TrashHandler struct {
+	TrashLister
+}
+
+// Handle implements Handler interface.
+func (h TrashHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	p, err := h.TrashLister.List(r.Context())
+	if err != nil {
+		return errors.Wrap(internalServerErrorResponse(w), "list trash")
+	}
+
+	data, err := p.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+	return nil
+}
+
+// RestorePostHandler for post restore requests.
+type RestorePostHandler struct {
+	PostRestorer
+}
+
+// Handle implements Handler interface.
+func (h RestorePostHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		return errors.Wrapf(badRequestResponse(w), "convert id query param to int: %v", err)
+	}
+
+	p, err := h.PostRestorer.Restore(r.Context(), id)
+	if err != nil {
+		switch errors.Cause(err) {
+		case post.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "restore post")
+		case authEng.ErrInsufficientScope:
+			return errors.Wrap(forbiddenResponse(w), "restore post")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "restore post")
+		}
+	}
+
+	data, err := p.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+	return nil
+}
+
+// FindProfileHandler for profile find requests.
+type FindProfileHandler struct {
+	ProfileFinder
+}
+
+// Handle implements Handler interface.
+func (h *FindProfileHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	p, err := h.ProfileFinder.Find(r.Context(), mux.Vars(r)["username"])
+	if err != nil {
+		switch errors.Cause(err) {
+		case user.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "find profile")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "find profile")
+		}
+	}
+
+	data, err := p.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+
+	return nil
+}
+
+// UpdateProfileHandler for profile update requests.
+type UpdateProfileHandler struct {
+	ProfileUpdater
+}
+
+// Handle implements Handler interface.
+func (h *UpdateProfileHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var f profileUpdate.Form
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return errors.Wrap(badRequestResponse(w), "read body")
+	}
+
+	if err := f.UnmarshalJSON(data); err != nil {
+		return errors.Wrap(badRequestResponse(w), "unmarshal json")
+	}
+
+	p, err := h.ProfileUpdater.Update(r.Context(), &f)
+	if err != nil {
+		if errors.Cause(err) == authEng.ErrInsufficientScope {
+			return errors.Wrap(forbiddenResponse(w), "forbidden response")
+		}
+		switch v := errors.Cause(err).(type) {
+		case validation.Errors:
+			return errors.Wrap(unprocessabeEntityResponse(w, v), "validation response")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "update profile")
+		}
+	}
+
+	data, err = p.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+
+	return nil
+}
+
+// ExportAccountHandler for account export requests.
+type ExportAccountHandler struct {
+	AccountExporter
+}
+
+// Handle implements Handler interface.
+func (h *ExportAccountHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	aw := attachmentWriter{
+		ResponseWriter: w,
+		contentType:    "application/json",
+		filename:       "export.json",
+	}
+
+	if err := h.AccountExporter.Export(r.Context(), &aw); err != nil {
+		// The status is sent with the first part of the archive,
+		// the only thing left is to cut the response.
+		if aw.started {
+			return errors.Wrap(err, "export account")
+		}
+
+		switch errors.Cause(err) {
+		case user.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "export account")
+		case authEng.ErrInsufficientScope:
+			return errors.Wrap(forbiddenResponse(w), "export account")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "export account")
+		}
+	}
+
+	return nil
+}
+
+// attachmentWriter sets headers of the attachment right
+// before its first part is written, so an error response
+// can still be sent until then.
+type attachmentWriter struct {
+	http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+// Write implements io.Writer interface.
+func (aw *attachmentWriter) Write(p []byte) (int, error) {
+	if !aw.started {
+		aw.started = true
+		aw.Header().Set("Content-Type", aw.contentType)
+		aw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", aw.filename))
+	}
+	return aw.ResponseWriter.Write(p)
+}
+
+// DeleteAccountHandler for account delete requests.
+type DeleteAccountHandler struct {
+	AccountDeleter
+}
+
+// Handle implements Handler interface.
+func (h *DeleteAccountHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	if err := h.AccountDeleter.Delete(r.Context()); err != nil {
+		switch errors.Cause(err) {
+		case user.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "delete account")
+		case authEng.ErrInsufficientScope:
+			return errors.Wrap(forbiddenResponse(w), "delete account")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "delete account")
+		}
+	}
+
+	return nil
+}
+
+// EnrollTwoFactorHandler for two-factor enroll requests.
+type EnrollTwoFactorHandler struct {
+	TwoFactorEnroller
+}
+
+// Handle implements Handler interface.
+func (h *EnrollTwoFactorHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	e, err := h.TwoFactorEnroller.Enroll(r.Context())
+	if err != nil {
+		switch errors.Cause(err) {
+		case twofactor.ErrEnabled:
+			return errors.Wrap(conflictResponse(w), "enroll two-factor")
+		case user.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "enroll two-factor")
+		case authEng.ErrInsufficientScope:
+			return errors.Wrap(forbiddenResponse(w), "enroll two-factor")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "enroll two-factor")
+		}
+	}
+
+	data, err := e.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+
+	return nil
+}
+
+// EnableTwoFactorHandler for two-factor enable requests.
+type EnableTwoFactorHandler struct {
+	TwoFactorEnabler
+}
+
+// Handle implements Handler interface.
+func (h *EnableTwoFactorHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var f twofactor.Form
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return errors.Wrap(badRequestResponse(w), "read body")
+	}
+
+	if err := f.UnmarshalJSON(data); err != nil {
+		return errors.Wrap(badRequestResponse(w), "unmarshal json")
+	}
+
+	if err := h.TwoFactorEnabler.Enable(r.Context(), &f); err != nil {
+		switch errors.Cause(err) {
+		case twofactor.ErrInvalidCode:
+			ers := validation.Errors{"code": err.Error()}
+			return errors.Wrap(unprocessabeEntityResponse(w, ers), "validation response")
+		case twofactor.ErrEnabled:
+			return errors.Wrap(conflictResponse(w), "enable two-factor")
+		case twofactor.ErrNotEnrolled, user.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "enable two-factor")
+		case authEng.ErrInsufficientScope:
+			return errors.Wrap(forbiddenResponse(w), "enable two-factor")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "enable two-factor")
+		}
+	}
+
+	return nil
+}
+
+// CreatePersonalTokenHandler for personal token create requests.
+type CreatePersonalTokenHandler struct {
+	PersonalTokenCreater
+}
+
+// Handle implements Handler interface.
+func (h *CreatePersonalTokenHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var f personal.Form
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return errors.Wrap(badRequestResponse(w), "read body")
+	}
+
+	if err := f.UnmarshalJSON(data); err != nil {
+		return errors.Wrap(badRequestResponse(w), "unmarshal json")
+	}
+
+	t, err := h.PersonalTokenCreater.Create(r.Context(), &f)
+	if err != nil {
+		switch v := errors.Cause(err).(type) {
+		case validation.Errors:
+			return errors.Wrap(unprocessabeEntityResponse(w, v), "validation response")
+		default:
+			switch v {
+			case authEng.ErrInsufficientScope:
+				return errors.Wrap(forbiddenResponse(w), "create personal token")
+			case user.ErrNotFound:
+				return errors.Wrap(notFoundResponse(w), "create personal token")
+			default:
+				return errors.Wrap(internalServerErrorResponse(w), "create personal token")
+			}
+		}
+	}
+
+	data, err = t.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+
+	return nil
+}
+
+// ListPersonalTokensHandler for personal token list requests.
+type ListPersonalTokensHandler struct {
+	PersonalTokenLister
+}
+
+// Handle implements Handler interface.
+func (h *ListPersonalTokensHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	ts, err := h.PersonalTokenLister.List(r.Context())
+	if err != nil {
+		switch errors.Cause(err) {
+		case authEng.ErrInsufficientScope:
+			return errors.Wrap(forbiddenResponse(w), "list personal tokens")
+		case user.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "list personal tokens")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "list personal tokens")
+		}
+	}
+
+	data, err := ts.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+
+	return nil
+}
+
+// RevokePersonalTokenHandler for personal token revoke requests.
+type RevokePersonalTokenHandler struct {
+	PersonalTokenRevoker
+}
+
+// Handle implements Handler interface.
+func (h *RevokePersonalTokenHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		return errors.Wrapf(badRequestResponse(w), "convert id query param to int: %v", err)
+	}
+
+	if err := h.PersonalTokenRevoker.Revoke(r.Context(), id); err != nil {
+		switch errors.Cause(err) {
+		case authEng.ErrInsufficientScope:
+			return errors.Wrap(forbiddenResponse(w), "revoke personal token")
+		case token.ErrPersonalNotFound, user.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "revoke personal token")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "revoke personal token")
+		}
+	}
+
+	return nil
+}
+
+// AssignRoleHandler for role assign requests.
+type AssignRoleHandler struct {
+	RoleAssigner
+}
+
+// Handle implements Handler interface.
+func (h *AssignRoleHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var f role.Form
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return errors.Wrap(badRequestResponse(w), "read body")
+	}
+
+	if err := f.UnmarshalJSON(data); err != nil {
+		return errors.Wrap(badRequestResponse(w), "unmarshal json")
+	}
+
+	a, err := h.RoleAssigner.Assign(r.Context(), mux.Vars(r)["username"], &f)
+	if err != nil {
+		switch errors.Cause(err) {
+		case role.ErrForbidden, authEng.ErrInsufficientScope:
+			return errors.Wrap(forbiddenResponse(w), "forbidden response")
+		case user.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "assign role")
+		}
+		switch v := errors.Cause(err).(type) {
+		case validation.Errors:
+			return errors.Wrap(unprocessabeEntityResponse(w, v), "validation response")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "assign role")
+		}
+	}
+
+	data, err = a.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+
+	return nil
+}
+
+// SearchHandler for search requests.
+type SearchHandler struct {
+	Searcher
+}
+
+// Handle implements Handler interface.
+func (h SearchHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+	f := search.Form{
+		Query: q.Get("q"),
+	}
+
+	var err error
+	if v := q.Get("limit"); v != "" {
+		if f.Limit, err = strconv.Atoi(v); err != nil {
+			return errors.Wrapf(badRequestResponse(w), "convert limit to int: %v", err)
+		}
+	}
+
+	if v := q.Get("offset"); v != "" {
+		if f.Offset, err = strconv.Atoi(v); err != nil {
+			return errors.Wrapf(badRequestResponse(w), "convert offset to int: %v", err)
+		}
+	}
+
+	hits, err := h.Searcher.Search(r.Context(), &f)
+	if err != nil {
+		switch errors.Cause(err) {
+		case search.ErrEmptyQuery:
+			return errors.Wrap(badRequestResponse(w), "search")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "search")
+		}
+	}
+
+	data, err := hits.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+	return nil
+}
+
+// ListTagsHandler for tag list requests.
+type ListTagsHandler struct {
+	TagLister
+}
+
+// Handle implements Handler interface.
+func (h ListTagsHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	t, err := h.TagLister.List(r.Context())
+	if err != nil {
+		return errors.Wrap(internalServerErrorResponse(w), "list tags")
+	}
+
+	data, err := t.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+	return nil
+}
+
+// parseListForm fills list form from limit, after,
+// user_id, from, to and status query params.
+func parseListForm(q url.Values, f *list.Form) error {
+	var err error
+
+	if v := q.Get("limit"); v != "" {
+		if f.Limit, err = strconv.Atoi(v); err != nil {
+			return errors.Wrap(err, "convert limit to int")
+		}
+	}
+
+	if v := q.Get("user_id"); v != "" {
+		if f.UserID, err = strconv.Atoi(v); err != nil {
+			return errors.Wrap(err, "convert user_id to int")
+		}
+	}
+
+	if v := q.Get("from"); v != "" {
+		if f.From, err = parseDate(v); err != nil {
+			return errors.Wrap(err, "parse from")
+		}
+	}
+
+	if v := q.Get("to"); v != "" {
+		if f.To, err = parseDate(v); err != nil {
+			return errors.Wrap(err, "parse to")
+		}
+	}
+
+	f.After = q.Get("after")
+	f.Status = q.Get("status")
+
+	return nil
+}
+
+// parseDate parses RFC 3339 timestamp or plain date.
+func parseDate(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", v)
+}
+
+// CreateCommentHandler for comment create requests.
+type CreateCommentHandler struct {
+	CommentCreater
+}
+
+// Handle implements Handler interface.
+func (h *CreateCommentHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var f commentCreate.Form
+	vars := mux.Vars(r)
+
+	postID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		return errors.Wrapf(badRequestResponse(w), "convert id query param to int: %v", err)
+	}
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return errors.Wrap(badRequestResponse(w), "read body")
+	}
+
+	if err := f.UnmarshalJSON(data); err != nil {
+		return errors.Wrap(badRequestResponse(w), "unmarshal json")
+	}
+
+	c, err := h.CommentCreater.Create(r.Context(), postID, &f)
+	if err != nil {
+		switch v := errors.Cause(err).(type) {
+		case validation.Errors:
+			return errors.Wrap(unprocessabeEntityResponse(w, v), "validation response")
+		default:
+			switch v {
+			case post.ErrNotFound, comment.ErrNotFound:
+				return errors.Wrap(notFoundResponse(w), "create comment")
+			case authEng.ErrInsufficientScope:
+				return errors.Wrap(forbiddenResponse(w), "forbidden response")
+			default:
+				return errors.Wrap(internalServerErrorResponse(w), "create comment")
+			}
+		}
+	}
+
+	data, err = c.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+
+	return nil
+}
+
+// ListCommentsHandler for comment list requests.
+type ListCommentsHandler struct {
+	CommentLister
+}
+
+// Handle implements Handler interface.
+func (h ListCommentsHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	vars := mux.Vars(r)
+	postID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		return errors.Wrapf(badRequestResponse(w), "convert id query param to int: %v", err)
+	}
+
+	c, err := h.CommentLister.List(r.Context(), postID)
+	if err != nil {
+		switch errors.Cause(err) {
+		case post.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "list comments")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "list comments")
+		}
+	}
+
+	data, err := c.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+	return nil
+}
+
+// UpdateCommentHandler for comment update requests.
+type UpdateCommentHandler struct {
+	CommentUpdater
+}
+
+// Handle implements Handler interface.
+func (h *UpdateCommentHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var f commentUpdate.Form
+	vars := mux.Vars(r)
+
+	postID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		return errors.Wrapf(badRequestResponse(w), "convert id query param to int: %v", err)
+	}
+
+	id, err := strconv.Atoi(vars["comment_id"])
+	if err != nil {
+		return errors.Wrapf(badRequestResponse(w), "convert comment_id query param to int: %v", err)
+	}
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return errors.Wrap(badRequestResponse(w), "read body")
+	}
+
+	if err := f.UnmarshalJSON(data); err != nil {
+		return errors.Wrap(badRequestResponse(w), "unmarshal json")
+	}
+
+	c, err := h.CommentUpdater.Update(r.Context(), postID, id, &f)
+	if err != nil {
+		switch v := errors.Cause(err).(type) {
+		case validation.Errors:
+			return errors.Wrap(unprocessabeEntityResponse(w, v), "validation response")
+		default:
+			switch v {
+			case comment.ErrNotFound:
+				return errors.Wrap(notFoundResponse(w), "update comment")
+			case authEng.ErrInsufficientScope:
+				return errors.Wrap(forbiddenResponse(w), "forbidden response")
+			default:
+				return errors.Wrap(internalServerErrorResponse(w), "update comment")
+			}
+		}
+	}
+
+	data, err = c.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+
+	return nil
+}
+
+// DeleteCommentHandler for comment delete requests.
+type DeleteCommentHandler struct {
+	CommentDeleter
+}
+
+// Handle implements Handler interface.
+func (h DeleteCommentHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	vars := mux.Vars(r)
+
+	postID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		return errors.Wrapf(badRequestResponse(w), "convert id query param to int: %v", err)
+	}
+
+	id, err := strconv.Atoi(vars["comment_id"])
+	if err != nil {
+		return errors.Wrapf(badRequestResponse(w), "convert comment_id query param to int: %v", err)
+	}
+
+	if err := h.CommentDeleter.Delete(r.Context(), postID, id); err != nil {
+		switch errors.Cause(err) {
+		case post.ErrNotFound, comment.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w), "delete comment")
+		case authEng.ErrInsufficientScope:
+			return errors.Wrap(forbiddenResponse(w), "delete comment")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w), "delete comment")
+		}
+	}
+
+	return nil
+}
+
+// remoteIP returns the ip address of the client. Behind
+// a trusted proxy it's set by the proxy middleware.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 // httpHandler allows to implement ServeHTTP for Handler.
 type httpHandler struct {
 	Handler
@@ -3,17 +3,40 @@ package http
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dipress/blog/internal/auth"
+	"github.com/dipress/blog/internal/comment"
+	commentCreate "github.com/dipress/blog/internal/comment/create"
+	commentUpdate "github.com/dipress/blog/internal/comment/update"
 	"github.com/dipress/blog/internal/create"
+	"github.com/dipress/blog/internal/feed"
+	"github.com/dipress/blog/internal/list"
+	"github.com/dipress/blog/internal/oidc"
+	"github.com/dipress/blog/internal/password"
 	"github.com/dipress/blog/internal/post"
+	profileUpdate "github.com/dipress/blog/internal/profile/update"
 	"github.com/dipress/blog/internal/reg"
+	"github.com/dipress/blog/internal/revision"
+	"github.com/dipress/blog/internal/role"
+	"github.com/dipress/blog/internal/search"
+	"github.com/dipress/blog/internal/sitemap"
+	"github.com/dipress/blog/internal/tag"
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/internal/token/personal"
+	"github.com/dipress/blog/internal/token/refresh"
+	"github.com/dipress/blog/internal/token/revoke"
+	"github.com/dipress/blog/internal/twofactor"
 	"github.com/dipress/blog/internal/update"
+	"github.com/dipress/blog/internal/user"
 	"github.com/dipress/blog/internal/validation"
+	"github.com/dipress/blog/internal/verify"
+	authEng "github.com/dipress/blog/kit/auth"
 	"github.com/gorilla/mux"
 )
 
@@ -37,6 +60,34 @@ func TestCreateHandler(t *testing.T) {
 			},
 			code: http.StatusUnprocessableEntity,
 		},
+		{
+			name: "tag slug taken",
+			createrFunc: func(ctx context.Context, f *create.Form) (*post.Post, error) {
+				return nil, &tag.SlugError{Name: "c plus plus", Taken: "c++"}
+			},
+			code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "forbidden",
+			createrFunc: func(ctx context.Context, f *create.Form) (*post.Post, error) {
+				return nil, create.ErrForbidden
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			name: "unverified",
+			createrFunc: func(ctx context.Context, f *create.Form) (*post.Post, error) {
+				return nil, create.ErrUnverified
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			name: "insufficient scope",
+			createrFunc: func(ctx context.Context, f *create.Form) (*post.Post, error) {
+				return nil, authEng.ErrInsufficientScope
+			},
+			code: http.StatusForbidden,
+		},
 		{
 			name: "internal error",
 			createrFunc: func(ctx context.Context, f *create.Form) (*post.Post, error) {
@@ -113,6 +164,265 @@ func (f findFunc) Find(ctx context.Context, id int) (*post.Post, error) {
 	return f(ctx, id)
 }
 
+func TestFindBySlugHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		findFunc func(ctx context.Context, slug string) (*post.Post, error)
+		code     int
+		location string
+	}{
+		{
+			name: "ok",
+			findFunc: func(ctx context.Context, slug string) (*post.Post, error) {
+				return &post.Post{Slug: slug}, nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "former slug",
+			findFunc: func(ctx context.Context, slug string) (*post.Post, error) {
+				return &post.Post{Slug: "new-title"}, nil
+			},
+			code:     http.StatusMovedPermanently,
+			location: "/posts/by-slug/new-title",
+		},
+		{
+			name: "not found",
+			findFunc: func(ctx context.Context, slug string) (*post.Post, error) {
+				return nil, post.ErrNotFound
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			findFunc: func(ctx context.Context, slug string) (*post.Post, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := FindBySlugHandler{slugFinderFunc(tc.findFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://example.com", nil)
+			r = mux.SetURLVars(r, map[string]string{"slug": "old-title"})
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+			if location := w.Header().Get("Location"); location != tc.location {
+				t.Errorf("unexpected location: %s expected %s", location, tc.location)
+			}
+		})
+	}
+}
+
+type slugFinderFunc func(ctx context.Context, slug string) (*post.Post, error)
+
+func (f slugFinderFunc) FindBySlug(ctx context.Context, slug string) (*post.Post, error) {
+	return f(ctx, slug)
+}
+
+func TestFeedHandler(t *testing.T) {
+	updated := time.Date(2019, 7, 20, 10, 0, 0, 0, time.UTC)
+	ok := func(ctx context.Context, f *feed.Form) (*feed.Feed, error) {
+		return &feed.Feed{Title: "Posts", Path: "/posts", Updated: updated}, nil
+	}
+
+	h := FeedHandler{Feeder: feederFunc(ok), Encoder: feed.JSON, ContentType: feed.ContentTypeJSON, BaseURL: "https://blog.example.com"}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "http://example.com/feed.json", nil)
+	if err := h.Handle(w, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(w.Body.String(), `"feed_url":"https://blog.example.com/feed.json"`) {
+		t.Errorf("expected feed url of the base url in: %s", w.Body.String())
+	}
+	etag := w.Header().Get("ETag")
+
+	tests := []struct {
+		name     string
+		feedFunc func(ctx context.Context, f *feed.Form) (*feed.Feed, error)
+		header   map[string]string
+		code     int
+	}{
+		{
+			name:     "ok",
+			feedFunc: ok,
+			code:     http.StatusOK,
+		},
+		{
+			name:     "same etag",
+			feedFunc: ok,
+			header:   map[string]string{"If-None-Match": etag},
+			code:     http.StatusNotModified,
+		},
+		{
+			name:     "other etag",
+			feedFunc: ok,
+			header:   map[string]string{"If-None-Match": `"other"`},
+			code:     http.StatusOK,
+		},
+		{
+			name:     "not modified since",
+			feedFunc: ok,
+			header:   map[string]string{"If-Modified-Since": updated.Format(http.TimeFormat)},
+			code:     http.StatusNotModified,
+		},
+		{
+			name:     "modified since",
+			feedFunc: ok,
+			header:   map[string]string{"If-Modified-Since": updated.Add(-time.Hour).Format(http.TimeFormat)},
+			code:     http.StatusOK,
+		},
+		{
+			name: "unknown author",
+			feedFunc: func(ctx context.Context, f *feed.Form) (*feed.Feed, error) {
+				return nil, user.ErrNotFound
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			feedFunc: func(ctx context.Context, f *feed.Form) (*feed.Feed, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := FeedHandler{Feeder: feederFunc(tc.feedFunc), Encoder: feed.JSON, ContentType: feed.ContentTypeJSON, BaseURL: "https://blog.example.com"}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://example.com/feed.json", nil)
+			for k, v := range tc.header {
+				r.Header.Set(k, v)
+			}
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+
+	t.Run("headers", func(t *testing.T) {
+		if etag == "" {
+			t.Error("expected etag")
+		}
+		if lm := w.Header().Get("Last-Modified"); lm != updated.Format(http.TimeFormat) {
+			t.Errorf("unexpected last modified: %s", lm)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != feed.ContentTypeJSON {
+			t.Errorf("unexpected content type: %s", ct)
+		}
+	})
+}
+
+type feederFunc func(ctx context.Context, f *feed.Form) (*feed.Feed, error)
+
+func (f feederFunc) Feed(ctx context.Context, fm *feed.Form) (*feed.Feed, error) {
+	return f(ctx, fm)
+}
+
+func TestSitemapHandler(t *testing.T) {
+	entries := &sitemap.Sitemap{Entries: []sitemap.Entry{{Slug: "my-title"}}}
+
+	tests := []struct {
+		name     string
+		page     string
+		s        sitemapper
+		code     int
+		contains string
+	}{
+		{
+			name: "root",
+			s: sitemapper{root: func(ctx context.Context) (*sitemap.Sitemap, error) {
+				return entries, nil
+			}},
+			code:     http.StatusOK,
+			contains: "<loc>https://blog.example.com/p/my-title</loc>",
+		},
+		{
+			name: "index",
+			s: sitemapper{root: func(ctx context.Context) (*sitemap.Sitemap, error) {
+				return &sitemap.Sitemap{Pages: 2}, nil
+			}},
+			code:     http.StatusOK,
+			contains: "<loc>https://blog.example.com/sitemap-2.xml</loc>",
+		},
+		{
+			name: "page",
+			page: "2",
+			s: sitemapper{page: func(ctx context.Context, number int) (*sitemap.Sitemap, error) {
+				if number != 2 {
+					return nil, sitemap.ErrNotFound
+				}
+				return entries, nil
+			}},
+			code:     http.StatusOK,
+			contains: "<loc>https://blog.example.com/p/my-title</loc>",
+		},
+		{
+			name: "page not found",
+			page: "3",
+			s: sitemapper{page: func(ctx context.Context, number int) (*sitemap.Sitemap, error) {
+				return nil, sitemap.ErrNotFound
+			}},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			s: sitemapper{root: func(ctx context.Context) (*sitemap.Sitemap, error) {
+				return nil, errors.New("mock error")
+			}},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := SitemapHandler{Sitemapper: tc.s, PostPath: "/p/", BaseURL: "https://blog.example.com"}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://example.com/sitemap.xml", nil)
+			if tc.page != "" {
+				r = mux.SetURLVars(r, map[string]string{"page": tc.page})
+			}
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+			if !strings.Contains(w.Body.String(), tc.contains) {
+				t.Errorf("body doesn't contain %s: %s", tc.contains, w.Body.String())
+			}
+		})
+	}
+}
+
+type sitemapper struct {
+	root func(ctx context.Context) (*sitemap.Sitemap, error)
+	page func(ctx context.Context, number int) (*sitemap.Sitemap, error)
+}
+
+func (s sitemapper) Root(ctx context.Context) (*sitemap.Sitemap, error) {
+	return s.root(ctx)
+}
+
+func (s sitemapper) Page(ctx context.Context, number int) (*sitemap.Sitemap, error) {
+	return s.page(ctx, number)
+}
+
 func TestUpdateHandler(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -133,6 +443,20 @@ func TestUpdateHandler(t *testing.T) {
 			},
 			code: http.StatusUnprocessableEntity,
 		},
+		{
+			name: "not found",
+			updateFunc: func(ctx context.Context, id int, f *update.Form) (*post.Post, error) {
+				return nil, post.ErrNotFound
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "insufficient scope",
+			updateFunc: func(ctx context.Context, id int, f *update.Form) (*post.Post, error) {
+				return nil, authEng.ErrInsufficientScope
+			},
+			code: http.StatusForbidden,
+		},
 		{
 			name: "internal error",
 			updateFunc: func(ctx context.Context, id int, f *update.Form) (*post.Post, error) {
@@ -178,6 +502,20 @@ func TestDeleteHandler(t *testing.T) {
 			},
 			code: http.StatusOK,
 		},
+		{
+			name: "not found",
+			deleteFunc: func(ctx context.Context, id int) error {
+				return post.ErrNotFound
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "insufficient scope",
+			deleteFunc: func(ctx context.Context, id int) error {
+				return authEng.ErrInsufficientScope
+			},
+			code: http.StatusForbidden,
+		},
 		{
 			name: "internal error",
 			deleteFunc: func(ctx context.Context, id int) error {
@@ -213,19 +551,76 @@ func (d deleteFunc) Delete(ctx context.Context, id int) error {
 func TestListHandler(t *testing.T) {
 	tests := []struct {
 		name     string
-		listFunc func(ctx context.Context) (*post.Posts, error)
+		query    string
+		vars     map[string]string
+		listFunc func(ctx context.Context, f *list.Form) (*post.Posts, error)
 		code     int
 	}{
 		{
-			name: "ok",
-			listFunc: func(ctx context.Context) (*post.Posts, error) {
+			name:  "ok",
+			query: "?limit=10&user_id=1&from=2019-01-01&to=2019-07-01T00:00:00Z",
+			listFunc: func(ctx context.Context, f *list.Form) (*post.Posts, error) {
+				return &post.Posts{}, nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "by tag",
+			vars: map[string]string{"slug": "go"},
+			listFunc: func(ctx context.Context, f *list.Form) (*post.Posts, error) {
+				if f.Tag != "go" {
+					return nil, errors.New("mock error")
+				}
+				return &post.Posts{}, nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "by author",
+			vars: map[string]string{"username": "john"},
+			listFunc: func(ctx context.Context, f *list.Form) (*post.Posts, error) {
+				if f.Author != "john" {
+					return nil, errors.New("mock error")
+				}
 				return &post.Posts{}, nil
 			},
 			code: http.StatusOK,
 		},
+		{
+			name: "author not found",
+			vars: map[string]string{"username": "john"},
+			listFunc: func(ctx context.Context, f *list.Form) (*post.Posts, error) {
+				return nil, user.ErrNotFound
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name:  "wrong limit",
+			query: "?limit=ten",
+			listFunc: func(ctx context.Context, f *list.Form) (*post.Posts, error) {
+				return &post.Posts{}, nil
+			},
+			code: http.StatusBadRequest,
+		},
+		{
+			name:  "wrong date",
+			query: "?from=yesterday",
+			listFunc: func(ctx context.Context, f *list.Form) (*post.Posts, error) {
+				return &post.Posts{}, nil
+			},
+			code: http.StatusBadRequest,
+		},
+		{
+			name:  "invalid cursor",
+			query: "?after=wrong",
+			listFunc: func(ctx context.Context, f *list.Form) (*post.Posts, error) {
+				return nil, list.ErrInvalidCursor
+			},
+			code: http.StatusBadRequest,
+		},
 		{
 			name: "internal error",
-			listFunc: func(ctx context.Context) (*post.Posts, error) {
+			listFunc: func(ctx context.Context, f *list.Form) (*post.Posts, error) {
 				return &post.Posts{}, errors.New("mock error")
 			},
 			code: http.StatusInternalServerError,
@@ -238,7 +633,8 @@ func TestListHandler(t *testing.T) {
 			t.Parallel()
 			h := ListHandler{listFunc(tc.listFunc)}
 			w := httptest.NewRecorder()
-			r := httptest.NewRequest("GET", "http://example.com", strings.NewReader("{}"))
+			r := httptest.NewRequest("GET", "http://example.com"+tc.query, strings.NewReader("{}"))
+			r = mux.SetURLVars(r, tc.vars)
 
 			err := h.Handle(w, r)
 			if w.Code != tc.code {
@@ -248,36 +644,36 @@ func TestListHandler(t *testing.T) {
 	}
 }
 
-type listFunc func(ctx context.Context) (*post.Posts, error)
+type listFunc func(ctx context.Context, f *list.Form) (*post.Posts, error)
 
-func (l listFunc) List(ctx context.Context) (*post.Posts, error) {
-	return l(ctx)
+func (l listFunc) List(ctx context.Context, f *list.Form) (*post.Posts, error) {
+	return l(ctx, f)
 }
 
-func TestRegHandler(t *testing.T) {
+func TestListRevisionsHandler(t *testing.T) {
 	tests := []struct {
-		name    string
-		regFunc func(ctx context.Context, f *reg.Form, token *reg.Token) error
-		code    int
+		name     string
+		listFunc func(ctx context.Context, postID int) (*revision.Revisions, error)
+		code     int
 	}{
 		{
 			name: "ok",
-			regFunc: func(ctx context.Context, f *reg.Form, token *reg.Token) error {
-				return nil
+			listFunc: func(ctx context.Context, postID int) (*revision.Revisions, error) {
+				return &revision.Revisions{}, nil
 			},
 			code: http.StatusOK,
 		},
 		{
-			name: "validation errors",
-			regFunc: func(ctx context.Context, f *reg.Form, token *reg.Token) error {
-				return make(validation.Errors)
+			name: "post not found",
+			listFunc: func(ctx context.Context, postID int) (*revision.Revisions, error) {
+				return nil, post.ErrNotFound
 			},
-			code: http.StatusUnprocessableEntity,
+			code: http.StatusNotFound,
 		},
 		{
 			name: "internal error",
-			regFunc: func(ctx context.Context, f *reg.Form, token *reg.Token) error {
-				return errors.New("mock error")
+			listFunc: func(ctx context.Context, postID int) (*revision.Revisions, error) {
+				return nil, errors.New("mock error")
 			},
 			code: http.StatusInternalServerError,
 		},
@@ -287,9 +683,10 @@ func TestRegHandler(t *testing.T) {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			h := RegHandler{regFunc(tc.regFunc)}
+			h := ListRevisionsHandler{revisionListerFunc(tc.listFunc)}
 			w := httptest.NewRecorder()
-			r := httptest.NewRequest("POST", "http://example.com", strings.NewReader("{}"))
+			r := httptest.NewRequest("GET", "http://example.com", nil)
+			r = mux.SetURLVars(r, map[string]string{"id": "1"})
 
 			err := h.Handle(w, r)
 			if w.Code != tc.code {
@@ -299,43 +696,48 @@ func TestRegHandler(t *testing.T) {
 	}
 }
 
-type regFunc func(ctx context.Context, f *reg.Form, token *reg.Token) error
+type revisionListerFunc func(ctx context.Context, postID int) (*revision.Revisions, error)
 
-func (r regFunc) Registrate(ctx context.Context, f *reg.Form, token *reg.Token) error {
-	return r(ctx, f, token)
+func (l revisionListerFunc) List(ctx context.Context, postID int) (*revision.Revisions, error) {
+	return l(ctx, postID)
 }
 
-func TestAuthHandler(t *testing.T) {
+func TestFindRevisionHandler(t *testing.T) {
 	tests := []struct {
 		name     string
-		authFunc func(ctx context.Context, email, password string, t *auth.Token) error
+		vars     map[string]string
+		findFunc func(ctx context.Context, postID, number int) (*revision.Diff, error)
 		code     int
 	}{
 		{
 			name: "ok",
-			authFunc: func(ctx context.Context, email, password string, t *auth.Token) error {
-				return nil
+			vars: map[string]string{"id": "1", "rev": "1"},
+			findFunc: func(ctx context.Context, postID, number int) (*revision.Diff, error) {
+				return &revision.Diff{}, nil
 			},
 			code: http.StatusOK,
 		},
 		{
-			name: "email error",
-			authFunc: func(ctx context.Context, email, password string, t *auth.Token) error {
-				return auth.ErrNotFound
+			name: "wrong rev",
+			vars: map[string]string{"id": "1", "rev": "first"},
+			findFunc: func(ctx context.Context, postID, number int) (*revision.Diff, error) {
+				return &revision.Diff{}, nil
 			},
-			code: http.StatusUnauthorized,
+			code: http.StatusBadRequest,
 		},
 		{
-			name: "password error",
-			authFunc: func(ctx context.Context, email, password string, t *auth.Token) error {
-				return auth.ErrWrongPassword
+			name: "revision not found",
+			vars: map[string]string{"id": "1", "rev": "1"},
+			findFunc: func(ctx context.Context, postID, number int) (*revision.Diff, error) {
+				return nil, revision.ErrNotFound
 			},
-			code: http.StatusUnauthorized,
+			code: http.StatusNotFound,
 		},
 		{
 			name: "internal error",
-			authFunc: func(ctx context.Context, email, password string, t *auth.Token) error {
-				return errors.New("mock error")
+			vars: map[string]string{"id": "1", "rev": "1"},
+			findFunc: func(ctx context.Context, postID, number int) (*revision.Diff, error) {
+				return nil, errors.New("mock error")
 			},
 			code: http.StatusInternalServerError,
 		},
@@ -345,9 +747,1767 @@ func TestAuthHandler(t *testing.T) {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			h := AuthHandler{authFunc(tc.authFunc)}
+			h := FindRevisionHandler{revisionFinderFunc(tc.findFunc)}
 			w := httptest.NewRecorder()
-			r := httptest.NewRequest("POST", "http://example.com", strings.NewReader("{}"))
+			r := httptest.NewRequest("GET", "http://example.com", nil)
+			r = mux.SetURLVars(r, tc.vars)
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type revisionFinderFunc func(ctx context.Context, postID, number int) (*revision.Diff, error)
+
+func (f revisionFinderFunc) Find(ctx context.Context, postID, number int) (*revision.Diff, error) {
+	return f(ctx, postID, number)
+}
+
+func TestRestoreRevisionHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		restoreFunc func(ctx context.Context, postID, number int) (*post.Post, error)
+		code        int
+	}{
+		{
+			name: "ok",
+			restoreFunc: func(ctx context.Context, postID, number int) (*post.Post, error) {
+				return &post.Post{}, nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "post not found",
+			restoreFunc: func(ctx context.Context, postID, number int) (*post.Post, error) {
+				return nil, post.ErrNotFound
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			restoreFunc: func(ctx context.Context, postID, number int) (*post.Post, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := RestoreRevisionHandler{revisionRestorerFunc(tc.restoreFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://example.com", nil)
+			r = mux.SetURLVars(r, map[string]string{"id": "1", "rev": "1"})
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type revisionRestorerFunc func(ctx context.Context, postID, number int) (*post.Post, error)
+
+func (r revisionRestorerFunc) Restore(ctx context.Context, postID, number int) (*post.Post, error) {
+	return r(ctx, postID, number)
+}
+
+func TestTrashHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		listFunc func(ctx context.Context) (*post.Posts, error)
+		code     int
+	}{
+		{
+			name: "ok",
+			listFunc: func(ctx context.Context) (*post.Posts, error) {
+				return &post.Posts{}, nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "internal error",
+			listFunc: func(ctx context.Context) (*post.Posts, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := TrashHandler{trashListerFunc(tc.listFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://example.com", nil)
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type trashListerFunc func(ctx context.Context) (*post.Posts, error)
+
+func (l trashListerFunc) List(ctx context.Context) (*post.Posts, error) {
+	return l(ctx)
+}
+
+func TestRestorePostHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		restoreFunc func(ctx context.Context, id int) (*post.Post, error)
+		code        int
+	}{
+		{
+			name: "ok",
+			restoreFunc: func(ctx context.Context, id int) (*post.Post, error) {
+				return &post.Post{}, nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "post not found",
+			restoreFunc: func(ctx context.Context, id int) (*post.Post, error) {
+				return nil, post.ErrNotFound
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			restoreFunc: func(ctx context.Context, id int) (*post.Post, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := RestorePostHandler{postRestorerFunc(tc.restoreFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://example.com", nil)
+			r = mux.SetURLVars(r, map[string]string{"id": "1"})
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type postRestorerFunc func(ctx context.Context, id int) (*post.Post, error)
+
+func (r postRestorerFunc) Restore(ctx context.Context, id int) (*post.Post, error) {
+	return r(ctx, id)
+}
+
+func TestFindProfileHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		findFunc func(ctx context.Context, username string) (*user.Profile, error)
+		code     int
+	}{
+		{
+			name: "ok",
+			findFunc: func(ctx context.Context, username string) (*user.Profile, error) {
+				return &user.Profile{Username: username}, nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "user not found",
+			findFunc: func(ctx context.Context, username string) (*user.Profile, error) {
+				return nil, user.ErrNotFound
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			findFunc: func(ctx context.Context, username string) (*user.Profile, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := FindProfileHandler{profileFinderFunc(tc.findFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://example.com", nil)
+			r = mux.SetURLVars(r, map[string]string{"username": "john"})
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type profileFinderFunc func(ctx context.Context, username string) (*user.Profile, error)
+
+func (p profileFinderFunc) Find(ctx context.Context, username string) (*user.Profile, error) {
+	return p(ctx, username)
+}
+
+func TestUpdateProfileHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		updateFunc func(ctx context.Context, f *profileUpdate.Form) (*user.Profile, error)
+		code       int
+	}{
+		{
+			name: "ok",
+			updateFunc: func(ctx context.Context, f *profileUpdate.Form) (*user.Profile, error) {
+				return &user.Profile{}, nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "validation errors",
+			updateFunc: func(ctx context.Context, f *profileUpdate.Form) (*user.Profile, error) {
+				return nil, make(validation.Errors)
+			},
+			code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "internal error",
+			updateFunc: func(ctx context.Context, f *profileUpdate.Form) (*user.Profile, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := UpdateProfileHandler{profileUpdaterFunc(tc.updateFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("PATCH", "http://example.com", strings.NewReader("{}"))
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type profileUpdaterFunc func(ctx context.Context, f *profileUpdate.Form) (*user.Profile, error)
+
+func (p profileUpdaterFunc) Update(ctx context.Context, f *profileUpdate.Form) (*user.Profile, error) {
+	return p(ctx, f)
+}
+
+func TestExportAccountHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		exportFunc func(ctx context.Context, w io.Writer) error
+		code       int
+	}{
+		{
+			name: "ok",
+			exportFunc: func(ctx context.Context, w io.Writer) error {
+				_, err := io.WriteString(w, "{}")
+				return err
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "user not found",
+			exportFunc: func(ctx context.Context, w io.Writer) error {
+				return user.ErrNotFound
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			exportFunc: func(ctx context.Context, w io.Writer) error {
+				return errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+		{
+			name: "error after start",
+			exportFunc: func(ctx context.Context, w io.Writer) error {
+				if _, err := io.WriteString(w, "{"); err != nil {
+					return err
+				}
+				return errors.New("mock error")
+			},
+			code: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := ExportAccountHandler{accountExporterFunc(tc.exportFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://example.com", nil)
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type accountExporterFunc func(ctx context.Context, w io.Writer) error
+
+func (a accountExporterFunc) Export(ctx context.Context, w io.Writer) error {
+	return a(ctx, w)
+}
+
+func TestDeleteAccountHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		deleteFunc func(ctx context.Context) error
+		code       int
+	}{
+		{
+			name: "ok",
+			deleteFunc: func(ctx context.Context) error {
+				return nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "user not found",
+			deleteFunc: func(ctx context.Context) error {
+				return user.ErrNotFound
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			deleteFunc: func(ctx context.Context) error {
+				return errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := DeleteAccountHandler{accountDeleterFunc(tc.deleteFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("DELETE", "http://example.com", nil)
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type accountDeleterFunc func(ctx context.Context) error
+
+func (a accountDeleterFunc) Delete(ctx context.Context) error {
+	return a(ctx)
+}
+
+func TestEnrollTwoFactorHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		enrollFunc func(ctx context.Context) (*twofactor.Enrollment, error)
+		code       int
+	}{
+		{
+			name: "ok",
+			enrollFunc: func(ctx context.Context) (*twofactor.Enrollment, error) {
+				return &twofactor.Enrollment{}, nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "enabled",
+			enrollFunc: func(ctx context.Context) (*twofactor.Enrollment, error) {
+				return nil, twofactor.ErrEnabled
+			},
+			code: http.StatusConflict,
+		},
+		{
+			name: "personal token",
+			enrollFunc: func(ctx context.Context) (*twofactor.Enrollment, error) {
+				return nil, authEng.ErrInsufficientScope
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			name: "internal error",
+			enrollFunc: func(ctx context.Context) (*twofactor.Enrollment, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := EnrollTwoFactorHandler{twoFactorEnrollerFunc(tc.enrollFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://example.com", nil)
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type twoFactorEnrollerFunc func(ctx context.Context) (*twofactor.Enrollment, error)
+
+func (e twoFactorEnrollerFunc) Enroll(ctx context.Context) (*twofactor.Enrollment, error) {
+	return e(ctx)
+}
+
+func TestEnableTwoFactorHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		enableFunc func(ctx context.Context, f *twofactor.Form) error
+		code       int
+	}{
+		{
+			name: "ok",
+			enableFunc: func(ctx context.Context, f *twofactor.Form) error {
+				return nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "invalid code",
+			enableFunc: func(ctx context.Context, f *twofactor.Form) error {
+				return twofactor.ErrInvalidCode
+			},
+			code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "not enrolled",
+			enableFunc: func(ctx context.Context, f *twofactor.Form) error {
+				return twofactor.ErrNotEnrolled
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "enabled",
+			enableFunc: func(ctx context.Context, f *twofactor.Form) error {
+				return twofactor.ErrEnabled
+			},
+			code: http.StatusConflict,
+		},
+		{
+			name: "internal error",
+			enableFunc: func(ctx context.Context, f *twofactor.Form) error {
+				return errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := EnableTwoFactorHandler{twoFactorEnablerFunc(tc.enableFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://example.com", strings.NewReader("{}"))
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type twoFactorEnablerFunc func(ctx context.Context, f *twofactor.Form) error
+
+func (e twoFactorEnablerFunc) Enable(ctx context.Context, f *twofactor.Form) error {
+	return e(ctx, f)
+}
+
+func TestAssignRoleHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		assignFunc func(ctx context.Context, username string, f *role.Form) (*role.Assignment, error)
+		code       int
+	}{
+		{
+			name: "ok",
+			assignFunc: func(ctx context.Context, username string, f *role.Form) (*role.Assignment, error) {
+				return &role.Assignment{}, nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "validation errors",
+			assignFunc: func(ctx context.Context, username string, f *role.Form) (*role.Assignment, error) {
+				return nil, make(validation.Errors)
+			},
+			code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "forbidden",
+			assignFunc: func(ctx context.Context, username string, f *role.Form) (*role.Assignment, error) {
+				return nil, role.ErrForbidden
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			name: "user not found",
+			assignFunc: func(ctx context.Context, username string, f *role.Form) (*role.Assignment, error) {
+				return nil, user.ErrNotFound
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			assignFunc: func(ctx context.Context, username string, f *role.Form) (*role.Assignment, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := AssignRoleHandler{roleAssignerFunc(tc.assignFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("PUT", "http://example.com", strings.NewReader("{}"))
+			r = mux.SetURLVars(r, map[string]string{"username": "john"})
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type roleAssignerFunc func(ctx context.Context, username string, f *role.Form) (*role.Assignment, error)
+
+func (a roleAssignerFunc) Assign(ctx context.Context, username string, f *role.Form) (*role.Assignment, error) {
+	return a(ctx, username, f)
+}
+
+func TestSearchHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		searchFunc func(ctx context.Context, f *search.Form) (*post.Hits, error)
+		code       int
+	}{
+		{
+			name:  "ok",
+			query: "?q=golang&limit=10&offset=10",
+			searchFunc: func(ctx context.Context, f *search.Form) (*post.Hits, error) {
+				return &post.Hits{}, nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name:  "wrong offset",
+			query: "?q=golang&offset=ten",
+			searchFunc: func(ctx context.Context, f *search.Form) (*post.Hits, error) {
+				return &post.Hits{}, nil
+			},
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "empty query",
+			searchFunc: func(ctx context.Context, f *search.Form) (*post.Hits, error) {
+				return nil, search.ErrEmptyQuery
+			},
+			code: http.StatusBadRequest,
+		},
+		{
+			name:  "internal error",
+			query: "?q=golang",
+			searchFunc: func(ctx context.Context, f *search.Form) (*post.Hits, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := SearchHandler{searcherFunc(tc.searchFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://example.com"+tc.query, strings.NewReader("{}"))
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type searcherFunc func(ctx context.Context, f *search.Form) (*post.Hits, error)
+
+func (s searcherFunc) Search(ctx context.Context, f *search.Form) (*post.Hits, error) {
+	return s(ctx, f)
+}
+
+func TestListTagsHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		listFunc func(ctx context.Context) (*tag.Tags, error)
+		code     int
+	}{
+		{
+			name: "ok",
+			listFunc: func(ctx context.Context) (*tag.Tags, error) {
+				return &tag.Tags{}, nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "internal error",
+			listFunc: func(ctx context.Context) (*tag.Tags, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := ListTagsHandler{tagListerFunc(tc.listFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://example.com", strings.NewReader("{}"))
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type tagListerFunc func(ctx context.Context) (*tag.Tags, error)
+
+func (l tagListerFunc) List(ctx context.Context) (*tag.Tags, error) {
+	return l(ctx)
+}
+
+func TestRegHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		regFunc func(ctx context.Context, f *reg.Form, token *reg.Token) error
+		code    int
+	}{
+		{
+			name: "ok",
+			regFunc: func(ctx context.Context, f *reg.Form, token *reg.Token) error {
+				return nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "validation errors",
+			regFunc: func(ctx context.Context, f *reg.Form, token *reg.Token) error {
+				return make(validation.Errors)
+			},
+			code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "internal error",
+			regFunc: func(ctx context.Context, f *reg.Form, token *reg.Token) error {
+				return errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := RegHandler{regFunc(tc.regFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://example.com", strings.NewReader("{}"))
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type regFunc func(ctx context.Context, f *reg.Form, token *reg.Token) error
+
+func (r regFunc) Registrate(ctx context.Context, f *reg.Form, token *reg.Token) error {
+	return r(ctx, f, token)
+}
+
+func TestRefreshHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		refreshFunc func(ctx context.Context, f *refresh.Form, t *refresh.Token) error
+		code        int
+	}{
+		{
+			name: "ok",
+			refreshFunc: func(ctx context.Context, f *refresh.Form, t *refresh.Token) error {
+				return nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "invalid token",
+			refreshFunc: func(ctx context.Context, f *refresh.Form, t *refresh.Token) error {
+				return refresh.ErrInvalidToken
+			},
+			code: http.StatusUnauthorized,
+		},
+		{
+			name: "internal error",
+			refreshFunc: func(ctx context.Context, f *refresh.Form, t *refresh.Token) error {
+				return errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := RefreshHandler{refresherFunc(tc.refreshFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://example.com", strings.NewReader("{}"))
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type refresherFunc func(ctx context.Context, f *refresh.Form, t *refresh.Token) error
+
+func (r refresherFunc) Refresh(ctx context.Context, f *refresh.Form, t *refresh.Token) error {
+	return r(ctx, f, t)
+}
+
+func TestSignoutHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		revokeFunc func(ctx context.Context, f *revoke.Form) error
+		code       int
+	}{
+		{
+			name: "ok",
+			revokeFunc: func(ctx context.Context, f *revoke.Form) error {
+				return nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "invalid token",
+			revokeFunc: func(ctx context.Context, f *revoke.Form) error {
+				return revoke.ErrInvalidToken
+			},
+			code: http.StatusUnauthorized,
+		},
+		{
+			name: "internal error",
+			revokeFunc: func(ctx context.Context, f *revoke.Form) error {
+				return errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := SignoutHandler{revokerFunc(tc.revokeFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://example.com", strings.NewReader("{}"))
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type revokerFunc func(ctx context.Context, f *revoke.Form) error
+
+func (r revokerFunc) Revoke(ctx context.Context, f *revoke.Form) error {
+	return r(ctx, f)
+}
+
+func TestVerifyEmailHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		verifyFunc func(ctx context.Context, f *verify.Form) error
+		code       int
+	}{
+		{
+			name: "ok",
+			verifyFunc: func(ctx context.Context, f *verify.Form) error {
+				return nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "invalid token",
+			verifyFunc: func(ctx context.Context, f *verify.Form) error {
+				return verify.ErrInvalidToken
+			},
+			code: http.StatusUnauthorized,
+		},
+		{
+			name: "internal error",
+			verifyFunc: func(ctx context.Context, f *verify.Form) error {
+				return errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := VerifyEmailHandler{emailVerifierFunc(tc.verifyFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://example.com", strings.NewReader("{}"))
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type emailVerifierFunc func(ctx context.Context, f *verify.Form) error
+
+func (e emailVerifierFunc) Verify(ctx context.Context, f *verify.Form) error {
+	return e(ctx, f)
+}
+
+func TestResendVerificationHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		resendFunc func(ctx context.Context) error
+		code       int
+	}{
+		{
+			name: "ok",
+			resendFunc: func(ctx context.Context) error {
+				return nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "already verified",
+			resendFunc: func(ctx context.Context) error {
+				return verify.ErrVerified
+			},
+			code: http.StatusConflict,
+		},
+		{
+			name: "internal error",
+			resendFunc: func(ctx context.Context) error {
+				return errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := ResendVerificationHandler{verificationResenderFunc(tc.resendFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://example.com", nil)
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type verificationResenderFunc func(ctx context.Context) error
+
+func (v verificationResenderFunc) Resend(ctx context.Context) error {
+	return v(ctx)
+}
+
+func TestForgotPasswordHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		forgotFunc func(ctx context.Context, f *password.ForgotForm) error
+		code       int
+	}{
+		{
+			name: "ok",
+			forgotFunc: func(ctx context.Context, f *password.ForgotForm) error {
+				return nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "internal error",
+			forgotFunc: func(ctx context.Context, f *password.ForgotForm) error {
+				return errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := ForgotPasswordHandler{passwordForgetterFunc(tc.forgotFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://example.com", strings.NewReader("{}"))
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type passwordForgetterFunc func(ctx context.Context, f *password.ForgotForm) error
+
+func (p passwordForgetterFunc) Forgot(ctx context.Context, f *password.ForgotForm) error {
+	return p(ctx, f)
+}
+
+func TestResetPasswordHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		resetFunc func(ctx context.Context, f *password.ResetForm) error
+		code      int
+	}{
+		{
+			name: "ok",
+			resetFunc: func(ctx context.Context, f *password.ResetForm) error {
+				return nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "invalid token",
+			resetFunc: func(ctx context.Context, f *password.ResetForm) error {
+				return password.ErrInvalidToken
+			},
+			code: http.StatusUnauthorized,
+		},
+		{
+			name: "validation errors",
+			resetFunc: func(ctx context.Context, f *password.ResetForm) error {
+				return make(validation.Errors)
+			},
+			code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "internal error",
+			resetFunc: func(ctx context.Context, f *password.ResetForm) error {
+				return errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := ResetPasswordHandler{passwordResetterFunc(tc.resetFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://example.com", strings.NewReader("{}"))
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type passwordResetterFunc func(ctx context.Context, f *password.ResetForm) error
+
+func (p passwordResetterFunc) Reset(ctx context.Context, f *password.ResetForm) error {
+	return p(ctx, f)
+}
+
+func TestJWKSHandler(t *testing.T) {
+	h := JWKSHandler{keySetFunc(func() authEng.JWKS {
+		return authEng.JWKS{Keys: []authEng.JWK{{Kid: "kid"}}}
+	})}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "http://example.com", nil)
+
+	if err := h.Handle(w, r); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if w.Code != http.StatusOK {
+		t.Errorf("unexpected code: %d expected %d", w.Code, http.StatusOK)
+	}
+	if !strings.Contains(w.Body.String(), `"kid":"kid"`) {
+		t.Errorf("unexpected body: %s", w.Body.String())
+	}
+}
+
+type keySetFunc func() authEng.JWKS
+
+func (k keySetFunc) JWKS() authEng.JWKS {
+	return k()
+}
+
+func TestAuthHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		authFunc func(ctx context.Context, email, password, ip string, t *auth.Token) error
+		code     int
+	}{
+		{
+			name: "ok",
+			authFunc: func(ctx context.Context, email, password, ip string, t *auth.Token) error {
+				return nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "email error",
+			authFunc: func(ctx context.Context, email, password, ip string, t *auth.Token) error {
+				return auth.ErrNotFound
+			},
+			code: http.StatusUnauthorized,
+		},
+		{
+			name: "password error",
+			authFunc: func(ctx context.Context, email, password, ip string, t *auth.Token) error {
+				return auth.ErrWrongPassword
+			},
+			code: http.StatusUnauthorized,
+		},
+		{
+			name: "account locked",
+			authFunc: func(ctx context.Context, email, password, ip string, t *auth.Token) error {
+				return &auth.LockedError{RetryAfter: time.Second}
+			},
+			code: http.StatusLocked,
+		},
+		{
+			name: "address locked",
+			authFunc: func(ctx context.Context, email, password, ip string, t *auth.Token) error {
+				return &auth.LockedError{RetryAfter: time.Second, Address: true}
+			},
+			code: http.StatusTooManyRequests,
+		},
+		{
+			name: "internal error",
+			authFunc: func(ctx context.Context, email, password, ip string, t *auth.Token) error {
+				return errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := AuthHandler{authFunc(tc.authFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://example.com", strings.NewReader("{}"))
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+			if tc.code == http.StatusLocked || tc.code == http.StatusTooManyRequests {
+				if got := w.Header().Get("Retry-After"); got != "1" {
+					t.Errorf("unexpected retry after: %q expected %q", got, "1")
+				}
+			}
+		})
+	}
+}
+
+type authFunc func(ctx context.Context, email, password, ip string, t *auth.Token) error
+
+func (a authFunc) Authenticate(ctx context.Context, email, password, ip string, t *auth.Token) error {
+	return a(ctx, email, password, ip, t)
+}
+
+func TestStartLoginHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		startFunc func(ctx context.Context, provider string) (*oidc.Redirect, error)
+		code      int
+	}{
+		{
+			name: "ok",
+			startFunc: func(ctx context.Context, provider string) (*oidc.Redirect, error) {
+				return &oidc.Redirect{URL: "https://example.com/authorize", State: "state"}, nil
+			},
+			code: http.StatusFound,
+		},
+		{
+			name: "unknown provider",
+			startFunc: func(ctx context.Context, provider string) (*oidc.Redirect, error) {
+				return nil, oidc.ErrUnknownProvider
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			startFunc: func(ctx context.Context, provider string) (*oidc.Redirect, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := StartLoginHandler{loginStarterFunc(tc.startFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://example.com", nil)
+			r = mux.SetURLVars(r, map[string]string{"provider": "example"})
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+
+			cookie := w.Header().Get("Set-Cookie")
+			if tc.code == http.StatusFound && (!strings.Contains(cookie, "oidc_state=state") || !strings.Contains(cookie, "HttpOnly")) {
+				t.Errorf("unexpected state cookie: %q", cookie)
+			}
+		})
+	}
+}
+
+func TestLinkIdentityHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		startFunc func(ctx context.Context, provider string) (*oidc.Redirect, error)
+		code      int
+	}{
+		{
+			name: "ok",
+			startFunc: func(ctx context.Context, provider string) (*oidc.Redirect, error) {
+				return &oidc.Redirect{URL: "https://example.com/authorize"}, nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "unknown provider",
+			startFunc: func(ctx context.Context, provider string) (*oidc.Redirect, error) {
+				return nil, oidc.ErrUnknownProvider
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			startFunc: func(ctx context.Context, provider string) (*oidc.Redirect, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := LinkIdentityHandler{loginStarterFunc(tc.startFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://example.com", nil)
+			r = mux.SetURLVars(r, map[string]string{"provider": "example"})
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type loginStarterFunc func(ctx context.Context, provider string) (*oidc.Redirect, error)
+
+func (l loginStarterFunc) Start(ctx context.Context, provider string) (*oidc.Redirect, error) {
+	return l(ctx, provider)
+}
+
+func TestLoginCallbackHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		callbackFunc func(ctx context.Context, provider string, f *oidc.CallbackForm, t *auth.Token) error
+		code         int
+	}{
+		{
+			name: "ok",
+			callbackFunc: func(ctx context.Context, provider string, f *oidc.CallbackForm, t *auth.Token) error {
+				if f.BrowserState != "state" {
+					return oidc.ErrInvalidLogin
+				}
+				return nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "unknown provider",
+			callbackFunc: func(ctx context.Context, provider string, f *oidc.CallbackForm, t *auth.Token) error {
+				return oidc.ErrUnknownProvider
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "invalid login",
+			callbackFunc: func(ctx context.Context, provider string, f *oidc.CallbackForm, t *auth.Token) error {
+				return oidc.ErrInvalidLogin
+			},
+			code: http.StatusUnauthorized,
+		},
+		{
+			name: "email required",
+			callbackFunc: func(ctx context.Context, provider string, f *oidc.CallbackForm, t *auth.Token) error {
+				return oidc.ErrEmailRequired
+			},
+			code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "email exists",
+			callbackFunc: func(ctx context.Context, provider string, f *oidc.CallbackForm, t *auth.Token) error {
+				return oidc.ErrEmailExists
+			},
+			code: http.StatusConflict,
+		},
+		{
+			name: "internal error",
+			callbackFunc: func(ctx context.Context, provider string, f *oidc.CallbackForm, t *auth.Token) error {
+				return errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := LoginCallbackHandler{loginCompleterFunc(tc.callbackFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://example.com?code=code&state=state", nil)
+			r.AddCookie(&http.Cookie{Name: "oidc_state", Value: "state"})
+			r = mux.SetURLVars(r, map[string]string{"provider": "example"})
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type loginCompleterFunc func(ctx context.Context, provider string, f *oidc.CallbackForm, t *auth.Token) error
+
+func (l loginCompleterFunc) Callback(ctx context.Context, provider string, f *oidc.CallbackForm, t *auth.Token) error {
+	return l(ctx, provider, f, t)
+}
+
+func TestChallengeHandler(t *testing.T) {
+	tests := []struct {
+		name          string
+		challengeFunc func(ctx context.Context, f *auth.ChallengeForm, ip string, t *auth.Token) error
+		code          int
+	}{
+		{
+			name: "ok",
+			challengeFunc: func(ctx context.Context, f *auth.ChallengeForm, ip string, t *auth.Token) error {
+				return nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "invalid challenge",
+			challengeFunc: func(ctx context.Context, f *auth.ChallengeForm, ip string, t *auth.Token) error {
+				return auth.ErrInvalidChallenge
+			},
+			code: http.StatusUnauthorized,
+		},
+		{
+			name: "locked account",
+			challengeFunc: func(ctx context.Context, f *auth.ChallengeForm, ip string, t *auth.Token) error {
+				return &auth.LockedError{RetryAfter: time.Minute}
+			},
+			code: http.StatusLocked,
+		},
+		{
+			name: "internal error",
+			challengeFunc: func(ctx context.Context, f *auth.ChallengeForm, ip string, t *auth.Token) error {
+				return errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := ChallengeHandler{challengerFunc(tc.challengeFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://example.com", strings.NewReader("{}"))
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type challengerFunc func(ctx context.Context, f *auth.ChallengeForm, ip string, t *auth.Token) error
+
+func (c challengerFunc) Challenge(ctx context.Context, f *auth.ChallengeForm, ip string, t *auth.Token) error {
+	return c(ctx, f, ip, t)
+}
+
+func TestCreateCommentHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		createFunc func(ctx context.Context, postID int, f *commentCreate.Form) (*comment.Comment, error)
+		code       int
+	}{
+		{
+			name: "ok",
+			createFunc: func(ctx context.Context, postID int, f *commentCreate.Form) (*comment.Comment, error) {
+				return &comment.Comment{}, nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "validation errors",
+			createFunc: func(ctx context.Context, postID int, f *commentCreate.Form) (*comment.Comment, error) {
+				return nil, make(validation.Errors)
+			},
+			code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "post not found",
+			createFunc: func(ctx context.Context, postID int, f *commentCreate.Form) (*comment.Comment, error) {
+				return nil, post.ErrNotFound
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			createFunc: func(ctx context.Context, postID int, f *commentCreate.Form) (*comment.Comment, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := CreateCommentHandler{commentCreaterFunc(tc.createFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://example.com", strings.NewReader("{}"))
+			r = mux.SetURLVars(r, map[string]string{"id": "1"})
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type commentCreaterFunc func(ctx context.Context, postID int, f *commentCreate.Form) (*comment.Comment, error)
+
+func (c commentCreaterFunc) Create(ctx context.Context, postID int, f *commentCreate.Form) (*comment.Comment, error) {
+	return c(ctx, postID, f)
+}
+
+func TestListCommentsHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		listFunc func(ctx context.Context, postID int) (*comment.Comments, error)
+		code     int
+	}{
+		{
+			name: "ok",
+			listFunc: func(ctx context.Context, postID int) (*comment.Comments, error) {
+				return &comment.Comments{}, nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "post not found",
+			listFunc: func(ctx context.Context, postID int) (*comment.Comments, error) {
+				return nil, post.ErrNotFound
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			listFunc: func(ctx context.Context, postID int) (*comment.Comments, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := ListCommentsHandler{commentListerFunc(tc.listFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://example.com", nil)
+			r = mux.SetURLVars(r, map[string]string{"id": "1"})
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type commentListerFunc func(ctx context.Context, postID int) (*comment.Comments, error)
+
+func (c commentListerFunc) List(ctx context.Context, postID int) (*comment.Comments, error) {
+	return c(ctx, postID)
+}
+
+func TestUpdateCommentHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		updateFunc func(ctx context.Context, postID, id int, f *commentUpdate.Form) (*comment.Comment, error)
+		code       int
+	}{
+		{
+			name: "ok",
+			updateFunc: func(ctx context.Context, postID, id int, f *commentUpdate.Form) (*comment.Comment, error) {
+				return &comment.Comment{}, nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "validation errors",
+			updateFunc: func(ctx context.Context, postID, id int, f *commentUpdate.Form) (*comment.Comment, error) {
+				return nil, make(validation.Errors)
+			},
+			code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "not found",
+			updateFunc: func(ctx context.Context, postID, id int, f *commentUpdate.Form) (*comment.Comment, error) {
+				return nil, comment.ErrNotFound
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			updateFunc: func(ctx context.Context, postID, id int, f *commentUpdate.Form) (*comment.Comment, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := UpdateCommentHandler{commentUpdaterFunc(tc.updateFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("PUT", "http://example.com", strings.NewReader("{}"))
+			r = mux.SetURLVars(r, map[string]string{"id": "1", "comment_id": "1"})
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type commentUpdaterFunc func(ctx context.Context, postID, id int, f *commentUpdate.Form) (*comment.Comment, error)
+
+func (c commentUpdaterFunc) Update(ctx context.Context, postID, id int, f *commentUpdate.Form) (*comment.Comment, error) {
+	return c(ctx, postID, id, f)
+}
+
+func TestDeleteCommentHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		deleteFunc func(ctx context.Context, postID, id int) error
+		code       int
+	}{
+		{
+			name: "ok",
+			deleteFunc: func(ctx context.Context, postID, id int) error {
+				return nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "not found",
+			deleteFunc: func(ctx context.Context, postID, id int) error {
+				return comment.ErrNotFound
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "insufficient scope",
+			deleteFunc: func(ctx context.Context, postID, id int) error {
+				return authEng.ErrInsufficientScope
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			name: "internal error",
+			deleteFunc: func(ctx context.Context, postID, id int) error {
+				return errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := DeleteCommentHandler{commentDeleterFunc(tc.deleteFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("DELETE", "http://example.com", nil)
+			r = mux.SetURLVars(r, map[string]string{"id": "1", "comment_id": "1"})
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type commentDeleterFunc func(ctx context.Context, postID, id int) error
+
+func (c commentDeleterFunc) Delete(ctx context.Context, postID, id int) error {
+	return c(ctx, postID, id)
+}
+
+func TestCreatePersonalTokenHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		createFunc func(ctx context.Context, f *personal.Form) (*personal.Token, error)
+		code       int
+	}{
+		{
+			name: "ok",
+			createFunc: func(ctx context.Context, f *personal.Form) (*personal.Token, error) {
+				return &personal.Token{Secret: "pat_secret"}, nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "validation errors",
+			createFunc: func(ctx context.Context, f *personal.Form) (*personal.Token, error) {
+				return nil, make(validation.Errors)
+			},
+			code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "personal token",
+			createFunc: func(ctx context.Context, f *personal.Form) (*personal.Token, error) {
+				return nil, authEng.ErrInsufficientScope
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			name: "internal error",
+			createFunc: func(ctx context.Context, f *personal.Form) (*personal.Token, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := CreatePersonalTokenHandler{personalTokenCreaterFunc(tc.createFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://example.com", strings.NewReader("{}"))
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type personalTokenCreaterFunc func(ctx context.Context, f *personal.Form) (*personal.Token, error)
+
+func (p personalTokenCreaterFunc) Create(ctx context.Context, f *personal.Form) (*personal.Token, error) {
+	return p(ctx, f)
+}
+
+func TestListPersonalTokensHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		listFunc func(ctx context.Context) (*personal.Tokens, error)
+		code     int
+	}{
+		{
+			name: "ok",
+			listFunc: func(ctx context.Context) (*personal.Tokens, error) {
+				return &personal.Tokens{}, nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "personal token",
+			listFunc: func(ctx context.Context) (*personal.Tokens, error) {
+				return nil, authEng.ErrInsufficientScope
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			name: "internal error",
+			listFunc: func(ctx context.Context) (*personal.Tokens, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := ListPersonalTokensHandler{personalTokenListerFunc(tc.listFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://example.com", nil)
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+		})
+	}
+}
+
+type personalTokenListerFunc func(ctx context.Context) (*personal.Tokens, error)
+
+func (p personalTokenListerFunc) List(ctx context.Context) (*personal.Tokens, error) {
+	return p(ctx)
+}
+
+func TestRevokePersonalTokenHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		revokeFunc func(ctx context.Context, id int) error
+		code       int
+	}{
+		{
+			name: "ok",
+			id:   "1",
+			revokeFunc: func(ctx context.Context, id int) error {
+				return nil
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "wrong id",
+			id:   "one",
+			revokeFunc: func(ctx context.Context, id int) error {
+				return nil
+			},
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "not found",
+			id:   "1",
+			revokeFunc: func(ctx context.Context, id int) error {
+				return token.ErrPersonalNotFound
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			id:   "1",
+			revokeFunc: func(ctx context.Context, id int) error {
+				return errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := RevokePersonalTokenHandler{personalTokenRevokerFunc(tc.revokeFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("DELETE", "http://example.com", nil)
+			r = mux.SetURLVars(r, map[string]string{"id": tc.id})
 
 			err := h.Handle(w, r)
 			if w.Code != tc.code {
@@ -357,8 +2517,8 @@ func TestAuthHandler(t *testing.T) {
 	}
 }
 
-type authFunc func(ctx context.Context, email, password string, t *auth.Token) error
+type personalTokenRevokerFunc func(ctx context.Context, id int) error
 
-func (a authFunc) Authenticate(ctx context.Context, email, password string, t *auth.Token) error {
-	return a(ctx, email, password, t)
+func (p personalTokenRevokerFunc) Revoke(ctx context.Context, id int) error {
+	return p(ctx, id)
 }
@@ -3,10 +3,17 @@ package http
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/dipress/blog/internal/token/personal"
 	"github.com/dipress/blog/kit/auth"
+	"github.com/dipress/blog/kit/ratelimit"
 )
 
 // Authenticator is used to authenticate clients.
@@ -15,8 +22,70 @@ type Authenticator interface {
 	ParseClaims(ctx context.Context, tknStr string) (auth.Claims, error)
 }
 
+// PersonalTokens is used to authenticate
+// clients by personal access tokens.
+type PersonalTokens interface {
+	Authenticate(ctx context.Context, secret string) (auth.Claims, error)
+}
+
+// TokenAuthenticator authenticates clients by JWTs and by
+// personal access tokens, which are told apart by the prefix.
+type TokenAuthenticator struct {
+	Authenticator
+	PersonalTokens
+}
+
+// ParseClaims implements Authenticator interface.
+func (a *TokenAuthenticator) ParseClaims(ctx context.Context, tknStr string) (auth.Claims, error) {
+	if personal.IsPersonal(tknStr) {
+		return a.PersonalTokens.Authenticate(ctx, tknStr)
+	}
+
+	return a.Authenticator.ParseClaims(ctx, tknStr)
+}
+
+// Revocations is used to check that
+// the token was not revoked.
+type Revocations interface {
+	TokenRevoked(ctx context.Context, id string) (bool, error)
+	SubjectValidAfter(ctx context.Context, subject string) (time.Time, error)
+}
+
+// CheckedAuthenticator authenticates clients by tokens
+// which are neither single-use nor revoked.
+type CheckedAuthenticator struct {
+	Authenticator
+	Revocations
+}
+
+// ParseClaims implements Authenticator interface.
+func (a *CheckedAuthenticator) ParseClaims(ctx context.Context, tknStr string) (auth.Claims, error) {
+	cl, err := a.Authenticator.ParseClaims(ctx, tknStr)
+	if err != nil {
+		return auth.Claims{}, err
+	}
+
+	// Single-use tokens sent by email have an audience
+	// and must not authenticate requests.
+	if cl.Audience != "" {
+		return auth.Claims{}, errors.New("token has an audience")
+	}
+
+	if err := checkRevoked(ctx, a.Revocations, &cl); err != nil {
+		return auth.Claims{}, err
+	}
+
+	return cl, nil
+}
+
+// Limiter is used to limit the rate of requests by key.
+type Limiter interface {
+	Allow(key string) ratelimit.Result
+}
+
 // AuthMiddleware represents middleware with authentication.
-func AuthMiddleware(next http.Handler, a Authenticator) http.Handler {
+// It rejects revoked tokens.
+func AuthMiddleware(next http.Handler, a Authenticator, rs Revocations) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		c := r.Context()
 		authHdr := r.Header.Get("Authorization")
@@ -31,7 +100,11 @@ func AuthMiddleware(next http.Handler, a Authenticator) http.Handler {
 			return
 		}
 
-		cl, err := a.ParseClaims(c, tknStr)
+		checked := CheckedAuthenticator{
+			Authenticator: a,
+			Revocations:   rs,
+		}
+		cl, err := checked.ParseClaims(c, tknStr)
 		if err != nil {
 			w.WriteHeader(http.StatusUnauthorized)
 			return
@@ -43,6 +116,149 @@ func AuthMiddleware(next http.Handler, a Authenticator) http.Handler {
 	})
 }
 
+// OptionalAuthMiddleware represents middleware which authenticates
+// clients sending a token and lets anonymous ones pass.
+func OptionalAuthMiddleware(next http.Handler, a Authenticator, rs Revocations) http.Handler {
+	authHandler := AuthMiddleware(next, a, rs)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		authHandler.ServeHTTP(w, r)
+	})
+}
+
+// RateLimitMiddleware represents middleware which limits the rate of
+// requests of every client. Authenticated clients are told apart by
+// the token subject, so it goes after the auth middleware, anonymous
+// ones by the ip address. The limit is reported by RateLimit headers.
+func RateLimitMiddleware(next http.Handler, l Limiter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := "ip:" + remoteIP(r)
+		if cl, ok := auth.FromContext(r.Context()); ok {
+			key = "sub:" + cl.Subject
+		}
+
+		res := l.Allow(key)
+		if res.Limit > 0 {
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			w.Header().Set("RateLimit-Reset", seconds(res.Reset))
+		}
+
+		if !res.Allowed {
+			if err := tooManyRequestsResponse(w, res.RetryAfter); err != nil {
+				log.Printf("rate limit: %+v\n", err)
+			}
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ParseProxies parses a comma separated list of addresses
+// and networks in CIDR notation of trusted proxies.
+func ParseProxies(s string) ([]*net.IPNet, error) {
+	var proxies []*net.IPNet
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+
+		if !strings.Contains(v, "/") {
+			ip := net.ParseIP(v)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid proxy address %q", v)
+			}
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			v = fmt.Sprintf("%s/%d", v, bits)
+		}
+
+		_, n, err := net.ParseCIDR(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy network %q", v)
+		}
+		proxies = append(proxies, n)
+	}
+
+	return proxies, nil
+}
+
+// ProxyMiddleware represents middleware which takes the address
+// of the client from the X-Forwarded-For header, but only when the
+// request comes from a trusted proxy. The header is read from the
+// right, the first address which is not a trusted proxy is the
+// client, since addresses on the left are set by the client itself.
+func ProxyMiddleware(next http.Handler, proxies []*net.IPNet) http.Handler {
+	trusted := func(addr string) bool {
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			return false
+		}
+		for _, n := range proxies {
+			if n.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(proxies) == 0 || !trusted(remoteIP(r)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var forwarded []string
+		for _, h := range r.Header["X-Forwarded-For"] {
+			forwarded = append(forwarded, strings.Split(h, ",")...)
+		}
+
+		for i := len(forwarded) - 1; i >= 0; i-- {
+			addr := strings.TrimSpace(forwarded[i])
+			if trusted(addr) {
+				continue
+			}
+			if net.ParseIP(addr) != nil {
+				r.RemoteAddr = net.JoinHostPort(addr, "0")
+			}
+			break
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// checkRevoked returns an error when the token is revoked by
+// its id or was issued before the subject revoked all tokens.
+func checkRevoked(ctx context.Context, rs Revocations, cl *auth.Claims) error {
+	if cl.Id != "" {
+		revoked, err := rs.TokenRevoked(ctx, cl.Id)
+		if err != nil {
+			return err
+		}
+		if revoked {
+			return errors.New("token is revoked")
+		}
+	}
+
+	after, err := rs.SubjectValidAfter(ctx, cl.Subject)
+	if err != nil {
+		return err
+	}
+	if cl.IssuedBefore(after) {
+		return errors.New("token is issued before revocation")
+	}
+
+	return nil
+}
+
 // parseAuthHeader parses an authorization header. Expected header is of
 // the format `Bearer <token>`.
 func parseAuthHeader(bearerStr string) (string, error) {
@@ -8,7 +8,9 @@ import (
 	"testing"
 	"time"
 
+	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/dipress/blog/kit/auth"
+	"github.com/dipress/blog/kit/ratelimit"
 )
 
 func TestAuthMiddleware(t *testing.T) {
@@ -16,6 +18,7 @@ func TestAuthMiddleware(t *testing.T) {
 		name      string
 		header    map[string]string
 		parseFunc func(ctx context.Context, tknStr string) (auth.Claims, error)
+		revs      revocations
 		callNext  bool
 		code      int
 	}{
@@ -61,6 +64,166 @@ func TestAuthMiddleware(t *testing.T) {
 			callNext: false,
 			code:     http.StatusUnauthorized,
 		},
+		{
+			name: "token with audience",
+			header: map[string]string{
+				"Authorization": "Bearer token",
+			},
+			parseFunc: func(ctx context.Context, tknStr string) (auth.Claims, error) {
+				c := auth.NewClaims("john", time.Now(), time.Hour)
+				c.Audience = "verify-email"
+				return c, nil
+			},
+			callNext: false,
+			code:     http.StatusUnauthorized,
+		},
+		{
+			name: "revoked token",
+			header: map[string]string{
+				"Authorization": "Bearer token",
+			},
+			parseFunc: func(ctx context.Context, tknStr string) (auth.Claims, error) {
+				return auth.NewClaims("john", time.Now(), time.Hour), nil
+			},
+			revs:     revocations{revoked: true},
+			callNext: false,
+			code:     http.StatusUnauthorized,
+		},
+		{
+			name: "revoked subject",
+			header: map[string]string{
+				"Authorization": "Bearer token",
+			},
+			parseFunc: func(ctx context.Context, tknStr string) (auth.Claims, error) {
+				return auth.NewClaims("john", time.Now().Add(-time.Minute), time.Hour), nil
+			},
+			revs:     revocations{after: time.Now()},
+			callNext: false,
+			code:     http.StatusUnauthorized,
+		},
+		{
+			name: "issued in the second of subject revocation before it",
+			header: map[string]string{
+				"Authorization": "Bearer token",
+			},
+			parseFunc: func(ctx context.Context, tknStr string) (auth.Claims, error) {
+				return auth.NewClaims("john", time.Unix(100, int64(400*time.Millisecond)), time.Hour), nil
+			},
+			revs:     revocations{after: time.Unix(100, int64(500*time.Millisecond))},
+			callNext: false,
+			code:     http.StatusUnauthorized,
+		},
+		{
+			name: "issued in the second of subject revocation after it",
+			header: map[string]string{
+				"Authorization": "Bearer token",
+			},
+			parseFunc: func(ctx context.Context, tknStr string) (auth.Claims, error) {
+				return auth.NewClaims("john", time.Unix(100, int64(600*time.Millisecond)), time.Hour), nil
+			},
+			revs:     revocations{after: time.Unix(100, int64(500*time.Millisecond))},
+			callNext: true,
+			code:     http.StatusOK,
+		},
+		{
+			name: "issued after subject revocation",
+			header: map[string]string{
+				"Authorization": "Bearer token",
+			},
+			parseFunc: func(ctx context.Context, tknStr string) (auth.Claims, error) {
+				return auth.NewClaims("john", time.Now(), time.Hour), nil
+			},
+			revs:     revocations{after: time.Now().Add(-time.Minute)},
+			callNext: true,
+			code:     http.StatusOK,
+		},
+		{
+			name: "revocations error",
+			header: map[string]string{
+				"Authorization": "Bearer token",
+			},
+			parseFunc: func(ctx context.Context, tknStr string) (auth.Claims, error) {
+				return auth.NewClaims("john", time.Now(), time.Hour), nil
+			},
+			revs:     revocations{err: errors.New("mock error")},
+			callNext: false,
+			code:     http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			nextCalls := make(chan struct{})
+			b := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				go func() {
+					nextCalls <- struct{}{}
+				}()
+			})
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://exapmle.com", nil)
+			for k, v := range tc.header {
+				r.Header.Set(k, v)
+			}
+			h := AuthMiddleware(b, parseFunc(tc.parseFunc), tc.revs)
+			h.ServeHTTP(w, r)
+
+			if tc.callNext {
+				select {
+				case <-nextCalls:
+				case <-time.After(time.Second):
+					t.Error("should write to next channel")
+				}
+				return
+			}
+
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected: %d", w.Code, tc.code)
+			}
+		})
+	}
+}
+
+func TestOptionalAuthMiddleware(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    map[string]string
+		parseFunc func(ctx context.Context, tknStr string) (auth.Claims, error)
+		callNext  bool
+		code      int
+	}{
+		{
+			name: "ok",
+			header: map[string]string{
+				"Authorization": "Bearer token",
+			},
+			parseFunc: func(ctx context.Context, tknStr string) (auth.Claims, error) {
+				return auth.Claims{}, nil
+			},
+			callNext: true,
+			code:     http.StatusOK,
+		},
+		{
+			name:   "anonymous",
+			header: map[string]string{},
+			parseFunc: func(ctx context.Context, tknStr string) (auth.Claims, error) {
+				return auth.Claims{}, errors.New("mock error")
+			},
+			callNext: true,
+			code:     http.StatusOK,
+		},
+		{
+			name: "wrong token",
+			header: map[string]string{
+				"Authorization": "Bearer wrong",
+			},
+			parseFunc: func(ctx context.Context, tknStr string) (auth.Claims, error) {
+				return auth.Claims{}, errors.New("mock error")
+			},
+			callNext: false,
+			code:     http.StatusUnauthorized,
+		},
 	}
 
 	for _, tc := range tests {
@@ -78,7 +241,7 @@ func TestAuthMiddleware(t *testing.T) {
 			for k, v := range tc.header {
 				r.Header.Set(k, v)
 			}
-			h := AuthMiddleware(b, parseFunc(tc.parseFunc))
+			h := OptionalAuthMiddleware(b, parseFunc(tc.parseFunc), revocations{})
 			h.ServeHTTP(w, r)
 
 			if tc.callNext {
@@ -97,8 +260,238 @@ func TestAuthMiddleware(t *testing.T) {
 	}
 }
 
+func TestTokenAuthenticator(t *testing.T) {
+	a := TokenAuthenticator{
+		Authenticator: parseFunc(func(ctx context.Context, tknStr string) (auth.Claims, error) {
+			return auth.Claims{StandardClaims: jwt.StandardClaims{Subject: "jwt"}}, nil
+		}),
+		PersonalTokens: personalFunc(func(ctx context.Context, secret string) (auth.Claims, error) {
+			return auth.Claims{StandardClaims: jwt.StandardClaims{Subject: "personal"}, Scopes: []string{}}, nil
+		}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t.Log("\ttest:0\tshould parse JWTs.")
+	{
+		cl, err := a.ParseClaims(ctx, "eyJhbGciOiJSUzI1NiJ9.e30.sig")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cl.Subject != "jwt" {
+			t.Errorf("unexpected subject: %s expected: %s", cl.Subject, "jwt")
+		}
+	}
+
+	t.Log("\ttest:1\tshould authenticate personal access tokens.")
+	{
+		cl, err := a.ParseClaims(ctx, "pat_secret")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cl.Subject != "personal" {
+			t.Errorf("unexpected subject: %s expected: %s", cl.Subject, "personal")
+		}
+	}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	tests := []struct {
+		name      string
+		claims    *auth.Claims
+		allowFunc func(key string) ratelimit.Result
+		callNext  bool
+		code      int
+	}{
+		{
+			name: "anonymous",
+			allowFunc: func(key string) ratelimit.Result {
+				if key != "ip:192.0.2.1" {
+					return ratelimit.Result{}
+				}
+				return ratelimit.Result{Allowed: true, Limit: 10, Remaining: 9, Reset: time.Second}
+			},
+			callNext: true,
+			code:     http.StatusOK,
+		},
+		{
+			name:   "authenticated",
+			claims: &auth.Claims{StandardClaims: jwt.StandardClaims{Subject: "john"}},
+			allowFunc: func(key string) ratelimit.Result {
+				if key != "sub:john" {
+					return ratelimit.Result{}
+				}
+				return ratelimit.Result{Allowed: true, Limit: 10, Remaining: 9, Reset: time.Second}
+			},
+			callNext: true,
+			code:     http.StatusOK,
+		},
+		{
+			name: "over limit",
+			allowFunc: func(key string) ratelimit.Result {
+				return ratelimit.Result{Limit: 10, Reset: 10 * time.Second, RetryAfter: time.Second}
+			},
+			callNext: false,
+			code:     http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			nextCalls := make(chan struct{})
+			b := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				go func() {
+					nextCalls <- struct{}{}
+				}()
+			})
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://exapmle.com", nil)
+			if tc.claims != nil {
+				r = r.WithContext(auth.ToContext(r.Context(), tc.claims))
+			}
+			h := RateLimitMiddleware(b, allowFunc(tc.allowFunc))
+			h.ServeHTTP(w, r)
+
+			if got := w.Header().Get("RateLimit-Limit"); got != "10" {
+				t.Errorf("unexpected rate limit: %q expected: %q", got, "10")
+			}
+
+			if tc.callNext {
+				select {
+				case <-nextCalls:
+				case <-time.After(time.Second):
+					t.Error("should write to next channel")
+				}
+				return
+			}
+
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected: %d", w.Code, tc.code)
+			}
+			if got := w.Header().Get("Retry-After"); got != "1" {
+				t.Errorf("unexpected retry after: %q expected: %q", got, "1")
+			}
+		})
+	}
+}
+
+type allowFunc func(key string) ratelimit.Result
+
+func (a allowFunc) Allow(key string) ratelimit.Result {
+	return a(key)
+}
+
 type parseFunc func(ctx context.Context, tknStr string) (auth.Claims, error)
 
 func (p parseFunc) ParseClaims(ctx context.Context, tknStr string) (auth.Claims, error) {
 	return p(ctx, tknStr)
 }
+
+type personalFunc func(ctx context.Context, secret string) (auth.Claims, error)
+
+func (p personalFunc) Authenticate(ctx context.Context, secret string) (auth.Claims, error) {
+	return p(ctx, secret)
+}
+
+type revocations struct {
+	revoked bool
+	after   time.Time
+	err     error
+}
+
+func (r revocations) TokenRevoked(ctx context.Context, id string) (bool, error) {
+	return r.revoked, r.err
+}
+
+func (r revocations) SubjectValidAfter(ctx context.Context, subject string) (time.Time, error) {
+	return r.after, r.err
+}
+
+func TestProxyMiddleware(t *testing.T) {
+	proxies, err := ParseProxies("10.0.0.0/8, 192.0.2.1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		want       string
+	}{
+		{
+			name:       "direct",
+			remoteAddr: "198.51.100.1:1234",
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "untrusted proxy",
+			remoteAddr: "198.51.100.1:1234",
+			forwarded:  []string{"203.0.113.1"},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "trusted proxy",
+			remoteAddr: "192.0.2.1:1234",
+			forwarded:  []string{"203.0.113.1"},
+			want:       "203.0.113.1",
+		},
+		{
+			name:       "proxy chain",
+			remoteAddr: "10.0.0.2:1234",
+			forwarded:  []string{"203.0.113.1, 10.0.0.1", "192.0.2.1"},
+			want:       "203.0.113.1",
+		},
+		{
+			name:       "spoofed by the client",
+			remoteAddr: "192.0.2.1:1234",
+			forwarded:  []string{"198.51.100.7, 203.0.113.1"},
+			want:       "203.0.113.1",
+		},
+		{
+			name:       "malformed",
+			remoteAddr: "192.0.2.1:1234",
+			forwarded:  []string{"unknown"},
+			want:       "192.0.2.1",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var got string
+			b := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = remoteIP(r)
+			})
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://example.com", nil)
+			r.RemoteAddr = tc.remoteAddr
+			for _, f := range tc.forwarded {
+				r.Header.Add("X-Forwarded-For", f)
+			}
+
+			ProxyMiddleware(b, proxies).ServeHTTP(w, r)
+
+			if got != tc.want {
+				t.Errorf("unexpected address: %q expected: %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseProxies(t *testing.T) {
+	for _, s := range []string{"10.0.0.0/33", "proxy", "10.0.0.1, 10.0.0"} {
+		if _, err := ParseProxies(s); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+
+	proxies, err := ParseProxies("")
+	if err != nil || len(proxies) != 0 {
+		t.Errorf("unexpected proxies: %v error: %v", proxies, err)
+	}
+}
@@ -1,7 +1,10 @@
 package http
 
 import (
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/dipress/blog/internal/validation"
 	"github.com/pkg/errors"
@@ -22,6 +25,18 @@ var (
 	unauthorizedBody = messageResponse{
 		Message: "unauthorized",
 	}
+	forbiddenBody = messageResponse{
+		Message: "forbidden",
+	}
+	conflictBody = messageResponse{
+		Message: "conflict",
+	}
+	lockedBody = messageResponse{
+		Message: "account is locked",
+	}
+	tooManyRequestsBody = messageResponse{
+		Message: "too many requests",
+	}
 )
 
 type messageResponse struct {
@@ -104,3 +119,68 @@ func unauthorizedResponse(w http.ResponseWriter) error {
 	}
 	return nil
 }
+
+func forbiddenResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusForbidden)
+
+	data, err := forbiddenBody.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+	return nil
+}
+
+func conflictResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusConflict)
+
+	data, err := conflictBody.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+	return nil
+}
+
+func lockedResponse(w http.ResponseWriter, retryAfter time.Duration) error {
+	setRetryAfter(w, retryAfter)
+	w.WriteHeader(http.StatusLocked)
+
+	data, err := lockedBody.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+	return nil
+}
+
+func tooManyRequestsResponse(w http.ResponseWriter, retryAfter time.Duration) error {
+	setRetryAfter(w, retryAfter)
+	w.WriteHeader(http.StatusTooManyRequests)
+
+	data, err := tooManyRequestsBody.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+	return nil
+}
+
+// setRetryAfter sets the Retry-After header.
+func setRetryAfter(w http.ResponseWriter, retryAfter time.Duration) {
+	w.Header().Set("Retry-After", seconds(retryAfter))
+}
+
+// seconds formats the duration in whole seconds rounded up,
+// as headers expect.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
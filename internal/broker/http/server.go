@@ -2,40 +2,178 @@ package http
 
 import (
 	"database/sql"
+	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/dipress/blog/internal/ability"
+	accountDelete "github.com/dipress/blog/internal/account/delete"
+	accountExport "github.com/dipress/blog/internal/account/export"
 	"github.com/dipress/blog/internal/auth"
+	"github.com/dipress/blog/internal/broker/web"
+	commentCreate "github.com/dipress/blog/internal/comment/create"
+	commentDelete "github.com/dipress/blog/internal/comment/delete"
+	commentList "github.com/dipress/blog/internal/comment/list"
+	commentUpdate "github.com/dipress/blog/internal/comment/update"
 	"github.com/dipress/blog/internal/create"
 	"github.com/dipress/blog/internal/delete"
+	"github.com/dipress/blog/internal/feed"
 	"github.com/dipress/blog/internal/find"
 	"github.com/dipress/blog/internal/list"
+	"github.com/dipress/blog/internal/notify"
+	"github.com/dipress/blog/internal/oidc"
+	"github.com/dipress/blog/internal/password"
+	profileFind "github.com/dipress/blog/internal/profile/find"
+	profileUpdate "github.com/dipress/blog/internal/profile/update"
 	"github.com/dipress/blog/internal/reg"
+	revisionFind "github.com/dipress/blog/internal/revision/find"
+	revisionList "github.com/dipress/blog/internal/revision/list"
+	revisionRestore "github.com/dipress/blog/internal/revision/restore"
+	"github.com/dipress/blog/internal/role"
+	"github.com/dipress/blog/internal/search"
+	"github.com/dipress/blog/internal/sitemap"
 	"github.com/dipress/blog/internal/storage/postgres"
+	tagList "github.com/dipress/blog/internal/tag/list"
+	"github.com/dipress/blog/internal/token/issue"
+	"github.com/dipress/blog/internal/token/onetime"
+	"github.com/dipress/blog/internal/token/personal"
+	"github.com/dipress/blog/internal/token/refresh"
+	"github.com/dipress/blog/internal/token/revoke"
+	trashList "github.com/dipress/blog/internal/trash/list"
+	trashRestore "github.com/dipress/blog/internal/trash/restore"
+	"github.com/dipress/blog/internal/twofactor"
 	"github.com/dipress/blog/internal/update"
 	"github.com/dipress/blog/internal/validation"
+	"github.com/dipress/blog/internal/verify"
 	authEng "github.com/dipress/blog/kit/auth"
+	"github.com/dipress/blog/kit/ratelimit"
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 )
 
 const (
-	timeout = 30 * time.Second
+	timeout         = 30 * time.Second
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 30 * 24 * time.Hour
+	verifyTokenTTL  = 24 * time.Hour
+	resetTokenTTL   = time.Hour
+	rateLimitSize   = 10000
+	totpIssuer      = "blog"
 )
 
+// RateLimits configures the rate of requests of every client
+// per group of routes.
+type RateLimits struct {
+	// Reads limits reading requests.
+	Reads ratelimit.Rate
+	// Writes limits changing requests of authenticated clients.
+	Writes ratelimit.Rate
+	// Auth limits sign up, sign in and other requests with credentials.
+	Auth ratelimit.Rate
+	// IP limits all requests of every address before they are
+	// authenticated, so invalid tokens can't skip the limits.
+	IP ratelimit.Rate
+}
+
+// DefaultRateLimits allows bursts of reading and keeps
+// guessing of credentials slow.
+var DefaultRateLimits = RateLimits{
+	Reads:  ratelimit.Rate{Requests: 300, Per: time.Minute},
+	Writes: ratelimit.Rate{Requests: 60, Per: time.Minute},
+	Auth:   ratelimit.Rate{Requests: 10, Per: time.Minute},
+	IP:     ratelimit.Rate{Requests: 600, Per: time.Minute},
+}
+
+// Config holds settings of the server.
+type Config struct {
+	// Revocations keeps revoked tokens.
+	Revocations authEng.RevocationStore
+	// Attempts keeps failed sign in attempts.
+	Attempts authEng.AttemptStore
+	// Notifier sends emails to users.
+	Notifier notify.Notifier
+	// DeletePolicy is what happens to content of deleted accounts.
+	DeletePolicy string
+	// RateLimits limits requests of every client.
+	RateLimits RateLimits
+	// Providers are OpenID Connect providers by their names
+	// in login URLs, which may be empty.
+	Providers map[string]oidc.Provider
+	// Frontend serves the HTML frontend alongside the API.
+	Frontend bool
+	// TrustedProxies are networks of proxies whose
+	// X-Forwarded-For header is trusted.
+	TrustedProxies []*net.IPNet
+	// BaseURL is the scheme and the host of the site
+	// absolute URLs of feeds, sitemaps and pages start with.
+	BaseURL string
+}
+
+// ParseBaseURL checks that s is an http or https URL
+// of the site and returns it without trailing slash.
+func ParseBaseURL(s string) (string, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid base url %q", s)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid base url %q: scheme and host expected", s)
+	}
+	return strings.TrimSuffix(s, "/"), nil
+}
+
 // NewServer prepares http server.
-func NewServer(addr string, db *sql.DB, authenticator *authEng.Authenticator) *http.Server {
+func NewServer(addr string, db *sql.DB, authenticator *authEng.Authenticator, cfg Config) *http.Server {
 	mux := mux.NewRouter()
 
+	readLimiter := ratelimit.NewLimiter(cfg.RateLimits.Reads, rateLimitSize)
+	writeLimiter := ratelimit.NewLimiter(cfg.RateLimits.Writes, rateLimitSize)
+	authLimiter := ratelimit.NewLimiter(cfg.RateLimits.Auth, rateLimitSize)
+	ipLimiter := ratelimit.NewLimiter(cfg.RateLimits.IP, rateLimitSize)
+
 	repo := postgres.NewRepository(db)
-	createService := create.NewService(repo, &validation.Create{})
-	findService := find.NewService(repo)
+	createService := create.NewService(repo, &validation.Create{}, &ability.PostAbillity{})
+	findService := find.NewService(repo, &ability.PostAbillity{})
 	listService := list.NewService(repo)
+	feedService := feed.NewService(listService, repo)
+	sitemapService := sitemap.NewService(repo, sitemap.MaxURLs)
 	updateService := update.NewService(repo, &validation.Update{}, &ability.PostAbillity{})
 	deleteService := delete.NewService(repo, &ability.PostAbillity{})
-	registateService := reg.NewService(repo, &validation.Registrate{}, authenticator, time.Hour*24)
-	authenticateService := auth.NewService(repo, authenticator, time.Hour*24)
+	issueService := issue.NewService(repo, refreshTokenTTL)
+	onetimeService := onetime.NewService(authenticator, authenticator, cfg.Revocations)
+	verifyService := verify.NewService(repo, onetimeService, cfg.Notifier, verifyTokenTTL)
+	passwordService := password.NewService(repo, &validation.ResetPassword{}, onetimeService, cfg.Revocations, cfg.Notifier, resetTokenTTL)
+	registateService := reg.NewService(repo, &validation.Registrate{}, authenticator, issueService, verifyService, accessTokenTTL)
+	twoFactorService := twofactor.NewService(repo, totpIssuer)
+	authenticateService := auth.NewService(repo, authenticator, issueService, cfg.Attempts, twoFactorService, onetimeService, accessTokenTTL)
+	loginService := oidc.NewService(repo, authenticateService, cfg.Providers)
+	refreshService := refresh.NewService(repo, authenticator, issueService, accessTokenTTL)
+	revokeService := revoke.NewService(repo, cfg.Revocations)
+	createCommentService := commentCreate.NewService(repo, &validation.CreateComment{}, &ability.PostAbillity{})
+	listCommentsService := commentList.NewService(repo, &ability.PostAbillity{})
+	updateCommentService := commentUpdate.NewService(repo, &validation.UpdateComment{}, &ability.CommentAbillity{})
+	deleteCommentService := commentDelete.NewService(repo, &ability.CommentAbillity{})
+	listTagsService := tagList.NewService(repo)
+	searchService := search.NewService(repo)
+	listRevisionsService := revisionList.NewService(repo, &ability.PostAbillity{})
+	findRevisionService := revisionFind.NewService(repo, &ability.PostAbillity{})
+	restoreRevisionService := revisionRestore.NewService(repo, &ability.PostAbillity{})
+	trashService := trashList.NewService(repo)
+	restorePostService := trashRestore.NewService(repo, &ability.PostAbillity{})
+	assignRoleService := role.NewService(repo, &validation.AssignRole{}, &ability.RoleAbillity{})
+	findProfileService := profileFind.NewService(repo)
+	updateProfileService := profileUpdate.NewService(repo, &validation.UpdateProfile{})
+	exportAccountService := accountExport.NewService(repo)
+	deleteAccountService := accountDelete.NewService(repo, cfg.Revocations, cfg.DeletePolicy)
+	personalTokenService := personal.NewService(repo, &validation.CreatePersonalToken{})
+
+	tokens := TokenAuthenticator{
+		Authenticator:  authenticator,
+		PersonalTokens: personalTokenService,
+	}
 
 	registrateHandler := RegHandler{
 		Registrater: registateService,
@@ -45,6 +183,50 @@ func NewServer(addr string, db *sql.DB, authenticator *authEng.Authenticator) *h
 		Authenticater: authenticateService,
 	}
 
+	challengeHandler := ChallengeHandler{
+		Challenger: authenticateService,
+	}
+
+	startLoginHandler := StartLoginHandler{
+		LoginStarter: loginService,
+	}
+
+	linkIdentityHandler := LinkIdentityHandler{
+		LoginStarter: loginService,
+	}
+
+	loginCallbackHandler := LoginCallbackHandler{
+		LoginCompleter: loginService,
+	}
+
+	refreshHandler := RefreshHandler{
+		Refresher: refreshService,
+	}
+
+	signoutHandler := SignoutHandler{
+		Revoker: revokeService,
+	}
+
+	verifyEmailHandler := VerifyEmailHandler{
+		EmailVerifier: verifyService,
+	}
+
+	resendVerificationHandler := ResendVerificationHandler{
+		VerificationResender: verifyService,
+	}
+
+	forgotPasswordHandler := ForgotPasswordHandler{
+		PasswordForgetter: passwordService,
+	}
+
+	resetPasswordHandler := ResetPasswordHandler{
+		PasswordResetter: passwordService,
+	}
+
+	jwksHandler := JWKSHandler{
+		KeySet: authenticator,
+	}
+
 	createHandler := CreateHandler{
 		Creater: createService,
 	}
@@ -53,10 +235,45 @@ func NewServer(addr string, db *sql.DB, authenticator *authEng.Authenticator) *h
 		Finder: findService,
 	}
 
+	findBySlugHandler := FindBySlugHandler{
+		SlugFinder: findService,
+	}
+
 	listHandler := ListHandler{
 		Lister: listService,
 	}
 
+	rssHandler := FeedHandler{
+		Feeder:      feedService,
+		Encoder:     feed.RSS,
+		ContentType: feed.ContentTypeRSS,
+		BaseURL:     cfg.BaseURL,
+	}
+
+	atomHandler := FeedHandler{
+		Feeder:      feedService,
+		Encoder:     feed.Atom,
+		ContentType: feed.ContentTypeAtom,
+		BaseURL:     cfg.BaseURL,
+	}
+
+	jsonFeedHandler := FeedHandler{
+		Feeder:      feedService,
+		Encoder:     feed.JSON,
+		ContentType: feed.ContentTypeJSON,
+		BaseURL:     cfg.BaseURL,
+	}
+
+	// Search engines index the pages of the frontend when it's served.
+	sitemapHandler := SitemapHandler{
+		Sitemapper: sitemapService,
+		PostPath:   "/posts/by-slug/",
+		BaseURL:    cfg.BaseURL,
+	}
+	if cfg.Frontend {
+		sitemapHandler.PostPath = "/p/"
+	}
+
 	updateHandler := UpdateHandler{
 		Updater: updateService,
 	}
@@ -65,41 +282,399 @@ func NewServer(addr string, db *sql.DB, authenticator *authEng.Authenticator) *h
 		Deleter: deleteService,
 	}
 
-	mux.HandleFunc("/signup", httpHandler{
+	createCommentHandler := CreateCommentHandler{
+		CommentCreater: createCommentService,
+	}
+
+	listCommentsHandler := ListCommentsHandler{
+		CommentLister: listCommentsService,
+	}
+
+	updateCommentHandler := UpdateCommentHandler{
+		CommentUpdater: updateCommentService,
+	}
+
+	deleteCommentHandler := DeleteCommentHandler{
+		CommentDeleter: deleteCommentService,
+	}
+
+	listRevisionsHandler := ListRevisionsHandler{
+		RevisionLister: listRevisionsService,
+	}
+
+	findRevisionHandler := FindRevisionHandler{
+		RevisionFinder: findRevisionService,
+	}
+
+	restoreRevisionHandler := RestoreRevisionHandler{
+		RevisionRestorer: restoreRevisionService,
+	}
+
+	trashHandler := TrashHandler{
+		TrashLister: trashService,
+	}
+
+	restorePostHandler := RestorePostHandler{
+		PostRestorer: restorePostService,
+	}
+
+	assignRoleHandler := AssignRoleHandler{
+		RoleAssigner: assignRoleService,
+	}
+
+	findProfileHandler := FindProfileHandler{
+		ProfileFinder: findProfileService,
+	}
+
+	updateProfileHandler := UpdateProfileHandler{
+		ProfileUpdater: updateProfileService,
+	}
+
+	exportAccountHandler := ExportAccountHandler{
+		AccountExporter: exportAccountService,
+	}
+
+	deleteAccountHandler := DeleteAccountHandler{
+		AccountDeleter: deleteAccountService,
+	}
+
+	enrollTwoFactorHandler := EnrollTwoFactorHandler{
+		TwoFactorEnroller: twoFactorService,
+	}
+
+	enableTwoFactorHandler := EnableTwoFactorHandler{
+		TwoFactorEnabler: twoFactorService,
+	}
+
+	createPersonalTokenHandler := CreatePersonalTokenHandler{
+		PersonalTokenCreater: personalTokenService,
+	}
+
+	listPersonalTokensHandler := ListPersonalTokensHandler{
+		PersonalTokenLister: personalTokenService,
+	}
+
+	revokePersonalTokenHandler := RevokePersonalTokenHandler{
+		PersonalTokenRevoker: personalTokenService,
+	}
+
+	searchHandler := SearchHandler{
+		Searcher: searchService,
+	}
+
+	listTagsHandler := ListTagsHandler{
+		TagLister: listTagsService,
+	}
+
+	mux.HandleFunc("/signup", RateLimitMiddleware(httpHandler{
 		Handler: &registrateHandler,
-	}.ServeHTTP).Methods("POST")
+	}, authLimiter).ServeHTTP).Methods("POST")
 
-	mux.HandleFunc("/signin", httpHandler{
+	mux.HandleFunc("/signin", RateLimitMiddleware(httpHandler{
 		Handler: &authenticateHandler,
-	}.ServeHTTP).Methods("POST")
+	}, authLimiter).ServeHTTP).Methods("POST")
+
+	mux.HandleFunc("/signin/2fa", RateLimitMiddleware(httpHandler{
+		Handler: &challengeHandler,
+	}, authLimiter).ServeHTTP).Methods("POST")
 
-	mux.HandleFunc("/posts", AuthMiddleware(httpHandler{
+	mux.HandleFunc("/oidc/{provider}/login", RateLimitMiddleware(httpHandler{
+		Handler: &startLoginHandler,
+	}, authLimiter).ServeHTTP).Methods("GET")
+
+	mux.HandleFunc("/oidc/{provider}/callback", RateLimitMiddleware(httpHandler{
+		Handler: &loginCallbackHandler,
+	}, authLimiter).ServeHTTP).Methods("GET")
+
+	mux.HandleFunc("/verify-email", RateLimitMiddleware(httpHandler{
+		Handler: &verifyEmailHandler,
+	}, authLimiter).ServeHTTP).Methods("POST")
+
+	mux.HandleFunc("/verify-email/resend", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &resendVerificationHandler,
+	}, authLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("POST")
+
+	mux.HandleFunc("/password/forgot", RateLimitMiddleware(httpHandler{
+		Handler: &forgotPasswordHandler,
+	}, authLimiter).ServeHTTP).Methods("POST")
+
+	mux.HandleFunc("/password/reset", RateLimitMiddleware(httpHandler{
+		Handler: &resetPasswordHandler,
+	}, authLimiter).ServeHTTP).Methods("POST")
+
+	mux.HandleFunc("/.well-known/jwks.json", RateLimitMiddleware(httpHandler{
+		Handler: &jwksHandler,
+	}, readLimiter).ServeHTTP).Methods("GET")
+
+	mux.HandleFunc("/token/refresh", RateLimitMiddleware(httpHandler{
+		Handler: &refreshHandler,
+	}, authLimiter).ServeHTTP).Methods("POST")
+
+	mux.HandleFunc("/signout", OptionalAuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &signoutHandler,
+	}, authLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("POST")
+
+	mux.HandleFunc("/posts", AuthMiddleware(RateLimitMiddleware(httpHandler{
 		Handler: &createHandler,
-	}, authenticator).ServeHTTP).Methods("POST")
+	}, writeLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("POST")
 
-	mux.HandleFunc("/posts/{id}", AuthMiddleware(httpHandler{
+	mux.HandleFunc("/posts/{id}", AuthMiddleware(RateLimitMiddleware(httpHandler{
 		Handler: &updateHandler,
-	}, authenticator).ServeHTTP).Methods("PUT")
+	}, writeLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("PUT")
 
-	mux.HandleFunc("/posts/{id}", AuthMiddleware(httpHandler{
+	mux.HandleFunc("/posts/{id}", AuthMiddleware(RateLimitMiddleware(httpHandler{
 		Handler: &deleteHandler,
-	}, authenticator).ServeHTTP).Methods("DELETE")
+	}, writeLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("DELETE")
+
+	mux.HandleFunc("/posts/search", RateLimitMiddleware(httpHandler{
+		Handler: &searchHandler,
+	}, readLimiter).ServeHTTP).Methods("GET")
 
-	mux.HandleFunc("/posts/{id}", httpHandler{
+	mux.HandleFunc("/posts/{id}", OptionalAuthMiddleware(RateLimitMiddleware(httpHandler{
 		Handler: &findHandler,
-	}.ServeHTTP).Methods("GET")
+	}, readLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("GET")
 
-	mux.HandleFunc("/posts", httpHandler{
+	mux.HandleFunc("/posts/by-slug/{slug}", OptionalAuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &findBySlugHandler,
+	}, readLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("GET")
+
+	mux.HandleFunc("/posts", OptionalAuthMiddleware(RateLimitMiddleware(httpHandler{
 		Handler: &listHandler,
-	}.ServeHTTP).Methods("GET")
+	}, readLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("GET")
+
+	mux.HandleFunc("/posts/{id}/comments", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &createCommentHandler,
+	}, writeLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("POST")
+
+	mux.HandleFunc("/posts/{id}/comments", OptionalAuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &listCommentsHandler,
+	}, readLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("GET")
+
+	mux.HandleFunc("/posts/{id}/comments/{comment_id}", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &updateCommentHandler,
+	}, writeLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("PUT")
+
+	mux.HandleFunc("/posts/{id}/comments/{comment_id}", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &deleteCommentHandler,
+	}, writeLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("DELETE")
+
+	mux.HandleFunc("/posts/{id}/revisions", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &listRevisionsHandler,
+	}, readLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("GET")
+
+	mux.HandleFunc("/posts/{id}/revisions/{rev}", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &findRevisionHandler,
+	}, readLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("GET")
+
+	mux.HandleFunc("/posts/{id}/revisions/{rev}/restore", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &restoreRevisionHandler,
+	}, writeLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("POST")
+
+	mux.HandleFunc("/posts/{id}/restore", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &restorePostHandler,
+	}, writeLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("POST")
+
+	mux.HandleFunc("/me/trash", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &trashHandler,
+	}, readLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("GET")
+
+	mux.HandleFunc("/me", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &updateProfileHandler,
+	}, writeLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("PATCH")
+
+	mux.HandleFunc("/me", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &deleteAccountHandler,
+	}, writeLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("DELETE")
+
+	mux.HandleFunc("/me/export", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &exportAccountHandler,
+	}, readLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("GET")
+
+	mux.HandleFunc("/me/2fa", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &enrollTwoFactorHandler,
+	}, writeLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("POST")
+
+	mux.HandleFunc("/me/2fa/verify", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &enableTwoFactorHandler,
+	}, writeLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("POST")
+
+	mux.HandleFunc("/me/identities/{provider}", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &linkIdentityHandler,
+	}, writeLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("POST")
+
+	mux.HandleFunc("/me/tokens", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &createPersonalTokenHandler,
+	}, writeLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("POST")
+
+	mux.HandleFunc("/me/tokens", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &listPersonalTokensHandler,
+	}, readLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("GET")
+
+	mux.HandleFunc("/me/tokens/{id}", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &revokePersonalTokenHandler,
+	}, writeLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("DELETE")
+
+	mux.HandleFunc("/users/{username}", RateLimitMiddleware(httpHandler{
+		Handler: &findProfileHandler,
+	}, readLimiter).ServeHTTP).Methods("GET")
+
+	mux.HandleFunc("/users/{username}/posts", OptionalAuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &listHandler,
+	}, readLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("GET")
+
+	mux.HandleFunc("/users/{username}/role", AuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &assignRoleHandler,
+	}, writeLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("PUT")
+
+	mux.HandleFunc("/tags", RateLimitMiddleware(httpHandler{
+		Handler: &listTagsHandler,
+	}, readLimiter).ServeHTTP).Methods("GET")
+
+	mux.HandleFunc("/tags/{slug}/posts", OptionalAuthMiddleware(RateLimitMiddleware(httpHandler{
+		Handler: &listHandler,
+	}, readLimiter), &tokens, cfg.Revocations).ServeHTTP).Methods("GET")
+
+	mux.HandleFunc("/feed.rss", RateLimitMiddleware(httpHandler{
+		Handler: &rssHandler,
+	}, readLimiter).ServeHTTP).Methods("GET", "HEAD")
+
+	mux.HandleFunc("/feed.atom", RateLimitMiddleware(httpHandler{
+		Handler: &atomHandler,
+	}, readLimiter).ServeHTTP).Methods("GET", "HEAD")
+
+	mux.HandleFunc("/feed.json", RateLimitMiddleware(httpHandler{
+		Handler: &jsonFeedHandler,
+	}, readLimiter).ServeHTTP).Methods("GET", "HEAD")
+
+	mux.HandleFunc("/users/{username}/feed.rss", RateLimitMiddleware(httpHandler{
+		Handler: &rssHandler,
+	}, readLimiter).ServeHTTP).Methods("GET", "HEAD")
+
+	mux.HandleFunc("/users/{username}/feed.atom", RateLimitMiddleware(httpHandler{
+		Handler: &atomHandler,
+	}, readLimiter).ServeHTTP).Methods("GET", "HEAD")
+
+	mux.HandleFunc("/users/{username}/feed.json", RateLimitMiddleware(httpHandler{
+		Handler: &jsonFeedHandler,
+	}, readLimiter).ServeHTTP).Methods("GET", "HEAD")
+
+	mux.HandleFunc("/tags/{slug}/feed.rss", RateLimitMiddleware(httpHandler{
+		Handler: &rssHandler,
+	}, readLimiter).ServeHTTP).Methods("GET", "HEAD")
+
+	mux.HandleFunc("/tags/{slug}/feed.atom", RateLimitMiddleware(httpHandler{
+		Handler: &atomHandler,
+	}, readLimiter).ServeHTTP).Methods("GET", "HEAD")
+
+	mux.HandleFunc("/tags/{slug}/feed.json", RateLimitMiddleware(httpHandler{
+		Handler: &jsonFeedHandler,
+	}, readLimiter).ServeHTTP).Methods("GET", "HEAD")
+
+	mux.HandleFunc("/sitemap.xml", RateLimitMiddleware(httpHandler{
+		Handler: &sitemapHandler,
+	}, readLimiter).ServeHTTP).Methods("GET", "HEAD")
+
+	mux.HandleFunc("/sitemap-{page:[0-9]+}.xml", RateLimitMiddleware(httpHandler{
+		Handler: &sitemapHandler,
+	}, readLimiter).ServeHTTP).Methods("GET", "HEAD")
+
+	// The frontend keeps the session in cookies, so its forms
+	// are protected from cross-site requests.
+	if cfg.Frontend {
+		sessions := CheckedAuthenticator{
+			Authenticator: authenticator,
+			Revocations:   cfg.Revocations,
+		}
+
+		indexPage := web.IndexHandler{
+			Lister: listService,
+		}
+
+		postPage := web.PostHandler{
+			SlugFinder: findService,
+			BaseURL:    cfg.BaseURL,
+		}
+
+		authorPage := web.AuthorHandler{
+			ProfileFinder: findProfileService,
+			Lister:        listService,
+		}
+
+		loginPage := web.LoginPageHandler{}
+
+		login := web.LoginHandler{
+			Authenticater: authenticateService,
+		}
+
+		loginChallenge := web.ChallengeHandler{
+			Challenger: authenticateService,
+		}
+
+		logout := web.LogoutHandler{
+			Revoker: revokeService,
+		}
+
+		editorPage := web.EditorHandler{
+			Finder: findService,
+		}
+
+		save := web.SaveHandler{
+			Creater: createService,
+			Updater: updateService,
+		}
+
+		mux.HandleFunc("/", web.SessionMiddleware(RateLimitMiddleware(web.CSRFMiddleware(httpHandler{
+			Handler: &indexPage,
+		}), readLimiter), &sessions, refreshService).ServeHTTP).Methods("GET")
+
+		mux.HandleFunc("/p/{slug}", web.SessionMiddleware(RateLimitMiddleware(web.CSRFMiddleware(httpHandler{
+			Handler: &postPage,
+		}), readLimiter), &sessions, refreshService).ServeHTTP).Methods("GET")
+
+		mux.HandleFunc("/u/{username}", web.SessionMiddleware(RateLimitMiddleware(web.CSRFMiddleware(httpHandler{
+			Handler: &authorPage,
+		}), readLimiter), &sessions, refreshService).ServeHTTP).Methods("GET")
+
+		mux.HandleFunc("/login", web.SessionMiddleware(RateLimitMiddleware(web.CSRFMiddleware(httpHandler{
+			Handler: &loginPage,
+		}), readLimiter), &sessions, refreshService).ServeHTTP).Methods("GET")
+
+		mux.HandleFunc("/login", web.SessionMiddleware(RateLimitMiddleware(web.CSRFMiddleware(httpHandler{
+			Handler: &login,
+		}), authLimiter), &sessions, refreshService).ServeHTTP).Methods("POST")
+
+		mux.HandleFunc("/login/challenge", web.SessionMiddleware(RateLimitMiddleware(web.CSRFMiddleware(httpHandler{
+			Handler: &loginChallenge,
+		}), authLimiter), &sessions, refreshService).ServeHTTP).Methods("POST")
+
+		mux.HandleFunc("/logout", web.SessionMiddleware(RateLimitMiddleware(web.CSRFMiddleware(httpHandler{
+			Handler: &logout,
+		}), authLimiter), &sessions, refreshService).ServeHTTP).Methods("POST")
+
+		mux.HandleFunc("/editor", web.SessionMiddleware(RateLimitMiddleware(web.CSRFMiddleware(httpHandler{
+			Handler: &editorPage,
+		}), readLimiter), &sessions, refreshService).ServeHTTP).Methods("GET")
+
+		mux.HandleFunc("/editor/{id:[0-9]+}", web.SessionMiddleware(RateLimitMiddleware(web.CSRFMiddleware(httpHandler{
+			Handler: &editorPage,
+		}), readLimiter), &sessions, refreshService).ServeHTTP).Methods("GET")
+
+		mux.HandleFunc("/editor", web.SessionMiddleware(RateLimitMiddleware(web.CSRFMiddleware(httpHandler{
+			Handler: &save,
+		}), writeLimiter), &sessions, refreshService).ServeHTTP).Methods("POST")
+
+		mux.HandleFunc("/editor/{id:[0-9]+}", web.SessionMiddleware(RateLimitMiddleware(web.CSRFMiddleware(httpHandler{
+			Handler: &save,
+		}), writeLimiter), &sessions, refreshService).ServeHTTP).Methods("POST")
+	}
 
 	allowedHeaders := handlers.AllowedHeaders([]string{"Content-Type", "Authorization"})
-	allowedMethods := handlers.AllowedMethods([]string{"GET", "HEAD", "POST", "PUT", "DELETE"})
+	allowedMethods := handlers.AllowedMethods([]string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"})
 	allowedOrigins := handlers.AllowedOrigins([]string{"*"})
 
 	s := http.Server{
 		Addr:         addr,
-		Handler:      handlers.CORS(allowedHeaders, allowedOrigins, allowedMethods)(mux),
+		Handler:      ProxyMiddleware(handlers.CORS(allowedHeaders, allowedOrigins, allowedMethods)(RateLimitMiddleware(mux, ipLimiter)), cfg.TrustedProxies),
 		ReadTimeout:  timeout,
 		WriteTimeout: timeout,
 	}
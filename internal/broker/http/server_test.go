@@ -0,0 +1,16 @@
+package http
+
+import "testing"
+
+func TestParseBaseURL(t *testing.T) {
+	for _, s := range []string{"", "blog.example.com", "ftp://blog.example.com", "https://", "http://blog example.com"} {
+		if _, err := ParseBaseURL(s); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+
+	base, err := ParseBaseURL("https://blog.example.com/")
+	if err != nil || base != "https://blog.example.com" {
+		t.Errorf("unexpected base url: %q error: %v", base, err)
+	}
+}
@@ -0,0 +1,519 @@
+package web
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dipress/blog/internal/auth"
+	"github.com/dipress/blog/internal/create"
+	"github.com/dipress/blog/internal/list"
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/tag"
+	"github.com/dipress/blog/internal/token/revoke"
+	"github.com/dipress/blog/internal/update"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/internal/validation"
+	authEng "github.com/dipress/blog/kit/auth"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// Lister abstraction for list service.
+type Lister interface {
+	List(ctx context.Context, f *list.Form) (*post.Posts, error)
+}
+
+// Finder abstraction for find service.
+type Finder interface {
+	Find(ctx context.Context, id int) (*post.Post, error)
+}
+
+// SlugFinder abstraction for find by slug service.
+type SlugFinder interface {
+	FindBySlug(ctx context.Context, slug string) (*post.Post, error)
+}
+
+// ProfileFinder abstraction for profile find service.
+type ProfileFinder interface {
+	Find(ctx context.Context, username string) (*user.Profile, error)
+}
+
+// Creater abstraction for create service.
+type Creater interface {
+	Create(ctx context.Context, f *create.Form) (*post.Post, error)
+}
+
+// Updater abstraction for update service.
+type Updater interface {
+	Update(ctx context.Context, id int, f *update.Form) (*post.Post, error)
+}
+
+// Authenticater abstraction for authenticate service.
+type Authenticater interface {
+	Authenticate(ctx context.Context, email, password, ip string, t *auth.Token) error
+}
+
+// Challenger abstraction for the second step of authenticate service.
+type Challenger interface {
+	Challenge(ctx context.Context, f *auth.ChallengeForm, ip string, t *auth.Token) error
+}
+
+// Revoker abstraction for token revoke service.
+type Revoker interface {
+	Revoke(ctx context.Context, f *revoke.Form) error
+}
+
+// Handler allows to handle requests.
+type Handler interface {
+	Handle(w http.ResponseWriter, r *http.Request) error
+}
+
+type postsPage struct {
+	Layout
+	Posts []post.Post
+	Next  string
+	Own   bool
+}
+
+type postPage struct {
+	Layout
+	Post      *post.Post
+	Canonical string
+}
+
+type authorPage struct {
+	postsPage
+	Profile *user.Profile
+}
+
+type loginPage struct {
+	Layout
+	Email string
+	Error string
+}
+
+type challengePage struct {
+	Layout
+	Challenge string
+}
+
+type editorPage struct {
+	Layout
+	ID     int
+	Form   create.Form
+	Errors validation.Errors
+	Error  string
+}
+
+// IndexHandler for the page of the latest posts.
+type IndexHandler struct {
+	Lister
+}
+
+// Handle implements Handler interface.
+func (h *IndexHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	posts, err := h.Lister.List(r.Context(), &list.Form{
+		After: r.URL.Query().Get("after"),
+	})
+	if err != nil {
+		switch errors.Cause(err) {
+		case list.ErrInvalidCursor:
+			return errors.Wrap(badRequestResponse(w, r), "list")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w, r), "list")
+		}
+	}
+
+	return render(w, http.StatusOK, "index.html", postsPage{
+		Layout: layout(r, "Posts"),
+		Posts:  posts.Posts,
+		Next:   posts.NextCursor,
+	})
+}
+
+// PostHandler for the post page. Former slugs
+// of the post are redirected to the current one.
+type PostHandler struct {
+	SlugFinder
+	BaseURL string
+}
+
+// Handle implements Handler interface.
+func (h *PostHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	slug := mux.Vars(r)["slug"]
+
+	p, err := h.SlugFinder.FindBySlug(r.Context(), slug)
+	if err != nil {
+		switch errors.Cause(err) {
+		case post.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w, r), "find by slug")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w, r), "find by slug")
+		}
+	}
+
+	if p.Slug != slug {
+		http.Redirect(w, r, postPath(p), http.StatusMovedPermanently)
+		return nil
+	}
+
+	// The canonical url is the page itself unless
+	// the post was published elsewhere first.
+	canonical := p.CanonicalURL
+	if canonical == "" {
+		canonical = h.BaseURL + postPath(p)
+	}
+
+	return render(w, http.StatusOK, "post.html", postPage{
+		Layout:    layout(r, p.Title),
+		Post:      p,
+		Canonical: canonical,
+	})
+}
+
+// AuthorHandler for the page of the author with their posts.
+// Authors see their drafts on their own page.
+type AuthorHandler struct {
+	ProfileFinder
+	Lister
+}
+
+// Handle implements Handler interface.
+func (h *AuthorHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	username := mux.Vars(r)["username"]
+
+	profile, err := h.ProfileFinder.Find(r.Context(), username)
+	if err != nil {
+		switch errors.Cause(err) {
+		case user.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w, r), "find profile")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w, r), "find profile")
+		}
+	}
+
+	posts, err := h.Lister.List(r.Context(), &list.Form{
+		After:  r.URL.Query().Get("after"),
+		Author: profile.Username,
+	})
+	if err != nil {
+		switch errors.Cause(err) {
+		case list.ErrInvalidCursor:
+			return errors.Wrap(badRequestResponse(w, r), "list")
+		case user.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w, r), "list")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w, r), "list")
+		}
+	}
+
+	title := profile.DisplayName
+	if title == "" {
+		title = profile.Username
+	}
+
+	l := layout(r, title)
+	return render(w, http.StatusOK, "author.html", authorPage{
+		postsPage: postsPage{
+			Layout: l,
+			Posts:  posts.Posts,
+			Next:   posts.NextCursor,
+			Own:    l.Username == profile.Username,
+		},
+		Profile: profile,
+	})
+}
+
+// LoginPageHandler for the sign in form.
+type LoginPageHandler struct{}
+
+// Handle implements Handler interface.
+func (h *LoginPageHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	return render(w, http.StatusOK, "login.html", loginPage{
+		Layout: layout(r, "Sign in"),
+	})
+}
+
+// LoginHandler for the sign in form submits. Users with
+// two-factor authentication are asked for the code.
+type LoginHandler struct {
+	Authenticater
+}
+
+// Handle implements Handler interface.
+func (h *LoginHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var t auth.Token
+	email := r.PostFormValue("email")
+
+	page := loginPage{
+		Layout: layout(r, "Sign in"),
+		Email:  email,
+	}
+
+	if err := h.Authenticater.Authenticate(r.Context(), email, r.PostFormValue("password"), remoteIP(r), &t); err != nil {
+		switch err := errors.Cause(err); err {
+		case auth.ErrNotFound, auth.ErrWrongPassword:
+			page.Error = "Wrong email or password."
+			return errors.Wrap(render(w, http.StatusUnauthorized, "login.html", page), "find user")
+		}
+
+		switch v := errors.Cause(err).(type) {
+		case *auth.LockedError:
+			page.Error = "Too many failed attempts, try again later."
+			w.Header().Set("Retry-After", strconv.Itoa(int(v.RetryAfter.Seconds())+1))
+			return errors.Wrap(render(w, http.StatusTooManyRequests, "login.html", page), "locked")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w, r), "authenticate")
+		}
+	}
+
+	if t.Challenge != "" {
+		return render(w, http.StatusOK, "challenge.html", challengePage{
+			Layout:    layout(r, "Sign in"),
+			Challenge: t.Challenge,
+		})
+	}
+
+	setSession(w, r, t.Token, t.RefreshToken)
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+
+	return nil
+}
+
+// ChallengeHandler for the two-factor code submits.
+type ChallengeHandler struct {
+	Challenger
+}
+
+// Handle implements Handler interface.
+func (h *ChallengeHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	var t auth.Token
+	f := auth.ChallengeForm{
+		Challenge: r.PostFormValue("challenge"),
+		Code:      r.PostFormValue("code"),
+	}
+
+	if err := h.Challenger.Challenge(r.Context(), &f, remoteIP(r), &t); err != nil {
+		page := loginPage{
+			Layout: layout(r, "Sign in"),
+		}
+
+		if errors.Cause(err) == auth.ErrInvalidChallenge {
+			page.Error = "Wrong code or the sign in took too long, try again."
+			return errors.Wrap(render(w, http.StatusUnauthorized, "login.html", page), "challenge")
+		}
+
+		switch v := errors.Cause(err).(type) {
+		case *auth.LockedError:
+			page.Error = "Too many failed attempts, try again later."
+			w.Header().Set("Retry-After", strconv.Itoa(int(v.RetryAfter.Seconds())+1))
+			return errors.Wrap(render(w, http.StatusTooManyRequests, "login.html", page), "locked")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w, r), "challenge")
+		}
+	}
+
+	setSession(w, r, t.Token, t.RefreshToken)
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+
+	return nil
+}
+
+// LogoutHandler for sign out requests. It revokes
+// the session and removes its cookies.
+type LogoutHandler struct {
+	Revoker
+}
+
+// Handle implements Handler interface.
+func (h *LogoutHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	if c, err := r.Cookie(refreshCookie); err == nil {
+		err := h.Revoker.Revoke(r.Context(), &revoke.Form{RefreshToken: c.Value})
+		if err != nil && errors.Cause(err) != revoke.ErrInvalidToken {
+			return errors.Wrap(internalServerErrorResponse(w, r), "revoke token")
+		}
+	}
+
+	clearSession(w, r)
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+
+	return nil
+}
+
+// EditorHandler for the form of a new post or of the post
+// with the id. Anonymous clients are sent to sign in.
+type EditorHandler struct {
+	Finder
+}
+
+// Handle implements Handler interface.
+func (h *EditorHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authEng.FromContext(r.Context()); !ok {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return nil
+	}
+
+	page := editorPage{
+		Layout: layout(r, "New post"),
+		Form: create.Form{
+			Format: post.FormatMarkdown,
+			Status: post.StatusDraft,
+		},
+	}
+
+	if v, ok := mux.Vars(r)["id"]; ok {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return errors.Wrapf(badRequestResponse(w, r), "convert id param to int: %v", err)
+		}
+
+		p, err := h.Finder.Find(r.Context(), id)
+		if err != nil {
+			switch errors.Cause(err) {
+			case post.ErrNotFound:
+				return errors.Wrap(notFoundResponse(w, r), "find")
+			default:
+				return errors.Wrap(internalServerErrorResponse(w, r), "find")
+			}
+		}
+
+		page.Title = "Edit " + p.Title
+		page.ID = p.ID
+		page.Form = create.Form{
+			Title:        p.Title,
+			Body:         p.Body,
+			Format:       p.Format,
+			Description:  p.Description,
+			CanonicalURL: p.CanonicalURL,
+			ImageURL:     p.ImageURL,
+			Tags:         p.Tags,
+			Status:       p.Status,
+			PublishedAt:  p.PublishedAt,
+		}
+	}
+
+	return render(w, http.StatusOK, "editor.html", page)
+}
+
+// SaveHandler for the editor submits. It creates a new post
+// or updates the post with the id and shows the saved post.
+type SaveHandler struct {
+	Creater
+	Updater
+}
+
+// Handle implements Handler interface.
+func (h *SaveHandler) Handle(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := authEng.FromContext(r.Context()); !ok {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return nil
+	}
+
+	f, formErr := parsePostForm(r)
+	page := editorPage{
+		Layout: layout(r, "New post"),
+		Form:   f,
+	}
+
+	var p *post.Post
+	var err error
+	v, editing := mux.Vars(r)["id"]
+	if editing {
+		id, convErr := strconv.Atoi(v)
+		if convErr != nil {
+			return errors.Wrapf(badRequestResponse(w, r), "convert id param to int: %v", convErr)
+		}
+		page.Title = "Edit post"
+		page.ID = id
+	}
+
+	if formErr != nil {
+		page.Errors = formErr
+		return errors.Wrap(render(w, http.StatusUnprocessableEntity, "editor.html", page), "validation response")
+	}
+
+	if editing {
+		// Forms of both services have the same fields.
+		uf := update.Form(f)
+		p, err = h.Updater.Update(r.Context(), page.ID, &uf)
+	} else {
+		p, err = h.Creater.Create(r.Context(), &f)
+	}
+
+	if err != nil {
+		switch cause := errors.Cause(err); cause {
+		case create.ErrForbidden, authEng.ErrInsufficientScope:
+			return errors.Wrap(forbiddenResponse(w, r), "forbidden response")
+		case create.ErrUnverified:
+			page.Error = "Verify your email to write posts."
+			return errors.Wrap(render(w, http.StatusForbidden, "editor.html", page), "unverified")
+		case post.ErrNotFound:
+			return errors.Wrap(notFoundResponse(w, r), "save")
+		}
+
+		switch v := errors.Cause(err).(type) {
+		case validation.Errors:
+			page.Errors = v
+			return errors.Wrap(render(w, http.StatusUnprocessableEntity, "editor.html", page), "validation response")
+		case *tag.SlugError:
+			page.Errors = validation.Errors{"tags": v.Error()}
+			return errors.Wrap(render(w, http.StatusUnprocessableEntity, "editor.html", page), "validation response")
+		default:
+			return errors.Wrap(internalServerErrorResponse(w, r), "save")
+		}
+	}
+
+	http.Redirect(w, r, postPath(p), http.StatusSeeOther)
+
+	return nil
+}
+
+// parsePostForm reads the editor fields.
+// Tags are separated by commas, the publication
+// time is the value of a datetime-local input in UTC.
+func parsePostForm(r *http.Request) (create.Form, validation.Errors) {
+	f := create.Form{
+		Title:        r.PostFormValue("title"),
+		Body:         r.PostFormValue("body"),
+		Format:       r.PostFormValue("format"),
+		Description:  strings.TrimSpace(r.PostFormValue("description")),
+		CanonicalURL: strings.TrimSpace(r.PostFormValue("canonical_url")),
+		ImageURL:     strings.TrimSpace(r.PostFormValue("image_url")),
+		Status:       r.PostFormValue("status"),
+	}
+
+	for _, t := range strings.Split(r.PostFormValue("tags"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			f.Tags = append(f.Tags, t)
+		}
+	}
+
+	if v := strings.TrimSpace(r.PostFormValue("published_at")); v != "" {
+		t, err := time.Parse(datetimeLayout, v)
+		if err != nil {
+			return f, validation.Errors{"published_at": "must be a valid date and time"}
+		}
+		f.PublishedAt = &t
+	}
+
+	return f, nil
+}
+
+// postPath returns the path of the post page.
+func postPath(p *post.Post) string {
+	return "/p/" + url.PathEscape(p.Slug)
+}
+
+// remoteIP returns the ip address of the client.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,396 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dipress/blog/internal/auth"
+	"github.com/dipress/blog/internal/create"
+	"github.com/dipress/blog/internal/list"
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/update"
+	"github.com/dipress/blog/internal/validation"
+	authEng "github.com/dipress/blog/kit/auth"
+	"github.com/gorilla/mux"
+)
+
+func TestIndexHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		listFunc func(ctx context.Context, f *list.Form) (*post.Posts, error)
+		code     int
+		contains string
+	}{
+		{
+			name: "ok",
+			listFunc: func(ctx context.Context, f *list.Form) (*post.Posts, error) {
+				return &post.Posts{
+					Posts:      []post.Post{{ID: 1, Title: "<b>my title</b>", Slug: "my-title", Status: post.StatusPublished}},
+					NextCursor: "next",
+				}, nil
+			},
+			code:     http.StatusOK,
+			contains: `<a href="/p/my-title">&lt;b&gt;my title&lt;/b&gt;</a>`,
+		},
+		{
+			name: "invalid cursor",
+			listFunc: func(ctx context.Context, f *list.Form) (*post.Posts, error) {
+				return nil, list.ErrInvalidCursor
+			},
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "internal error",
+			listFunc: func(ctx context.Context, f *list.Form) (*post.Posts, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := IndexHandler{listerFunc(tc.listFunc)}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://example.com/", nil)
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+			if !strings.Contains(w.Body.String(), tc.contains) {
+				t.Errorf("body doesn't contain %s: %s", tc.contains, w.Body.String())
+			}
+		})
+	}
+}
+
+type listerFunc func(ctx context.Context, f *list.Form) (*post.Posts, error)
+
+func (f listerFunc) List(ctx context.Context, lf *list.Form) (*post.Posts, error) {
+	return f(ctx, lf)
+}
+
+func TestPostHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		findFunc func(ctx context.Context, slug string) (*post.Post, error)
+		code     int
+		location string
+		contains string
+	}{
+		{
+			name: "ok",
+			findFunc: func(ctx context.Context, slug string) (*post.Post, error) {
+				return &post.Post{Title: "my title", Slug: slug, BodyHTML: "<p>my <em>body</em></p>"}, nil
+			},
+			code:     http.StatusOK,
+			contains: "<p>my <em>body</em></p>",
+		},
+		{
+			name: "seo fields",
+			findFunc: func(ctx context.Context, slug string) (*post.Post, error) {
+				return &post.Post{Title: "my title", Slug: slug, Description: "my description", CanonicalURL: "https://example.com/my-title"}, nil
+			},
+			code:     http.StatusOK,
+			contains: `<link rel="canonical" href="https://example.com/my-title">`,
+		},
+		{
+			name: "own canonical",
+			findFunc: func(ctx context.Context, slug string) (*post.Post, error) {
+				return &post.Post{Title: "my title", Slug: slug}, nil
+			},
+			code:     http.StatusOK,
+			contains: `<link rel="canonical" href="https://blog.example.com/p/old-title">`,
+		},
+		{
+			name: "former slug",
+			findFunc: func(ctx context.Context, slug string) (*post.Post, error) {
+				return &post.Post{Slug: "new-title"}, nil
+			},
+			code:     http.StatusMovedPermanently,
+			location: "/p/new-title",
+		},
+		{
+			name: "not found",
+			findFunc: func(ctx context.Context, slug string) (*post.Post, error) {
+				return nil, post.ErrNotFound
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			findFunc: func(ctx context.Context, slug string) (*post.Post, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := PostHandler{SlugFinder: slugFinderFunc(tc.findFunc), BaseURL: "https://blog.example.com"}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://example.com", nil)
+			r = mux.SetURLVars(r, map[string]string{"slug": "old-title"})
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+			if location := w.Header().Get("Location"); location != tc.location {
+				t.Errorf("unexpected location: %s expected %s", location, tc.location)
+			}
+			if !strings.Contains(w.Body.String(), tc.contains) {
+				t.Errorf("body doesn't contain %s: %s", tc.contains, w.Body.String())
+			}
+		})
+	}
+}
+
+type slugFinderFunc func(ctx context.Context, slug string) (*post.Post, error)
+
+func (f slugFinderFunc) FindBySlug(ctx context.Context, slug string) (*post.Post, error) {
+	return f(ctx, slug)
+}
+
+func TestLoginHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		authFunc func(ctx context.Context, email, password, ip string, t *auth.Token) error
+		code     int
+		location string
+		cookies  int
+		contains string
+	}{
+		{
+			name: "ok",
+			authFunc: func(ctx context.Context, email, password, ip string, t *auth.Token) error {
+				t.Token = "token"
+				t.RefreshToken = "refresh"
+				return nil
+			},
+			code:     http.StatusSeeOther,
+			location: "/",
+			cookies:  2,
+		},
+		{
+			name: "two-factor",
+			authFunc: func(ctx context.Context, email, password, ip string, t *auth.Token) error {
+				t.Challenge = "challenge"
+				return nil
+			},
+			code:     http.StatusOK,
+			contains: `name="challenge" value="challenge"`,
+		},
+		{
+			name: "wrong password",
+			authFunc: func(ctx context.Context, email, password, ip string, t *auth.Token) error {
+				return auth.ErrWrongPassword
+			},
+			code:     http.StatusUnauthorized,
+			contains: `value="john@example.com"`,
+		},
+		{
+			name: "locked",
+			authFunc: func(ctx context.Context, email, password, ip string, t *auth.Token) error {
+				return &auth.LockedError{RetryAfter: time.Minute}
+			},
+			code: http.StatusTooManyRequests,
+		},
+		{
+			name: "internal error",
+			authFunc: func(ctx context.Context, email, password, ip string, t *auth.Token) error {
+				return errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := LoginHandler{authenticaterFunc(tc.authFunc)}
+			w := httptest.NewRecorder()
+			form := url.Values{"email": {"john@example.com"}, "password": {"password"}}
+			r := httptest.NewRequest("POST", "http://example.com/login", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+			if location := w.Header().Get("Location"); location != tc.location {
+				t.Errorf("unexpected location: %s expected %s", location, tc.location)
+			}
+			if cookies := len(w.Result().Cookies()); cookies != tc.cookies {
+				t.Errorf("unexpected cookies: %d expected %d", cookies, tc.cookies)
+			}
+			if !strings.Contains(w.Body.String(), tc.contains) {
+				t.Errorf("body doesn't contain %s: %s", tc.contains, w.Body.String())
+			}
+		})
+	}
+}
+
+type authenticaterFunc func(ctx context.Context, email, password, ip string, t *auth.Token) error
+
+func (f authenticaterFunc) Authenticate(ctx context.Context, email, password, ip string, t *auth.Token) error {
+	return f(ctx, email, password, ip, t)
+}
+
+func TestSaveHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		signedIn   bool
+		id         string
+		form       url.Values
+		createFunc func(ctx context.Context, f *create.Form) (*post.Post, error)
+		updateFunc func(ctx context.Context, id int, f *update.Form) (*post.Post, error)
+		code       int
+		location   string
+		contains   string
+	}{
+		{
+			name:     "anonymous",
+			code:     http.StatusSeeOther,
+			location: "/login",
+		},
+		{
+			name:     "create",
+			signedIn: true,
+			createFunc: func(ctx context.Context, f *create.Form) (*post.Post, error) {
+				if len(f.Tags) != 2 || f.Tags[0] != "go" || f.Tags[1] != "web" {
+					return nil, errors.New("unexpected tags")
+				}
+				return &post.Post{Slug: "my-title"}, nil
+			},
+			code:     http.StatusSeeOther,
+			location: "/p/my-title",
+		},
+		{
+			name:     "create scheduled",
+			signedIn: true,
+			form:     url.Values{"title": {"my title"}, "status": {"scheduled"}, "published_at": {"2030-01-02T15:04"}},
+			createFunc: func(ctx context.Context, f *create.Form) (*post.Post, error) {
+				publishedAt := time.Date(2030, 1, 2, 15, 4, 0, 0, time.UTC)
+				if f.Status != post.StatusScheduled || f.PublishedAt == nil || !f.PublishedAt.Equal(publishedAt) {
+					return nil, errors.New("unexpected schedule")
+				}
+				return &post.Post{Slug: "my-title"}, nil
+			},
+			code:     http.StatusSeeOther,
+			location: "/p/my-title",
+		},
+		{
+			name:     "invalid publication time",
+			signedIn: true,
+			form:     url.Values{"title": {"my title"}, "status": {"scheduled"}, "published_at": {"tomorrow"}},
+			code:     http.StatusUnprocessableEntity,
+			contains: "must be a valid date and time",
+		},
+		{
+			name:     "create invalid",
+			signedIn: true,
+			createFunc: func(ctx context.Context, f *create.Form) (*post.Post, error) {
+				return nil, validation.Errors{"title": "cannot be blank"}
+			},
+			code:     http.StatusUnprocessableEntity,
+			contains: "cannot be blank",
+		},
+		{
+			name:     "create forbidden",
+			signedIn: true,
+			createFunc: func(ctx context.Context, f *create.Form) (*post.Post, error) {
+				return nil, create.ErrForbidden
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			name:     "update",
+			signedIn: true,
+			id:       "1",
+			updateFunc: func(ctx context.Context, id int, f *update.Form) (*post.Post, error) {
+				return &post.Post{ID: id, Slug: "my-title"}, nil
+			},
+			code:     http.StatusSeeOther,
+			location: "/p/my-title",
+		},
+		{
+			name:     "update not found",
+			signedIn: true,
+			id:       "1",
+			updateFunc: func(ctx context.Context, id int, f *update.Form) (*post.Post, error) {
+				return nil, post.ErrNotFound
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name:     "internal error",
+			signedIn: true,
+			createFunc: func(ctx context.Context, f *create.Form) (*post.Post, error) {
+				return nil, errors.New("mock error")
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := SaveHandler{
+				Creater: createrFunc(tc.createFunc),
+				Updater: updaterFunc(tc.updateFunc),
+			}
+			w := httptest.NewRecorder()
+			form := url.Values{"title": {"my title"}, "body": {"my body"}, "tags": {" go, web ,"}, "status": {"draft"}}
+			if tc.form != nil {
+				form = tc.form
+			}
+			r := httptest.NewRequest("POST", "http://example.com/editor", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tc.id != "" {
+				r = mux.SetURLVars(r, map[string]string{"id": tc.id})
+			}
+			if tc.signedIn {
+				cl := authEng.NewClaims("john", time.Now(), time.Hour)
+				r = r.WithContext(authEng.ToContext(r.Context(), &cl))
+			}
+
+			err := h.Handle(w, r)
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d error: %v", w.Code, tc.code, err)
+			}
+			if location := w.Header().Get("Location"); location != tc.location {
+				t.Errorf("unexpected location: %s expected %s", location, tc.location)
+			}
+			if !strings.Contains(w.Body.String(), tc.contains) {
+				t.Errorf("body doesn't contain %s: %s", tc.contains, w.Body.String())
+			}
+		})
+	}
+}
+
+type createrFunc func(ctx context.Context, f *create.Form) (*post.Post, error)
+
+func (f createrFunc) Create(ctx context.Context, cf *create.Form) (*post.Post, error) {
+	return f(ctx, cf)
+}
+
+type updaterFunc func(ctx context.Context, id int, f *update.Form) (*post.Post, error)
+
+func (f updaterFunc) Update(ctx context.Context, id int, uf *update.Form) (*post.Post, error) {
+	return f(ctx, id, uf)
+}
@@ -0,0 +1,179 @@
+package web
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/dipress/blog/internal/token/refresh"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+const (
+	tokenCookie   = "token"
+	refreshCookie = "refresh"
+	csrfCookie    = "csrf"
+	csrfField     = "csrf_token"
+	sessionTTL    = 30 * 24 * time.Hour
+)
+
+type contextKey int
+
+const contextKeyCSRF contextKey = iota
+
+// Authenticator is used to authenticate clients.
+// It recreates the claims by parsing the token.
+type Authenticator interface {
+	ParseClaims(ctx context.Context, tknStr string) (auth.Claims, error)
+}
+
+// Refresher abstraction for token refresh service.
+type Refresher interface {
+	Refresh(ctx context.Context, f *refresh.Form, t *refresh.Token) error
+}
+
+// SessionMiddleware represents middleware which authenticates clients
+// by the token cookie. Expired tokens are refreshed with the refresh
+// cookie, clients without a valid session pass as anonymous.
+func SessionMiddleware(next http.Handler, a Authenticator, rf Refresher) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := r.Context()
+
+		tc, err := r.Cookie(tokenCookie)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cl, err := a.ParseClaims(c, tc.Value)
+		if err != nil {
+			cl, err = refreshSession(w, r, a, rf)
+			if err != nil {
+				if errors.Cause(err) != refresh.ErrInvalidToken {
+					log.Printf("refresh session: %+v\n", err)
+				}
+				clearSession(w, r)
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		r = r.WithContext(auth.ToContext(c, &cl))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// refreshSession exchanges the refresh cookie for new tokens
+// and sets them as cookies.
+func refreshSession(w http.ResponseWriter, r *http.Request, a Authenticator, rf Refresher) (auth.Claims, error) {
+	rc, err := r.Cookie(refreshCookie)
+	if err != nil {
+		return auth.Claims{}, refresh.ErrInvalidToken
+	}
+
+	var t refresh.Token
+	if err := rf.Refresh(r.Context(), &refresh.Form{RefreshToken: rc.Value}, &t); err != nil {
+		return auth.Claims{}, errors.Wrap(err, "refresh")
+	}
+
+	cl, err := a.ParseClaims(r.Context(), t.Token)
+	if err != nil {
+		return auth.Claims{}, errors.Wrap(err, "parse refreshed token")
+	}
+
+	setSession(w, r, t.Token, t.RefreshToken)
+
+	return cl, nil
+}
+
+// CSRFMiddleware represents middleware which protects forms with
+// the double submit cookie. The token of the cookie is put into
+// forms and must come back with every POST request.
+func CSRFMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var csrf string
+		if c, err := r.Cookie(csrfCookie); err == nil && c.Value != "" {
+			csrf = c.Value
+		}
+
+		if r.Method == http.MethodPost {
+			sent := r.PostFormValue(csrfField)
+			if csrf == "" || subtle.ConstantTimeCompare([]byte(sent), []byte(csrf)) != 1 {
+				if err := forbiddenResponse(w, r); err != nil {
+					log.Printf("csrf: %+v\n", err)
+				}
+				return
+			}
+		}
+
+		if csrf == "" {
+			var err error
+			if csrf, err = newCSRFToken(); err != nil {
+				log.Printf("csrf: %+v\n", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			http.SetCookie(w, &http.Cookie{
+				Name:     csrfCookie,
+				Value:    csrf,
+				Path:     "/",
+				HttpOnly: true,
+				Secure:   secure(r),
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+
+		r = r.WithContext(context.WithValue(r.Context(), contextKeyCSRF, csrf))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// csrfFromContext returns the csrf token of the request.
+func csrfFromContext(ctx context.Context) string {
+	csrf, _ := ctx.Value(contextKeyCSRF).(string)
+	return csrf
+}
+
+// newCSRFToken generates a random csrf token.
+func newCSRFToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "read random")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// setSession sets cookies of the signed in client.
+func setSession(w http.ResponseWriter, r *http.Request, token, refreshToken string) {
+	setCookie(w, r, tokenCookie, token, int(sessionTTL.Seconds()))
+	setCookie(w, r, refreshCookie, refreshToken, int(sessionTTL.Seconds()))
+}
+
+// clearSession removes cookies of the signed in client.
+func clearSession(w http.ResponseWriter, r *http.Request) {
+	setCookie(w, r, tokenCookie, "", -1)
+	setCookie(w, r, refreshCookie, "", -1)
+}
+
+func setCookie(w http.ResponseWriter, r *http.Request, name, value string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   secure(r),
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// secure reports whether the client uses https.
+// TLS terminated by a proxy is told by its header.
+func secure(r *http.Request) bool {
+	return r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https"
+}
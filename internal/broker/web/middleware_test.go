@@ -0,0 +1,206 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dipress/blog/internal/token/refresh"
+	"github.com/dipress/blog/kit/auth"
+)
+
+func TestSessionMiddleware(t *testing.T) {
+	parse := func(ctx context.Context, tknStr string) (auth.Claims, error) {
+		if tknStr != "token" && tknStr != "new token" {
+			return auth.Claims{}, errors.New("mock error")
+		}
+		return auth.NewClaims("john", time.Now(), time.Hour), nil
+	}
+
+	tests := []struct {
+		name        string
+		cookies     map[string]string
+		refreshFunc func(ctx context.Context, f *refresh.Form, t *refresh.Token) error
+		subject     string
+		setCookies  map[string]string
+	}{
+		{
+			name: "anonymous",
+		},
+		{
+			name:    "ok",
+			cookies: map[string]string{tokenCookie: "token"},
+			subject: "john",
+		},
+		{
+			name:    "refreshed",
+			cookies: map[string]string{tokenCookie: "expired", refreshCookie: "refresh"},
+			refreshFunc: func(ctx context.Context, f *refresh.Form, t *refresh.Token) error {
+				if f.RefreshToken != "refresh" {
+					return refresh.ErrInvalidToken
+				}
+				t.Token = "new token"
+				t.RefreshToken = "new refresh"
+				return nil
+			},
+			subject:    "john",
+			setCookies: map[string]string{tokenCookie: "new token", refreshCookie: "new refresh"},
+		},
+		{
+			name:    "refresh failed",
+			cookies: map[string]string{tokenCookie: "expired", refreshCookie: "revoked"},
+			refreshFunc: func(ctx context.Context, f *refresh.Form, t *refresh.Token) error {
+				return refresh.ErrInvalidToken
+			},
+			setCookies: map[string]string{tokenCookie: "", refreshCookie: ""},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var subject string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if cl, ok := auth.FromContext(r.Context()); ok {
+					subject = cl.Subject
+				}
+			})
+
+			h := SessionMiddleware(next, authenticatorFunc(parse), refresherFunc(tc.refreshFunc))
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://example.com", nil)
+			for name, value := range tc.cookies {
+				r.AddCookie(&http.Cookie{Name: name, Value: value})
+			}
+			h.ServeHTTP(w, r)
+
+			if subject != tc.subject {
+				t.Errorf("unexpected subject: %q expected %q", subject, tc.subject)
+			}
+
+			set := make(map[string]string)
+			for _, c := range w.Result().Cookies() {
+				set[c.Name] = c.Value
+			}
+			if len(set) != len(tc.setCookies) {
+				t.Errorf("unexpected cookies: %v expected %v", set, tc.setCookies)
+			}
+			for name, value := range tc.setCookies {
+				if set[name] != value {
+					t.Errorf("unexpected cookie %s: %q expected %q", name, set[name], value)
+				}
+			}
+		})
+	}
+}
+
+type authenticatorFunc func(ctx context.Context, tknStr string) (auth.Claims, error)
+
+func (f authenticatorFunc) ParseClaims(ctx context.Context, tknStr string) (auth.Claims, error) {
+	return f(ctx, tknStr)
+}
+
+type refresherFunc func(ctx context.Context, f *refresh.Form, t *refresh.Token) error
+
+func (f refresherFunc) Refresh(ctx context.Context, rf *refresh.Form, t *refresh.Token) error {
+	return f(ctx, rf, t)
+}
+
+func TestCSRFMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		cookie   string
+		token    string
+		callNext bool
+		code     int
+		setToken bool
+	}{
+		{
+			name:     "first visit",
+			method:   "GET",
+			callNext: true,
+			code:     http.StatusOK,
+			setToken: true,
+		},
+		{
+			name:     "known token",
+			method:   "GET",
+			cookie:   "csrf",
+			callNext: true,
+			code:     http.StatusOK,
+		},
+		{
+			name:     "valid form",
+			method:   "POST",
+			cookie:   "csrf",
+			token:    "csrf",
+			callNext: true,
+			code:     http.StatusOK,
+		},
+		{
+			name:   "missing token",
+			method: "POST",
+			cookie: "csrf",
+			code:   http.StatusForbidden,
+		},
+		{
+			name:   "wrong token",
+			method: "POST",
+			cookie: "csrf",
+			token:  "other",
+			code:   http.StatusForbidden,
+		},
+		{
+			name:   "missing cookie",
+			method: "POST",
+			token:  "csrf",
+			code:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var called bool
+			var csrf string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				csrf = csrfFromContext(r.Context())
+			})
+
+			h := CSRFMiddleware(next)
+			w := httptest.NewRecorder()
+			form := url.Values{}
+			if tc.token != "" {
+				form.Set(csrfField, tc.token)
+			}
+			r := httptest.NewRequest(tc.method, "http://example.com", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tc.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: csrfCookie, Value: tc.cookie})
+			}
+			h.ServeHTTP(w, r)
+
+			if called != tc.callNext {
+				t.Errorf("unexpected call of next: %t expected %t", called, tc.callNext)
+			}
+			if w.Code != tc.code {
+				t.Errorf("unexpected code: %d expected %d", w.Code, tc.code)
+			}
+			if called && csrf == "" {
+				t.Error("expected csrf token in context")
+			}
+			if set := len(w.Result().Cookies()) == 1; set != tc.setToken {
+				t.Errorf("unexpected setting of the token cookie: %t expected %t", set, tc.setToken)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package web
+
+import (
+	"net/http"
+)
+
+type errorPage struct {
+	Layout
+}
+
+// errorResponse renders the error page with the status text as its title.
+func errorResponse(w http.ResponseWriter, r *http.Request, status int) error {
+	return render(w, status, "error.html", errorPage{
+		Layout: layout(r, http.StatusText(status)),
+	})
+}
+
+func badRequestResponse(w http.ResponseWriter, r *http.Request) error {
+	return errorResponse(w, r, http.StatusBadRequest)
+}
+
+func forbiddenResponse(w http.ResponseWriter, r *http.Request) error {
+	return errorResponse(w, r, http.StatusForbidden)
+}
+
+func notFoundResponse(w http.ResponseWriter, r *http.Request) error {
+	return errorResponse(w, r, http.StatusNotFound)
+}
+
+func internalServerErrorResponse(w http.ResponseWriter, r *http.Request) error {
+	return errorResponse(w, r, http.StatusInternalServerError)
+}
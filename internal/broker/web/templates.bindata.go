@@ -0,0 +1,396 @@
+// Code generated by go-bindata.
+// sources:
+// templates/author.html
+// templates/challenge.html
+// templates/editor.html
+// templates/error.html
+// templates/index.html
+// templates/layout.html
+// templates/login.html
+// templates/post.html
+// DO NOT EDIT!
+
+package web
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func bindataRead(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type asset struct {
+	bytes []byte
+	info  os.FileInfo
+}
+
+type bindataFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi bindataFileInfo) Name() string {
+	return fi.name
+}
+func (fi bindataFileInfo) Size() int64 {
+	return fi.size
+}
+func (fi bindataFileInfo) Mode() os.FileMode {
+	return fi.mode
+}
+func (fi bindataFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+func (fi bindataFileInfo) IsDir() bool {
+	return false
+}
+func (fi bindataFileInfo) Sys() interface{} {
+	return nil
+}
+
+var _authorHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6c\x8d\x41\x4a\x04\x31\x10\x45\xf7\x39\x45\xc8\x01\x12\x66\x9f\xc9\x42\xc4\xa5\xb8\xf1\x00\xc5\xf4\x6f\x3a\x90\x4e\x42\x57\x89\x48\x51\x77\x97\xd1\xe9\x8d\xb8\xf9\xf0\xe1\xf1\x9e\xea\x82\xb5\x76\xf8\x70\x1b\x5d\xd0\x25\x98\xb9\xbc\x5d\x8a\xea\x67\x95\xcd\xc7\xb7\x63\xac\xb5\x21\x3e\x57\x9e\x8d\xbe\x5e\x69\x87\x99\x6a\xbc\x0f\x1a\xff\x9e\x13\x7a\x67\x1c\xfd\x41\xa0\x2f\x66\x39\x6d\x97\xe2\xfe\xba\x9e\xea\x30\xcb\xb3\xfc\x68\x72\x9a\xe5\x41\xbb\x3c\xfd\xad\x11\xf3\x35\xec\x10\x0a\x25\x93\xdf\x0e\xac\xd7\x90\x3e\x18\x07\xa7\x7f\x53\x69\x05\x96\x48\x32\xf6\x50\x5e\x80\x25\x27\x2a\x77\xa9\x53\x15\xec\xb3\x91\xc0\x87\x39\x58\x38\xf8\x68\xe6\xce\xd8\xf7\x00\x22\xf5\xc7\xee\xfb\x00\x00\x00")
+
+func authorHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_authorHtml,
+		"author.html",
+	)
+}
+
+func authorHtml() (*asset, error) {
+	bytes, err := authorHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "author.html", size: 251, mode: os.FileMode(420), modTime: time.Unix(1792305883, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _challengeHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x84\x90\xb1\x6e\xc3\x30\x0c\x44\xe7\xe8\x2b\x04\xee\xa9\x91\x5d\xd6\x12\xa0\x1f\xd0\x74\x2f\x14\x89\x8e\x84\x4a\xa4\x2b\x53\x29\x02\xc3\xff\x5e\xb8\x76\x8b\x6e\x5d\xef\xf1\x78\x3c\xce\x73\xc0\x21\x11\x6a\xf0\x4c\x82\x24\xb0\x2c\xca\xc4\x93\x7d\xfd\xe4\xe3\xe0\xbc\x70\xd5\xae\x49\x44\x92\xe4\x9d\x24\x26\xd3\xc5\x93\x55\x66\xe0\x5a\x74\x41\x89\x1c\x7a\x18\x79\x12\xd0\xce\xaf\xbc\x87\x2e\xf3\x2d\x51\xe7\xa3\xcb\x19\xe9\x86\x60\xd5\xc1\x24\x1a\x9b\x68\x79\x8c\xd8\x43\x4c\x21\x20\x81\x26\x57\xb0\x07\x3f\xd5\xe1\x4d\xf8\x7d\x55\xee\x2e\x37\xec\x61\x9e\x9f\xce\x97\x97\xe7\x65\xf9\xcf\xfa\x1b\xf1\xd7\xf9\x23\xee\xf6\xec\xae\x98\xed\x99\x03\xea\xa1\x72\xd1\x12\x51\xbb\x71\xd4\x6b\x33\x5d\xd1\xf3\x1d\xeb\x43\xfb\x95\xef\x51\xfb\x72\x0e\x08\x6b\x79\xf6\x5c\xc6\x8c\x82\x3d\x30\xe1\x51\x52\xc1\xe3\x06\x2b\x7e\xb4\x54\x31\x7c\x4f\x0d\xec\xdb\x64\x4d\xb7\xe5\xa9\x83\xb9\x36\x11\xa6\xfd\xf0\xa9\x5d\x4b\x12\xb0\x97\x74\x23\x9d\xc8\x74\x1b\xb5\xca\x74\xeb\x2b\xad\x9a\x67\xa4\xb0\x2c\xea\x6b\x00\x75\xd6\xdb\x79\x92\x01\x00\x00")
+
+func challengeHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_challengeHtml,
+		"challenge.html",
+	)
+}
+
+func challengeHtml() (*asset, error) {
+	bytes, err := challengeHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "challenge.html", size: 402, mode: os.FileMode(420), modTime: time.Unix(1792305883, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _editorHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x55\x4f\x6f\xa3\x3e\x10\x3d\x87\x4f\x31\xf2\x39\xbf\xf0\xeb\xee\x95\x20\x75\xfb\x47\xaa\xd4\x5d\xad\x9a\xf6\x5c\x39\x78\x08\xde\x82\x4d\x6d\x13\x1a\x21\x7f\xf7\x95\xc1\x90\x34\x25\x6d\xd8\x53\x14\x3f\xcf\x9b\x37\x33\xcf\x43\xd3\x30\x4c\xb9\x40\x20\x89\x14\x06\x85\x21\xd6\x06\x51\x76\x11\x37\x0d\x4f\x61\x71\x77\x6d\xed\x0d\xe3\x06\x4a\xa9\x4d\xd3\x60\xae\xd1\xda\x5f\x58\xf7\xff\x05\xb3\x36\x0a\xb3\x8b\x38\x68\x9a\x9a\x9b\x0c\x16\x37\x4a\x49\x65\x6d\x54\x42\x92\x53\xad\x97\x04\xdd\x01\x89\x9b\x66\xe1\xae\x96\xb1\x8f\x0a\xa2\x54\xaa\x02\x0a\x34\x99\x64\x4b\xe2\xf8\x08\xd0\xc4\x70\x29\x96\x24\x44\xc6\x8d\x54\x7b\x0d\x61\xd3\xb4\xbf\x3e\x98\xc4\xc1\x2c\xe2\xa2\xac\x0c\x98\x5d\x89\x4b\x92\x71\xc6\x50\x10\x10\xb4\xc0\x25\x49\xb4\x4a\x9f\x8d\x7c\x71\x27\x5b\x9a\x57\xb8\x24\x4d\xb3\xb8\x5a\x3d\xdc\xfa\xd0\x9c\xae\x31\x8f\x1f\xb9\xc9\x11\x3c\x4f\x17\x69\xdc\xd1\x61\xd0\xad\x54\xc5\xa2\xbd\x68\x2d\x01\x85\xaf\x15\x57\xc8\xe2\x28\xec\x28\x82\x99\xaf\x9b\x0b\x86\x6f\xbe\x7a\x0d\x9e\xe7\x9c\x36\xf4\x62\x7e\x48\xb6\x83\xc8\xe0\x9b\xa1\x0a\xa9\x97\xb3\x96\x6c\x47\x40\xc9\x5a\x2f\xc9\xb7\xff\x49\xdc\x0b\x72\x97\x1d\x4d\x7f\xfd\x4b\x3d\x2d\xd1\x14\x39\x2e\x0d\x35\xc1\x6c\x16\x69\xcc\x31\xe9\xfb\x93\xb6\xc7\xae\x87\xb3\x59\x24\x4b\x37\xad\xbe\x59\x65\x4e\xb9\x20\xed\xc8\xf0\x15\x3a\x9d\x1d\x0b\x78\xcc\x5a\xe8\xc8\x90\xf9\x74\xf1\x6f\x07\x80\x2b\x23\x0a\x3b\xba\x31\xea\x82\xaa\x17\x26\xeb\x53\xec\x03\x3c\x92\xe0\xa7\xc7\x0e\xe9\xa3\xb0\xbb\xe5\x9c\xf0\x45\xdf\x7c\xbd\x53\x3a\xf7\x48\x37\x7a\x0e\x1a\x4b\xaa\xa8\x41\x06\xeb\x1d\x24\xb2\x28\xa8\x3e\x72\x1a\xdd\xe8\x03\xa3\xfd\x91\x5c\xf8\xb2\x1c\x01\x90\x39\x10\x67\xd6\x2f\x8d\xe6\x68\xa6\xc8\xbb\x46\x9d\x28\xde\xb5\x37\x95\x0a\x34\x52\x95\x64\x80\x62\xc3\x05\x6a\xa0\x82\x41\xce\xc5\x0b\x94\x0a\xb7\x1c\x6b\xfd\xc1\x93\x6c\x4f\xd0\x5b\xf3\xfb\xde\x99\x07\xf4\x93\x0c\x7a\xc8\x3a\xa5\x9c\x2b\x2a\xa4\xe0\x09\xcd\xe1\xe9\xe1\x7e\x0e\x75\x86\x02\x4c\x86\xed\x7e\x82\x9a\x6a\x48\xb9\xd2\x06\xca\x6a\x9d\x73\x9d\x21\x03\xb7\xc2\xea\x0c\x15\xc2\xbb\x05\x52\xa9\x7c\xd8\x1e\x3d\xe5\x73\x7b\x78\xb4\x0b\x86\x84\x4f\x0f\xf7\xe7\x0c\xe8\x3d\xdb\x94\xd2\xee\x0a\xba\x41\x57\x56\x3b\xa7\xa3\xa1\x9c\xd0\xce\x5d\xcc\xa8\xee\x96\xed\x4c\xcd\x7b\x96\x29\x7a\x57\x86\x9a\x4a\x7f\x58\x19\xba\x3d\x1e\x5d\x19\x4c\xd1\xd4\x1c\x3d\xea\x8e\x05\x3c\x36\xf2\xa2\xaf\x1d\xf0\xd9\xb6\xd0\x49\x86\xac\xca\x91\x9d\x60\xde\xe3\x23\xec\xab\x1e\xfc\x2c\xc3\xe0\xa6\x13\x19\xf6\xf8\xd8\xca\xeb\xc1\xcf\x32\xb8\x37\xc9\xb7\x27\x13\x0c\xf0\x08\xff\xa5\xc7\xfe\x71\xe3\xf9\x71\x4d\x19\x7c\x5b\x51\x42\x5b\xfd\x86\x17\x08\x5c\xc0\xd3\xe3\xd5\x7c\xf8\x5c\x76\x8b\xa6\xef\x6c\xfb\x36\x8f\x2c\xcc\xa8\x41\x17\xfa\x5f\x2e\x13\x3a\xb8\x79\xe8\xe3\x33\x35\x07\x86\xee\x2f\xfb\xa6\x0c\x0d\xbd\x34\xe7\x98\xfb\x1d\xe9\x79\x65\xae\x2b\x63\xa4\xf0\x52\x75\xb5\x2e\xb8\x21\xf1\x8a\x6e\x31\x0a\x3b\x28\x0e\xa2\xd0\x7d\x2a\xe2\xc0\x07\x05\x7f\x07\x00\xdd\x07\xad\x95\x58\x09\x00\x00")
+
+func editorHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_editorHtml,
+		"editor.html",
+	)
+}
+
+func editorHtml() (*asset, error) {
+	bytes, err := editorHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "editor.html", size: 2392, mode: os.FileMode(420), modTime: time.Unix(1792305883, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _errorHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x04\xc0\xb1\x0d\x03\x31\x08\x00\xc0\x9e\x29\x10\x03\x04\x7d\x4f\x28\x32\x43\x16\xb0\xfe\x79\xd9\x4a\x84\xad\x98\x0e\xb1\x7b\x2e\xf3\xb2\x7b\xb8\x21\x9d\xd3\xc3\x3c\xa8\x0a\xa4\x1f\x9a\xf9\x78\x8f\xf8\x5a\x95\x70\x3f\x14\x64\xa9\x34\xec\x3f\xbb\x9f\xc4\xa4\xaf\x76\x7e\x30\x26\xae\xb9\x63\x0b\x37\x15\x5e\x0a\x99\xe6\x57\x15\xfc\x07\x00\xaf\xce\xa3\x67\x56\x00\x00\x00")
+
+func errorHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_errorHtml,
+		"error.html",
+	)
+}
+
+func errorHtml() (*asset, error) {
+	bytes, err := errorHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "error.html", size: 86, mode: os.FileMode(420), modTime: time.Unix(1792305883, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _indexHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xaa\xae\x4e\x49\x4d\xcb\xcc\x4b\x55\x50\x4a\xce\xcf\x2b\x49\xcd\x2b\x51\xaa\xad\xe5\xaa\xae\x2e\x49\xcd\x2d\xc8\x49\x2c\x49\x55\x50\x2a\xc8\x2f\x2e\x29\x56\x52\xd0\x03\x0b\xa7\xe6\xa5\xd4\xd6\x72\x01\x06\x00\x2e\x81\xce\x9f\x34\x00\x00\x00")
+
+func indexHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_indexHtml,
+		"index.html",
+	)
+}
+
+func indexHtml() (*asset, error) {
+	bytes, err := indexHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "index.html", size: 52, mode: os.FileMode(420), modTime: time.Unix(1792305883, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _layoutHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6c\x54\x4d\x93\xe3\x34\x10\x3d\x27\xbf\xa2\xd1\xc2\x89\x89\x3d\x49\x01\x03\x89\x6d\x0a\x76\x17\x8a\xcb\xcc\x14\x33\x1c\x38\x51\x8a\xd5\xb6\xc5\xc8\x92\x4b\x6a\xe7\x03\x95\x7f\x17\x77\x7e\x19\x25\xcb\xce\x84\x61\x4f\x89\x5b\xaf\x5b\x4f\xaf\x5f\x77\xf6\xd9\x87\x87\xf7\xcf\xbf\x3f\x7e\x84\x86\x5a\x55\x2c\xb3\xf0\x03\x8a\xeb\x3a\x67\xa8\x59\x08\x20\x17\xc5\x72\x91\xb5\x48\x1c\xca\x86\x5b\x87\x94\xb3\x9e\xaa\xd5\xb7\xec\x12\xd7\xbc\xc5\x9c\x1d\x24\x1e\x3b\x63\x89\x41\x69\x34\xa1\xa6\x9c\x1d\xa5\xa0\x26\x17\x78\x90\x25\xae\xc6\x8f\x1b\x90\x5a\x92\xe4\x6a\xe5\x4a\xae\x30\x5f\x8f\x55\x48\x92\xc2\xc2\xfb\xe4\x39\xfc\x19\x86\x2c\x8d\x91\xe5\x22\x53\x52\xbf\x80\x45\x95\x33\xae\x08\xad\xe6\x84\x0c\xe8\xdc\x61\xce\x78\xd7\x29\x59\x72\x92\x46\xa7\x9c\x4c\xfb\xe5\xa9\x55\x0c\xc6\xcc\x9c\x3d\x1a\x47\x8e\x41\x63\xb1\xca\x59\x5a\x21\x8a\x24\x60\xc2\x75\xde\xef\x95\x29\x5f\x80\x85\xc7\x31\x48\x86\xc1\x7b\xd4\x62\x18\x96\x8b\xcc\xd1\x79\xbc\x77\xb1\x37\xe2\x0c\x1e\x5a\x7e\x8a\xc4\xb7\xf0\xd5\xc6\x62\xbb\x83\x96\xdb\x5a\xea\x2d\xdc\x02\xef\xc9\xec\xa0\xe3\x42\x48\x5d\x6f\x61\x3d\x1e\x57\x46\xd3\x16\xd6\x49\xf8\x4a\xd7\xc9\x37\xf0\x33\x1a\x5b\x4b\x7e\x03\x0e\xad\xac\x76\x50\x1a\x65\xec\x16\xde\x6d\x36\x9b\x1d\x0c\xcb\xc5\x42\xf3\x03\x78\x10\xd2\x75\x8a\x9f\xb7\x50\x29\x3c\xed\xa0\xe6\xdd\x5c\x91\x2b\x59\xeb\x95\x24\x6c\xdd\x16\x4a\xd4\x84\x76\x07\x7b\x63\x05\xda\xd5\xde\x10\x99\x76\x0b\xeb\xee\x04\xce\x28\x29\xe0\x9d\x10\xe2\x42\xea\x72\x9e\x7c\x3d\x96\x9a\xaf\x4b\x1a\xd3\x22\xf8\x91\xec\xea\x88\xb2\x6e\x68\x0b\x7b\xa3\xc4\xfc\xbc\x95\x8d\xb1\xf8\xc4\x90\x56\x19\xdb\x26\x52\x2b\xa9\xf1\x9a\x6d\x8c\x44\x88\xe2\x7b\x54\xd7\x87\xa3\xcc\xaf\x8a\x25\x77\x81\x05\xdc\x46\xb4\xd4\x5d\x4f\x37\x40\x78\x22\x6e\x71\xd4\x47\x61\x49\x9f\xc8\x9f\xf4\x5f\xdf\xde\x7e\x31\xeb\x2b\x75\x83\x56\x52\xac\x34\x97\x98\x1f\x54\xf1\x56\xaa\xf3\x16\x5a\xa3\x8d\xeb\x78\x39\xd1\x4b\x82\x57\x6f\x20\x71\xc4\xa9\x77\xe0\x2f\x9d\xb8\xbb\xbb\x8b\x75\x57\x4e\xfe\x85\x5b\x48\xbe\xbb\x88\x95\xa0\xb5\xc6\x5e\x61\xf7\xb7\x13\xfd\xce\x06\x1d\xcc\x01\x6d\xa5\xcc\x71\x75\xba\xd2\x2a\x4b\x27\x17\x65\x69\x1c\x9f\x2c\x98\x29\x98\x59\xf3\x43\xf0\x56\xc6\xa1\x54\xdc\xb9\x9c\x85\x3e\x5c\x4c\xca\x8a\x1f\x95\xa9\xb3\x94\x07\x8c\xf7\xb2\x82\xe4\x37\x17\x2c\xdf\xe2\x30\xc4\xb4\x09\x89\x42\x92\xb1\xac\xb8\xc7\x23\x74\xc6\xd1\x94\xf3\x0a\xe8\x53\xef\xaf\x92\x59\xf1\x9f\xcf\x19\x1e\x7a\x3a\x33\x89\x8d\x64\xd0\x22\x35\x46\xe4\x2c\x94\x65\xc0\xcb\x30\x5d\x39\x4b\x95\xa9\x4d\x4f\x61\x7a\x16\x8b\x6c\x6c\xdd\x34\x84\x8d\x14\x02\x35\x9b\x76\x40\xe9\x6c\xf5\x07\x99\x97\x10\x39\x70\xd5\x63\xce\xbc\x4f\xde\x3f\xfd\xfa\xd3\x30\x4c\xc9\xfb\x9e\xc8\xe8\x29\xdb\xf5\xfb\x56\x12\x2b\x9e\x64\xad\xc1\xf4\x94\xa5\xf1\x78\xa4\x97\x06\x7e\x51\x0b\x54\xee\xad\x06\xca\xd4\x52\x4f\x99\x52\x5f\x54\x9b\x07\x39\x8d\x5a\x67\x2d\x97\x3a\x9e\x10\xb6\x9d\xe2\x84\xc0\xa6\xfd\x34\x8e\x7e\x80\x46\x4c\x96\xc6\x36\x65\x69\x5c\x86\xde\x0b\xac\x82\xdd\x47\x2d\x1c\x1b\x86\xa5\xf7\x96\xeb\x1a\x21\x19\xb7\xcb\x30\x2c\x33\x6e\x49\x96\x71\x53\x35\x9b\xe2\x95\x5d\x17\x1a\xf0\xa4\xfa\x7a\x12\xff\xb2\xd8\x78\x91\xa5\xcd\x26\xe0\xbb\x59\xfa\xe0\x4b\x16\x39\x1e\x25\x35\x90\x3c\xf6\x7b\x25\x5d\x83\xe2\x07\x0a\xbb\x49\x04\xd2\xd7\x5b\x2a\x9a\x43\x23\x24\x4f\xd1\xcb\xac\x9b\x33\xd8\x30\x64\xae\xe3\x7a\xae\x1d\xcd\x3e\x52\x88\xd8\xc0\x21\x00\x8a\x37\xd5\x92\x67\x5e\xbb\x61\xf8\xe7\x6f\xf0\xfe\x4f\x23\x75\x0c\x00\xbb\x01\xf6\xbf\x9b\x3f\x4f\x1e\x8e\x7a\xc4\xbe\xb5\x64\x78\xf5\x2f\x1f\xc2\x9b\x3f\x0a\x39\xda\xf2\xaa\x23\x5d\xd0\xf6\x22\xd8\xa5\xa9\x59\x57\xdc\x9b\xd1\xc6\x0e\xce\x48\xc9\x08\x9c\xd3\x22\xb7\x7b\x3c\xd1\x30\x64\xdd\xab\xc0\xdf\xf3\x8a\xd0\xe6\xde\x4f\x67\xac\x78\x50\x02\x6d\x2c\x13\x55\xee\x8a\xd7\x22\xf1\xf7\xdf\x01\x00\x51\x10\xa2\x19\xef\x06\x00\x00")
+
+func layoutHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_layoutHtml,
+		"layout.html",
+	)
+}
+
+func layoutHtml() (*asset, error) {
+	bytes, err := layoutHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "layout.html", size: 1775, mode: os.FileMode(420), modTime: time.Unix(1792305883, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _loginHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\x90\x41\x6e\xeb\x30\x0c\x44\xd7\xdf\xa7\x10\x78\x80\x18\xd9\xcb\xda\x7c\xa4\xeb\xa2\x39\x40\xa1\x58\x74\x2c\x54\x26\x55\x89\x6a\x50\x08\xba\x7b\xe1\xc4\x69\x9a\x25\x1f\x31\x6f\x80\xa9\xd5\xe1\xe4\x09\x15\x8c\x4c\x82\x24\xd0\x5a\xa7\xe7\xbd\x39\xfa\x33\x29\x4f\xba\x9f\xf7\xa6\xab\xf5\xe2\x65\x56\xbb\x43\x4a\x9c\x5a\xd3\x51\x8d\xc1\xe6\x3c\x00\xae\x00\x4c\xad\xbb\xd6\x74\x1f\x4d\xad\x48\x6e\x35\x4c\x9c\x16\xb5\xa0\xcc\xec\x06\x88\x9c\x05\x94\x1d\xc5\x33\x0d\xd0\x07\x3e\x7b\x02\xd3\xfd\xd3\x9e\x62\x11\x25\xdf\x11\x07\x98\xbd\x73\x48\xa0\xc8\x2e\x38\xc0\x98\xd3\xf4\x2e\xfc\xb1\x92\x2f\x1b\x0a\x0e\x50\xeb\xee\xff\xf1\xed\xa5\xb5\x6b\x34\xd8\x13\x06\x73\x58\xac\x0f\xea\xc9\x83\x2b\xba\x6b\xb6\xe3\x61\xb8\x06\x5a\x03\x95\xf0\xb3\xf8\x84\x4e\xd9\x22\x3c\xf1\x58\xb2\xd1\xfd\x4d\xfa\x6b\x7f\xb5\x39\x5f\x38\xb9\xe7\x82\xb8\xd1\x7b\xc7\xe3\xbe\x3b\xff\x9a\x4e\x45\x84\x69\x8b\xe6\x72\x5a\xbc\xc0\x63\xdc\xdb\xd7\x74\xba\x5f\x07\x33\xdd\xb6\x5f\xf7\x33\x00\xe4\x5b\xb3\x87\x97\x01\x00\x00")
+
+func loginHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_loginHtml,
+		"login.html",
+	)
+}
+
+func loginHtml() (*asset, error) {
+	bytes, err := loginHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "login.html", size: 407, mode: os.FileMode(420), modTime: time.Unix(1792305883, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _postHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x94\x91\xdf\x6a\xf3\x30\x0c\xc5\xaf\xdb\xa7\x30\xba\xfe\x48\xe9\xed\x47\x52\xd8\x9f\x8b\x0d\x3a\x28\xa3\x7b\x00\x2f\x56\x1b\x6d\xae\x1d\x6c\x95\xd1\x19\x3d\xd7\xee\xf7\x64\xc3\x21\x59\x9b\xd1\xc2\x76\x65\x90\xce\xf9\x49\x47\x4e\xc9\xe0\x86\x1c\x2a\x68\x50\x1b\x10\x99\x4e\x4a\x4b\xee\x55\x05\xb4\x15\xd4\xda\x79\x47\xb5\xb6\xa0\x9a\x80\x9b\x0a\x52\x2a\x6e\x86\x9a\x08\x2c\xa6\x93\x94\xde\x88\x1b\x55\xac\x7c\xe4\xe2\x16\x63\x1d\xa8\x65\xf2\x4e\xa4\xdc\x21\x6b\xe5\xf4\x0e\x2b\x30\xc7\x06\xa8\xda\x3b\x46\xc7\x1d\x2d\x43\x52\x42\x67\xba\xc9\x9d\xa3\x0d\xbe\xc5\xc0\x87\x0a\xfc\xf6\x3f\x1f\x5a\x3c\x71\xe8\xc0\x54\x5b\x84\xc5\x79\x31\xb1\xc5\x31\xbf\x5b\x6b\x9d\xeb\x22\x17\x5c\xfb\x60\xc7\x9e\x3f\x26\x1c\xc1\x7e\x17\x74\x84\xbc\xdf\xe9\x2d\x3e\x3d\x2e\xcf\xf2\x28\x37\x2f\x93\x8e\xef\xf0\x8d\xbd\x32\xff\x64\xd9\x5f\x2b\xc7\x6e\xe6\x8b\x1f\xd7\x28\x67\xcd\x3c\x77\x5a\x55\x5b\x1d\x63\x05\x79\x78\xce\x3b\xde\x6e\xb5\x7f\xb6\x14\x1b\x34\x57\x2c\x92\x92\xd1\x8c\xaa\x10\x19\x06\x67\x35\x6d\x7a\xed\x5a\x6f\xa3\xc8\xe7\x87\x4a\xe9\xc5\x93\x3b\xa9\x2a\xf8\xa7\xe0\xc4\x55\xce\xda\xee\xb2\x51\x3b\x62\x7a\x47\xd3\x6b\xaf\xbd\x39\xdc\xad\x1f\x96\x79\xfb\xd9\xf7\xfa\x83\xed\x6b\x00\x5c\x9d\x72\xb4\xad\x02\x00\x00")
+
+func postHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_postHtml,
+		"post.html",
+	)
+}
+
+func postHtml() (*asset, error) {
+	bytes, err := postHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "post.html", size: 685, mode: os.FileMode(420), modTime: time.Unix(1792305883, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
+		}
+		return a.bytes, nil
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name string) []byte {
+	a, err := Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+
+	return a
+}
+
+// AssetInfo loads and returns the asset info for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func AssetInfo(name string) (os.FileInfo, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+		}
+		return a.info, nil
+	}
+	return nil, fmt.Errorf("AssetInfo %s not found", name)
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names := make([]string, 0, len(_bindata))
+	for name := range _bindata {
+		names = append(names, name)
+	}
+	return names
+}
+
+// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() (*asset, error){
+	"author.html": authorHtml,
+	"challenge.html": challengeHtml,
+	"editor.html": editorHtml,
+	"error.html": errorHtml,
+	"index.html": indexHtml,
+	"layout.html": layoutHtml,
+	"login.html": loginHtml,
+	"post.html": postHtml,
+}
+
+// AssetDir returns the file names below a certain
+// directory embedded in the file by go-bindata.
+// For example if you run go-bindata on data/... and data contains the
+// following hierarchy:
+//     data/
+//       foo.txt
+//       img/
+//         a.png
+//         b.png
+// then AssetDir("data") would return []string{"foo.txt", "img"}
+// AssetDir("data/img") would return []string{"a.png", "b.png"}
+// AssetDir("foo.txt") and AssetDir("notexist") would return an error
+// AssetDir("") will return []string{"data"}.
+func AssetDir(name string) ([]string, error) {
+	node := _bintree
+	if len(name) != 0 {
+		cannonicalName := strings.Replace(name, "\\", "/", -1)
+		pathList := strings.Split(cannonicalName, "/")
+		for _, p := range pathList {
+			node = node.Children[p]
+			if node == nil {
+				return nil, fmt.Errorf("Asset %s not found", name)
+			}
+		}
+	}
+	if node.Func != nil {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	rv := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		rv = append(rv, childName)
+	}
+	return rv, nil
+}
+
+type bintree struct {
+	Func     func() (*asset, error)
+	Children map[string]*bintree
+}
+var _bintree = &bintree{nil, map[string]*bintree{
+	"author.html": &bintree{authorHtml, map[string]*bintree{}},
+	"challenge.html": &bintree{challengeHtml, map[string]*bintree{}},
+	"editor.html": &bintree{editorHtml, map[string]*bintree{}},
+	"error.html": &bintree{errorHtml, map[string]*bintree{}},
+	"index.html": &bintree{indexHtml, map[string]*bintree{}},
+	"layout.html": &bintree{layoutHtml, map[string]*bintree{}},
+	"login.html": &bintree{loginHtml, map[string]*bintree{}},
+	"post.html": &bintree{postHtml, map[string]*bintree{}},
+}}
+
+// RestoreAsset restores an asset under the given directory
+func RestoreAsset(dir, name string) error {
+	data, err := Asset(name)
+	if err != nil {
+		return err
+	}
+	info, err := AssetInfo(name)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(_filePath(dir, filepath.Dir(name)), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
+	if err != nil {
+		return err
+	}
+	err = os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RestoreAssets restores an asset under the given directory recursively
+func RestoreAssets(dir, name string) error {
+	children, err := AssetDir(name)
+	// File
+	if err != nil {
+		return RestoreAsset(dir, name)
+	}
+	// Dir
+	for _, child := range children {
+		err = RestoreAssets(dir, filepath.Join(name, child))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _filePath(dir, name string) string {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
+}
+
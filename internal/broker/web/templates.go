@@ -0,0 +1,100 @@
+package web
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+//go:generate go-bindata -prefix templates/ -pkg web -o templates.bindata.go templates/
+
+// datetimeLayout is the format of datetime-local inputs.
+const datetimeLayout = "2006-01-02T15:04"
+
+// funcs are available in all templates.
+var funcs = template.FuncMap{
+	"date": func(t time.Time) string {
+		return t.Format("January 2, 2006")
+	},
+	// datetime formats the time for datetime-local inputs in UTC.
+	"datetime": func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(datetimeLayout)
+	},
+	"join": strings.Join,
+	// sanitized marks HTML of the post body as safe,
+	// it's sanitized when the post is saved.
+	"sanitized": func(s string) template.HTML {
+		return template.HTML(s)
+	},
+}
+
+// pages are parsed once, every page is rendered inside the layout.
+var pages = parsePages(
+	"index.html",
+	"post.html",
+	"author.html",
+	"login.html",
+	"challenge.html",
+	"editor.html",
+	"error.html",
+)
+
+// parsePages parses the layout with each of the pages.
+// Templates are embedded, so errors are bugs and panic.
+func parsePages(names ...string) map[string]*template.Template {
+	layout := template.Must(template.New("layout.html").Funcs(funcs).Parse(string(MustAsset("layout.html"))))
+
+	pages := make(map[string]*template.Template, len(names))
+	for _, name := range names {
+		t := template.Must(layout.Clone())
+		pages[name] = template.Must(t.New(name).Parse(string(MustAsset(name))))
+	}
+
+	return pages
+}
+
+// Layout holds data of the page around its content.
+type Layout struct {
+	Title    string
+	Username string
+	CSRF     string
+}
+
+// layout fills the layout data from the request.
+func layout(r *http.Request, title string) Layout {
+	l := Layout{
+		Title: title,
+		CSRF:  csrfFromContext(r.Context()),
+	}
+	if cl, ok := auth.FromContext(r.Context()); ok {
+		l.Username = cl.Subject
+	}
+
+	return l
+}
+
+// render writes the page with the status. The page is rendered
+// into a buffer first, so errors don't leave half of the page.
+func render(w http.ResponseWriter, status int, name string, data interface{}) error {
+	var buf bytes.Buffer
+	if err := pages[name].ExecuteTemplate(&buf, "layout.html", data); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return errors.Wrapf(err, "execute %s", name)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if _, err := buf.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write response")
+	}
+
+	return nil
+}
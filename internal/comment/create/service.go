@@ -0,0 +1,111 @@
+package create
+
+import (
+	"context"
+
+	"github.com/dipress/blog/internal/comment"
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+// easyjson service.go
+//go:generate mockgen -source=service.go -package=create -destination=service.mock.go
+
+// Validater validates comment fields.
+type Validater interface {
+	Validate(context.Context, *Form) error
+}
+
+// Abillity checks permissions to view posts.
+type Abillity interface {
+	CanView(u *user.User, post *post.Post) bool
+}
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	FindPost(ctx context.Context, id int) (*post.Post, error)
+	FindComment(ctx context.Context, id int) (*comment.Comment, error)
+	CreateComment(ctx context.Context, f *comment.NewComment, c *comment.Comment) error
+}
+
+// Form is a comment form.
+//easyjson:json
+type Form struct {
+	ParentID int    `json:"parent_id"`
+	Body     string `json:"body"`
+}
+
+// Service is a use case for comment validation and creation.
+type Service struct {
+	Repository
+	Validater
+	Abillity
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, v Validater, a Abillity) *Service {
+	s := Service{
+		Repository: r,
+		Validater:  v,
+		Abillity:   a,
+	}
+
+	return &s
+}
+
+// Create creates a comment for the post, or a reply
+// to another comment of the same post if parent is given.
+// Posts the user can't view are not found.
+func (s *Service) Create(ctx context.Context, postID int, f *Form) (*comment.Comment, error) {
+	if err := auth.RequireScope(ctx, token.ScopeCommentsWrite); err != nil {
+		return nil, errors.Wrap(err, "require scope")
+	}
+
+	if err := s.Validater.Validate(ctx, f); err != nil {
+		return nil, errors.Wrap(err, "validater validate")
+	}
+
+	claims, _ := auth.FromContext(ctx)
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+		return nil, errors.Wrap(err, "repository find user")
+	}
+
+	p, err := s.Repository.FindPost(ctx, postID)
+	if err != nil {
+		return nil, errors.Wrap(err, "find post")
+	}
+
+	if !s.Abillity.CanView(&u, p) {
+		return nil, post.ErrNotFound
+	}
+
+	if f.ParentID != 0 {
+		parent, err := s.Repository.FindComment(ctx, f.ParentID)
+		if err != nil {
+			return nil, errors.Wrap(err, "find parent comment")
+		}
+
+		if parent.PostID != postID {
+			return nil, comment.ErrNotFound
+		}
+	}
+
+	nc := comment.NewComment{
+		PostID:   postID,
+		UserID:   u.ID,
+		ParentID: f.ParentID,
+		Body:     f.Body,
+	}
+
+	var c comment.Comment
+	if err := s.Repository.CreateComment(ctx, &nc, &c); err != nil {
+		return nil, errors.Wrap(err, "repository create comment")
+	}
+	return &c, nil
+}
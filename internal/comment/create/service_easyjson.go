@@ -0,0 +1,102 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package create
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonCd93bc43DecodeGithubComDipressBlogInternalCommentCreate(in *jlexer.Lexer, out *Form) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "parent_id":
+			out.ParentID = int(in.Int())
+		case "body":
+			out.Body = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCd93bc43EncodeGithubComDipressBlogInternalCommentCreate(out *jwriter.Writer, in Form) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"parent_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.ParentID))
+	}
+	{
+		const prefix string = ",\"body\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Body))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Form) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonCd93bc43EncodeGithubComDipressBlogInternalCommentCreate(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Form) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonCd93bc43EncodeGithubComDipressBlogInternalCommentCreate(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Form) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonCd93bc43DecodeGithubComDipressBlogInternalCommentCreate(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Form) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonCd93bc43DecodeGithubComDipressBlogInternalCommentCreate(l, v)
+}
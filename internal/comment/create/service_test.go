@@ -0,0 +1,169 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dipress/blog/internal/comment"
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceCreate(t *testing.T) {
+	tests := []struct {
+		name           string
+		form           Form
+		validateFunc   func(mock *MockValidater)
+		repositoryFunc func(mock *MockRepository)
+		abilityFunc    func(mock *MockAbillity)
+		wantErr        bool
+	}{
+		{
+			name: "ok",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().FindPost(gomock.Any(), gomock.Any()).Return(&post.Post{}, nil)
+				m.EXPECT().CreateComment(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanView(gomock.Any(), gomock.Any()).Return(true)
+			},
+		},
+		{
+			name: "reply",
+			form: Form{
+				ParentID: 1,
+			},
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().FindPost(gomock.Any(), gomock.Any()).Return(&post.Post{}, nil)
+				m.EXPECT().FindComment(gomock.Any(), gomock.Any()).Return(&comment.Comment{PostID: 1}, nil)
+				m.EXPECT().CreateComment(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanView(gomock.Any(), gomock.Any()).Return(true)
+			},
+		},
+		{
+			name: "validation",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			repositoryFunc: func(m *MockRepository) {},
+			abilityFunc:    func(m *MockAbillity) {},
+			wantErr:        true,
+		},
+		{
+			name: "find post",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().FindPost(gomock.Any(), gomock.Any()).Return(nil, post.ErrNotFound)
+			},
+			abilityFunc: func(m *MockAbillity) {},
+			wantErr:     true,
+		},
+		{
+			name: "post not visible",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().FindPost(gomock.Any(), gomock.Any()).Return(&post.Post{Status: post.StatusDraft}, nil)
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanView(gomock.Any(), gomock.Any()).Return(false)
+			},
+			wantErr: true,
+		},
+		{
+			name: "parent of another post",
+			form: Form{
+				ParentID: 1,
+			},
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().FindPost(gomock.Any(), gomock.Any()).Return(&post.Post{}, nil)
+				m.EXPECT().FindComment(gomock.Any(), gomock.Any()).Return(&comment.Comment{PostID: 2}, nil)
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanView(gomock.Any(), gomock.Any()).Return(true)
+			},
+			wantErr: true,
+		},
+		{
+			name: "find user",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			abilityFunc: func(m *MockAbillity) {},
+			wantErr:     true,
+		},
+		{
+			name: "create comment",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().FindPost(gomock.Any(), gomock.Any()).Return(&post.Post{}, nil)
+				m.EXPECT().CreateComment(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanView(gomock.Any(), gomock.Any()).Return(true)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			validator := NewMockValidater(ctrl)
+			repo := NewMockRepository(ctrl)
+			ability := NewMockAbillity(ctrl)
+			tc.validateFunc(validator)
+			tc.repositoryFunc(repo)
+			tc.abilityFunc(ability)
+
+			s := NewService(repo, validator, ability)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			claims := auth.Claims{}
+			newCtx := auth.ToContext(ctx, &claims)
+
+			tc.form.Body = "my awesome comment"
+
+			_, err := s.Create(newCtx, 1, &tc.form)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package delete
+
+import (
+	"context"
+
+	"github.com/dipress/blog/internal/comment"
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=service.go -package=delete -destination=service.mock.go
+
+// Abillity checks permissions to delete comments.
+type Abillity interface {
+	CanDelete(userID int, c *comment.Comment, p *post.Post) bool
+}
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	FindPost(ctx context.Context, id int) (*post.Post, error)
+	FindComment(ctx context.Context, id int) (*comment.Comment, error)
+	DeleteComment(ctx context.Context, id int) error
+}
+
+// Service is a use case for comment delete.
+type Service struct {
+	Repository
+	Abillity
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, a Abillity) *Service {
+	s := Service{
+		Repository: r,
+		Abillity:   a,
+	}
+
+	return &s
+}
+
+// Delete deletes a comment of the post together with its replies.
+func (s *Service) Delete(ctx context.Context, postID, id int) error {
+	if err := auth.RequireScope(ctx, token.ScopeCommentsWrite); err != nil {
+		return errors.Wrap(err, "require scope")
+	}
+
+	c, err := s.Repository.FindComment(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "find comment")
+	}
+
+	if c.PostID != postID {
+		return comment.ErrNotFound
+	}
+
+	p, err := s.Repository.FindPost(ctx, postID)
+	if err != nil {
+		return errors.Wrap(err, "find post")
+	}
+
+	claims, _ := auth.FromContext(ctx)
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+		return errors.Wrap(err, "repository find user")
+	}
+
+	ok := s.Abillity.CanDelete(u.ID, c, p)
+	if !ok {
+		return comment.ErrNotFound
+	}
+
+	if err := s.Repository.DeleteComment(ctx, c.ID); err != nil {
+		return errors.Wrap(err, "delete comment")
+	}
+
+	return nil
+}
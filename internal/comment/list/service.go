@@ -0,0 +1,68 @@
+package list
+
+import (
+	"context"
+
+	"github.com/dipress/blog/internal/comment"
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+// Abillity checks permissions to view posts.
+type Abillity interface {
+	CanView(u *user.User, post *post.Post) bool
+}
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	FindPost(ctx context.Context, id int) (*post.Post, error)
+	ListComments(ctx context.Context, postID int, cs *comment.Comments) error
+}
+
+// Service is a use case for comments showing.
+type Service struct {
+	Repository
+	Abillity
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, a Abillity) *Service {
+	s := Service{
+		Repository: r,
+		Abillity:   a,
+	}
+
+	return &s
+}
+
+// List shows all comments of the post, oldest first. Replies
+// reference their parent comment by parent id. Comments of
+// posts the current user can't view are not found.
+func (s *Service) List(ctx context.Context, postID int) (*comment.Comments, error) {
+	p, err := s.Repository.FindPost(ctx, postID)
+	if err != nil {
+		return nil, errors.Wrap(err, "find post")
+	}
+
+	var u *user.User
+	if claims, ok := auth.FromContext(ctx); ok {
+		u = new(user.User)
+		if err := s.Repository.FindByUsername(ctx, claims.Subject, u); err != nil {
+			return nil, errors.Wrap(err, "repository find user")
+		}
+	}
+
+	if !s.Abillity.CanView(u, p) {
+		return nil, post.ErrNotFound
+	}
+
+	var comments comment.Comments
+	if err := s.Repository.ListComments(ctx, postID, &comments); err != nil {
+		return nil, errors.Wrap(err, "list comments")
+	}
+
+	return &comments, nil
+}
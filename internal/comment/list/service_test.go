@@ -0,0 +1,100 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dipress/blog/internal/ability"
+	"github.com/dipress/blog/internal/comment"
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceList(t *testing.T) {
+	tests := []struct {
+		name         string
+		findPostFunc func(ctx context.Context, id int) (*post.Post, error)
+		listFunc     func(ctx context.Context, postID int, cs *comment.Comments) error
+		err          error
+		wantErr      bool
+	}{
+		{
+			name: "ok",
+			findPostFunc: func(ctx context.Context, id int) (*post.Post, error) {
+				return &post.Post{Status: post.StatusPublished}, nil
+			},
+			listFunc: func(ctx context.Context, postID int, cs *comment.Comments) error {
+				return nil
+			},
+		},
+		{
+			name: "draft post",
+			findPostFunc: func(ctx context.Context, id int) (*post.Post, error) {
+				return &post.Post{Status: post.StatusDraft}, nil
+			},
+			err:     post.ErrNotFound,
+			wantErr: true,
+		},
+		{
+			name: "post not found",
+			findPostFunc: func(ctx context.Context, id int) (*post.Post, error) {
+				return nil, post.ErrNotFound
+			},
+			wantErr: true,
+		},
+		{
+			name: "repository error",
+			findPostFunc: func(ctx context.Context, id int) (*post.Post, error) {
+				return &post.Post{Status: post.StatusPublished}, nil
+			},
+			listFunc: func(ctx context.Context, postID int, cs *comment.Comments) error {
+				return errors.New("mock error")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewService(repository{
+				findPostFunc: tc.findPostFunc,
+				listFunc:     tc.listFunc,
+			}, ability.PostAbillity{})
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			_, err := s.List(ctx, 1)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.err != nil {
+					assert.Equal(t, tc.err, err)
+				}
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
+
+type repository struct {
+	findPostFunc func(ctx context.Context, id int) (*post.Post, error)
+	listFunc     func(ctx context.Context, postID int, cs *comment.Comments) error
+}
+
+func (r repository) FindByUsername(ctx context.Context, username string, u *user.User) error {
+	return errors.New("unexpected user")
+}
+
+func (r repository) FindPost(ctx context.Context, id int) (*post.Post, error) {
+	return r.findPostFunc(ctx, id)
+}
+
+func (r repository) ListComments(ctx context.Context, postID int, cs *comment.Comments) error {
+	return r.listFunc(ctx, postID, cs)
+}
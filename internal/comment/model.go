@@ -0,0 +1,37 @@
+package comment
+
+import (
+	"errors"
+	"time"
+)
+
+// easyjson -all model.go
+
+var (
+	// ErrNotFound raises when comment not found in the database.
+	ErrNotFound = errors.New("comment not found")
+)
+
+// Comment contains all comment field.
+type Comment struct {
+	ID        int       `json:"id"`
+	PostID    int       `json:"post_id"`
+	UserID    int       `json:"user_id"`
+	ParentID  int       `json:"parent_id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewComment contains the information which needs to create a new Comment.
+type NewComment struct {
+	PostID   int
+	UserID   int
+	ParentID int
+	Body     string
+}
+
+// Comments contains slice of comments.
+type Comments struct {
+	Comments []Comment `json:"comments"`
+}
@@ -0,0 +1,93 @@
+package update
+
+import (
+	"context"
+
+	"github.com/dipress/blog/internal/comment"
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+// easyjson service.go
+//go:generate mockgen -source=service.go -package=update -destination=service.mock.go
+
+// Abillity checks permissions to update comments.
+type Abillity interface {
+	CanUpdate(userID int, c *comment.Comment) bool
+}
+
+// Validater validates comment fields.
+type Validater interface {
+	Validate(context.Context, *Form) error
+}
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	FindComment(ctx context.Context, id int) (*comment.Comment, error)
+	UpdateComment(ctx context.Context, id int, c *comment.Comment) error
+}
+
+// Form is a comment form.
+//easyjson:json
+type Form struct {
+	Body string `json:"body"`
+}
+
+// Service is a use case for comment validation and updation.
+type Service struct {
+	Repository
+	Validater
+	Abillity
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, v Validater, a Abillity) *Service {
+	s := Service{
+		Repository: r,
+		Validater:  v,
+		Abillity:   a,
+	}
+	return &s
+}
+
+// Update updates a comment of the post.
+func (s *Service) Update(ctx context.Context, postID, id int, f *Form) (*comment.Comment, error) {
+	if err := auth.RequireScope(ctx, token.ScopeCommentsWrite); err != nil {
+		return nil, errors.Wrap(err, "require scope")
+	}
+
+	if err := s.Validater.Validate(ctx, f); err != nil {
+		return nil, errors.Wrap(err, "validater validate")
+	}
+
+	c, err := s.Repository.FindComment(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "find comment")
+	}
+
+	if c.PostID != postID {
+		return nil, comment.ErrNotFound
+	}
+
+	claims, _ := auth.FromContext(ctx)
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+		return nil, errors.Wrap(err, "repository find user")
+	}
+
+	ok := s.Abillity.CanUpdate(u.ID, c)
+	if !ok {
+		return nil, comment.ErrNotFound
+	}
+
+	c.Body = f.Body
+
+	if err := s.Repository.UpdateComment(ctx, id, c); err != nil {
+		return nil, errors.Wrap(err, "update comment")
+	}
+	return c, nil
+}
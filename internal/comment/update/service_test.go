@@ -0,0 +1,145 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dipress/blog/internal/comment"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceUpdate(t *testing.T) {
+	tests := []struct {
+		name           string
+		validateFunc   func(mock *MockValidater)
+		repositoryFunc func(mock *MockRepository)
+		abilityFunc    func(mock *MockAbillity)
+		wantErr        bool
+	}{
+		{
+			name: "ok",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindComment(gomock.Any(), gomock.Any()).Return(&comment.Comment{PostID: 1}, nil)
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().UpdateComment(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanUpdate(gomock.Any(), gomock.Any()).Return(true)
+			},
+		},
+		{
+			name: "validation",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			repositoryFunc: func(m *MockRepository) {},
+			abilityFunc:    func(m *MockAbillity) {},
+			wantErr:        true,
+		},
+		{
+			name: "find comment error",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindComment(gomock.Any(), gomock.Any()).Return(nil, errors.New("mock error"))
+			},
+			abilityFunc: func(m *MockAbillity) {},
+			wantErr:     true,
+		},
+		{
+			name: "comment of another post",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindComment(gomock.Any(), gomock.Any()).Return(&comment.Comment{PostID: 2}, nil)
+			},
+			abilityFunc: func(m *MockAbillity) {},
+			wantErr:     true,
+		},
+		{
+			name: "find user error",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindComment(gomock.Any(), gomock.Any()).Return(&comment.Comment{PostID: 1}, nil)
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			abilityFunc: func(m *MockAbillity) {},
+			wantErr:     true,
+		},
+		{
+			name: "ability error",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindComment(gomock.Any(), gomock.Any()).Return(&comment.Comment{PostID: 1}, nil)
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanUpdate(gomock.Any(), gomock.Any()).Return(false)
+			},
+			wantErr: true,
+		},
+		{
+			name: "update error",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindComment(gomock.Any(), gomock.Any()).Return(&comment.Comment{PostID: 1}, nil)
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().UpdateComment(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanUpdate(gomock.Any(), gomock.Any()).Return(true)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			validator := NewMockValidater(ctrl)
+			repo := NewMockRepository(ctrl)
+			ability := NewMockAbillity(ctrl)
+			tc.validateFunc(validator)
+			tc.repositoryFunc(repo)
+			tc.abilityFunc(ability)
+
+			s := NewService(repo, validator, ability)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			claims := auth.Claims{}
+			newCtx := auth.ToContext(ctx, &claims)
+
+			form := Form{
+				Body: "update my awesome comment",
+			}
+
+			_, err := s.Update(newCtx, 1, 1, &form)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
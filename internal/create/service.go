@@ -2,8 +2,11 @@ package create
 
 import (
 	"context"
+	"time"
 
 	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/tag"
+	"github.com/dipress/blog/internal/token"
 	"github.com/dipress/blog/internal/user"
 	"github.com/dipress/blog/kit/auth"
 	"github.com/pkg/errors"
@@ -12,11 +15,23 @@ import (
 // easyjson service.go
 // go:generate mockgen -source=service.go -package=create -destination=service.mock.go
 
+var (
+	// ErrForbidden returns when the user is not allowed to create posts.
+	ErrForbidden = errors.New("forbidden")
+	// ErrUnverified returns when the user has not verified the email yet.
+	ErrUnverified = errors.New("email is not verified")
+)
+
 // Validater validates post fields.
 type Validater interface {
 	Validate(context.Context, *Form) error
 }
 
+// Abillity checks permissions to create posts.
+type Abillity interface {
+	CanCreate(u *user.User) bool
+}
+
 // Repository allows to work with the database.
 type Repository interface {
 	FindByUsername(ctx context.Context, username string, u *user.User) error
@@ -26,21 +41,30 @@ type Repository interface {
 // Form is a post form.
 //easyjson:json
 type Form struct {
-	Title string `json:"title"`
-	Body  string `json:"body"`
+	Title        string     `json:"title"`
+	Body         string     `json:"body"`
+	Format       string     `json:"format"`
+	Description  string     `json:"description"`
+	CanonicalURL string     `json:"canonical_url"`
+	ImageURL     string     `json:"image_url"`
+	Tags         []string   `json:"tags"`
+	Status       string     `json:"status"`
+	PublishedAt  *time.Time `json:"published_at"`
 }
 
 // Service is a use case for post validation and creation.
 type Service struct {
 	Repository
 	Validater
+	Abillity
 }
 
 // NewService factory prepares service for all futher operations.
-func NewService(r Repository, v Validater) *Service {
+func NewService(r Repository, v Validater, a Abillity) *Service {
 	s := Service{
 		Repository: r,
 		Validater:  v,
+		Abillity:   a,
 	}
 
 	return &s
@@ -48,6 +72,10 @@ func NewService(r Repository, v Validater) *Service {
 
 // Create creates a post.
 func (s *Service) Create(ctx context.Context, f *Form) (*post.Post, error) {
+	if err := auth.RequireScope(ctx, token.ScopePostsWrite); err != nil {
+		return nil, errors.Wrap(err, "require scope")
+	}
+
 	if err := s.Validater.Validate(ctx, f); err != nil {
 		return nil, errors.Wrap(err, "validater validate")
 	}
@@ -58,10 +86,37 @@ func (s *Service) Create(ctx context.Context, f *Form) (*post.Post, error) {
 		return nil, errors.Wrap(err, "repository find user")
 	}
 
+	if u.VerifiedAt == nil {
+		return nil, ErrUnverified
+	}
+
+	if !s.Abillity.CanCreate(&u) {
+		return nil, ErrForbidden
+	}
+
+	status := f.Status
+	if status == "" {
+		status = post.StatusPublished
+	}
+
+	format := f.Format
+	if format == "" {
+		format = post.FormatPlain
+	}
+
 	np := post.NewPost{
-		UserID: u.ID,
-		Title:  f.Title,
-		Body:   f.Body,
+		UserID:       u.ID,
+		Title:        f.Title,
+		Slug:         post.Slug(f.Title),
+		Body:         f.Body,
+		Format:       format,
+		BodyHTML:     post.RenderBody(format, f.Body),
+		Description:  f.Description,
+		CanonicalURL: f.CanonicalURL,
+		ImageURL:     f.ImageURL,
+		Tags:         tag.Normalize(f.Tags),
+		Status:       status,
+		PublishedAt:  post.PublishedAt(status, f.PublishedAt, time.Now()),
 	}
 
 	var p post.Post
@@ -49,6 +49,43 @@ func (mr *MockValidaterMockRecorder) Validate(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockValidater)(nil).Validate), arg0, arg1)
 }
 
+// MockAbillity is a mock of Abillity interface
+type MockAbillity struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbillityMockRecorder
+}
+
+// MockAbillityMockRecorder is the mock recorder for MockAbillity
+type MockAbillityMockRecorder struct {
+	mock *MockAbillity
+}
+
+// NewMockAbillity creates a new mock instance
+func NewMockAbillity(ctrl *gomock.Controller) *MockAbillity {
+	mock := &MockAbillity{ctrl: ctrl}
+	mock.recorder = &MockAbillityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAbillity) EXPECT() *MockAbillityMockRecorder {
+	return m.recorder
+}
+
+// CanCreate mocks base method
+func (m *MockAbillity) CanCreate(u *user.User) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanCreate", u)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CanCreate indicates an expected call of CanCreate
+func (mr *MockAbillityMockRecorder) CanCreate(u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanCreate", reflect.TypeOf((*MockAbillity)(nil).CanCreate), u)
+}
+
 // MockRepository is a mock of Repository interface
 type MockRepository struct {
 	ctrl     *gomock.Controller
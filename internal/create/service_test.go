@@ -4,17 +4,27 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/user"
 	"github.com/dipress/blog/kit/auth"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestServiceCreate(t *testing.T) {
+	findVerified := func(_ context.Context, _ string, u *user.User) error {
+		now := time.Now()
+		u.VerifiedAt = &now
+		return nil
+	}
+
 	tests := []struct {
 		name           string
 		validateFunc   func(mock *MockValidater)
 		repositoryFunc func(mock *MockRepository)
+		abilityFunc    func(mock *MockAbillity)
 		wantErr        bool
 	}{
 		{
@@ -23,8 +33,16 @@ func TestServiceCreate(t *testing.T) {
 				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			repositoryFunc: func(m *MockRepository) {
-				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-				m.EXPECT().CreatePost(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(findVerified)
+				m.EXPECT().CreatePost(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, np *post.NewPost, _ *post.Post) error {
+					assert.Equal(t, "my-awesome-titie", np.Slug)
+					assert.Equal(t, post.FormatPlain, np.Format)
+					assert.Equal(t, "<p>my awesome body</p>", np.BodyHTML)
+					return nil
+				})
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanCreate(gomock.Any()).Return(true)
 			},
 		},
 		{
@@ -33,6 +51,7 @@ func TestServiceCreate(t *testing.T) {
 				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
 			},
 			repositoryFunc: func(m *MockRepository) {},
+			abilityFunc:    func(m *MockAbillity) {},
 			wantErr:        true,
 		},
 		{
@@ -43,6 +62,31 @@ func TestServiceCreate(t *testing.T) {
 			repositoryFunc: func(m *MockRepository) {
 				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
 			},
+			abilityFunc: func(m *MockAbillity) {},
+			wantErr:     true,
+		},
+		{
+			name: "unverified",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			abilityFunc: func(m *MockAbillity) {},
+			wantErr:     true,
+		},
+		{
+			name: "forbidden",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(findVerified)
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanCreate(gomock.Any()).Return(false)
+			},
 			wantErr: true,
 		},
 		{
@@ -51,9 +95,12 @@ func TestServiceCreate(t *testing.T) {
 				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			repositoryFunc: func(m *MockRepository) {
-				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(findVerified)
 				m.EXPECT().CreatePost(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
 			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanCreate(gomock.Any()).Return(true)
+			},
 			wantErr: true,
 		},
 	}
@@ -67,10 +114,12 @@ func TestServiceCreate(t *testing.T) {
 
 			validator := NewMockValidater(ctrl)
 			repo := NewMockRepository(ctrl)
+			ability := NewMockAbillity(ctrl)
 			tc.validateFunc(validator)
 			tc.repositoryFunc(repo)
+			tc.abilityFunc(ability)
 
-			s := NewService(repo, validator)
+			s := NewService(repo, validator, ability)
 
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/token"
 	"github.com/dipress/blog/internal/user"
 	"github.com/dipress/blog/kit/auth"
 	"github.com/pkg/errors"
@@ -13,7 +14,7 @@ import (
 
 // Abillity checks permissions to view posts.
 type Abillity interface {
-	CanDelete(userID int, post *post.Post) bool
+	CanDelete(u *user.User, post *post.Post) bool
 }
 
 // Repository allows to work with the database.
@@ -41,6 +42,10 @@ func NewService(r Repository, a Abillity) *Service {
 
 // Delete deletes a post.
 func (s *Service) Delete(ctx context.Context, id int) error {
+	if err := auth.RequireScope(ctx, token.ScopePostsWrite); err != nil {
+		return errors.Wrap(err, "require scope")
+	}
+
 	p, err := s.Repository.FindPost(ctx, id)
 	if err != nil {
 		return errors.Wrap(err, "find post")
@@ -53,7 +58,7 @@ func (s *Service) Delete(ctx context.Context, id int) error {
 		return errors.Wrap(err, "repository find user")
 	}
 
-	ok := s.Abillity.CanDelete(u.ID, p)
+	ok := s.Abillity.CanDelete(&u, p)
 	if !ok {
 		return post.ErrNotFound
 	}
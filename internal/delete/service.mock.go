@@ -36,17 +36,17 @@ func (m *MockAbillity) EXPECT() *MockAbillityMockRecorder {
 }
 
 // CanDelete mocks base method
-func (m *MockAbillity) CanDelete(userID int, post *post.Post) bool {
+func (m *MockAbillity) CanDelete(u *user.User, post *post.Post) bool {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CanDelete", userID, post)
+	ret := m.ctrl.Call(m, "CanDelete", u, post)
 	ret0, _ := ret[0].(bool)
 	return ret0
 }
 
 // CanDelete indicates an expected call of CanDelete
-func (mr *MockAbillityMockRecorder) CanDelete(userID, post interface{}) *gomock.Call {
+func (mr *MockAbillityMockRecorder) CanDelete(u, post interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanDelete", reflect.TypeOf((*MockAbillity)(nil).CanDelete), userID, post)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanDelete", reflect.TypeOf((*MockAbillity)(nil).CanDelete), u, post)
 }
 
 // MockRepository is a mock of Repository interface
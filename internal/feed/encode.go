@@ -0,0 +1,249 @@
+package feed
+
+import (
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// easyjson encode.go
+
+// Content types of the feed formats.
+const (
+	ContentTypeRSS  = "application/rss+xml; charset=utf-8"
+	ContentTypeAtom = "application/atom+xml; charset=utf-8"
+	ContentTypeJSON = "application/feed+json; charset=utf-8"
+)
+
+// Encoder writes the feed in one of the formats. Links of the
+// feed are absolute, base is the scheme and the host of the site
+// and self is the url of the feed itself.
+type Encoder func(f *Feed, base, self string) ([]byte, error)
+
+// link returns the url of the item page.
+func (i *Item) link(base string) string {
+	return base + "/posts/by-slug/" + url.PathEscape(i.Slug)
+}
+
+// id returns the url which identifies the item.
+// Unlike the link it doesn't change with the title.
+func (i *Item) id(base string) string {
+	return base + "/posts/" + strconv.Itoa(i.ID)
+}
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	DC      string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Self          atomLink  `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        rssGUID  `xml:"guid"`
+	Author      string   `xml:"dc:creator,omitempty"`
+	Categories  []string `xml:"category"`
+	Description string   `xml:"description"`
+	PubDate     string   `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+// RSS encodes the feed as RSS 2.0.
+func RSS(f *Feed, base, self string) ([]byte, error) {
+	doc := rss{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		DC:      "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:       f.Title,
+			Link:        base + f.Path,
+			Description: f.Title,
+			Self:        atomLink{Href: self, Rel: "self", Type: "application/rss+xml"},
+			Items:       make([]rssItem, 0, len(f.Items)),
+		},
+	}
+	if !f.Updated.IsZero() {
+		doc.Channel.LastBuildDate = f.Updated.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, i := range f.Items {
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       i.Title,
+			Link:        i.link(base),
+			GUID:        rssGUID{Value: i.id(base)},
+			Author:      i.Author,
+			Categories:  i.Tags,
+			Description: i.ContentHTML,
+			PubDate:     i.Published.UTC().Format(time.RFC1123Z),
+		})
+	}
+
+	data, err := xml.Marshal(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal xml")
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Link       atomLink       `xml:"link"`
+	Author     atomAuthor     `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+	Content    atomContent    `xml:"content"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Atom encodes the feed as Atom 1.0.
+func Atom(f *Feed, base, self string) ([]byte, error) {
+	doc := atomFeed{
+		ID:      base + f.Path,
+		Title:   f.Title,
+		Updated: f.Updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: base + f.Path, Rel: "alternate"},
+			{Href: self, Rel: "self", Type: "application/atom+xml"},
+		},
+		Entries: make([]atomEntry, 0, len(f.Items)),
+	}
+
+	for _, i := range f.Items {
+		// Atom requires an author of every entry,
+		// authors of deleted accounts have no names.
+		author := i.Author
+		if author == "" {
+			author = "unknown"
+		}
+
+		categories := make([]atomCategory, 0, len(i.Tags))
+		for _, t := range i.Tags {
+			categories = append(categories, atomCategory{Term: t})
+		}
+
+		doc.Entries = append(doc.Entries, atomEntry{
+			ID:         i.id(base),
+			Title:      i.Title,
+			Link:       atomLink{Href: i.link(base), Rel: "alternate"},
+			Author:     atomAuthor{Name: author},
+			Categories: categories,
+			Content:    atomContent{Type: "html", Value: i.ContentHTML},
+			Published:  i.Published.UTC().Format(time.RFC3339),
+			Updated:    i.Updated.UTC().Format(time.RFC3339),
+		})
+	}
+
+	data, err := xml.Marshal(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal xml")
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+// jsonFeed is JSON Feed 1.1.
+//easyjson:json
+type jsonFeed struct {
+	Version     string     `json:"version"`
+	Title       string     `json:"title"`
+	HomePageURL string     `json:"home_page_url"`
+	FeedURL     string     `json:"feed_url"`
+	Items       []jsonItem `json:"items"`
+}
+
+type jsonItem struct {
+	ID            string       `json:"id"`
+	URL           string       `json:"url"`
+	Title         string       `json:"title"`
+	ContentHTML   string       `json:"content_html"`
+	Tags          []string     `json:"tags,omitempty"`
+	Authors       []jsonAuthor `json:"authors,omitempty"`
+	DatePublished time.Time    `json:"date_published"`
+	DateModified  time.Time    `json:"date_modified"`
+}
+
+type jsonAuthor struct {
+	Name string `json:"name"`
+}
+
+// JSON encodes the feed as JSON Feed 1.1.
+func JSON(f *Feed, base, self string) ([]byte, error) {
+	doc := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       f.Title,
+		HomePageURL: base + f.Path,
+		FeedURL:     self,
+		Items:       make([]jsonItem, 0, len(f.Items)),
+	}
+
+	for _, i := range f.Items {
+		item := jsonItem{
+			ID:            i.id(base),
+			URL:           i.link(base),
+			Title:         i.Title,
+			ContentHTML:   i.ContentHTML,
+			Tags:          i.Tags,
+			DatePublished: i.Published.UTC(),
+			DateModified:  i.Updated.UTC(),
+		}
+		if i.Author != "" {
+			item.Authors = []jsonAuthor{{Name: i.Author}}
+		}
+		doc.Items = append(doc.Items, item)
+	}
+
+	data, err := doc.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal json")
+	}
+
+	return data, nil
+}
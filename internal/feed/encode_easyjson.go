@@ -0,0 +1,412 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package feed
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonF0ae8156DecodeGithubComDipressBlogInternalFeed(in *jlexer.Lexer, out *jsonFeed) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "version":
+			out.Version = string(in.String())
+		case "title":
+			out.Title = string(in.String())
+		case "home_page_url":
+			out.HomePageURL = string(in.String())
+		case "feed_url":
+			out.FeedURL = string(in.String())
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]jsonItem, 0, 1)
+					} else {
+						out.Items = []jsonItem{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 jsonItem
+					easyjsonF0ae8156DecodeGithubComDipressBlogInternalFeed1(in, &v1)
+					out.Items = append(out.Items, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF0ae8156EncodeGithubComDipressBlogInternalFeed(out *jwriter.Writer, in jsonFeed) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"title\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"home_page_url\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.HomePageURL))
+	}
+	{
+		const prefix string = ",\"feed_url\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.FeedURL))
+	}
+	{
+		const prefix string = ",\"items\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Items {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonF0ae8156EncodeGithubComDipressBlogInternalFeed1(out, v3)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v jsonFeed) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF0ae8156EncodeGithubComDipressBlogInternalFeed(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v jsonFeed) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF0ae8156EncodeGithubComDipressBlogInternalFeed(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *jsonFeed) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF0ae8156DecodeGithubComDipressBlogInternalFeed(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *jsonFeed) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF0ae8156DecodeGithubComDipressBlogInternalFeed(l, v)
+}
+func easyjsonF0ae8156DecodeGithubComDipressBlogInternalFeed1(in *jlexer.Lexer, out *jsonItem) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "url":
+			out.URL = string(in.String())
+		case "title":
+			out.Title = string(in.String())
+		case "content_html":
+			out.ContentHTML = string(in.String())
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 string
+					v4 = string(in.String())
+					out.Tags = append(out.Tags, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "authors":
+			if in.IsNull() {
+				in.Skip()
+				out.Authors = nil
+			} else {
+				in.Delim('[')
+				if out.Authors == nil {
+					if !in.IsDelim(']') {
+						out.Authors = make([]jsonAuthor, 0, 4)
+					} else {
+						out.Authors = []jsonAuthor{}
+					}
+				} else {
+					out.Authors = (out.Authors)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 jsonAuthor
+					easyjsonF0ae8156DecodeGithubComDipressBlogInternalFeed2(in, &v5)
+					out.Authors = append(out.Authors, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "date_published":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.DatePublished).UnmarshalJSON(data))
+			}
+		case "date_modified":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.DateModified).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF0ae8156EncodeGithubComDipressBlogInternalFeed1(out *jwriter.Writer, in jsonItem) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"url\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.URL))
+	}
+	{
+		const prefix string = ",\"title\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"content_html\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ContentHTML))
+	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v6, v7 := range in.Tags {
+				if v6 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v7))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Authors) != 0 {
+		const prefix string = ",\"authors\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v8, v9 := range in.Authors {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonF0ae8156EncodeGithubComDipressBlogInternalFeed2(out, v9)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"date_published\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.DatePublished).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"date_modified\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.DateModified).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+func easyjsonF0ae8156DecodeGithubComDipressBlogInternalFeed2(in *jlexer.Lexer, out *jsonAuthor) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF0ae8156EncodeGithubComDipressBlogInternalFeed2(out *jwriter.Writer, in jsonAuthor) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Name))
+	}
+	out.RawByte('}')
+}
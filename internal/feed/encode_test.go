@@ -0,0 +1,116 @@
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testFeed() *Feed {
+	published := time.Date(2019, 7, 20, 10, 0, 0, 0, time.UTC)
+	updated := published.Add(time.Hour)
+
+	f := Feed{
+		Title:   "Posts by john",
+		Path:    "/users/john/posts",
+		Updated: updated,
+		Items: []Item{
+			{
+				ID:          7,
+				Title:       "Tom & Jerry",
+				Slug:        "tom-jerry",
+				Author:      "John",
+				ContentHTML: "<p>cat <em>and</em> mouse</p>",
+				Tags:        []string{"cartoons"},
+				Published:   published,
+				Updated:     updated,
+			},
+		},
+	}
+
+	return &f
+}
+
+func TestRSS(t *testing.T) {
+	data, err := RSS(testFeed(), "https://example.com", "https://example.com/users/john/feed.rss")
+	assert.Nil(t, err)
+
+	var doc struct {
+		Channel struct {
+			Links         []string `xml:"link"`
+			LastBuildDate string   `xml:"lastBuildDate"`
+			Items         []struct {
+				Title       string `xml:"title"`
+				Link        string `xml:"link"`
+				GUID        string `xml:"guid"`
+				Creator     string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+				Description string `xml:"description"`
+				PubDate     string `xml:"pubDate"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	assert.Nil(t, xml.Unmarshal(data, &doc))
+	// The second link is atom:link to the feed itself.
+	assert.Equal(t, "https://example.com/users/john/posts", doc.Channel.Links[0])
+	assert.Equal(t, "Sat, 20 Jul 2019 11:00:00 +0000", doc.Channel.LastBuildDate)
+	assert.Len(t, doc.Channel.Items, 1)
+
+	item := doc.Channel.Items[0]
+	assert.Equal(t, "Tom & Jerry", item.Title)
+	assert.Equal(t, "https://example.com/posts/by-slug/tom-jerry", item.Link)
+	assert.Equal(t, "https://example.com/posts/7", item.GUID)
+	assert.Equal(t, "John", item.Creator)
+	assert.Equal(t, "<p>cat <em>and</em> mouse</p>", item.Description)
+	assert.Equal(t, "Sat, 20 Jul 2019 10:00:00 +0000", item.PubDate)
+}
+
+func TestAtom(t *testing.T) {
+	data, err := Atom(testFeed(), "https://example.com", "https://example.com/users/john/feed.atom")
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(data), xml.Header))
+
+	var doc struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		Updated string   `xml:"updated"`
+		Links   []struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+		} `xml:"link"`
+		Entries []struct {
+			ID      string `xml:"id"`
+			Author  string `xml:"author>name"`
+			Content string `xml:"content"`
+			Updated string `xml:"updated"`
+		} `xml:"entry"`
+	}
+	assert.Nil(t, xml.Unmarshal(data, &doc))
+	assert.Equal(t, "2019-07-20T11:00:00Z", doc.Updated)
+	assert.Len(t, doc.Links, 2)
+	assert.Equal(t, "https://example.com/users/john/feed.atom", doc.Links[1].Href)
+	assert.Len(t, doc.Entries, 1)
+	assert.Equal(t, "https://example.com/posts/7", doc.Entries[0].ID)
+	assert.Equal(t, "John", doc.Entries[0].Author)
+	assert.Equal(t, "<p>cat <em>and</em> mouse</p>", doc.Entries[0].Content)
+}
+
+func TestJSON(t *testing.T) {
+	data, err := JSON(testFeed(), "https://example.com", "https://example.com/users/john/feed.json")
+	assert.Nil(t, err)
+
+	var doc map[string]interface{}
+	assert.Nil(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, "https://jsonfeed.org/version/1.1", doc["version"])
+	assert.Equal(t, "https://example.com/users/john/feed.json", doc["feed_url"])
+
+	items := doc["items"].([]interface{})
+	assert.Len(t, items, 1)
+
+	item := items[0].(map[string]interface{})
+	assert.Equal(t, "https://example.com/posts/7", item["id"])
+	assert.Equal(t, "https://example.com/posts/by-slug/tom-jerry", item["url"])
+	assert.Equal(t, "2019-07-20T10:00:00Z", item["date_published"])
+}
@@ -0,0 +1,139 @@
+package feed
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/dipress/blog/internal/list"
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/user"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=service.go -package=feed -destination=service.mock.go
+
+// size is the number of the latest posts in a feed.
+const size = 20
+
+// Lister lists published posts.
+type Lister interface {
+	List(ctx context.Context, f *list.Form) (*post.Posts, error)
+}
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByID(ctx context.Context, id int, u *user.User) error
+}
+
+// Form holds feed parameters. The feed has posts
+// of all authors and tags when they are empty.
+type Form struct {
+	Author string
+	Tag    string
+}
+
+// Feed contains the latest posts.
+type Feed struct {
+	Title   string
+	Path    string
+	Updated time.Time
+	Items   []Item
+}
+
+// Item is a post of the feed.
+type Item struct {
+	ID          int
+	Title       string
+	Slug        string
+	Author      string
+	ContentHTML string
+	Tags        []string
+	Published   time.Time
+	Updated     time.Time
+}
+
+// Service is a use case for feeds.
+type Service struct {
+	Lister
+	Repository
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(l Lister, r Repository) *Service {
+	s := Service{
+		Lister:     l,
+		Repository: r,
+	}
+
+	return &s
+}
+
+// Feed builds the feed of the latest published posts. It's updated
+// when the latest of its posts is updated.
+func (s *Service) Feed(ctx context.Context, f *Form) (*Feed, error) {
+	posts, err := s.Lister.List(ctx, &list.Form{
+		Limit:  size,
+		Author: f.Author,
+		Tag:    f.Tag,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "list posts")
+	}
+
+	fd := Feed{
+		Title: "Posts",
+		Path:  "/posts",
+		Items: make([]Item, 0, len(posts.Posts)),
+	}
+	switch {
+	case f.Author != "":
+		fd.Title = "Posts by " + f.Author
+		fd.Path = "/users/" + url.PathEscape(f.Author) + "/posts"
+	case f.Tag != "":
+		fd.Title = "Posts tagged " + f.Tag
+		fd.Path = "/tags/" + url.PathEscape(f.Tag) + "/posts"
+	}
+
+	authors := make(map[int]string)
+	for _, p := range posts.Posts {
+		author, ok := authors[p.UserID]
+		if !ok {
+			var u user.User
+			if err := s.Repository.FindByID(ctx, p.UserID, &u); err != nil && errors.Cause(err) != user.ErrNotFound {
+				return nil, errors.Wrap(err, "repository find author")
+			}
+			author = u.DisplayName
+			if author == "" {
+				author = u.Username
+			}
+			authors[p.UserID] = author
+		}
+
+		published := p.CreatedAt
+		if p.PublishedAt != nil {
+			published = *p.PublishedAt
+		}
+
+		updated := p.UpdatedAt
+		if published.After(updated) {
+			updated = published
+		}
+		if updated.After(fd.Updated) {
+			fd.Updated = updated
+		}
+
+		fd.Items = append(fd.Items, Item{
+			ID:          p.ID,
+			Title:       p.Title,
+			Slug:        p.Slug,
+			Author:      author,
+			ContentHTML: p.BodyHTML,
+			Tags:        p.Tags,
+			Published:   published,
+			Updated:     updated,
+		})
+	}
+
+	return &fd, nil
+}
@@ -0,0 +1,119 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dipress/blog/internal/list"
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/user"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceFeed(t *testing.T) {
+	earlier := time.Date(2019, 7, 20, 10, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+
+	posts := post.Posts{
+		Posts: []post.Post{
+			{ID: 2, UserID: 1, Title: "second", PublishedAt: &later, UpdatedAt: later},
+			{ID: 1, UserID: 1, Title: "first", PublishedAt: &earlier, UpdatedAt: earlier},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		form           Form
+		listerFunc     func(mock *MockLister)
+		repositoryFunc func(mock *MockRepository)
+		wantErr        bool
+		wantTitle      string
+	}{
+		{
+			name: "ok",
+			listerFunc: func(m *MockLister) {
+				m.EXPECT().List(gomock.Any(), &list.Form{Limit: size}).Return(&posts, nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByID(gomock.Any(), 1, gomock.Any()).DoAndReturn(func(ctx context.Context, id int, u *user.User) error {
+					u.Username = "john"
+					return nil
+				})
+			},
+			wantTitle: "Posts",
+		},
+		{
+			name: "author",
+			form: Form{Author: "john"},
+			listerFunc: func(m *MockLister) {
+				m.EXPECT().List(gomock.Any(), &list.Form{Limit: size, Author: "john"}).Return(&posts, nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByID(gomock.Any(), 1, gomock.Any()).Return(nil)
+			},
+			wantTitle: "Posts by john",
+		},
+		{
+			name: "deleted author",
+			form: Form{Tag: "go"},
+			listerFunc: func(m *MockLister) {
+				m.EXPECT().List(gomock.Any(), &list.Form{Limit: size, Tag: "go"}).Return(&posts, nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByID(gomock.Any(), 1, gomock.Any()).Return(user.ErrNotFound)
+			},
+			wantTitle: "Posts tagged go",
+		},
+		{
+			name: "list error",
+			listerFunc: func(m *MockLister) {
+				m.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("mock error"))
+			},
+			repositoryFunc: func(m *MockRepository) {},
+			wantErr:        true,
+		},
+		{
+			name: "find author error",
+			listerFunc: func(m *MockLister) {
+				m.EXPECT().List(gomock.Any(), gomock.Any()).Return(&posts, nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByID(gomock.Any(), 1, gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			lister := NewMockLister(ctrl)
+			repo := NewMockRepository(ctrl)
+			tc.listerFunc(lister)
+			tc.repositoryFunc(repo)
+
+			s := NewService(lister, repo)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			got, err := s.Feed(ctx, &tc.form)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantTitle, got.Title)
+			assert.Equal(t, later, got.Updated)
+			assert.Len(t, got.Items, 2)
+		})
+	}
+}
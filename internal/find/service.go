@@ -4,32 +4,74 @@ import (
 	"context"
 
 	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
 	"github.com/pkg/errors"
 )
 
+//go:generate mockgen -source=service.go -package=find -destination=service.mock.go
+
+// Abillity checks permissions to view posts.
+type Abillity interface {
+	CanView(u *user.User, post *post.Post) bool
+}
+
 // Repository allows to work with the database.
 type Repository interface {
+	FindByUsername(ctx context.Context, username string, u *user.User) error
 	FindPost(ctx context.Context, id int) (*post.Post, error)
+	FindPostBySlug(ctx context.Context, slug string) (*post.Post, error)
 }
 
 // Service is a use case for post finding.
 type Service struct {
 	Repository
+	Abillity
 }
 
 // NewService factory prepares service for all futher operations.
-func NewService(r Repository) *Service {
+func NewService(r Repository, a Abillity) *Service {
 	s := Service{
 		Repository: r,
+		Abillity:   a,
 	}
 	return &s
 }
 
-// Find finds post
+// Find finds post visible for the current user.
+// Anonymous readers can see published posts only.
 func (s *Service) Find(ctx context.Context, id int) (*post.Post, error) {
 	p, err := s.Repository.FindPost(ctx, id)
 	if err != nil {
 		return nil, errors.Wrap(err, "repository find")
 	}
+
+	return s.visible(ctx, p)
+}
+
+// FindBySlug finds post visible for the current user by the
+// current or a former slug. The post has the current one.
+func (s *Service) FindBySlug(ctx context.Context, slug string) (*post.Post, error) {
+	p, err := s.Repository.FindPostBySlug(ctx, slug)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository find by slug")
+	}
+
+	return s.visible(ctx, p)
+}
+
+// visible returns the post if the current user can view it.
+func (s *Service) visible(ctx context.Context, p *post.Post) (*post.Post, error) {
+	var u *user.User
+	if claims, ok := auth.FromContext(ctx); ok {
+		u = new(user.User)
+		if err := s.Repository.FindByUsername(ctx, claims.Subject, u); err != nil {
+			return nil, errors.Wrap(err, "repository find user")
+		}
+	}
+
+	if !s.Abillity.CanView(u, p) {
+		return nil, post.ErrNotFound
+	}
 	return p, nil
 }
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package find is a generated GoMock package.
+package find
+
+import (
+	context "context"
+	post "github.com/dipress/blog/internal/post"
+	user "github.com/dipress/blog/internal/user"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockAbillity is a mock of Abillity interface
+type MockAbillity struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbillityMockRecorder
+}
+
+// MockAbillityMockRecorder is the mock recorder for MockAbillity
+type MockAbillityMockRecorder struct {
+	mock *MockAbillity
+}
+
+// NewMockAbillity creates a new mock instance
+func NewMockAbillity(ctrl *gomock.Controller) *MockAbillity {
+	mock := &MockAbillity{ctrl: ctrl}
+	mock.recorder = &MockAbillityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAbillity) EXPECT() *MockAbillityMockRecorder {
+	return m.recorder
+}
+
+// CanView mocks base method
+func (m *MockAbillity) CanView(u *user.User, post *post.Post) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanView", u, post)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CanView indicates an expected call of CanView
+func (mr *MockAbillityMockRecorder) CanView(u, post interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanView", reflect.TypeOf((*MockAbillity)(nil).CanView), u, post)
+}
+
+// MockRepository is a mock of Repository interface
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByUsername mocks base method
+func (m *MockRepository) FindByUsername(ctx context.Context, username string, u *user.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUsername", ctx, username, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindByUsername indicates an expected call of FindByUsername
+func (mr *MockRepositoryMockRecorder) FindByUsername(ctx, username, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockRepository)(nil).FindByUsername), ctx, username, u)
+}
+
+// FindPost mocks base method
+func (m *MockRepository) FindPost(ctx context.Context, id int) (*post.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPost", ctx, id)
+	ret0, _ := ret[0].(*post.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPost indicates an expected call of FindPost
+func (mr *MockRepositoryMockRecorder) FindPost(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPost", reflect.TypeOf((*MockRepository)(nil).FindPost), ctx, id)
+}
+
+// FindPostBySlug mocks base method
+func (m *MockRepository) FindPostBySlug(ctx context.Context, slug string) (*post.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPostBySlug", ctx, slug)
+	ret0, _ := ret[0].(*post.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPostBySlug indicates an expected call of FindPostBySlug
+func (mr *MockRepositoryMockRecorder) FindPostBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPostBySlug", reflect.TypeOf((*MockRepository)(nil).FindPostBySlug), ctx, slug)
+}
@@ -6,25 +6,64 @@ import (
 	"testing"
 
 	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
 
 func Test_Service(t *testing.T) {
 	tests := []struct {
 		name           string
-		repositoryFunc func(ctx context.Context, id int) (*post.Post, error)
+		claims         *auth.Claims
+		repositoryFunc func(mock *MockRepository)
+		abilityFunc    func(mock *MockAbillity)
 		wantErr        bool
 	}{
 		{
 			name: "ok",
-			repositoryFunc: func(ctx context.Context, id int) (*post.Post, error) {
-				return &post.Post{}, nil
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindPost(gomock.Any(), gomock.Any()).Return(&post.Post{}, nil)
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanView(gomock.Nil(), gomock.Any()).Return(true)
+			},
+		},
+		{
+			name:   "owner",
+			claims: &auth.Claims{},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindPost(gomock.Any(), gomock.Any()).Return(&post.Post{}, nil)
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanView(gomock.Any(), gomock.Any()).Return(true)
 			},
 		},
 		{
 			name: "repository error",
-			repositoryFunc: func(ctx context.Context, id int) (*post.Post, error) {
-				return nil, errors.New("mock error")
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindPost(gomock.Any(), gomock.Any()).Return(nil, errors.New("mock error"))
+			},
+			abilityFunc: func(m *MockAbillity) {},
+			wantErr:     true,
+		},
+		{
+			name:   "find user error",
+			claims: &auth.Claims{},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindPost(gomock.Any(), gomock.Any()).Return(&post.Post{}, nil)
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			abilityFunc: func(m *MockAbillity) {},
+			wantErr:     true,
+		},
+		{
+			name: "ability error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindPost(gomock.Any(), gomock.Any()).Return(&post.Post{}, nil)
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanView(gomock.Any(), gomock.Any()).Return(false)
 			},
 			wantErr: true,
 		},
@@ -34,12 +73,23 @@ func Test_Service(t *testing.T) {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepository(ctrl)
+			ability := NewMockAbillity(ctrl)
+			tc.repositoryFunc(repo)
+			tc.abilityFunc(ability)
 
-			s := NewService(repositoryFunc(tc.repositoryFunc))
+			s := NewService(repo, ability)
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
-			_, err := s.FindPost(ctx, 1)
+			if tc.claims != nil {
+				ctx = auth.ToContext(ctx, tc.claims)
+			}
+
+			_, err := s.Find(ctx, 1)
 			if tc.wantErr {
 				assert.Error(t, err)
 				return
@@ -50,8 +100,65 @@ func Test_Service(t *testing.T) {
 	}
 }
 
-type repositoryFunc func(ctx context.Context, id int) (*post.Post, error)
+func TestServiceFindBySlug(t *testing.T) {
+	tests := []struct {
+		name           string
+		repositoryFunc func(mock *MockRepository)
+		abilityFunc    func(mock *MockAbillity)
+		wantErr        bool
+	}{
+		{
+			name: "ok",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindPostBySlug(gomock.Any(), "my-awesome-title").Return(&post.Post{Slug: "my-awesome-title"}, nil)
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanView(gomock.Nil(), gomock.Any()).Return(true)
+			},
+		},
+		{
+			name: "repository error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindPostBySlug(gomock.Any(), "my-awesome-title").Return(nil, post.ErrNotFound)
+			},
+			abilityFunc: func(m *MockAbillity) {},
+			wantErr:     true,
+		},
+		{
+			name: "ability error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindPostBySlug(gomock.Any(), "my-awesome-title").Return(&post.Post{}, nil)
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanView(gomock.Any(), gomock.Any()).Return(false)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
 
-func (r repositoryFunc) FindPost(ctx context.Context, id int) (*post.Post, error) {
-	return r(ctx, id)
+			repo := NewMockRepository(ctrl)
+			ability := NewMockAbillity(ctrl)
+			tc.repositoryFunc(repo)
+			tc.abilityFunc(ability)
+
+			s := NewService(repo, ability)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			_, err := s.FindBySlug(ctx, "my-awesome-title")
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
 }
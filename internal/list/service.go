@@ -2,14 +2,44 @@ package list
 
 import (
 	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
 	"github.com/pkg/errors"
 )
 
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+var (
+	// ErrInvalidCursor returns when given cursor
+	// can't be decoded.
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
 // Repository allows to work with the database.
 type Repository interface {
-	ListPost(ctx context.Context, pos *post.Posts) error
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	ListPost(ctx context.Context, f *post.Filter, pos *post.Posts) error
+}
+
+// Form holds list query parameters.
+type Form struct {
+	Limit  int
+	After  string
+	UserID int
+	Author string
+	From   time.Time
+	To     time.Time
+	Tag    string
+	Status string
 }
 
 // Service is a use case for posts showing.
@@ -26,12 +56,97 @@ func NewService(r Repository) *Service {
 	return &s
 }
 
-// List shows all posts.
-func (s *Service) List(ctx context.Context) (*post.Posts, error) {
+// List shows a page of posts, newest first.
+func (s *Service) List(ctx context.Context, f *Form) (*post.Posts, error) {
+	limit := f.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	userID := f.UserID
+	if f.Author != "" {
+		var author user.User
+		if err := s.Repository.FindByUsername(ctx, f.Author, &author); err != nil {
+			return nil, errors.Wrap(err, "repository find author")
+		}
+		userID = author.ID
+	}
+
+	// Ask for one extra post to know if there is a next page.
+	filter := post.Filter{
+		Limit:  limit + 1,
+		UserID: userID,
+		From:   f.From,
+		To:     f.To,
+		Tag:    f.Tag,
+		Status: post.StatusPublished,
+	}
+
+	// Owners can see all their posts including drafts.
+	if claims, ok := auth.FromContext(ctx); ok && userID != 0 {
+		var u user.User
+		if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+			return nil, errors.Wrap(err, "repository find user")
+		}
+		if u.ID == userID {
+			filter.Status = f.Status
+		}
+	}
+
+	if f.After != "" {
+		createdAt, id, err := decodeCursor(f.After)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode cursor")
+		}
+		filter.AfterCreatedAt = createdAt
+		filter.AfterID = id
+	}
+
 	var posts post.Posts
-	if err := s.Repository.ListPost(ctx, &posts); err != nil {
+	if err := s.Repository.ListPost(ctx, &filter, &posts); err != nil {
 		return nil, errors.Wrap(err, "list posts")
 	}
 
+	if len(posts.Posts) > limit {
+		posts.Posts = posts.Posts[:limit]
+		last := posts.Posts[limit-1]
+		posts.NextCursor = encodeCursor(last.CreatedAt, last.ID)
+	}
+
 	return &posts, nil
 }
+
+// encodeCursor builds an opaque cursor pointing
+// right after the post with given created_at and id.
+func encodeCursor(createdAt time.Time, id int) string {
+	raw := createdAt.UTC().Format(time.RFC3339Nano) + "," + strconv.Itoa(id)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor parses cursor built by encodeCursor.
+func decodeCursor(cursor string) (time.Time, int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, ErrInvalidCursor
+	}
+
+	parts := strings.SplitN(string(raw), ",", 2)
+	if len(parts) != 2 {
+		return time.Time{}, 0, ErrInvalidCursor
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, 0, ErrInvalidCursor
+	}
+
+	id, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return time.Time{}, 0, ErrInvalidCursor
+	}
+
+	return createdAt, id, nil
+}
@@ -4,26 +4,107 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestServiceList(t *testing.T) {
 	tests := []struct {
 		name           string
-		repositoryFunc func(ctx context.Context, pos *post.Posts) error
+		form           Form
+		claims         *auth.Claims
+		repositoryFunc func(ctx context.Context, f *post.Filter, pos *post.Posts) error
 		wantErr        bool
+		wantCursor     bool
 	}{
 		{
 			name: "ok",
-			repositoryFunc: func(ctx context.Context, pos *post.Posts) error {
+			repositoryFunc: func(ctx context.Context, f *post.Filter, pos *post.Posts) error {
+				return nil
+			},
+		},
+		{
+			name: "next page",
+			form: Form{
+				Limit: 1,
+			},
+			repositoryFunc: func(ctx context.Context, f *post.Filter, pos *post.Posts) error {
+				pos.Posts = []post.Post{
+					{ID: 2, CreatedAt: time.Now()},
+					{ID: 1, CreatedAt: time.Now()},
+				}
+				return nil
+			},
+			wantCursor: true,
+		},
+		{
+			name: "after cursor",
+			form: Form{
+				After: encodeCursor(time.Now(), 1),
+			},
+			repositoryFunc: func(ctx context.Context, f *post.Filter, pos *post.Posts) error {
+				if f.AfterID != 1 {
+					return errors.New("mock error")
+				}
+				return nil
+			},
+		},
+		{
+			name: "invalid cursor",
+			form: Form{
+				After: "wrong",
+			},
+			repositoryFunc: func(ctx context.Context, f *post.Filter, pos *post.Posts) error {
+				return nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "anonymous sees published only",
+			form: Form{
+				UserID: 1,
+				Status: post.StatusDraft,
+			},
+			repositoryFunc: func(ctx context.Context, f *post.Filter, pos *post.Posts) error {
+				if f.Status != post.StatusPublished {
+					return errors.New("mock error")
+				}
+				return nil
+			},
+		},
+		{
+			name: "owner sees drafts",
+			form: Form{
+				UserID: 1,
+				Status: post.StatusDraft,
+			},
+			claims: &auth.Claims{},
+			repositoryFunc: func(ctx context.Context, f *post.Filter, pos *post.Posts) error {
+				if f.Status != post.StatusDraft {
+					return errors.New("mock error")
+				}
+				return nil
+			},
+		},
+		{
+			name: "author posts",
+			form: Form{
+				Author: "username",
+			},
+			repositoryFunc: func(ctx context.Context, f *post.Filter, pos *post.Posts) error {
+				if f.UserID != 1 || f.Status != post.StatusPublished {
+					return errors.New("mock error")
+				}
 				return nil
 			},
 		},
 		{
 			name: "repository error",
-			repositoryFunc: func(ctx context.Context, pos *post.Posts) error {
+			repositoryFunc: func(ctx context.Context, f *post.Filter, pos *post.Posts) error {
 				return errors.New("mock error")
 			},
 			wantErr: true,
@@ -39,19 +120,29 @@ func TestServiceList(t *testing.T) {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
-			_, err := s.List(ctx)
+			if tc.claims != nil {
+				ctx = auth.ToContext(ctx, tc.claims)
+			}
+
+			posts, err := s.List(ctx, &tc.form)
 
 			if tc.wantErr {
 				assert.Error(t, err)
 				return
 			}
 			assert.Nil(t, err)
+			assert.Equal(t, tc.wantCursor, posts.NextCursor != "")
 		})
 	}
 }
 
-type repositoryFunc func(ctx context.Context, pos *post.Posts) error
+type repositoryFunc func(ctx context.Context, f *post.Filter, pos *post.Posts) error
+
+func (r repositoryFunc) FindByUsername(ctx context.Context, username string, u *user.User) error {
+	u.ID = 1
+	return nil
+}
 
-func (r repositoryFunc) ListPost(ctx context.Context, pos *post.Posts) error {
-	return r(ctx, pos)
+func (r repositoryFunc) ListPost(ctx context.Context, f *post.Filter, pos *post.Posts) error {
+	return r(ctx, f, pos)
 }
@@ -0,0 +1,39 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Log writes messages to the writer instead of sending them.
+// It suits development and tests, where the writer is a file
+// or a buffer to read the messages from.
+type Log struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewLog prepares log notifier.
+func NewLog(w io.Writer) *Log {
+	l := Log{
+		w: w,
+	}
+
+	return &l
+}
+
+// Notify implements Notifier interface.
+func (l *Log) Notify(ctx context.Context, m *Message) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := fmt.Fprintf(l.w, "To: %s\nSubject: %s\n\n%s\n\n", m.To, m.Subject, m.Body); err != nil {
+		return errors.Wrap(err, "write message")
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package notify
+
+import "context"
+
+// Message is an email message to the user.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier sends messages to users.
+type Notifier interface {
+	Notify(ctx context.Context, m *Message) error
+}
@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogNotify(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLog(&buf)
+
+	m := Message{
+		To:      "username@example.com",
+		Subject: "Verify your email",
+		Body:    "token",
+	}
+
+	err := l.Notify(context.Background(), &m)
+	assert.Nil(t, err)
+	assert.Equal(t, "To: username@example.com\nSubject: Verify your email\n\ntoken\n\n", buf.String())
+}
+
+func TestBuildMessage(t *testing.T) {
+	m := Message{
+		To:      "username@example.com",
+		Subject: "Verify your email",
+		Body:    "token",
+	}
+
+	msg := string(buildMessage("blog@example.com", &m))
+	assert.True(t, strings.HasPrefix(msg, "From: blog@example.com\r\nTo: username@example.com\r\nSubject: Verify your email\r\n"))
+	assert.True(t, strings.HasSuffix(msg, "\r\n\r\ntoken\r\n"))
+}
+
+func TestNewSMTP(t *testing.T) {
+	s, err := NewSMTP("localhost:25", "blog@example.com", "", "")
+	assert.Nil(t, err)
+	assert.Nil(t, s.Auth)
+
+	s, err = NewSMTP("localhost:25", "blog@example.com", "user", "secret")
+	assert.Nil(t, err)
+	assert.NotNil(t, s.Auth)
+
+	_, err = NewSMTP("localhost", "blog@example.com", "", "")
+	assert.Error(t, err)
+}
+
+func TestSMTPTimeout(t *testing.T) {
+	// The server accepts connections and never greets.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer lis.Close()
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	s, err := NewSMTP(lis.Addr().String(), "blog@example.com", "", "")
+	assert.Nil(t, err)
+	s.Timeout = 100 * time.Millisecond
+
+	start := time.Now()
+	err = s.Notify(context.Background(), &Message{To: "username@example.com"})
+	assert.Error(t, err)
+	assert.True(t, time.Since(start) < time.Second)
+}
@@ -0,0 +1,146 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultTimeout limits the whole conversation with the mail server.
+const DefaultTimeout = 10 * time.Second
+
+// SMTP sends messages through the mail server.
+type SMTP struct {
+	Addr    string
+	From    string
+	Auth    smtp.Auth
+	Timeout time.Duration
+}
+
+// NewSMTP prepares SMTP notifier. Plain authentication
+// is used when the username is given.
+func NewSMTP(addr, from, username, password string) (*SMTP, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "split host port")
+	}
+
+	s := SMTP{
+		Addr:    addr,
+		From:    from,
+		Timeout: DefaultTimeout,
+	}
+
+	if username != "" {
+		s.Auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &s, nil
+}
+
+// Notify implements Notifier interface. The message is sent
+// like smtp.SendMail does, but the connection is closed
+// when the timeout passes or the context is done.
+func (s *SMTP) Notify(ctx context.Context, m *Message) error {
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", s.Addr)
+	if err != nil {
+		return errors.Wrap(err, "dial")
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return errors.Wrap(err, "set deadline")
+	}
+
+	// Unblock the conversation when the context is canceled.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	host, _, err := net.SplitHostPort(s.Addr)
+	if err != nil {
+		return errors.Wrap(err, "split host port")
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return errors.Wrap(err, "new client")
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return errors.Wrap(err, "start tls")
+		}
+	}
+
+	if s.Auth != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("smtp server doesn't support AUTH")
+		}
+		if err := c.Auth(s.Auth); err != nil {
+			return errors.Wrap(err, "auth")
+		}
+	}
+
+	if err := c.Mail(s.From); err != nil {
+		return errors.Wrap(err, "mail")
+	}
+	if err := c.Rcpt(m.To); err != nil {
+		return errors.Wrap(err, "rcpt")
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return errors.Wrap(err, "data")
+	}
+	if _, err := w.Write(buildMessage(s.From, m)); err != nil {
+		return errors.Wrap(err, "write message")
+	}
+	if err := w.Close(); err != nil {
+		return errors.Wrap(err, "close message")
+	}
+
+	if err := c.Quit(); err != nil {
+		return errors.Wrap(err, "quit")
+	}
+
+	return nil
+}
+
+// buildMessage builds RFC 5322 message with plain text body.
+func buildMessage(from string, m *Message) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", m.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", m.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(m.Body)
+	b.WriteString("\r\n")
+
+	return b.Bytes()
+}
@@ -0,0 +1,350 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dipress/blog/internal/auth"
+	"github.com/dipress/blog/internal/reg"
+	"github.com/dipress/blog/internal/user"
+	authEng "github.com/dipress/blog/kit/auth"
+	oidcEng "github.com/dipress/blog/kit/oidc"
+	"github.com/pkg/errors"
+)
+
+// easyjson service.go
+//go:generate mockgen -source=service.go -package=oidc -destination=service.mock.go
+
+// StateTTL is how long the user has to sign in at the provider.
+const StateTTL = 10 * time.Minute
+
+const (
+	// maxUsername is the length of generated usernames
+	// without the suffix.
+	maxUsername = 40
+	// usernameAttempts is the number of suffixes tried
+	// when the generated username is taken.
+	usernameAttempts = 5
+)
+
+var (
+	// ErrUnknownProvider returns when the provider is not configured.
+	ErrUnknownProvider = errors.New("unknown provider")
+	// ErrInvalidLogin returns when the state is unknown or expired,
+	// or the provider rejects the code or issues an invalid token.
+	ErrInvalidLogin = errors.New("invalid login")
+	// ErrEmailRequired returns when the provider doesn't share
+	// the email, which new users must have.
+	ErrEmailRequired = errors.New("email is required")
+	// ErrEmailExists returns when a user with the email exists.
+	// The user has to sign in and link the identity, so nobody
+	// gets the account by an identity with the same email.
+	ErrEmailExists = errors.New("email already exists")
+	// ErrIdentityLinked returns when the identity is
+	// linked to another user.
+	ErrIdentityLinked = errors.New("identity is linked to another user")
+)
+
+// usernameRegexp matches characters which are not allowed in usernames.
+var usernameRegexp = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// Repository allows to work with the database.
+type Repository interface {
+	SaveLoginState(ctx context.Context, s *State) error
+	TakeLoginState(ctx context.Context, state string, now time.Time) (*State, error)
+	FindIdentity(ctx context.Context, provider, subject string) (*user.Identity, error)
+	CreateIdentity(ctx context.Context, i *user.Identity) error
+	FindByID(ctx context.Context, id int, u *user.User) error
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	UniqueUsername(ctx context.Context, username string) error
+	UniqueEmail(ctx context.Context, email string) error
+	CreateUser(ctx context.Context, f *user.NewUser, usr *user.User) (func() error, func() error, error)
+	VerifyUser(ctx context.Context, userID int) error
+}
+
+// Provider is an OpenID Connect provider.
+type Provider interface {
+	AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error)
+	Exchange(ctx context.Context, code, verifier string) (string, error)
+	Verify(ctx context.Context, rawIDToken, nonce string) (*oidcEng.Claims, error)
+}
+
+// SignIner signs authenticated users in.
+type SignIner interface {
+	SignIn(ctx context.Context, u *user.User, t *auth.Token) error
+}
+
+// State is a pending sign in at the provider. Username
+// is set when the identity is linked to the signed in user.
+type State struct {
+	State     string
+	Provider  string
+	Verifier  string
+	Nonce     string
+	Username  string
+	ExpiresAt time.Time
+}
+
+// Redirect holds the URL of the provider to sign in. State
+// must be kept by the browser, see CallbackForm.
+//easyjson:json
+type Redirect struct {
+	URL   string `json:"url"`
+	State string `json:"-"`
+}
+
+// CallbackForm holds the parameters the provider redirects
+// the user back with. BrowserState is the state kept by the
+// browser, so a sign in started in another browser can't be
+// completed in this one.
+type CallbackForm struct {
+	Code         string
+	State        string
+	Error        string
+	BrowserState string
+}
+
+// Service is a use case for signing in with OpenID Connect
+// providers by the authorization code flow with PKCE.
+type Service struct {
+	Repository
+	SignIner
+	Providers map[string]Provider
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, si SignIner, providers map[string]Provider) *Service {
+	s := Service{
+		Repository: r,
+		SignIner:   si,
+		Providers:  providers,
+	}
+
+	return &s
+}
+
+// Start starts signing in with the provider. When the user is
+// signed in already, the identity is linked to the user.
+func (s *Service) Start(ctx context.Context, provider string) (*Redirect, error) {
+	if err := authEng.RequireSession(ctx); err != nil {
+		return nil, errors.Wrap(err, "require session")
+	}
+
+	p, ok := s.Providers[provider]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	values := make([]string, 3)
+	for i := range values {
+		v, err := oidcEng.GenerateVerifier()
+		if err != nil {
+			return nil, errors.Wrap(err, "generate verifier")
+		}
+		values[i] = v
+	}
+
+	st := State{
+		State:     values[0],
+		Provider:  provider,
+		Verifier:  values[1],
+		Nonce:     values[2],
+		ExpiresAt: time.Now().Add(StateTTL),
+	}
+	if claims, ok := authEng.FromContext(ctx); ok {
+		st.Username = claims.Subject
+	}
+
+	u, err := p.AuthCodeURL(ctx, st.State, st.Nonce, st.Verifier)
+	if err != nil {
+		return nil, errors.Wrap(err, "auth code url")
+	}
+
+	if err := s.Repository.SaveLoginState(ctx, &st); err != nil {
+		return nil, errors.Wrap(err, "repository save login state")
+	}
+
+	r := Redirect{
+		URL:   u,
+		State: st.State,
+	}
+
+	return &r, nil
+}
+
+// Callback completes signing in with the provider. Known
+// identities sign their users in, unknown ones are linked to
+// the signed in user or create a new one.
+func (s *Service) Callback(ctx context.Context, provider string, f *CallbackForm, t *auth.Token) error {
+	p, ok := s.Providers[provider]
+	if !ok {
+		return ErrUnknownProvider
+	}
+
+	// The state is taken before anything else, so
+	// it is used once even when the sign in fails.
+	st, err := s.Repository.TakeLoginState(ctx, f.State, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "repository take login state")
+	}
+	if st.Provider != provider || f.Error != "" || f.Code == "" {
+		return ErrInvalidLogin
+	}
+	if subtle.ConstantTimeCompare([]byte(f.State), []byte(f.BrowserState)) != 1 {
+		return ErrInvalidLogin
+	}
+
+	rawIDToken, err := p.Exchange(ctx, f.Code, st.Verifier)
+	if err != nil {
+		if errors.Cause(err) == oidcEng.ErrInvalidGrant || errors.Cause(err) == oidcEng.ErrInvalidToken {
+			return ErrInvalidLogin
+		}
+		return errors.Wrap(err, "exchange code")
+	}
+
+	claims, err := p.Verify(ctx, rawIDToken, st.Nonce)
+	if err != nil {
+		if errors.Cause(err) == oidcEng.ErrInvalidToken {
+			return ErrInvalidLogin
+		}
+		return errors.Wrap(err, "verify id token")
+	}
+
+	var u user.User
+	i, err := s.Repository.FindIdentity(ctx, provider, claims.Subject)
+	switch {
+	case err == nil:
+		if err := s.Repository.FindByID(ctx, i.UserID, &u); err != nil {
+			return errors.Wrap(err, "repository find user")
+		}
+		if st.Username != "" && st.Username != u.Username {
+			return ErrIdentityLinked
+		}
+	case errors.Cause(err) != user.ErrIdentityNotFound:
+		return errors.Wrap(err, "repository find identity")
+	case st.Username != "":
+		if err := s.Repository.FindByUsername(ctx, st.Username, &u); err != nil {
+			return errors.Wrap(err, "repository find user")
+		}
+		if err := s.link(ctx, provider, claims, &u); err != nil {
+			return errors.Wrap(err, "link identity")
+		}
+	default:
+		if err := s.register(ctx, claims, &u); err != nil {
+			return errors.Wrap(err, "register")
+		}
+		if err := s.link(ctx, provider, claims, &u); err != nil {
+			return errors.Wrap(err, "link identity")
+		}
+	}
+
+	if err := s.SignIner.SignIn(ctx, &u, t); err != nil {
+		return errors.Wrap(err, "sign in")
+	}
+
+	return nil
+}
+
+// link links the identity to the user.
+func (s *Service) link(ctx context.Context, provider string, claims *oidcEng.Claims, u *user.User) error {
+	i := user.Identity{
+		UserID:   u.ID,
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}
+
+	if err := s.Repository.CreateIdentity(ctx, &i); err != nil {
+		if errors.Cause(err) == user.ErrIdentityExists {
+			return ErrIdentityLinked
+		}
+		return errors.Wrap(err, "repository create identity")
+	}
+
+	return nil
+}
+
+// register creates a new user of the identity. The user has no
+// password, so the user signs in with the provider only until
+// the password is reset.
+func (s *Service) register(ctx context.Context, claims *oidcEng.Claims, u *user.User) error {
+	if claims.Email == "" {
+		return ErrEmailRequired
+	}
+
+	if err := s.Repository.UniqueEmail(ctx, claims.Email); err != nil {
+		if errors.Cause(err) == reg.ErrEmailExists {
+			return ErrEmailExists
+		}
+		return errors.Wrap(err, "repository unique email")
+	}
+
+	username, err := s.username(ctx, claims)
+	if err != nil {
+		return errors.Wrap(err, "username")
+	}
+
+	nu := user.NewUser{
+		Username: username,
+		Email:    claims.Email,
+	}
+
+	commit, _, err := s.Repository.CreateUser(ctx, &nu, u)
+	if err != nil {
+		return errors.Wrap(err, "repository create user")
+	}
+
+	if err := commit(); err != nil {
+		return errors.Wrap(err, "commit")
+	}
+
+	// The provider has verified the email already.
+	if claims.EmailVerified {
+		if err := s.Repository.VerifyUser(ctx, u.ID); err != nil {
+			return errors.Wrap(err, "repository verify user")
+		}
+	}
+
+	return nil
+}
+
+// username generates a free username from the preferred username
+// or the email of the identity.
+func (s *Service) username(ctx context.Context, claims *oidcEng.Claims) (string, error) {
+	base := claims.PreferredUsername
+	if base == "" {
+		base = strings.SplitN(claims.Email, "@", 2)[0]
+	}
+	base = usernameRegexp.ReplaceAllString(strings.ToLower(base), "")
+	if len(base) > maxUsername {
+		base = base[:maxUsername]
+	}
+	if base == "" {
+		base = "user"
+	}
+
+	username := base
+	for i := 0; i < usernameAttempts; i++ {
+		err := s.Repository.UniqueUsername(ctx, username)
+		if err == nil {
+			return username, nil
+		}
+		if errors.Cause(err) != reg.ErrUsernameExists {
+			return "", errors.Wrap(err, "repository unique username")
+		}
+
+		n, err := rand.Int(rand.Reader, big.NewInt(10000))
+		if err != nil {
+			return "", errors.Wrap(err, "random suffix")
+		}
+		username = fmt.Sprintf("%s%04d", base, n)
+	}
+
+	return "", errors.New("no free username")
+}
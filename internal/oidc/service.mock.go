@@ -0,0 +1,287 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package oidc is a generated GoMock package.
+package oidc
+
+import (
+	context "context"
+	auth "github.com/dipress/blog/internal/auth"
+	user "github.com/dipress/blog/internal/user"
+	oidc "github.com/dipress/blog/kit/oidc"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+	time "time"
+)
+
+// MockRepository is a mock of Repository interface
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// SaveLoginState mocks base method
+func (m *MockRepository) SaveLoginState(ctx context.Context, s *State) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLoginState", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveLoginState indicates an expected call of SaveLoginState
+func (mr *MockRepositoryMockRecorder) SaveLoginState(ctx, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLoginState", reflect.TypeOf((*MockRepository)(nil).SaveLoginState), ctx, s)
+}
+
+// TakeLoginState mocks base method
+func (m *MockRepository) TakeLoginState(ctx context.Context, state string, now time.Time) (*State, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeLoginState", ctx, state, now)
+	ret0, _ := ret[0].(*State)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeLoginState indicates an expected call of TakeLoginState
+func (mr *MockRepositoryMockRecorder) TakeLoginState(ctx, state, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeLoginState", reflect.TypeOf((*MockRepository)(nil).TakeLoginState), ctx, state, now)
+}
+
+// FindIdentity mocks base method
+func (m *MockRepository) FindIdentity(ctx context.Context, provider, subject string) (*user.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindIdentity", ctx, provider, subject)
+	ret0, _ := ret[0].(*user.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindIdentity indicates an expected call of FindIdentity
+func (mr *MockRepositoryMockRecorder) FindIdentity(ctx, provider, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindIdentity", reflect.TypeOf((*MockRepository)(nil).FindIdentity), ctx, provider, subject)
+}
+
+// CreateIdentity mocks base method
+func (m *MockRepository) CreateIdentity(ctx context.Context, i *user.Identity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdentity", ctx, i)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIdentity indicates an expected call of CreateIdentity
+func (mr *MockRepositoryMockRecorder) CreateIdentity(ctx, i interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdentity", reflect.TypeOf((*MockRepository)(nil).CreateIdentity), ctx, i)
+}
+
+// FindByID mocks base method
+func (m *MockRepository) FindByID(ctx context.Context, id int, u *user.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindByID indicates an expected call of FindByID
+func (mr *MockRepositoryMockRecorder) FindByID(ctx, id, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRepository)(nil).FindByID), ctx, id, u)
+}
+
+// FindByUsername mocks base method
+func (m *MockRepository) FindByUsername(ctx context.Context, username string, u *user.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUsername", ctx, username, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindByUsername indicates an expected call of FindByUsername
+func (mr *MockRepositoryMockRecorder) FindByUsername(ctx, username, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockRepository)(nil).FindByUsername), ctx, username, u)
+}
+
+// UniqueUsername mocks base method
+func (m *MockRepository) UniqueUsername(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UniqueUsername", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UniqueUsername indicates an expected call of UniqueUsername
+func (mr *MockRepositoryMockRecorder) UniqueUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UniqueUsername", reflect.TypeOf((*MockRepository)(nil).UniqueUsername), ctx, username)
+}
+
+// UniqueEmail mocks base method
+func (m *MockRepository) UniqueEmail(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UniqueEmail", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UniqueEmail indicates an expected call of UniqueEmail
+func (mr *MockRepositoryMockRecorder) UniqueEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UniqueEmail", reflect.TypeOf((*MockRepository)(nil).UniqueEmail), ctx, email)
+}
+
+// CreateUser mocks base method
+func (m *MockRepository) CreateUser(ctx context.Context, f *user.NewUser, usr *user.User) (func() error, func() error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, f, usr)
+	ret0, _ := ret[0].(func() error)
+	ret1, _ := ret[1].(func() error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateUser indicates an expected call of CreateUser
+func (mr *MockRepositoryMockRecorder) CreateUser(ctx, f, usr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockRepository)(nil).CreateUser), ctx, f, usr)
+}
+
+// VerifyUser mocks base method
+func (m *MockRepository) VerifyUser(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyUser indicates an expected call of VerifyUser
+func (mr *MockRepositoryMockRecorder) VerifyUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUser", reflect.TypeOf((*MockRepository)(nil).VerifyUser), ctx, userID)
+}
+
+// MockProvider is a mock of Provider interface
+type MockProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockProviderMockRecorder
+}
+
+// MockProviderMockRecorder is the mock recorder for MockProvider
+type MockProviderMockRecorder struct {
+	mock *MockProvider
+}
+
+// NewMockProvider creates a new mock instance
+func NewMockProvider(ctrl *gomock.Controller) *MockProvider {
+	mock := &MockProvider{ctrl: ctrl}
+	mock.recorder = &MockProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockProvider) EXPECT() *MockProviderMockRecorder {
+	return m.recorder
+}
+
+// AuthCodeURL mocks base method
+func (m *MockProvider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthCodeURL", ctx, state, nonce, verifier)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthCodeURL indicates an expected call of AuthCodeURL
+func (mr *MockProviderMockRecorder) AuthCodeURL(ctx, state, nonce, verifier interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthCodeURL", reflect.TypeOf((*MockProvider)(nil).AuthCodeURL), ctx, state, nonce, verifier)
+}
+
+// Exchange mocks base method
+func (m *MockProvider) Exchange(ctx context.Context, code, verifier string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", ctx, code, verifier)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exchange indicates an expected call of Exchange
+func (mr *MockProviderMockRecorder) Exchange(ctx, code, verifier interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockProvider)(nil).Exchange), ctx, code, verifier)
+}
+
+// Verify mocks base method
+func (m *MockProvider) Verify(ctx context.Context, rawIDToken, nonce string) (*oidc.Claims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, rawIDToken, nonce)
+	ret0, _ := ret[0].(*oidc.Claims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify
+func (mr *MockProviderMockRecorder) Verify(ctx, rawIDToken, nonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockProvider)(nil).Verify), ctx, rawIDToken, nonce)
+}
+
+// MockSignIner is a mock of SignIner interface
+type MockSignIner struct {
+	ctrl     *gomock.Controller
+	recorder *MockSignInerMockRecorder
+}
+
+// MockSignInerMockRecorder is the mock recorder for MockSignIner
+type MockSignInerMockRecorder struct {
+	mock *MockSignIner
+}
+
+// NewMockSignIner creates a new mock instance
+func NewMockSignIner(ctrl *gomock.Controller) *MockSignIner {
+	mock := &MockSignIner{ctrl: ctrl}
+	mock.recorder = &MockSignInerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSignIner) EXPECT() *MockSignInerMockRecorder {
+	return m.recorder
+}
+
+// SignIn mocks base method
+func (m *MockSignIner) SignIn(ctx context.Context, u *user.User, t *auth.Token) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignIn", ctx, u, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SignIn indicates an expected call of SignIn
+func (mr *MockSignInerMockRecorder) SignIn(ctx, u, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockSignIner)(nil).SignIn), ctx, u, t)
+}
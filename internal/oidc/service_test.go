@@ -0,0 +1,269 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dipress/blog/internal/auth"
+	"github.com/dipress/blog/internal/reg"
+	"github.com/dipress/blog/internal/user"
+	authEng "github.com/dipress/blog/kit/auth"
+	oidcEng "github.com/dipress/blog/kit/oidc"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceStart(t *testing.T) {
+	tests := []struct {
+		name         string
+		provider     string
+		claims       *authEng.Claims
+		providerFunc func(mock *MockProvider)
+		wantUsername string
+		wantErr      error
+	}{
+		{
+			name:     "ok",
+			provider: "example",
+			providerFunc: func(m *MockProvider) {
+				m.EXPECT().AuthCodeURL(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("https://example.com/authorize", nil)
+			},
+		},
+		{
+			name:     "link",
+			provider: "example",
+			claims:   &authEng.Claims{},
+			providerFunc: func(m *MockProvider) {
+				m.EXPECT().AuthCodeURL(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("https://example.com/authorize", nil)
+			},
+			wantUsername: "username",
+		},
+		{
+			name:         "unknown provider",
+			provider:     "unknown",
+			providerFunc: func(m *MockProvider) {},
+			wantErr:      ErrUnknownProvider,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepository(ctrl)
+			provider := NewMockProvider(ctrl)
+			tc.providerFunc(provider)
+
+			var saved State
+			repo.EXPECT().SaveLoginState(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, s *State) {
+				saved = *s
+			}).Return(nil).AnyTimes()
+
+			s := NewService(repo, NewMockSignIner(ctrl), map[string]Provider{"example": provider})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			if tc.claims != nil {
+				tc.claims.Subject = tc.wantUsername
+				ctx = authEng.ToContext(ctx, tc.claims)
+			}
+
+			r, err := s.Start(ctx, tc.provider)
+
+			if tc.wantErr != nil {
+				assert.Equal(t, tc.wantErr, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, "https://example.com/authorize", r.URL)
+			assert.Equal(t, tc.wantUsername, saved.Username)
+		})
+	}
+}
+
+func TestServiceCallback(t *testing.T) {
+	state := State{
+		State:     "state",
+		Provider:  "example",
+		Verifier:  "verifier",
+		Nonce:     "nonce",
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+	linkState := state
+	linkState.Username = "username"
+
+	claims := oidcEng.Claims{
+		Subject:       "1234",
+		Email:         "username@example.com",
+		EmailVerified: true,
+	}
+
+	signedIn := func(m *MockSignIner) {
+		m.EXPECT().SignIn(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	}
+	exchanged := func(m *MockProvider) {
+		m.EXPECT().Exchange(gomock.Any(), "code", "verifier").Return("id token", nil)
+		m.EXPECT().Verify(gomock.Any(), "id token", "nonce").Return(&claims, nil)
+	}
+
+	tests := []struct {
+		name           string
+		repositoryFunc func(mock *MockRepository)
+		providerFunc   func(mock *MockProvider)
+		signInerFunc   func(mock *MockSignIner)
+		otherBrowser   bool
+		wantErr        error
+	}{
+		{
+			name: "known identity",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().TakeLoginState(gomock.Any(), "state", gomock.Any()).Return(&state, nil)
+				m.EXPECT().FindIdentity(gomock.Any(), "example", "1234").Return(&user.Identity{UserID: 1}, nil)
+				m.EXPECT().FindByID(gomock.Any(), 1, gomock.Any()).Return(nil)
+			},
+			providerFunc: exchanged,
+			signInerFunc: signedIn,
+		},
+		{
+			name: "new user",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().TakeLoginState(gomock.Any(), "state", gomock.Any()).Return(&state, nil)
+				m.EXPECT().FindIdentity(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, user.ErrIdentityNotFound)
+				m.EXPECT().UniqueEmail(gomock.Any(), "username@example.com").Return(nil)
+				gomock.InOrder(
+					m.EXPECT().UniqueUsername(gomock.Any(), "username").Return(reg.ErrUsernameExists),
+					m.EXPECT().UniqueUsername(gomock.Any(), gomock.Any()).Return(nil),
+				)
+				commit := func() error { return nil }
+				m.EXPECT().CreateUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(commit, commit, nil)
+				m.EXPECT().VerifyUser(gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().CreateIdentity(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			providerFunc: exchanged,
+			signInerFunc: signedIn,
+		},
+		{
+			name: "email exists",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().TakeLoginState(gomock.Any(), "state", gomock.Any()).Return(&state, nil)
+				m.EXPECT().FindIdentity(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, user.ErrIdentityNotFound)
+				m.EXPECT().UniqueEmail(gomock.Any(), gomock.Any()).Return(reg.ErrEmailExists)
+			},
+			providerFunc: exchanged,
+			signInerFunc: func(m *MockSignIner) {},
+			wantErr:      ErrEmailExists,
+		},
+		{
+			name: "link",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().TakeLoginState(gomock.Any(), "state", gomock.Any()).Return(&linkState, nil)
+				m.EXPECT().FindIdentity(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, user.ErrIdentityNotFound)
+				m.EXPECT().FindByUsername(gomock.Any(), "username", gomock.Any()).Return(nil)
+				m.EXPECT().CreateIdentity(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			providerFunc: exchanged,
+			signInerFunc: signedIn,
+		},
+		{
+			name: "linked to another user",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().TakeLoginState(gomock.Any(), "state", gomock.Any()).Return(&linkState, nil)
+				m.EXPECT().FindIdentity(gomock.Any(), gomock.Any(), gomock.Any()).Return(&user.Identity{UserID: 2}, nil)
+				m.EXPECT().FindByID(gomock.Any(), 2, gomock.Any()).DoAndReturn(func(ctx context.Context, id int, u *user.User) error {
+					u.Username = "other"
+					return nil
+				})
+			},
+			providerFunc: exchanged,
+			signInerFunc: func(m *MockSignIner) {},
+			wantErr:      ErrIdentityLinked,
+		},
+		{
+			name: "unknown state",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().TakeLoginState(gomock.Any(), "state", gomock.Any()).Return(nil, ErrInvalidLogin)
+			},
+			providerFunc: func(m *MockProvider) {},
+			signInerFunc: func(m *MockSignIner) {},
+			wantErr:      ErrInvalidLogin,
+		},
+		{
+			name: "invalid grant",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().TakeLoginState(gomock.Any(), "state", gomock.Any()).Return(&state, nil)
+			},
+			providerFunc: func(m *MockProvider) {
+				m.EXPECT().Exchange(gomock.Any(), gomock.Any(), gomock.Any()).Return("", oidcEng.ErrInvalidGrant)
+			},
+			signInerFunc: func(m *MockSignIner) {},
+			wantErr:      ErrInvalidLogin,
+		},
+		{
+			name: "invalid id token",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().TakeLoginState(gomock.Any(), "state", gomock.Any()).Return(&state, nil)
+			},
+			providerFunc: func(m *MockProvider) {
+				m.EXPECT().Exchange(gomock.Any(), gomock.Any(), gomock.Any()).Return("id token", nil)
+				m.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, oidcEng.ErrInvalidToken)
+			},
+			signInerFunc: func(m *MockSignIner) {},
+			wantErr:      ErrInvalidLogin,
+		},
+		{
+			name: "other browser",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().TakeLoginState(gomock.Any(), "state", gomock.Any()).Return(&state, nil)
+			},
+			providerFunc: func(m *MockProvider) {},
+			signInerFunc: func(m *MockSignIner) {},
+			otherBrowser: true,
+			wantErr:      ErrInvalidLogin,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepository(ctrl)
+			provider := NewMockProvider(ctrl)
+			signIner := NewMockSignIner(ctrl)
+			tc.repositoryFunc(repo)
+			tc.providerFunc(provider)
+			tc.signInerFunc(signIner)
+
+			s := NewService(repo, signIner, map[string]Provider{"example": provider})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			f := CallbackForm{
+				Code:         "code",
+				State:        "state",
+				BrowserState: "state",
+			}
+			if tc.otherBrowser {
+				f.BrowserState = ""
+			}
+
+			var tkn auth.Token
+			err := s.Callback(ctx, "example", &f, &tkn)
+
+			if tc.wantErr != nil {
+				assert.Equal(t, tc.wantErr, errors.Cause(err))
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
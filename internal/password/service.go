@@ -0,0 +1,171 @@
+package password
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dipress/blog/internal/auth"
+	"github.com/dipress/blog/internal/notify"
+	"github.com/dipress/blog/internal/token/onetime"
+	"github.com/dipress/blog/internal/user"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// easyjson service.go
+//go:generate mockgen -source=service.go -package=password -destination=service.mock.go
+
+// purpose is the audience of password reset tokens.
+const purpose = "reset-password"
+
+var (
+	// ErrInvalidToken returns when reset token is
+	// malformed, expired or already used.
+	ErrInvalidToken = errors.New("invalid reset token")
+)
+
+// Validater validates reset form fields.
+type Validater interface {
+	Validate(context.Context, *ResetForm) error
+}
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByEmail(ctx context.Context, email string, u *user.User) error
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	UpdatePassword(ctx context.Context, userID int, hash string) error
+	VerifyUser(ctx context.Context, userID int) error
+	RevokeUserRefreshTokens(ctx context.Context, userID int) error
+}
+
+// Tokens issues and redeems single-use tokens.
+type Tokens interface {
+	Issue(ctx context.Context, subject, purpose string, exp time.Duration) (string, error)
+	Redeem(ctx context.Context, tknStr, purpose string) (string, error)
+}
+
+// SubjectRevoker revokes all access tokens of the user.
+type SubjectRevoker interface {
+	RevokeSubject(ctx context.Context, subject string, after time.Time) error
+}
+
+// Notifier sends messages to users.
+type Notifier interface {
+	Notify(ctx context.Context, m *notify.Message) error
+}
+
+// ForgotForm is a forgotten password form.
+//easyjson:json
+type ForgotForm struct {
+	Email string `json:"email"`
+}
+
+// ResetForm is a password reset form.
+//easyjson:json
+type ResetForm struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// Service is a use case for password resetting.
+type Service struct {
+	Repository
+	Validater
+	Tokens
+	SubjectRevoker
+	Notifier
+	ExpireAfter time.Duration
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, v Validater, t Tokens, sr SubjectRevoker, n Notifier, exp time.Duration) *Service {
+	s := Service{
+		Repository:     r,
+		Validater:      v,
+		Tokens:         t,
+		SubjectRevoker: sr,
+		Notifier:       n,
+		ExpireAfter:    exp,
+	}
+
+	return &s
+}
+
+// Forgot sends the reset token to the email. Unknown
+// emails are ignored, so clients can't find out who
+// has an account.
+func (s *Service) Forgot(ctx context.Context, f *ForgotForm) error {
+	var u user.User
+	if err := s.Repository.FindByEmail(ctx, f.Email, &u); err != nil {
+		if errors.Cause(err) == auth.ErrNotFound {
+			return nil
+		}
+		return errors.Wrap(err, "repository find user")
+	}
+
+	tknStr, err := s.Tokens.Issue(ctx, u.Username, purpose, s.ExpireAfter)
+	if err != nil {
+		return errors.Wrap(err, "issue token")
+	}
+
+	m := notify.Message{
+		To:      u.Email,
+		Subject: "Reset your password",
+		Body:    fmt.Sprintf("Hi %s,\n\nuse the token below to reset your password:\n\n%s\n\nIt expires in %s. Ignore this email if you didn't ask for it.", u.Username, tknStr, s.ExpireAfter),
+	}
+
+	if err := s.Notifier.Notify(ctx, &m); err != nil {
+		return errors.Wrap(err, "notify")
+	}
+
+	return nil
+}
+
+// Reset changes the password of the token owner. All sessions
+// of the user are revoked. The email counts as verified, since
+// the token was received by it.
+func (s *Service) Reset(ctx context.Context, f *ResetForm) error {
+	if err := s.Validater.Validate(ctx, f); err != nil {
+		return errors.Wrap(err, "validater validate")
+	}
+
+	username, err := s.Tokens.Redeem(ctx, f.Token, purpose)
+	if err != nil {
+		if errors.Cause(err) == onetime.ErrInvalidToken {
+			return ErrInvalidToken
+		}
+		return errors.Wrap(err, "redeem token")
+	}
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, username, &u); err != nil {
+		if errors.Cause(err) == user.ErrNotFound {
+			return ErrInvalidToken
+		}
+		return errors.Wrap(err, "repository find user")
+	}
+
+	pw, err := bcrypt.GenerateFromPassword([]byte(f.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.Wrap(err, "generating password hash")
+	}
+
+	if err := s.Repository.UpdatePassword(ctx, u.ID, string(pw)); err != nil {
+		return errors.Wrap(err, "repository update password")
+	}
+
+	if err := s.Repository.VerifyUser(ctx, u.ID); err != nil {
+		return errors.Wrap(err, "repository verify user")
+	}
+
+	if err := s.SubjectRevoker.RevokeSubject(ctx, u.Username, time.Now()); err != nil {
+		return errors.Wrap(err, "revoke subject")
+	}
+
+	if err := s.Repository.RevokeUserRefreshTokens(ctx, u.ID); err != nil {
+		return errors.Wrap(err, "repository revoke refresh tokens")
+	}
+
+	return nil
+}
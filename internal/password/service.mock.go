@@ -0,0 +1,271 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package password is a generated GoMock package.
+package password
+
+import (
+	context "context"
+	notify "github.com/dipress/blog/internal/notify"
+	user "github.com/dipress/blog/internal/user"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+	time "time"
+)
+
+// MockValidater is a mock of Validater interface
+type MockValidater struct {
+	ctrl     *gomock.Controller
+	recorder *MockValidaterMockRecorder
+}
+
+// MockValidaterMockRecorder is the mock recorder for MockValidater
+type MockValidaterMockRecorder struct {
+	mock *MockValidater
+}
+
+// NewMockValidater creates a new mock instance
+func NewMockValidater(ctrl *gomock.Controller) *MockValidater {
+	mock := &MockValidater{ctrl: ctrl}
+	mock.recorder = &MockValidaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockValidater) EXPECT() *MockValidaterMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method
+func (m *MockValidater) Validate(arg0 context.Context, arg1 *ResetForm) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate
+func (mr *MockValidaterMockRecorder) Validate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockValidater)(nil).Validate), arg0, arg1)
+}
+
+// MockRepository is a mock of Repository interface
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByEmail mocks base method
+func (m *MockRepository) FindByEmail(ctx context.Context, email string, u *user.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEmail", ctx, email, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindByEmail indicates an expected call of FindByEmail
+func (mr *MockRepositoryMockRecorder) FindByEmail(ctx, email, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockRepository)(nil).FindByEmail), ctx, email, u)
+}
+
+// FindByUsername mocks base method
+func (m *MockRepository) FindByUsername(ctx context.Context, username string, u *user.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUsername", ctx, username, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindByUsername indicates an expected call of FindByUsername
+func (mr *MockRepositoryMockRecorder) FindByUsername(ctx, username, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockRepository)(nil).FindByUsername), ctx, username, u)
+}
+
+// UpdatePassword mocks base method
+func (m *MockRepository) UpdatePassword(ctx context.Context, userID int, hash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, userID, hash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword
+func (mr *MockRepositoryMockRecorder) UpdatePassword(ctx, userID, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockRepository)(nil).UpdatePassword), ctx, userID, hash)
+}
+
+// VerifyUser mocks base method
+func (m *MockRepository) VerifyUser(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyUser indicates an expected call of VerifyUser
+func (mr *MockRepositoryMockRecorder) VerifyUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUser", reflect.TypeOf((*MockRepository)(nil).VerifyUser), ctx, userID)
+}
+
+// RevokeUserRefreshTokens mocks base method
+func (m *MockRepository) RevokeUserRefreshTokens(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserRefreshTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserRefreshTokens indicates an expected call of RevokeUserRefreshTokens
+func (mr *MockRepositoryMockRecorder) RevokeUserRefreshTokens(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserRefreshTokens", reflect.TypeOf((*MockRepository)(nil).RevokeUserRefreshTokens), ctx, userID)
+}
+
+// MockTokens is a mock of Tokens interface
+type MockTokens struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokensMockRecorder
+}
+
+// MockTokensMockRecorder is the mock recorder for MockTokens
+type MockTokensMockRecorder struct {
+	mock *MockTokens
+}
+
+// NewMockTokens creates a new mock instance
+func NewMockTokens(ctrl *gomock.Controller) *MockTokens {
+	mock := &MockTokens{ctrl: ctrl}
+	mock.recorder = &MockTokensMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTokens) EXPECT() *MockTokensMockRecorder {
+	return m.recorder
+}
+
+// Issue mocks base method
+func (m *MockTokens) Issue(ctx context.Context, subject, purpose string, exp time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", ctx, subject, purpose, exp)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Issue indicates an expected call of Issue
+func (mr *MockTokensMockRecorder) Issue(ctx, subject, purpose, exp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockTokens)(nil).Issue), ctx, subject, purpose, exp)
+}
+
+// Redeem mocks base method
+func (m *MockTokens) Redeem(ctx context.Context, tknStr, purpose string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeem", ctx, tknStr, purpose)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeem indicates an expected call of Redeem
+func (mr *MockTokensMockRecorder) Redeem(ctx, tknStr, purpose interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockTokens)(nil).Redeem), ctx, tknStr, purpose)
+}
+
+// MockSubjectRevoker is a mock of SubjectRevoker interface
+type MockSubjectRevoker struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubjectRevokerMockRecorder
+}
+
+// MockSubjectRevokerMockRecorder is the mock recorder for MockSubjectRevoker
+type MockSubjectRevokerMockRecorder struct {
+	mock *MockSubjectRevoker
+}
+
+// NewMockSubjectRevoker creates a new mock instance
+func NewMockSubjectRevoker(ctrl *gomock.Controller) *MockSubjectRevoker {
+	mock := &MockSubjectRevoker{ctrl: ctrl}
+	mock.recorder = &MockSubjectRevokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSubjectRevoker) EXPECT() *MockSubjectRevokerMockRecorder {
+	return m.recorder
+}
+
+// RevokeSubject mocks base method
+func (m *MockSubjectRevoker) RevokeSubject(ctx context.Context, subject string, after time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSubject", ctx, subject, after)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSubject indicates an expected call of RevokeSubject
+func (mr *MockSubjectRevokerMockRecorder) RevokeSubject(ctx, subject, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSubject", reflect.TypeOf((*MockSubjectRevoker)(nil).RevokeSubject), ctx, subject, after)
+}
+
+// MockNotifier is a mock of Notifier interface
+type MockNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifierMockRecorder
+}
+
+// MockNotifierMockRecorder is the mock recorder for MockNotifier
+type MockNotifierMockRecorder struct {
+	mock *MockNotifier
+}
+
+// NewMockNotifier creates a new mock instance
+func NewMockNotifier(ctrl *gomock.Controller) *MockNotifier {
+	mock := &MockNotifier{ctrl: ctrl}
+	mock.recorder = &MockNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockNotifier) EXPECT() *MockNotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method
+func (m_2 *MockNotifier) Notify(ctx context.Context, m *notify.Message) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Notify", ctx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify
+func (mr *MockNotifierMockRecorder) Notify(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotifier)(nil).Notify), ctx, m)
+}
@@ -0,0 +1,221 @@
+package password
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dipress/blog/internal/auth"
+	"github.com/dipress/blog/internal/token/onetime"
+	"github.com/dipress/blog/internal/user"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceForgot(t *testing.T) {
+	tests := []struct {
+		name           string
+		repositoryFunc func(mock *MockRepository)
+		tokensFunc     func(mock *MockTokens)
+		notifierFunc   func(mock *MockNotifier)
+		wantErr        bool
+	}{
+		{
+			name: "ok",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByEmail(gomock.Any(), "username@example.com", gomock.Any()).Return(nil)
+			},
+			tokensFunc: func(m *MockTokens) {
+				m.EXPECT().Issue(gomock.Any(), gomock.Any(), purpose, time.Hour).Return("token", nil)
+			},
+			notifierFunc: func(m *MockNotifier) {
+				m.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "unknown email",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), gomock.Any()).Return(auth.ErrNotFound)
+			},
+			tokensFunc:   func(m *MockTokens) {},
+			notifierFunc: func(m *MockNotifier) {},
+		},
+		{
+			name: "find error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			tokensFunc:   func(m *MockTokens) {},
+			notifierFunc: func(m *MockNotifier) {},
+			wantErr:      true,
+		},
+		{
+			name: "notify error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			tokensFunc: func(m *MockTokens) {
+				m.EXPECT().Issue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("token", nil)
+			},
+			notifierFunc: func(m *MockNotifier) {
+				m.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepository(ctrl)
+			tokens := NewMockTokens(ctrl)
+			notifier := NewMockNotifier(ctrl)
+			tc.repositoryFunc(repo)
+			tc.tokensFunc(tokens)
+			tc.notifierFunc(notifier)
+
+			s := NewService(repo, NewMockValidater(ctrl), tokens, NewMockSubjectRevoker(ctrl), notifier, time.Hour)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			err := s.Forgot(ctx, &ForgotForm{Email: "username@example.com"})
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestServiceReset(t *testing.T) {
+	findUser := func(_ context.Context, _ string, u *user.User) error {
+		u.ID = 1
+		u.Username = "username"
+		return nil
+	}
+
+	tests := []struct {
+		name           string
+		validateFunc   func(mock *MockValidater)
+		tokensFunc     func(mock *MockTokens)
+		repositoryFunc func(mock *MockRepository)
+		revokerFunc    func(mock *MockSubjectRevoker)
+		wantErr        bool
+	}{
+		{
+			name: "ok",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			tokensFunc: func(m *MockTokens) {
+				m.EXPECT().Redeem(gomock.Any(), "token", purpose).Return("username", nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), "username", gomock.Any()).DoAndReturn(findUser)
+				m.EXPECT().UpdatePassword(gomock.Any(), 1, gomock.Any()).Return(nil)
+				m.EXPECT().VerifyUser(gomock.Any(), 1).Return(nil)
+				m.EXPECT().RevokeUserRefreshTokens(gomock.Any(), 1).Return(nil)
+			},
+			revokerFunc: func(m *MockSubjectRevoker) {
+				m.EXPECT().RevokeSubject(gomock.Any(), "username", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "validation",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			tokensFunc:     func(m *MockTokens) {},
+			repositoryFunc: func(m *MockRepository) {},
+			revokerFunc:    func(m *MockSubjectRevoker) {},
+			wantErr:        true,
+		},
+		{
+			name: "invalid token",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			tokensFunc: func(m *MockTokens) {
+				m.EXPECT().Redeem(gomock.Any(), gomock.Any(), gomock.Any()).Return("", onetime.ErrInvalidToken)
+			},
+			repositoryFunc: func(m *MockRepository) {},
+			revokerFunc:    func(m *MockSubjectRevoker) {},
+			wantErr:        true,
+		},
+		{
+			name: "update password",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			tokensFunc: func(m *MockTokens) {
+				m.EXPECT().Redeem(gomock.Any(), gomock.Any(), gomock.Any()).Return("username", nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(findUser)
+				m.EXPECT().UpdatePassword(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			revokerFunc: func(m *MockSubjectRevoker) {},
+			wantErr:     true,
+		},
+		{
+			name: "revoke subject",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			tokensFunc: func(m *MockTokens) {
+				m.EXPECT().Redeem(gomock.Any(), gomock.Any(), gomock.Any()).Return("username", nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(findUser)
+				m.EXPECT().UpdatePassword(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().VerifyUser(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			revokerFunc: func(m *MockSubjectRevoker) {
+				m.EXPECT().RevokeSubject(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			validator := NewMockValidater(ctrl)
+			tokens := NewMockTokens(ctrl)
+			repo := NewMockRepository(ctrl)
+			revoker := NewMockSubjectRevoker(ctrl)
+			tc.validateFunc(validator)
+			tc.tokensFunc(tokens)
+			tc.repositoryFunc(repo)
+			tc.revokerFunc(revoker)
+
+			s := NewService(repo, validator, tokens, revoker, NewMockNotifier(ctrl), time.Hour)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			form := ResetForm{
+				Token:    "token",
+				Password: "password123",
+			}
+
+			err := s.Reset(ctx, &form)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package post
+
+import (
+	"html"
+	"strings"
+
+	"github.com/dipress/blog/kit/markdown"
+)
+
+// RenderBody renders the body of the given format to HTML which is
+// safe to show to readers. Plain bodies are escaped and keep their
+// line breaks, markdown is rendered and sanitized.
+func RenderBody(format, body string) string {
+	if format == FormatMarkdown {
+		return markdown.Render(body)
+	}
+
+	return "<p>" + strings.Replace(html.EscapeString(body), "\n", "<br>\n", -1) + "</p>"
+}
@@ -0,0 +1,42 @@
+package post
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderBody(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		body   string
+		expect string
+	}{
+		{
+			name:   "plain",
+			format: FormatPlain,
+			body:   "first <b>line</b>\nsecond & \"last\"",
+			expect: "<p>first &lt;b&gt;line&lt;/b&gt;<br>\nsecond &amp; &#34;last&#34;</p>",
+		},
+		{
+			name:   "markdown",
+			format: FormatMarkdown,
+			body:   "# Title\n\n*text*<script>alert(1)</script>",
+			expect: "<h1>Title</h1>\n\n<p><em>text</em></p>\n",
+		},
+		{
+			name:   "unknown",
+			body:   "*text*",
+			expect: "<p>*text*</p>",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expect, RenderBody(tc.format, tc.body))
+		})
+	}
+}
@@ -12,24 +12,93 @@ var (
 	ErrNotFound = errors.New("post not found")
 )
 
-// Post contains all post field.
+// Post statuses.
+const (
+	StatusDraft     = "draft"
+	StatusScheduled = "scheduled"
+	StatusPublished = "published"
+	StatusArchived  = "archived"
+)
+
+// Post formats.
+const (
+	FormatPlain    = "plain"
+	FormatMarkdown = "markdown"
+)
+
+// Post contains all post field. Description, CanonicalURL and
+// ImageURL are shown to search engines and link previews.
 type Post struct {
-	ID        int       `json:"id"`
-	UserID    int       `json:"user_id"`
-	Title     string    `json:"title"`
-	Body      string    `json:"body"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID           int        `json:"id"`
+	UserID       int        `json:"user_id"`
+	Title        string     `json:"title"`
+	Slug         string     `json:"slug"`
+	Body         string     `json:"body"`
+	Format       string     `json:"format"`
+	BodyHTML     string     `json:"body_html"`
+	Description  string     `json:"description"`
+	CanonicalURL string     `json:"canonical_url"`
+	ImageURL     string     `json:"image_url"`
+	Tags         []string   `json:"tags"`
+	Status       string     `json:"status"`
+	PublishedAt  *time.Time `json:"published_at"`
+	DeletedAt    *time.Time `json:"deleted_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
 }
 
 // NewPost contains the information which needs to create a new Post.
 type NewPost struct {
-	UserID int
-	Title  string
-	Body   string
+	UserID       int
+	Title        string
+	Slug         string
+	Body         string
+	Format       string
+	BodyHTML     string
+	Description  string
+	CanonicalURL string
+	ImageURL     string
+	Tags         []string
+	Status       string
+	PublishedAt  *time.Time
 }
 
 // Posts contains slice of posts.
 type Posts struct {
-	Posts []Post `json:"posts"`
+	Posts      []Post `json:"posts"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// Filter contains the conditions which needs to select posts.
+type Filter struct {
+	Limit          int
+	UserID         int
+	From           time.Time
+	To             time.Time
+	AfterCreatedAt time.Time
+	AfterID        int
+	Tag            string
+	Status         string
+	Deleted        bool
+}
+
+// Hit is a post found by the search with its rank
+// and a snippet of the body with highlighted terms.
+// The snippet is escaped HTML with terms in mark elements.
+type Hit struct {
+	Post
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+// Hits contains slice of search hits.
+type Hits struct {
+	Hits []Hit `json:"hits"`
+}
+
+// SearchFilter contains the conditions which needs to search posts.
+type SearchFilter struct {
+	Query  string
+	Limit  int
+	Offset int
 }
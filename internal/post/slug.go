@@ -0,0 +1,86 @@
+package post
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// maxSlugLength limits slugs generated from long titles.
+const maxSlugLength = 80
+
+// defaultSlug is used for titles without letters and digits.
+const defaultSlug = "post"
+
+// translit spells letters of other alphabets in latin.
+var translit = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+	'æ': "ae", 'ç': "c", 'ć': "c", 'č': "c", 'ď': "d", 'đ': "d", 'ð': "d",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ė': "e", 'ę': "e", 'ě': "e",
+	'ğ': "g", 'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ī': "i", 'į': "i", 'ı': "i",
+	'ķ': "k", 'ļ': "l", 'ł': "l", 'ľ': "l", 'ñ': "n", 'ń': "n", 'ň': "n", 'ņ': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ő': "o", 'œ': "oe",
+	'ř': "r", 'ś': "s", 'š': "s", 'ş': "s", 'ș': "s", 'ß': "ss", 'ť': "t", 'ţ': "t", 'ț': "t", 'þ': "th",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ū': "u", 'ů': "u", 'ű': "u", 'ų': "u",
+	'ý': "y", 'ÿ': "y", 'ź': "z", 'ż': "z", 'ž': "z",
+
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "h", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "sch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g",
+}
+
+// Slug returns url friendly representation of the post title.
+// Letters are spelled in latin where it's known how,
+// the rest of the letters are kept as is.
+func Slug(title string) string {
+	var b strings.Builder
+	dash := false
+
+	write := func(s string) {
+		if b.Len()+len(s) > maxSlugLength {
+			return
+		}
+		b.WriteString(s)
+		dash = false
+	}
+
+	for _, r := range strings.ToLower(title) {
+		if s, ok := translit[r]; ok {
+			write(s)
+			continue
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			write(string(r))
+			continue
+		}
+		if !dash && b.Len() > 0 && b.Len() < maxSlugLength {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+
+	slug := strings.Trim(b.String(), "-")
+	if slug == "" {
+		return defaultSlug
+	}
+	return slug
+}
+
+// AvailableSlug returns the slug itself when it's free or
+// the slug with the first free number suffix otherwise.
+func AvailableSlug(slug string, taken []string) string {
+	used := make(map[string]struct{}, len(taken))
+	for _, s := range taken {
+		used[s] = struct{}{}
+	}
+
+	candidate := slug
+	for i := 2; ; i++ {
+		if _, ok := used[candidate]; !ok {
+			return candidate
+		}
+		candidate = slug + "-" + strconv.Itoa(i)
+	}
+}
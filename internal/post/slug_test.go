@@ -0,0 +1,67 @@
+package post
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		name   string
+		title  string
+		expect string
+	}{
+		{
+			name:   "words",
+			title:  "My Awesome Title",
+			expect: "my-awesome-title",
+		},
+		{
+			name:   "punctuation",
+			title:  "  Go: the good, the bad & the ugly!  ",
+			expect: "go-the-good-the-bad-the-ugly",
+		},
+		{
+			name:   "diacritics",
+			title:  "Crème brûlée für Straße",
+			expect: "creme-brulee-fur-strasse",
+		},
+		{
+			name:   "cyrillic",
+			title:  "Привет, мир",
+			expect: "privet-mir",
+		},
+		{
+			name:   "unknown alphabet",
+			title:  "日本語 blog",
+			expect: "日本語-blog",
+		},
+		{
+			name:   "no letters",
+			title:  "!!!",
+			expect: "post",
+		},
+		{
+			name:   "long title",
+			title:  strings.Repeat("word ", 30),
+			expect: strings.TrimSuffix(strings.Repeat("word-", 16), "-"),
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expect, Slug(tc.title))
+		})
+	}
+}
+
+func TestAvailableSlug(t *testing.T) {
+	assert.Equal(t, "go", AvailableSlug("go", nil))
+	assert.Equal(t, "go", AvailableSlug("go", []string{"go-2"}))
+	assert.Equal(t, "go-2", AvailableSlug("go", []string{"go"}))
+	assert.Equal(t, "go-4", AvailableSlug("go", []string{"go", "go-2", "go-3", "go-5"}))
+}
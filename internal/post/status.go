@@ -0,0 +1,27 @@
+package post
+
+import (
+	"time"
+)
+
+// PublishedAt returns the publication time of a post with the given status.
+// Drafts have no publication time, scheduled and archived posts keep
+// the requested one and published posts get now unless they already have it.
+// The time is in UTC whatever the offset of the requested one is.
+func PublishedAt(status string, requested *time.Time, now time.Time) *time.Time {
+	now = now.UTC()
+	if requested != nil {
+		utc := requested.UTC()
+		requested = &utc
+	}
+
+	switch status {
+	case StatusDraft:
+		return nil
+	case StatusPublished:
+		if requested == nil || requested.After(now) {
+			return &now
+		}
+	}
+	return requested
+}
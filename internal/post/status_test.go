@@ -0,0 +1,78 @@
+package post
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishedAt(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	offset := time.Date(2030, 1, 2, 9, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+	utc := time.Date(2030, 1, 2, 7, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		status    string
+		requested *time.Time
+		expect    *time.Time
+	}{
+		{
+			name:      "draft",
+			status:    StatusDraft,
+			requested: &past,
+		},
+		{
+			name:      "scheduled",
+			status:    StatusScheduled,
+			requested: &future,
+			expect:    &future,
+		},
+		{
+			name:      "scheduled with offset",
+			status:    StatusScheduled,
+			requested: &offset,
+			expect:    &utc,
+		},
+		{
+			name:   "published now",
+			status: StatusPublished,
+			expect: &now,
+		},
+		{
+			name:      "published before",
+			status:    StatusPublished,
+			requested: &past,
+			expect:    &past,
+		},
+		{
+			name:      "published in future",
+			status:    StatusPublished,
+			requested: &future,
+			expect:    &now,
+		},
+		{
+			name:      "archived",
+			status:    StatusArchived,
+			requested: &past,
+			expect:    &past,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := PublishedAt(tc.status, tc.requested, now)
+			if tc.expect == nil {
+				assert.Nil(t, got)
+				return
+			}
+			assert.True(t, tc.expect.Equal(*got), "got %v expected %v", got, tc.expect)
+			assert.Equal(t, time.UTC, got.Location())
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package find
+
+import (
+	"context"
+
+	"github.com/dipress/blog/internal/user"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=service.go -package=find -destination=service.mock.go
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+}
+
+// Service is a use case for public profile showing.
+type Service struct {
+	Repository
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository) *Service {
+	s := Service{
+		Repository: r,
+	}
+
+	return &s
+}
+
+// Find finds the public profile of the user.
+func (s *Service) Find(ctx context.Context, username string) (*user.Profile, error) {
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, username, &u); err != nil {
+		return nil, errors.Wrap(err, "repository find user")
+	}
+
+	return u.Profile(), nil
+}
@@ -0,0 +1,83 @@
+package update
+
+import (
+	"context"
+
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+// easyjson service.go
+//go:generate mockgen -source=service.go -package=update -destination=service.mock.go
+
+// Validater validates profile fields.
+type Validater interface {
+	Validate(context.Context, *Form) error
+}
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	UpdateProfile(ctx context.Context, userID int, p *user.Profile) error
+}
+
+// Form is a profile form. Only given fields are changed.
+//easyjson:json
+type Form struct {
+	DisplayName *string `json:"display_name"`
+	Bio         *string `json:"bio"`
+	AvatarURL   *string `json:"avatar_url"`
+}
+
+// Service is a use case for profile updating.
+type Service struct {
+	Repository
+	Validater
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, v Validater) *Service {
+	s := Service{
+		Repository: r,
+		Validater:  v,
+	}
+
+	return &s
+}
+
+// Update updates the profile of the current user.
+func (s *Service) Update(ctx context.Context, f *Form) (*user.Profile, error) {
+	if err := auth.RequireScope(ctx, token.ScopeProfileWrite); err != nil {
+		return nil, errors.Wrap(err, "require scope")
+	}
+
+	if err := s.Validater.Validate(ctx, f); err != nil {
+		return nil, errors.Wrap(err, "validater validate")
+	}
+
+	claims, _ := auth.FromContext(ctx)
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+		return nil, errors.Wrap(err, "repository find user")
+	}
+
+	p := u.Profile()
+	if f.DisplayName != nil {
+		p.DisplayName = *f.DisplayName
+	}
+	if f.Bio != nil {
+		p.Bio = *f.Bio
+	}
+	if f.AvatarURL != nil {
+		p.AvatarURL = *f.AvatarURL
+	}
+
+	if err := s.Repository.UpdateProfile(ctx, u.ID, p); err != nil {
+		return nil, errors.Wrap(err, "repository update profile")
+	}
+
+	return p, nil
+}
@@ -0,0 +1,150 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package update
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonCd93bc43DecodeGithubComDipressBlogInternalProfileUpdate(in *jlexer.Lexer, out *Form) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "display_name":
+			if in.IsNull() {
+				in.Skip()
+				out.DisplayName = nil
+			} else {
+				if out.DisplayName == nil {
+					out.DisplayName = new(string)
+				}
+				*out.DisplayName = string(in.String())
+			}
+		case "bio":
+			if in.IsNull() {
+				in.Skip()
+				out.Bio = nil
+			} else {
+				if out.Bio == nil {
+					out.Bio = new(string)
+				}
+				*out.Bio = string(in.String())
+			}
+		case "avatar_url":
+			if in.IsNull() {
+				in.Skip()
+				out.AvatarURL = nil
+			} else {
+				if out.AvatarURL == nil {
+					out.AvatarURL = new(string)
+				}
+				*out.AvatarURL = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCd93bc43EncodeGithubComDipressBlogInternalProfileUpdate(out *jwriter.Writer, in Form) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"display_name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.DisplayName == nil {
+			out.RawString("null")
+		} else {
+			out.String(string(*in.DisplayName))
+		}
+	}
+	{
+		const prefix string = ",\"bio\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Bio == nil {
+			out.RawString("null")
+		} else {
+			out.String(string(*in.Bio))
+		}
+	}
+	{
+		const prefix string = ",\"avatar_url\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.AvatarURL == nil {
+			out.RawString("null")
+		} else {
+			out.String(string(*in.AvatarURL))
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Form) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonCd93bc43EncodeGithubComDipressBlogInternalProfileUpdate(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Form) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonCd93bc43EncodeGithubComDipressBlogInternalProfileUpdate(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Form) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonCd93bc43DecodeGithubComDipressBlogInternalProfileUpdate(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Form) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonCd93bc43DecodeGithubComDipressBlogInternalProfileUpdate(l, v)
+}
@@ -0,0 +1,102 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceUpdate(t *testing.T) {
+	findUser := func(_ context.Context, _ string, u *user.User) error {
+		u.ID = 1
+		u.DisplayName = "Old Name"
+		u.Bio = "old bio"
+		return nil
+	}
+
+	tests := []struct {
+		name           string
+		validateFunc   func(mock *MockValidater)
+		repositoryFunc func(mock *MockRepository)
+		wantErr        bool
+	}{
+		{
+			name: "ok",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(findUser)
+				m.EXPECT().UpdateProfile(gomock.Any(), 1, &user.Profile{ID: 1, DisplayName: "New Name", Bio: "old bio"}).Return(nil)
+			},
+		},
+		{
+			name: "validation",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			repositoryFunc: func(m *MockRepository) {},
+			wantErr:        true,
+		},
+		{
+			name: "find user error",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "update error",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(findUser)
+				m.EXPECT().UpdateProfile(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			validator := NewMockValidater(ctrl)
+			repo := NewMockRepository(ctrl)
+			tc.validateFunc(validator)
+			tc.repositoryFunc(repo)
+
+			s := NewService(repo, validator)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			claims := auth.Claims{}
+			newCtx := auth.ToContext(ctx, &claims)
+
+			name := "New Name"
+			form := Form{
+				DisplayName: &name,
+			}
+
+			_, err := s.Update(newCtx, &form)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package publish
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Repository allows to work with the database.
+type Repository interface {
+	PublishScheduled(ctx context.Context, now time.Time) (int64, error)
+}
+
+// Service is a use case for scheduled posts publishing.
+type Service struct {
+	Repository
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository) *Service {
+	s := Service{
+		Repository: r,
+	}
+
+	return &s
+}
+
+// Publish publishes scheduled posts whose time has come.
+func (s *Service) Publish(ctx context.Context, now time.Time) (int64, error) {
+	n, err := s.Repository.PublishScheduled(ctx, now)
+	if err != nil {
+		return 0, errors.Wrap(err, "repository publish scheduled")
+	}
+
+	return n, nil
+}
+
+// Run publishes scheduled posts every interval
+// until the context is done.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := s.Publish(ctx, time.Now().UTC())
+		if err != nil {
+			log.Printf("publish scheduled posts: %v\n", err)
+		} else if n > 0 {
+			log.Printf("published %d scheduled posts\n", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package publish
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServicePublish(t *testing.T) {
+	tests := []struct {
+		name           string
+		repositoryFunc func(ctx context.Context, now time.Time) (int64, error)
+		wantErr        bool
+	}{
+		{
+			name: "ok",
+			repositoryFunc: func(ctx context.Context, now time.Time) (int64, error) {
+				return 1, nil
+			},
+		},
+		{
+			name: "repository error",
+			repositoryFunc: func(ctx context.Context, now time.Time) (int64, error) {
+				return 0, errors.New("mock error")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewService(repositoryFunc(tc.repositoryFunc))
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			_, err := s.Publish(ctx, time.Now())
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestServiceRun(t *testing.T) {
+	calls := make(chan struct{}, 2)
+	s := NewService(repositoryFunc(func(ctx context.Context, now time.Time) (int64, error) {
+		select {
+		case calls <- struct{}{}:
+		default:
+		}
+		return 0, nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-calls:
+		case <-time.After(time.Second):
+			t.Fatal("should publish on every tick")
+		}
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("should stop when context is done")
+	}
+}
+
+type repositoryFunc func(ctx context.Context, now time.Time) (int64, error)
+
+func (r repositoryFunc) PublishScheduled(ctx context.Context, now time.Time) (int64, error) {
+	return r(ctx, now)
+}
@@ -2,6 +2,7 @@ package reg
 
 import (
 	"context"
+	"log"
 	"time"
 
 	jwt "github.com/dgrijalva/jwt-go"
@@ -41,6 +42,16 @@ type TokenGenerator interface {
 	GenerateToken(ctx context.Context, claims jwt.Claims) (string, error)
 }
 
+// Issuer issues refresh tokens.
+type Issuer interface {
+	Issue(ctx context.Context, userID int, family string) (string, error)
+}
+
+// Verifier sends email verification tokens.
+type Verifier interface {
+	Send(ctx context.Context, u *user.User) error
+}
+
 // Form is a user form.
 //easyjson:json
 type Form struct {
@@ -52,7 +63,8 @@ type Form struct {
 // Token holds token data.
 //easyjson:json
 type Token struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
 }
 
 // Service holds everything required to registrate user.
@@ -60,17 +72,21 @@ type Service struct {
 	Repository
 	Validater
 	TokenGenerator
+	Issuer
+	Verifier
 	ExpireAfter time.Duration
 }
 
 // NewService factory prepares service for
 // futher operations.
-func NewService(r Repository, v Validater, tg TokenGenerator, exp time.Duration) *Service {
+func NewService(r Repository, v Validater, tg TokenGenerator, i Issuer, vr Verifier, exp time.Duration) *Service {
 	s := Service{
 		Repository:     r,
 		Validater:      v,
 		ExpireAfter:    exp,
 		TokenGenerator: tg,
+		Issuer:         i,
+		Verifier:       vr,
 	}
 	return &s
 }
@@ -95,6 +111,7 @@ func (s *Service) Registrate(ctx context.Context, f *Form, token *Token) error {
 	}
 
 	nu := user.NewUser{
+		Username:     f.Username,
 		Email:        f.Email,
 		PasswordHash: string(pw),
 	}
@@ -105,9 +122,8 @@ func (s *Service) Registrate(ctx context.Context, f *Form, token *Token) error {
 		return errors.Wrap(err, "create user")
 	}
 
-	//TODO: create email notifier service.
-
 	claims := auth.NewClaims(user.Username, time.Now(), s.ExpireAfter)
+	claims.Role = user.Role
 
 	tknStr, err := s.TokenGenerator.GenerateToken(ctx, claims)
 	if err != nil {
@@ -115,7 +131,25 @@ func (s *Service) Registrate(ctx context.Context, f *Form, token *Token) error {
 		return errors.Wrap(err, "generate token")
 	}
 
+	if err := commit(); err != nil {
+		return errors.Wrap(err, "commit")
+	}
+
+	// The user must be committed before the refresh token references it.
+	refresh, err := s.Issuer.Issue(ctx, user.ID, "")
+	if err != nil {
+		return errors.Wrap(err, "issue refresh token")
+	}
+
+	// The email is sent outside of the transaction so a slow mail
+	// server doesn't hold it open. A failed send doesn't undo the
+	// registration, the user can ask for the email again.
+	if err := s.Verifier.Send(ctx, &user); err != nil {
+		log.Printf("send verification: %v\n", err)
+	}
+
 	token.Token = tknStr
+	token.RefreshToken = refresh
 
-	return commit()
+	return nil
 }
@@ -6,7 +6,7 @@ package reg
 
 import (
 	context "context"
-	jwt_go "github.com/dgrijalva/jwt-go"
+	jwt "github.com/dgrijalva/jwt-go"
 	user "github.com/dipress/blog/internal/user"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
@@ -140,7 +140,7 @@ func (m *MockTokenGenerator) EXPECT() *MockTokenGeneratorMockRecorder {
 }
 
 // GenerateToken mocks base method
-func (m *MockTokenGenerator) GenerateToken(ctx context.Context, claims jwt_go.Claims) (string, error) {
+func (m *MockTokenGenerator) GenerateToken(ctx context.Context, claims jwt.Claims) (string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GenerateToken", ctx, claims)
 	ret0, _ := ret[0].(string)
@@ -153,3 +153,78 @@ func (mr *MockTokenGeneratorMockRecorder) GenerateToken(ctx, claims interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockTokenGenerator)(nil).GenerateToken), ctx, claims)
 }
+
+// MockIssuer is a mock of Issuer interface
+type MockIssuer struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssuerMockRecorder
+}
+
+// MockIssuerMockRecorder is the mock recorder for MockIssuer
+type MockIssuerMockRecorder struct {
+	mock *MockIssuer
+}
+
+// NewMockIssuer creates a new mock instance
+func NewMockIssuer(ctrl *gomock.Controller) *MockIssuer {
+	mock := &MockIssuer{ctrl: ctrl}
+	mock.recorder = &MockIssuerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockIssuer) EXPECT() *MockIssuerMockRecorder {
+	return m.recorder
+}
+
+// Issue mocks base method
+func (m *MockIssuer) Issue(ctx context.Context, userID int, family string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", ctx, userID, family)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Issue indicates an expected call of Issue
+func (mr *MockIssuerMockRecorder) Issue(ctx, userID, family interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockIssuer)(nil).Issue), ctx, userID, family)
+}
+
+// MockVerifier is a mock of Verifier interface
+type MockVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockVerifierMockRecorder
+}
+
+// MockVerifierMockRecorder is the mock recorder for MockVerifier
+type MockVerifierMockRecorder struct {
+	mock *MockVerifier
+}
+
+// NewMockVerifier creates a new mock instance
+func NewMockVerifier(ctrl *gomock.Controller) *MockVerifier {
+	mock := &MockVerifier{ctrl: ctrl}
+	mock.recorder = &MockVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockVerifier) EXPECT() *MockVerifierMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method
+func (m *MockVerifier) Send(ctx context.Context, u *user.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send
+func (mr *MockVerifierMockRecorder) Send(ctx, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockVerifier)(nil).Send), ctx, u)
+}
@@ -38,6 +38,8 @@ func easyjsonCd93bc43DecodeGithubComDipressBlogInternalReg(in *jlexer.Lexer, out
 		switch key {
 		case "token":
 			out.Token = string(in.String())
+		case "refresh_token":
+			out.RefreshToken = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -62,6 +64,16 @@ func easyjsonCd93bc43EncodeGithubComDipressBlogInternalReg(out *jwriter.Writer,
 		}
 		out.String(string(in.Token))
 	}
+	{
+		const prefix string = ",\"refresh_token\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.RefreshToken))
+	}
 	out.RawByte('}')
 }
 
@@ -23,6 +23,8 @@ func Test_Service(t *testing.T) {
 		validateFunc       func(mock *MockValidater)
 		repositoryFunc     func(mock *MockRepository)
 		tokenGeneratorFunc func(moock *MockTokenGenerator)
+		issuerFunc         func(mock *MockIssuer)
+		verifierFunc       func(mock *MockVerifier)
 		wantErr            bool
 	}{
 		{
@@ -38,6 +40,12 @@ func Test_Service(t *testing.T) {
 			tokenGeneratorFunc: func(m *MockTokenGenerator) {
 				m.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).Return("", nil)
 			},
+			issuerFunc: func(m *MockIssuer) {
+				m.EXPECT().Issue(gomock.Any(), gomock.Any(), "").Return("", nil)
+			},
+			verifierFunc: func(m *MockVerifier) {
+				m.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+			},
 		},
 		{
 			name: "validation",
@@ -46,6 +54,8 @@ func Test_Service(t *testing.T) {
 			},
 			repositoryFunc:     func(m *MockRepository) {},
 			tokenGeneratorFunc: func(m *MockTokenGenerator) {},
+			issuerFunc:         func(m *MockIssuer) {},
+			verifierFunc:       func(m *MockVerifier) {},
 			wantErr:            true,
 		},
 		{
@@ -57,6 +67,8 @@ func Test_Service(t *testing.T) {
 				m.EXPECT().UniqueUsername(gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
 			},
 			tokenGeneratorFunc: func(m *MockTokenGenerator) {},
+			issuerFunc:         func(m *MockIssuer) {},
+			verifierFunc:       func(m *MockVerifier) {},
 			wantErr:            true,
 		},
 		{
@@ -69,6 +81,8 @@ func Test_Service(t *testing.T) {
 				m.EXPECT().UniqueEmail(gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
 			},
 			tokenGeneratorFunc: func(m *MockTokenGenerator) {},
+			issuerFunc:         func(m *MockIssuer) {},
+			verifierFunc:       func(m *MockVerifier) {},
 			wantErr:            true,
 		},
 		{
@@ -82,8 +96,30 @@ func Test_Service(t *testing.T) {
 				m.EXPECT().CreateUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, rollback, errors.New("mock error"))
 			},
 			tokenGeneratorFunc: func(m *MockTokenGenerator) {},
+			issuerFunc:         func(m *MockIssuer) {},
+			verifierFunc:       func(m *MockVerifier) {},
 			wantErr:            true,
 		},
+		{
+			name: "verification",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().ValidateUser(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().UniqueUsername(gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().UniqueEmail(gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().CreateUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(commit, rollback, nil)
+			},
+			tokenGeneratorFunc: func(m *MockTokenGenerator) {
+				m.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).Return("", nil)
+			},
+			issuerFunc: func(m *MockIssuer) {
+				m.EXPECT().Issue(gomock.Any(), gomock.Any(), "").Return("", nil)
+			},
+			verifierFunc: func(m *MockVerifier) {
+				m.EXPECT().Send(gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+		},
 		{
 			name: "token",
 			validateFunc: func(m *MockValidater) {
@@ -97,7 +133,28 @@ func Test_Service(t *testing.T) {
 			tokenGeneratorFunc: func(m *MockTokenGenerator) {
 				m.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).Return("", errors.New("mock error"))
 			},
-			wantErr: true,
+			issuerFunc:   func(m *MockIssuer) {},
+			verifierFunc: func(m *MockVerifier) {},
+			wantErr:      true,
+		},
+		{
+			name: "refresh token",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().ValidateUser(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().UniqueUsername(gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().UniqueEmail(gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().CreateUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(commit, rollback, nil)
+			},
+			tokenGeneratorFunc: func(m *MockTokenGenerator) {
+				m.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).Return("", nil)
+			},
+			issuerFunc: func(m *MockIssuer) {
+				m.EXPECT().Issue(gomock.Any(), gomock.Any(), "").Return("", errors.New("mock error"))
+			},
+			verifierFunc: func(m *MockVerifier) {},
+			wantErr:      true,
 		},
 	}
 
@@ -110,12 +167,16 @@ func Test_Service(t *testing.T) {
 			validator := NewMockValidater(ctrl)
 			repo := NewMockRepository(ctrl)
 			generator := NewMockTokenGenerator(ctrl)
+			issuer := NewMockIssuer(ctrl)
+			verifier := NewMockVerifier(ctrl)
 
 			tt.validateFunc(validator)
 			tt.repositoryFunc(repo)
 			tt.tokenGeneratorFunc(generator)
+			tt.issuerFunc(issuer)
+			tt.verifierFunc(verifier)
 
-			s := NewService(repo, validator, generator, time.Hour)
+			s := NewService(repo, validator, generator, issuer, verifier, time.Hour)
 
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
@@ -0,0 +1,86 @@
+package revision
+
+import (
+	"strings"
+)
+
+// Diff operations.
+const (
+	OpEqual  = "="
+	OpDelete = "-"
+	OpInsert = "+"
+)
+
+// LineDiff compares two texts line by line and returns
+// the changes which turn the first text into the second one.
+func LineDiff(from, to string) []Line {
+	a := strings.Split(from, "\n")
+	b := strings.Split(to, "\n")
+	lines := make([]Line, 0, len(a)+len(b))
+
+	// Common lines at both ends are equal, only
+	// the lines between them are compared.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		lines = append(lines, Line{Op: OpEqual, Text: a[prefix]})
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	lines = lcsDiff(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix], lines)
+	for _, text := range a[len(a)-suffix:] {
+		lines = append(lines, Line{Op: OpEqual, Text: text})
+	}
+
+	return lines
+}
+
+// lcsDiff appends the changes between a and b
+// by the longest common subsequence of lines.
+func lcsDiff(a, b []string, lines []Line) []Line {
+	// lcs[i][j] holds the length of the longest common
+	// subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, Line{Op: OpEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, Line{Op: OpDelete, Text: a[i]})
+			i++
+		default:
+			lines = append(lines, Line{Op: OpInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, Line{Op: OpDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, Line{Op: OpInsert, Text: b[j]})
+	}
+
+	return lines
+}
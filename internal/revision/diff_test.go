@@ -0,0 +1,65 @@
+package revision
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		old    string
+		new    string
+		expect []Line
+	}{
+		{
+			name: "equal",
+			old:  "a\nb",
+			new:  "a\nb",
+			expect: []Line{
+				{Op: OpEqual, Text: "a"},
+				{Op: OpEqual, Text: "b"},
+			},
+		},
+		{
+			name: "changed line",
+			old:  "a\nb\nc",
+			new:  "a\nx\nc",
+			expect: []Line{
+				{Op: OpEqual, Text: "a"},
+				{Op: OpDelete, Text: "b"},
+				{Op: OpInsert, Text: "x"},
+				{Op: OpEqual, Text: "c"},
+			},
+		},
+		{
+			name: "appended lines",
+			old:  "a",
+			new:  "a\nb\nc",
+			expect: []Line{
+				{Op: OpEqual, Text: "a"},
+				{Op: OpInsert, Text: "b"},
+				{Op: OpInsert, Text: "c"},
+			},
+		},
+		{
+			name: "removed lines",
+			old:  "a\nb\nc",
+			new:  "c",
+			expect: []Line{
+				{Op: OpDelete, Text: "a"},
+				{Op: OpDelete, Text: "b"},
+				{Op: OpEqual, Text: "c"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expect, LineDiff(tc.old, tc.new))
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package find
+
+import (
+	"context"
+
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/revision"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=service.go -package=find -destination=service.mock.go
+
+// Abillity checks permissions to view revisions.
+type Abillity interface {
+	CanUpdate(u *user.User, post *post.Post) bool
+}
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	FindPost(ctx context.Context, id int) (*post.Post, error)
+	FindRevision(ctx context.Context, postID, number int) (*revision.Revision, error)
+}
+
+// Service is a use case for revision finding.
+type Service struct {
+	Repository
+	Abillity
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, a Abillity) *Service {
+	s := Service{
+		Repository: r,
+		Abillity:   a,
+	}
+
+	return &s
+}
+
+// Find finds the revision of the post and compares
+// it with the current version of the post.
+func (s *Service) Find(ctx context.Context, postID, number int) (*revision.Diff, error) {
+	p, err := s.Repository.FindPost(ctx, postID)
+	if err != nil {
+		return nil, errors.Wrap(err, "find post")
+	}
+
+	claims, _ := auth.FromContext(ctx)
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+		return nil, errors.Wrap(err, "repository find user")
+	}
+
+	ok := s.Abillity.CanUpdate(&u, p)
+	if !ok {
+		return nil, post.ErrNotFound
+	}
+
+	r, err := s.Repository.FindRevision(ctx, postID, number)
+	if err != nil {
+		return nil, errors.Wrap(err, "find revision")
+	}
+
+	d := revision.Diff{
+		Revision: *r,
+		Title:    revision.LineDiff(r.Title, p.Title),
+		Body:     revision.LineDiff(r.Body, p.Body),
+	}
+
+	return &d, nil
+}
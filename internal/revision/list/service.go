@@ -0,0 +1,68 @@
+package list
+
+import (
+	"context"
+
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/revision"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=service.go -package=list -destination=service.mock.go
+
+// Abillity checks permissions to view revisions.
+type Abillity interface {
+	CanUpdate(u *user.User, post *post.Post) bool
+}
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	FindPost(ctx context.Context, id int) (*post.Post, error)
+	ListRevisions(ctx context.Context, postID int, rs *revision.Revisions) error
+}
+
+// Service is a use case for revisions showing.
+type Service struct {
+	Repository
+	Abillity
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, a Abillity) *Service {
+	s := Service{
+		Repository: r,
+		Abillity:   a,
+	}
+
+	return &s
+}
+
+// List shows all revisions of the post, newest first.
+func (s *Service) List(ctx context.Context, postID int) (*revision.Revisions, error) {
+	p, err := s.Repository.FindPost(ctx, postID)
+	if err != nil {
+		return nil, errors.Wrap(err, "find post")
+	}
+
+	claims, _ := auth.FromContext(ctx)
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+		return nil, errors.Wrap(err, "repository find user")
+	}
+
+	ok := s.Abillity.CanUpdate(&u, p)
+	if !ok {
+		return nil, post.ErrNotFound
+	}
+
+	var revisions revision.Revisions
+	if err := s.Repository.ListRevisions(ctx, postID, &revisions); err != nil {
+		return nil, errors.Wrap(err, "list revisions")
+	}
+
+	return &revisions, nil
+}
@@ -0,0 +1,56 @@
+package revision
+
+import (
+	"errors"
+	"time"
+)
+
+// easyjson -all model.go
+
+var (
+	// ErrNotFound raises when revision not found in the database.
+	ErrNotFound = errors.New("revision not found")
+)
+
+// Revision contains the version of the post
+// which was replaced by the user.
+type Revision struct {
+	ID        int       `json:"id"`
+	PostID    int       `json:"post_id"`
+	UserID    int       `json:"user_id"`
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	Format    string    `json:"format"`
+	Tags      []string  `json:"tags"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewRevision contains the information which needs to create a new Revision.
+type NewRevision struct {
+	PostID int
+	UserID int
+	Title  string
+	Body   string
+	Format string
+	Tags   []string
+}
+
+// Revisions contains slice of revisions.
+type Revisions struct {
+	Revisions []Revision `json:"revisions"`
+}
+
+// Line is a line of the diff.
+type Line struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// Diff contains the revision and the line changes
+// which turn it into the current version of the post.
+type Diff struct {
+	Revision Revision `json:"revision"`
+	Title    []Line   `json:"title"`
+	Body     []Line   `json:"body"`
+}
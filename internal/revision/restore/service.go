@@ -0,0 +1,97 @@
+package restore
+
+import (
+	"context"
+
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/revision"
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=service.go -package=restore -destination=service.mock.go
+
+// Abillity checks permissions to restore revisions.
+type Abillity interface {
+	CanUpdate(u *user.User, post *post.Post) bool
+}
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	FindPost(ctx context.Context, id int) (*post.Post, error)
+	UpdatePost(ctx context.Context, id int, p *post.Post, nr *revision.NewRevision) error
+	FindRevision(ctx context.Context, postID, number int) (*revision.Revision, error)
+}
+
+// Service is a use case for revision restoring.
+type Service struct {
+	Repository
+	Abillity
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, a Abillity) *Service {
+	s := Service{
+		Repository: r,
+		Abillity:   a,
+	}
+
+	return &s
+}
+
+// Restore brings the post back to the revision.
+// The current version of the post becomes a new revision.
+func (s *Service) Restore(ctx context.Context, postID, number int) (*post.Post, error) {
+	if err := auth.RequireScope(ctx, token.ScopePostsWrite); err != nil {
+		return nil, errors.Wrap(err, "require scope")
+	}
+
+	p, err := s.Repository.FindPost(ctx, postID)
+	if err != nil {
+		return nil, errors.Wrap(err, "find post")
+	}
+
+	claims, _ := auth.FromContext(ctx)
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+		return nil, errors.Wrap(err, "repository find user")
+	}
+
+	ok := s.Abillity.CanUpdate(&u, p)
+	if !ok {
+		return nil, post.ErrNotFound
+	}
+
+	r, err := s.Repository.FindRevision(ctx, postID, number)
+	if err != nil {
+		return nil, errors.Wrap(err, "find revision")
+	}
+
+	nr := revision.NewRevision{
+		PostID: p.ID,
+		UserID: u.ID,
+		Title:  p.Title,
+		Body:   p.Body,
+		Format: p.Format,
+		Tags:   p.Tags,
+	}
+
+	if post.Slug(r.Title) != post.Slug(p.Title) {
+		p.Slug = post.Slug(r.Title)
+	}
+	p.Title = r.Title
+	p.Body = r.Body
+	p.Format = r.Format
+	p.Tags = r.Tags
+	p.BodyHTML = post.RenderBody(p.Format, p.Body)
+
+	if err := s.Repository.UpdatePost(ctx, postID, p, &nr); err != nil {
+		return nil, errors.Wrap(err, "update post")
+	}
+
+	return p, nil
+}
@@ -0,0 +1,101 @@
+package role
+
+import (
+	"context"
+
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+// easyjson service.go
+//go:generate mockgen -source=service.go -package=role -destination=service.mock.go
+
+var (
+	// ErrForbidden returns when the user is not allowed to assign roles.
+	ErrForbidden = errors.New("forbidden")
+)
+
+// Validater validates role fields.
+type Validater interface {
+	Validate(context.Context, *Form) error
+}
+
+// Abillity checks permissions to assign roles.
+type Abillity interface {
+	CanAssign(u *user.User) bool
+}
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	UpdateRole(ctx context.Context, userID int, role string) error
+}
+
+// Form is a role form.
+//easyjson:json
+type Form struct {
+	Role string `json:"role"`
+}
+
+// Assignment is a result of the role assignment.
+//easyjson:json
+type Assignment struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// Service is a use case for user roles assignment.
+type Service struct {
+	Repository
+	Validater
+	Abillity
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, v Validater, a Abillity) *Service {
+	s := Service{
+		Repository: r,
+		Validater:  v,
+		Abillity:   a,
+	}
+
+	return &s
+}
+
+// Assign assigns the role to the user with given username.
+func (s *Service) Assign(ctx context.Context, username string, f *Form) (*Assignment, error) {
+	if err := auth.RequireSession(ctx); err != nil {
+		return nil, errors.Wrap(err, "require session")
+	}
+
+	if err := s.Validater.Validate(ctx, f); err != nil {
+		return nil, errors.Wrap(err, "validater validate")
+	}
+
+	claims, _ := auth.FromContext(ctx)
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+		return nil, errors.Wrap(err, "repository find user")
+	}
+
+	if !s.Abillity.CanAssign(&u) {
+		return nil, ErrForbidden
+	}
+
+	var target user.User
+	if err := s.Repository.FindByUsername(ctx, username, &target); err != nil {
+		return nil, errors.Wrap(err, "repository find target")
+	}
+
+	if err := s.Repository.UpdateRole(ctx, target.ID, f.Role); err != nil {
+		return nil, errors.Wrap(err, "repository update role")
+	}
+
+	a := Assignment{
+		Username: target.Username,
+		Role:     f.Role,
+	}
+	return &a, nil
+}
@@ -0,0 +1,130 @@
+package role
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dipress/blog/kit/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceAssign(t *testing.T) {
+	tests := []struct {
+		name           string
+		validateFunc   func(mock *MockValidater)
+		repositoryFunc func(mock *MockRepository)
+		abilityFunc    func(mock *MockAbillity)
+		wantErr        bool
+	}{
+		{
+			name: "ok",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), "admin", gomock.Any()).Return(nil)
+				m.EXPECT().FindByUsername(gomock.Any(), "john", gomock.Any()).Return(nil)
+				m.EXPECT().UpdateRole(gomock.Any(), gomock.Any(), "editor").Return(nil)
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanAssign(gomock.Any()).Return(true)
+			},
+		},
+		{
+			name: "validation",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			repositoryFunc: func(m *MockRepository) {},
+			abilityFunc:    func(m *MockAbillity) {},
+			wantErr:        true,
+		},
+		{
+			name: "find user",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), "admin", gomock.Any()).Return(errors.New("mock error"))
+			},
+			abilityFunc: func(m *MockAbillity) {},
+			wantErr:     true,
+		},
+		{
+			name: "forbidden",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), "admin", gomock.Any()).Return(nil)
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanAssign(gomock.Any()).Return(false)
+			},
+			wantErr: true,
+		},
+		{
+			name: "find target",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), "admin", gomock.Any()).Return(nil)
+				m.EXPECT().FindByUsername(gomock.Any(), "john", gomock.Any()).Return(errors.New("mock error"))
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanAssign(gomock.Any()).Return(true)
+			},
+			wantErr: true,
+		},
+		{
+			name: "update role",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), "admin", gomock.Any()).Return(nil)
+				m.EXPECT().FindByUsername(gomock.Any(), "john", gomock.Any()).Return(nil)
+				m.EXPECT().UpdateRole(gomock.Any(), gomock.Any(), "editor").Return(errors.New("mock error"))
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanAssign(gomock.Any()).Return(true)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			validator := NewMockValidater(ctrl)
+			repo := NewMockRepository(ctrl)
+			ability := NewMockAbillity(ctrl)
+			tc.validateFunc(validator)
+			tc.repositoryFunc(repo)
+			tc.abilityFunc(ability)
+
+			s := NewService(repo, validator, ability)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			claims := auth.Claims{}
+			claims.Subject = "admin"
+			newCtx := auth.ToContext(ctx, &claims)
+
+			_, err := s.Assign(newCtx, "john", &Form{Role: "editor"})
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package search
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dipress/blog/internal/post"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+var (
+	// ErrEmptyQuery returns when search query is blank.
+	ErrEmptyQuery = errors.New("empty query")
+)
+
+// Repository allows to work with the database.
+type Repository interface {
+	SearchPosts(ctx context.Context, f *post.SearchFilter, hs *post.Hits) error
+}
+
+// Form holds search query parameters.
+type Form struct {
+	Query  string
+	Limit  int
+	Offset int
+}
+
+// Service is a use case for posts searching.
+type Service struct {
+	Repository
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository) *Service {
+	s := Service{
+		Repository: r,
+	}
+
+	return &s
+}
+
+// Search finds published posts matching the query,
+// most relevant first.
+func (s *Service) Search(ctx context.Context, f *Form) (*post.Hits, error) {
+	query := strings.TrimSpace(f.Query)
+	if query == "" {
+		return nil, ErrEmptyQuery
+	}
+
+	limit := f.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	offset := f.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	filter := post.SearchFilter{
+		Query:  query,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	var hits post.Hits
+	if err := s.Repository.SearchPosts(ctx, &filter, &hits); err != nil {
+		return nil, errors.Wrap(err, "search posts")
+	}
+
+	return &hits, nil
+}
@@ -0,0 +1,91 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dipress/blog/internal/post"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceSearch(t *testing.T) {
+	tests := []struct {
+		name           string
+		form           Form
+		repositoryFunc func(ctx context.Context, f *post.SearchFilter, hs *post.Hits) error
+		wantErr        bool
+	}{
+		{
+			name: "ok",
+			form: Form{
+				Query: "golang",
+			},
+			repositoryFunc: func(ctx context.Context, f *post.SearchFilter, hs *post.Hits) error {
+				if f.Limit != defaultLimit {
+					return errors.New("mock error")
+				}
+				return nil
+			},
+		},
+		{
+			name: "limit bounds",
+			form: Form{
+				Query:  "golang",
+				Limit:  1000,
+				Offset: -1,
+			},
+			repositoryFunc: func(ctx context.Context, f *post.SearchFilter, hs *post.Hits) error {
+				if f.Limit != maxLimit || f.Offset != 0 {
+					return errors.New("mock error")
+				}
+				return nil
+			},
+		},
+		{
+			name: "empty query",
+			form: Form{
+				Query: "  ",
+			},
+			repositoryFunc: func(ctx context.Context, f *post.SearchFilter, hs *post.Hits) error {
+				return nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "repository error",
+			form: Form{
+				Query: "golang",
+			},
+			repositoryFunc: func(ctx context.Context, f *post.SearchFilter, hs *post.Hits) error {
+				return errors.New("mock error")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewService(repositoryFunc(tc.repositoryFunc))
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			_, err := s.Search(ctx, &tc.form)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
+
+type repositoryFunc func(ctx context.Context, f *post.SearchFilter, hs *post.Hits) error
+
+func (r repositoryFunc) SearchPosts(ctx context.Context, f *post.SearchFilter, hs *post.Hits) error {
+	return r(ctx, f, hs)
+}
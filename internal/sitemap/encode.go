@@ -0,0 +1,70 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ContentType is the content type of sitemaps.
+const ContentType = "application/xml; charset=utf-8"
+
+const xmlns = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+type urlSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	URLs    []urlLoc `xml:"url"`
+}
+
+type urlLoc struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name `xml:"sitemapindex"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Sitemaps []urlLoc `xml:"sitemap"`
+}
+
+// Encode writes the sitemap as XML. Links are absolute, base is
+// the scheme and the host of the site and postPath is the path
+// of post pages followed by the slug.
+func Encode(sm *Sitemap, base, postPath string) ([]byte, error) {
+	var doc interface{}
+	if sm.Pages > 0 {
+		index := sitemapIndex{
+			Xmlns:    xmlns,
+			Sitemaps: make([]urlLoc, 0, sm.Pages),
+		}
+		for i := 1; i <= sm.Pages; i++ {
+			index.Sitemaps = append(index.Sitemaps, urlLoc{
+				Loc: base + "/sitemap-" + strconv.Itoa(i) + ".xml",
+			})
+		}
+		doc = index
+	} else {
+		set := urlSet{
+			Xmlns: xmlns,
+			URLs:  make([]urlLoc, 0, len(sm.Entries)),
+		}
+		for _, e := range sm.Entries {
+			set.URLs = append(set.URLs, urlLoc{
+				Loc:     base + postPath + url.PathEscape(e.Slug),
+				LastMod: e.UpdatedAt.UTC().Format(time.RFC3339),
+			})
+		}
+		doc = set
+	}
+
+	data, err := xml.Marshal(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal xml")
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
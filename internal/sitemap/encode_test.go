@@ -0,0 +1,50 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodePage(t *testing.T) {
+	sm := Sitemap{
+		Entries: []Entry{
+			{Slug: "привет-мир", UpdatedAt: time.Date(2019, 7, 20, 10, 0, 0, 0, time.FixedZone("MSK", 3*60*60))},
+		},
+	}
+
+	data, err := Encode(&sm, "https://example.com", "/p/")
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(data), xml.Header))
+
+	var doc struct {
+		XMLName xml.Name `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+		URLs    []struct {
+			Loc     string `xml:"loc"`
+			LastMod string `xml:"lastmod"`
+		} `xml:"url"`
+	}
+	assert.Nil(t, xml.Unmarshal(data, &doc))
+	assert.Len(t, doc.URLs, 1)
+	assert.Equal(t, "https://example.com/p/%D0%BF%D1%80%D0%B8%D0%B2%D0%B5%D1%82-%D0%BC%D0%B8%D1%80", doc.URLs[0].Loc)
+	assert.Equal(t, "2019-07-20T07:00:00Z", doc.URLs[0].LastMod)
+}
+
+func TestEncodeIndex(t *testing.T) {
+	data, err := Encode(&Sitemap{Pages: 2}, "https://example.com", "/p/")
+	assert.Nil(t, err)
+
+	var doc struct {
+		XMLName  xml.Name `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+		Sitemaps []struct {
+			Loc string `xml:"loc"`
+		} `xml:"sitemap"`
+	}
+	assert.Nil(t, xml.Unmarshal(data, &doc))
+	assert.Len(t, doc.Sitemaps, 2)
+	assert.Equal(t, "https://example.com/sitemap-1.xml", doc.Sitemaps[0].Loc)
+	assert.Equal(t, "https://example.com/sitemap-2.xml", doc.Sitemaps[1].Loc)
+}
@@ -0,0 +1,95 @@
+package sitemap
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=service.go -package=sitemap -destination=service.mock.go
+
+// MaxURLs is the limit of urls in a sitemap file set by the protocol.
+const MaxURLs = 50000
+
+var (
+	// ErrNotFound returns when the page of the sitemap doesn't exist.
+	ErrNotFound = errors.New("sitemap page not found")
+)
+
+// Repository allows to work with the database.
+type Repository interface {
+	CountSitemapPosts(ctx context.Context) (int, error)
+	ListSitemapPosts(ctx context.Context, offset, limit int, entries *[]Entry) error
+}
+
+// Entry is a post page of the sitemap.
+type Entry struct {
+	Slug      string
+	UpdatedAt time.Time
+}
+
+// Sitemap is either the index of sitemap pages
+// or a page with entries when Pages is zero.
+type Sitemap struct {
+	Pages   int
+	Entries []Entry
+}
+
+// Service is a use case for sitemaps.
+type Service struct {
+	Repository
+	Size int
+}
+
+// NewService factory prepares service for all futher operations.
+// Every sitemap page has up to size entries.
+func NewService(r Repository, size int) *Service {
+	s := Service{
+		Repository: r,
+		Size:       size,
+	}
+
+	return &s
+}
+
+// Root returns the only page when all entries fit into it,
+// the index of pages otherwise.
+func (s *Service) Root(ctx context.Context) (*Sitemap, error) {
+	count, err := s.Repository.CountSitemapPosts(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "count sitemap posts")
+	}
+
+	if count > s.Size {
+		return &Sitemap{Pages: (count + s.Size - 1) / s.Size}, nil
+	}
+
+	return s.page(ctx, 1)
+}
+
+// Page returns the page of the index by its number starting with one.
+func (s *Service) Page(ctx context.Context, number int) (*Sitemap, error) {
+	if number < 1 {
+		return nil, ErrNotFound
+	}
+
+	sm, err := s.page(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if len(sm.Entries) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return sm, nil
+}
+
+func (s *Service) page(ctx context.Context, number int) (*Sitemap, error) {
+	var sm Sitemap
+	if err := s.Repository.ListSitemapPosts(ctx, (number-1)*s.Size, s.Size, &sm.Entries); err != nil {
+		return nil, errors.Wrap(err, "list sitemap posts")
+	}
+
+	return &sm, nil
+}
@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package sitemap is a generated GoMock package.
+package sitemap
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockRepository is a mock of Repository interface
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountSitemapPosts mocks base method
+func (m *MockRepository) CountSitemapPosts(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSitemapPosts", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSitemapPosts indicates an expected call of CountSitemapPosts
+func (mr *MockRepositoryMockRecorder) CountSitemapPosts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSitemapPosts", reflect.TypeOf((*MockRepository)(nil).CountSitemapPosts), ctx)
+}
+
+// ListSitemapPosts mocks base method
+func (m *MockRepository) ListSitemapPosts(ctx context.Context, offset, limit int, entries *[]Entry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSitemapPosts", ctx, offset, limit, entries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListSitemapPosts indicates an expected call of ListSitemapPosts
+func (mr *MockRepositoryMockRecorder) ListSitemapPosts(ctx, offset, limit, entries interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSitemapPosts", reflect.TypeOf((*MockRepository)(nil).ListSitemapPosts), ctx, offset, limit, entries)
+}
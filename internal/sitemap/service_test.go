@@ -0,0 +1,135 @@
+package sitemap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceRoot(t *testing.T) {
+	tests := []struct {
+		name           string
+		repositoryFunc func(mock *MockRepository)
+		wantErr        bool
+		wantPages      int
+		wantEntries    int
+	}{
+		{
+			name: "single page",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().CountSitemapPosts(gomock.Any()).Return(2, nil)
+				m.EXPECT().ListSitemapPosts(gomock.Any(), 0, 2, gomock.Any()).DoAndReturn(func(ctx context.Context, offset, limit int, entries *[]Entry) error {
+					*entries = []Entry{{Slug: "first"}, {Slug: "second"}}
+					return nil
+				})
+			},
+			wantEntries: 2,
+		},
+		{
+			name: "index",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().CountSitemapPosts(gomock.Any()).Return(5, nil)
+			},
+			wantPages: 3,
+		},
+		{
+			name: "count error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().CountSitemapPosts(gomock.Any()).Return(0, errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "list error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().CountSitemapPosts(gomock.Any()).Return(1, nil)
+				m.EXPECT().ListSitemapPosts(gomock.Any(), 0, 2, gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepository(ctrl)
+			tc.repositoryFunc(repo)
+
+			s := NewService(repo, 2)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			got, err := s.Root(ctx)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantPages, got.Pages)
+			assert.Len(t, got.Entries, tc.wantEntries)
+		})
+	}
+}
+
+func TestServicePage(t *testing.T) {
+	tests := []struct {
+		name           string
+		number         int
+		repositoryFunc func(mock *MockRepository)
+		err            error
+	}{
+		{
+			name:   "ok",
+			number: 2,
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().ListSitemapPosts(gomock.Any(), 2, 2, gomock.Any()).DoAndReturn(func(ctx context.Context, offset, limit int, entries *[]Entry) error {
+					*entries = []Entry{{Slug: "third"}}
+					return nil
+				})
+			},
+		},
+		{
+			name:           "zero page",
+			number:         0,
+			repositoryFunc: func(m *MockRepository) {},
+			err:            ErrNotFound,
+		},
+		{
+			name:   "past the last page",
+			number: 3,
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().ListSitemapPosts(gomock.Any(), 4, 2, gomock.Any()).Return(nil)
+			},
+			err: ErrNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepository(ctrl)
+			tc.repositoryFunc(repo)
+
+			s := NewService(repo, 2)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			_, err := s.Page(ctx, tc.number)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
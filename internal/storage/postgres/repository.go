@@ -3,12 +3,22 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/dipress/blog/internal/auth"
+	"github.com/dipress/blog/internal/comment"
+	"github.com/dipress/blog/internal/oidc"
 	"github.com/dipress/blog/internal/post"
 	"github.com/dipress/blog/internal/reg"
+	"github.com/dipress/blog/internal/revision"
+	"github.com/dipress/blog/internal/sitemap"
+	"github.com/dipress/blog/internal/tag"
+	"github.com/dipress/blog/internal/token"
 	"github.com/dipress/blog/internal/user"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 )
 
@@ -30,25 +40,88 @@ func NewRepository(db *sql.DB) *Repository {
 	return &r
 }
 
-const createQuery = `INSERT INTO posts (user_id, title, body) VALUES ($1, $2, $3) RETURNING id, user_id, title, body, created_at, updated_at`
+const createQuery = `INSERT INTO posts (user_id, title, slug, body, format, body_html, description, canonical_url, image_url, status, published_at) VALUES ($1, $2, $3, $4, COALESCE(NULLIF($5, ''), 'plain'), $6, $7, $8, $9, COALESCE(NULLIF($10, ''), 'published'), $11) RETURNING id, user_id, title, slug, body, format, body_html, description, canonical_url, image_url, status, published_at, created_at, updated_at`
 
-// CreatePost inserts a post into a database.
+// CreatePost inserts a post with its tags into a database.
+// The slug gets a number suffix when it's taken by another post.
 func (r *Repository) CreatePost(ctx context.Context, f *post.NewPost, post *post.Post) error {
-	if err := r.db.QueryRowContext(ctx, createQuery, f.UserID, f.Title, f.Body).
-		Scan(&post.ID, &post.UserID, &post.Title, &post.Body, &post.CreatedAt, &post.UpdatedAt); err != nil {
+	return retrySlug(func() error {
+		return r.createPost(ctx, f, post)
+	})
+}
+
+func (r *Repository) createPost(ctx context.Context, f *post.NewPost, post *post.Post) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin tx")
+	}
+
+	slug, err := availableSlug(ctx, tx, f.Slug, 0)
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "available slug")
+	}
+
+	if err := tx.QueryRowContext(ctx, createQuery, f.UserID, f.Title, slug, f.Body, f.Format, f.BodyHTML, f.Description, f.CanonicalURL, f.ImageURL, f.Status, f.PublishedAt).
+		Scan(&post.ID, &post.UserID, &post.Title, &post.Slug, &post.Body, &post.Format, &post.BodyHTML, &post.Description, &post.CanonicalURL, &post.ImageURL, &post.Status, &post.PublishedAt, &post.CreatedAt, &post.UpdatedAt); err != nil {
+		tx.Rollback()
 		return errors.Wrap(err, "query scan error")
 	}
 
+	if err := setPostTags(ctx, tx, post.ID, f.Tags); err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "set post tags")
+	}
+	post.Tags = append([]string{}, f.Tags...)
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "commit tx")
+	}
+
 	return nil
 }
 
-const findPostQuery = `SELECT id, user_id, title, body, created_at, updated_at FROM posts where id = $1`
+// postTagsColumn selects sorted tag names of the post as an array.
+const postTagsColumn = `ARRAY(SELECT t.name FROM tags t JOIN posts_tags pt ON pt.tag_id = t.id WHERE pt.post_id = posts.id ORDER BY t.name)`
+
+const findPostQuery = `SELECT id, user_id, title, slug, body, format, body_html, description, canonical_url, image_url, ` + postTagsColumn + `, status, published_at, deleted_at, created_at, updated_at FROM posts where id = $1 AND deleted_at IS NULL`
 
 // FindPost finds post by id.
 func (r *Repository) FindPost(ctx context.Context, id int) (*post.Post, error) {
 	var p post.Post
 	if err := r.db.QueryRowContext(ctx, findPostQuery, id).
-		Scan(&p.ID, &p.UserID, &p.Title, &p.Body, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		Scan(&p.ID, &p.UserID, &p.Title, &p.Slug, &p.Body, &p.Format, &p.BodyHTML, &p.Description, &p.CanonicalURL, &p.ImageURL, pq.Array(&p.Tags), &p.Status, &p.PublishedAt, &p.DeletedAt, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, post.ErrNotFound
+		}
+		return nil, errors.Wrap(err, "query row scan")
+	}
+	return &p, nil
+}
+
+const findDeletedPostQuery = `SELECT id, user_id, title, slug, body, format, body_html, description, canonical_url, image_url, ` + postTagsColumn + `, status, published_at, deleted_at, created_at, updated_at FROM posts where id = $1 AND deleted_at IS NOT NULL`
+
+// FindDeletedPost finds post in the trash by id.
+func (r *Repository) FindDeletedPost(ctx context.Context, id int) (*post.Post, error) {
+	var p post.Post
+	if err := r.db.QueryRowContext(ctx, findDeletedPostQuery, id).
+		Scan(&p.ID, &p.UserID, &p.Title, &p.Slug, &p.Body, &p.Format, &p.BodyHTML, &p.Description, &p.CanonicalURL, &p.ImageURL, pq.Array(&p.Tags), &p.Status, &p.PublishedAt, &p.DeletedAt, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, post.ErrNotFound
+		}
+		return nil, errors.Wrap(err, "query row scan")
+	}
+	return &p, nil
+}
+
+const findPostBySlugQuery = `SELECT id, user_id, title, slug, body, format, body_html, description, canonical_url, image_url, ` + postTagsColumn + `, status, published_at, deleted_at, created_at, updated_at FROM posts
+WHERE (slug = $1 OR id = (SELECT post_id FROM post_slugs WHERE slug = $1)) AND deleted_at IS NULL`
+
+// FindPostBySlug finds post by the current or a former slug.
+func (r *Repository) FindPostBySlug(ctx context.Context, slug string) (*post.Post, error) {
+	var p post.Post
+	if err := r.db.QueryRowContext(ctx, findPostBySlugQuery, slug).
+		Scan(&p.ID, &p.UserID, &p.Title, &p.Slug, &p.Body, &p.Format, &p.BodyHTML, &p.Description, &p.CanonicalURL, &p.ImageURL, pq.Array(&p.Tags), &p.Status, &p.PublishedAt, &p.DeletedAt, &p.CreatedAt, &p.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, post.ErrNotFound
 		}
@@ -57,33 +130,292 @@ func (r *Repository) FindPost(ctx context.Context, id int) (*post.Post, error) {
 	return &p, nil
 }
 
-const updatePostQuery = `UPDATE posts SET title=:title, body=:body, updated_at=now() WHERE id=:id`
+const updatePostQuery = `UPDATE posts SET title=:title, slug=:slug, body=:body, format=:format, body_html=:body_html, description=:description, canonical_url=:canonical_url, image_url=:image_url, status=:status, published_at=:published_at, updated_at=now() WHERE id=:id`
+
+// UpdatePost updates post and replaces its tags by id.
+// The replaced slug is kept to find the post by old links.
+// The revision is inserted in the same transaction if given.
+func (r *Repository) UpdatePost(ctx context.Context, id int, p *post.Post, nr *revision.NewRevision) error {
+	return retrySlug(func() error {
+		return r.updatePost(ctx, id, p, nr)
+	})
+}
+
+func (r *Repository) updatePost(ctx context.Context, id int, p *post.Post, nr *revision.NewRevision) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin tx")
+	}
+
+	var current string
+	if err := tx.QueryRowContext(ctx, lockPostSlugQuery, id).Scan(&current); err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return post.ErrNotFound
+		}
+		return errors.Wrap(err, "lock post slug")
+	}
+
+	if nr != nil {
+		if _, err := tx.ExecContext(ctx, createRevisionQuery, nr.PostID, nr.UserID, nr.Title, nr.Body, nr.Format, pq.Array(revisionTags(nr.Tags))); err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "create revision")
+		}
+	}
+
+	slug := current
+	if p.Slug != "" && p.Slug != current {
+		if slug, err = availableSlug(ctx, tx, p.Slug, id); err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "available slug")
+		}
+	}
+
+	if slug != current {
+		if _, err := tx.ExecContext(ctx, keepPostSlugQuery, current, id); err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "keep post slug")
+		}
+		if _, err := tx.ExecContext(ctx, reclaimPostSlugQuery, slug); err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "reclaim post slug")
+		}
+	}
 
-// UpdatePost updates post by id.
-func (r *Repository) UpdatePost(ctx context.Context, id int, p *post.Post) error {
-	stmt, err := r.db.PrepareNamed(updatePostQuery)
+	stmt, err := tx.PrepareNamed(updatePostQuery)
 	if err != nil {
+		tx.Rollback()
 		return errors.Wrap(err, "prepare named")
 	}
 	defer stmt.Close()
 
 	if _, err := stmt.ExecContext(ctx, map[string]interface{}{
-		"id":    id,
-		"title": p.Title,
-		"body":  p.Body,
+		"id":            id,
+		"title":         p.Title,
+		"slug":          slug,
+		"body":          p.Body,
+		"format":        p.Format,
+		"body_html":     p.BodyHTML,
+		"description":   p.Description,
+		"canonical_url": p.CanonicalURL,
+		"image_url":     p.ImageURL,
+		"status":        p.Status,
+		"published_at":  p.PublishedAt,
 	}); err != nil {
+		tx.Rollback()
 		if err == sql.ErrNoRows {
 			return post.ErrNotFound
 		}
 		return errors.Wrap(err, "exec context")
 	}
 
+	if err := setPostTags(ctx, tx, id, p.Tags); err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "set post tags")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "commit tx")
+	}
+	p.Slug = slug
+
+	return nil
+}
+
+const (
+	lockPostSlugQuery    = `SELECT slug FROM posts WHERE id = $1 FOR UPDATE`
+	keepPostSlugQuery    = `INSERT INTO post_slugs (slug, post_id) VALUES ($1, $2) ON CONFLICT (slug) DO UPDATE SET post_id = EXCLUDED.post_id`
+	reclaimPostSlugQuery = `DELETE FROM post_slugs WHERE slug = $1`
+	takenSlugsQuery      = `SELECT slug FROM posts WHERE id <> $2 AND (slug = $1 OR slug LIKE $1 || '-%')
+UNION SELECT slug FROM post_slugs WHERE post_id <> $2 AND (slug = $1 OR slug LIKE $1 || '-%')`
+)
+
+const (
+	listSlugsBackfillQuery  = `SELECT b.post_id, p.title FROM post_slugs_backfill b JOIN posts p ON p.id = b.post_id ORDER BY b.post_id`
+	deleteSlugBackfillQuery = `DELETE FROM post_slugs_backfill WHERE post_id = $1`
+	updatePostSlugQuery     = `UPDATE posts SET slug = $2 WHERE id = $1`
+)
+
+// BackfillPostSlugs gives the posts left by the slugs migration
+// the slugs of their titles and returns the number of changed ones.
+// The replaced slugs are kept to find the posts by old links.
+func (r *Repository) BackfillPostSlugs(ctx context.Context) (int64, error) {
+	rows, err := r.db.QueryxContext(ctx, listSlugsBackfillQuery)
+	if err != nil {
+		return 0, errors.Wrap(err, "query rows")
+	}
+	defer rows.Close()
+
+	titles := make(map[int]string)
+	var ids []int
+	for rows.Next() {
+		var (
+			id    int
+			title string
+		)
+		if err := rows.Scan(&id, &title); err != nil {
+			return 0, errors.Wrap(err, "query row scan on loop")
+		}
+		titles[id] = title
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, errors.Wrap(err, "rows")
+	}
+	rows.Close()
+
+	var n int64
+	for _, id := range ids {
+		var changed bool
+		if err := retrySlug(func() error {
+			var err error
+			changed, err = r.backfillPostSlug(ctx, id, titles[id])
+			return err
+		}); err != nil {
+			return n, errors.Wrapf(err, "backfill post %d slug", id)
+		}
+		if changed {
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+func (r *Repository) backfillPostSlug(ctx context.Context, id int, title string) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, errors.Wrap(err, "begin tx")
+	}
+
+	var current string
+	if err := tx.QueryRowContext(ctx, lockPostSlugQuery, id).Scan(&current); err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "lock post slug")
+	}
+
+	slug, err := availableSlug(ctx, tx, post.Slug(title), id)
+	if err != nil {
+		tx.Rollback()
+		return false, errors.Wrap(err, "available slug")
+	}
+
+	if slug != current {
+		if _, err := tx.ExecContext(ctx, keepPostSlugQuery, current, id); err != nil {
+			tx.Rollback()
+			return false, errors.Wrap(err, "keep post slug")
+		}
+		if _, err := tx.ExecContext(ctx, reclaimPostSlugQuery, slug); err != nil {
+			tx.Rollback()
+			return false, errors.Wrap(err, "reclaim post slug")
+		}
+		if _, err := tx.ExecContext(ctx, updatePostSlugQuery, id, slug); err != nil {
+			tx.Rollback()
+			return false, errors.Wrap(err, "update post slug")
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, deleteSlugBackfillQuery, id); err != nil {
+		tx.Rollback()
+		return false, errors.Wrap(err, "delete slug backfill")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, errors.Wrap(err, "commit tx")
+	}
+
+	return slug != current, nil
+}
+
+// slugAttempts limits transactions retried when
+// a concurrent one takes the same slug first.
+const slugAttempts = 3
+
+// retrySlug runs fn again while it fails because the
+// slug it picked was taken after the check.
+func retrySlug(fn func() error) error {
+	var err error
+	for i := 0; i < slugAttempts; i++ {
+		if err = fn(); !slugTaken(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// slugTaken reports whether err is the posts slug unique violation.
+func slugTaken(err error) bool {
+	pqErr, ok := errors.Cause(err).(*pq.Error)
+	return ok && pqErr.Code == "23505" && pqErr.Constraint == "posts_slug_key"
+}
+
+// availableSlug returns the slug which is not taken by other posts
+// now and was not taken by them before.
+func availableSlug(ctx context.Context, tx *sqlx.Tx, slug string, postID int) (string, error) {
+	if slug == "" {
+		slug = post.Slug("")
+	}
+
+	rows, err := tx.QueryxContext(ctx, takenSlugsQuery, slug, postID)
+	if err != nil {
+		return "", errors.Wrap(err, "query rows")
+	}
+	defer rows.Close()
+
+	var taken []string
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return "", errors.Wrap(err, "query row scan on loop")
+		}
+		taken = append(taken, s)
+	}
+	if err := rows.Err(); err != nil {
+		return "", errors.Wrap(err, "rows")
+	}
+
+	return post.AvailableSlug(slug, taken), nil
+}
+
+const (
+	clearPostTagsQuery = `DELETE FROM posts_tags WHERE post_id = $1`
+	upsertTagQuery     = `INSERT INTO tags (name, slug) VALUES ($1, $2) ON CONFLICT (slug) DO UPDATE SET name = tags.name RETURNING id, name`
+	addPostTagQuery    = `INSERT INTO posts_tags (post_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+)
+
+// setPostTags replaces tags of the post, creating missing ones.
+// Names are rejected when their slug belongs to another name.
+func setPostTags(ctx context.Context, tx *sqlx.Tx, postID int, names []string) error {
+	if _, err := tx.ExecContext(ctx, clearPostTagsQuery, postID); err != nil {
+		return errors.Wrap(err, "clear post tags")
+	}
+
+	for _, name := range names {
+		var (
+			tagID int
+			taken string
+		)
+		if err := tx.QueryRowContext(ctx, upsertTagQuery, name, tag.Slug(name)).Scan(&tagID, &taken); err != nil {
+			return errors.Wrap(err, "upsert tag")
+		}
+		if taken != name {
+			return &tag.SlugError{Name: name, Taken: taken}
+		}
+
+		if _, err := tx.ExecContext(ctx, addPostTagQuery, postID, tagID); err != nil {
+			return errors.Wrap(err, "add post tag")
+		}
+	}
+
 	return nil
 }
 
-const deletePostQuery = "DELETE FROM posts WHERE id=:id"
+const deletePostQuery = "UPDATE posts SET deleted_at=now() WHERE id=:id AND deleted_at IS NULL"
 
-// DeletePost deletes post by id.
+// DeletePost moves post to the trash by id.
 func (r *Repository) DeletePost(ctx context.Context, id int) error {
 	stmt, err := r.db.PrepareNamed(deletePostQuery)
 	if err != nil {
@@ -102,11 +434,54 @@ func (r *Repository) DeletePost(ctx context.Context, id int) error {
 	return nil
 }
 
-const listPostQuery = `SELECT * FROM posts`
+const listPostQuery = `SELECT id, user_id, title, slug, body, format, body_html, description, canonical_url, image_url, ` + postTagsColumn + `, status, published_at, deleted_at, created_at, updated_at FROM posts`
+
+// ListPost shows a page of posts, newest first,
+// matching the given filter.
+func (r *Repository) ListPost(ctx context.Context, f *post.Filter, pos *post.Posts) error {
+	var (
+		conds = []string{"deleted_at IS NULL"}
+		args  []interface{}
+	)
+
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if f.Deleted {
+		conds[0] = "deleted_at IS NOT NULL"
+	}
+
+	if f.UserID != 0 {
+		conds = append(conds, "user_id = "+arg(f.UserID))
+	}
+	if !f.From.IsZero() {
+		conds = append(conds, "created_at >= "+arg(f.From))
+	}
+	if !f.To.IsZero() {
+		conds = append(conds, "created_at < "+arg(f.To))
+	}
+	if f.Status != "" {
+		conds = append(conds, "status = "+arg(f.Status))
+	}
+	if f.Tag != "" {
+		conds = append(conds, "EXISTS (SELECT 1 FROM posts_tags pt JOIN tags t ON t.id = pt.tag_id WHERE pt.post_id = posts.id AND t.slug = "+arg(f.Tag)+")")
+	}
+	if f.AfterID != 0 {
+		conds = append(conds, fmt.Sprintf("(created_at, id) < (%s, %s)", arg(f.AfterCreatedAt), arg(f.AfterID)))
+	}
+
+	query := listPostQuery
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	if f.Limit > 0 {
+		query += " LIMIT " + arg(f.Limit)
+	}
 
-// ListPost shows all posts.
-func (r *Repository) ListPost(ctx context.Context, pos *post.Posts) error {
-	rows, err := r.db.QueryxContext(ctx, listPostQuery)
+	rows, err := r.db.QueryxContext(ctx, query, args...)
 	if err != nil {
 		return errors.Wrap(err, "query rows")
 	}
@@ -116,90 +491,1097 @@ func (r *Repository) ListPost(ctx context.Context, pos *post.Posts) error {
 
 	for rows.Next() {
 		var post post.Post
-		if err := rows.Scan(&post.ID, &post.UserID, &post.Title, &post.Body, &post.CreatedAt, &post.UpdatedAt); err != nil {
-			errors.Wrap(err, "query row scan on loop")
+		if err := rows.Scan(&post.ID, &post.UserID, &post.Title, &post.Slug, &post.Body, &post.Format, &post.BodyHTML, &post.Description, &post.CanonicalURL, &post.ImageURL, pq.Array(&post.Tags), &post.Status, &post.PublishedAt, &post.DeletedAt, &post.CreatedAt, &post.UpdatedAt); err != nil {
+			return errors.Wrap(err, "query row scan on loop")
 		}
 		posts = append(posts, post)
 	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "rows")
+	}
 	pos.Posts = posts
 
 	return nil
 }
 
-const createUserQuery = `INSERT INTO users (username, email, password_hash) VALUES ($1, $2, $3) RETURNING id, username, email, password_hash, created_at, updated_at`
+const createRevisionQuery = `INSERT INTO revisions (post_id, user_id, number, title, body, format, tags)
+VALUES ($1, $2, (SELECT COALESCE(MAX(number), 0) + 1 FROM revisions WHERE post_id = $1), $3, $4, COALESCE(NULLIF($5, ''), 'plain'), $6)
+RETURNING id, post_id, user_id, number, title, body, format, tags, created_at`
 
-// CreateUser inserts a new user into the database.
-func (r *Repository) CreateUser(ctx context.Context, f *user.NewUser, usr *user.User) (func() error, func() error, error) {
-	tx, err := r.db.Beginx()
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "begin tx")
+// CreateRevision inserts the next revision of the post into a database.
+func (r *Repository) CreateRevision(ctx context.Context, f *revision.NewRevision, rev *revision.Revision) error {
+	if err := r.db.QueryRowContext(ctx, createRevisionQuery, f.PostID, f.UserID, f.Title, f.Body, f.Format, pq.Array(revisionTags(f.Tags))).
+		Scan(&rev.ID, &rev.PostID, &rev.UserID, &rev.Number, &rev.Title, &rev.Body, &rev.Format, pq.Array(&rev.Tags), &rev.CreatedAt); err != nil {
+		return errors.Wrap(err, "query scan error")
 	}
 
-	if err := tx.QueryRowContext(ctx, createUserQuery, f.Username, f.Email, f.PasswordHash).
-		Scan(&usr.ID, &usr.Username, &usr.Email, &usr.PasswordHash, &usr.CreatedAt, &usr.UpdatedAt); err != nil {
-		return nil, nil, errors.Wrap(err, "query context scan")
+	return nil
+}
+
+// revisionTags stores missing tags as an empty array.
+func revisionTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
 	}
-	return tx.Commit, tx.Rollback, nil
+	return tags
 }
 
-const uniqueUsernameQuery = `SELECT COUNT(*) FROM users WHERE username = $1`
+const findRevisionQuery = `SELECT id, post_id, user_id, number, title, body, format, tags, created_at FROM revisions WHERE post_id = $1 AND number = $2`
 
-// UniqueUsername checks that username is unique.
-func (r *Repository) UniqueUsername(ctx context.Context, username string) error {
-	var c int
-	if err := r.db.QueryRowContext(ctx, uniqueUsernameQuery, username).Scan(&c); err != nil {
-		return errors.Wrap(err, "scan error")
+// FindRevision finds revision of the post by number.
+func (r *Repository) FindRevision(ctx context.Context, postID, number int) (*revision.Revision, error) {
+	var rev revision.Revision
+	if err := r.db.QueryRowContext(ctx, findRevisionQuery, postID, number).
+		Scan(&rev.ID, &rev.PostID, &rev.UserID, &rev.Number, &rev.Title, &rev.Body, &rev.Format, pq.Array(&rev.Tags), &rev.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, revision.ErrNotFound
+		}
+		return nil, errors.Wrap(err, "query row scan")
 	}
+	return &rev, nil
+}
 
-	if c > 0 {
-		return reg.ErrUsernameExists
+const listRevisionsQuery = `SELECT id, post_id, user_id, number, title, body, format, tags, created_at FROM revisions WHERE post_id = $1 ORDER BY number DESC`
+
+// ListRevisions shows all revisions of the post, newest first.
+func (r *Repository) ListRevisions(ctx context.Context, postID int, rs *revision.Revisions) error {
+	return r.listRevisions(ctx, rs, listRevisionsQuery, postID)
+}
+
+const listUserRevisionsQuery = `SELECT id, post_id, user_id, number, title, body, format, tags, created_at FROM revisions WHERE user_id = $1 AND id > $2 ORDER BY id LIMIT $3`
+
+// ListUserRevisions shows a page of revisions made by the user
+// which follow the revision with afterID.
+func (r *Repository) ListUserRevisions(ctx context.Context, userID, afterID, limit int, rs *revision.Revisions) error {
+	return r.listRevisions(ctx, rs, listUserRevisionsQuery, userID, afterID, limit)
+}
+
+// listRevisions scans revisions selected by the query.
+func (r *Repository) listRevisions(ctx context.Context, rs *revision.Revisions, query string, args ...interface{}) error {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "query rows")
 	}
+	defer rows.Close()
+
+	revisions := make([]revision.Revision, 0)
+
+	for rows.Next() {
+		var rev revision.Revision
+		if err := rows.Scan(&rev.ID, &rev.PostID, &rev.UserID, &rev.Number, &rev.Title, &rev.Body, &rev.Format, pq.Array(&rev.Tags), &rev.CreatedAt); err != nil {
+			return errors.Wrap(err, "query row scan on loop")
+		}
+		revisions = append(revisions, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "rows")
+	}
+	rs.Revisions = revisions
 
 	return nil
 }
 
-const uniqueEmailQuery = `SELECT COUNT(*) FROM users WHERE email = $1`
+// searchPostsQuery escapes the body before highlighting,
+// so snippets have no markup except the marks of matches.
+const searchPostsQuery = `SELECT id, user_id, title, slug, body, format, body_html, description, canonical_url, image_url, ` + postTagsColumn + `, status, published_at, created_at, updated_at,
+	ts_rank(search, q) AS rank,
+	ts_headline('english', replace(replace(replace(body, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), q, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10') AS snippet
+FROM posts, websearch_to_tsquery('english', $1) q
+WHERE search @@ q AND status = 'published' AND deleted_at IS NULL
+ORDER BY rank DESC, id DESC
+LIMIT $2 OFFSET $3`
 
-// UniqueEmail checks that email address is unique.
-func (r *Repository) UniqueEmail(ctx context.Context, email string) error {
-	var c int
-	if err := r.db.QueryRowContext(ctx, uniqueEmailQuery, email).Scan(&c); err != nil {
-		return errors.Wrap(err, "scan error")
+// SearchPosts finds published posts matching the query,
+// most relevant first, with highlighted snippets which
+// are safe to render as HTML.
+func (r *Repository) SearchPosts(ctx context.Context, f *post.SearchFilter, hs *post.Hits) error {
+	rows, err := r.db.QueryxContext(ctx, searchPostsQuery, f.Query, f.Limit, f.Offset)
+	if err != nil {
+		return errors.Wrap(err, "query rows")
 	}
+	defer rows.Close()
 
-	if c > 0 {
-		return reg.ErrEmailExists
+	hits := make([]post.Hit, 0)
+
+	for rows.Next() {
+		var h post.Hit
+		if err := rows.Scan(&h.ID, &h.UserID, &h.Title, &h.Slug, &h.Body, &h.Format, &h.BodyHTML, &h.Description, &h.CanonicalURL, &h.ImageURL, pq.Array(&h.Tags), &h.Status, &h.PublishedAt, &h.CreatedAt, &h.UpdatedAt, &h.Rank, &h.Snippet); err != nil {
+			return errors.Wrap(err, "query row scan on loop")
+		}
+		hits = append(hits, h)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "rows")
 	}
+	hs.Hits = hits
 
 	return nil
 }
 
-const emailFindQuery = `SELECT id, username, email, password_hash, created_at, updated_at FROM users WHERE email = $1`
+// sitemapPostsCondition selects published posts which have no
+// canonical url elsewhere, search engines index their own pages.
+const sitemapPostsCondition = `status = 'published' AND deleted_at IS NULL AND canonical_url = ''`
 
-// FindByEmail finds user by email.
-func (r *Repository) FindByEmail(ctx context.Context, email string, user *user.User) error {
-	if err := r.db.QueryRowContext(ctx, emailFindQuery, email).
-		Scan(&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.CreatedAt, &user.UpdatedAt); err != nil {
-		if err == sql.ErrNoRows {
-			return auth.ErrNotFound
+const (
+	countSitemapPostsQuery = `SELECT count(*) FROM posts WHERE ` + sitemapPostsCondition
+	listSitemapPostsQuery  = `SELECT slug, updated_at FROM posts WHERE ` + sitemapPostsCondition + ` ORDER BY id LIMIT $1 OFFSET $2`
+)
+
+// CountSitemapPosts counts posts of the sitemap.
+func (r *Repository) CountSitemapPosts(ctx context.Context) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, countSitemapPostsQuery).Scan(&count); err != nil {
+		return 0, errors.Wrap(err, "query row scan")
+	}
+
+	return count, nil
+}
+
+// ListSitemapPosts shows a page of posts of the sitemap, oldest first,
+// so pages keep their posts while new ones are published.
+func (r *Repository) ListSitemapPosts(ctx context.Context, offset, limit int, entries *[]sitemap.Entry) error {
+	rows, err := r.db.QueryxContext(ctx, listSitemapPostsQuery, limit, offset)
+	if err != nil {
+		return errors.Wrap(err, "query rows")
+	}
+	defer rows.Close()
+
+	es := make([]sitemap.Entry, 0)
+
+	for rows.Next() {
+		var e sitemap.Entry
+		if err := rows.Scan(&e.Slug, &e.UpdatedAt); err != nil {
+			return errors.Wrap(err, "query row scan on loop")
 		}
-		return errors.Wrap(err, "scan error")
+		es = append(es, e)
 	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "rows")
+	}
+	*entries = es
 
 	return nil
 }
 
-const usernameFindQuery = `SELECT id, username, email, password_hash, created_at, updated_at FROM users WHERE username = $1`
+const restorePostQuery = `UPDATE posts SET deleted_at = NULL WHERE id = $1`
 
-// FindByUsername finds user by username.
-func (r *Repository) FindByUsername(ctx context.Context, username string, u *user.User) error {
-	if err := r.db.QueryRowContext(ctx, usernameFindQuery, username).
-		Scan(&u.ID, &u.Username, &u.Email, &u.PasswordHash, &u.CreatedAt, &u.UpdatedAt); err != nil {
-		if err == sql.ErrNoRows {
-			return user.ErrNotFound
-		}
-		return errors.Wrap(err, "scan error")
+// RestorePost takes post out of the trash by id.
+func (r *Repository) RestorePost(ctx context.Context, id int) error {
+	if _, err := r.db.ExecContext(ctx, restorePostQuery, id); err != nil {
+		return errors.Wrap(err, "exec context")
+	}
+
+	return nil
+}
+
+const purgePostsQuery = `DELETE FROM posts WHERE deleted_at < $1`
+
+// PurgePosts permanently deletes posts moved to the trash
+// before the given time and returns the number of them.
+func (r *Repository) PurgePosts(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, purgePostsQuery, before)
+	if err != nil {
+		return 0, errors.Wrap(err, "exec context")
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "rows affected")
+	}
+
+	return n, nil
+}
+
+const publishScheduledQuery = `UPDATE posts SET status = 'published', updated_at = now() WHERE status = 'scheduled' AND published_at <= $1 AND deleted_at IS NULL`
+
+// PublishScheduled publishes scheduled posts whose time has come
+// and returns the number of them.
+func (r *Repository) PublishScheduled(ctx context.Context, now time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, publishScheduledQuery, now)
+	if err != nil {
+		return 0, errors.Wrap(err, "exec context")
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "rows affected")
 	}
 
+	return n, nil
+}
+
+const listTagsQuery = `SELECT t.id, t.name, t.slug, COUNT(p.id), t.created_at FROM tags t LEFT JOIN posts_tags pt ON pt.tag_id = t.id LEFT JOIN posts p ON p.id = pt.post_id AND p.status = 'published' AND p.deleted_at IS NULL GROUP BY t.id ORDER BY t.name`
+
+// ListTags shows all tags with the number of their published posts.
+func (r *Repository) ListTags(ctx context.Context, ts *tag.Tags) error {
+	rows, err := r.db.QueryxContext(ctx, listTagsQuery)
+	if err != nil {
+		return errors.Wrap(err, "query rows")
+	}
+	defer rows.Close()
+
+	tags := make([]tag.Tag, 0)
+
+	for rows.Next() {
+		var t tag.Tag
+		if err := rows.Scan(&t.ID, &t.Name, &t.Slug, &t.Posts, &t.CreatedAt); err != nil {
+			return errors.Wrap(err, "query row scan on loop")
+		}
+		tags = append(tags, t)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "rows")
+	}
+	ts.Tags = tags
+
+	return nil
+}
+
+const createUserQuery = `INSERT INTO users (username, email, password_hash) VALUES ($1, $2, $3) RETURNING id, username, email, password_hash, role, display_name, bio, avatar_url, verified_at, created_at, updated_at`
+
+// CreateUser inserts a new user into the database.
+func (r *Repository) CreateUser(ctx context.Context, f *user.NewUser, usr *user.User) (func() error, func() error, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "begin tx")
+	}
+
+	if err := tx.QueryRowContext(ctx, createUserQuery, f.Username, f.Email, f.PasswordHash).
+		Scan(&usr.ID, &usr.Username, &usr.Email, &usr.PasswordHash, &usr.Role, &usr.DisplayName, &usr.Bio, &usr.AvatarURL, &usr.VerifiedAt, &usr.CreatedAt, &usr.UpdatedAt); err != nil {
+		return nil, nil, errors.Wrap(err, "query context scan")
+	}
+	return tx.Commit, tx.Rollback, nil
+}
+
+const uniqueUsernameQuery = `SELECT COUNT(*) FROM users WHERE username = $1`
+
+// UniqueUsername checks that username is unique.
+func (r *Repository) UniqueUsername(ctx context.Context, username string) error {
+	var c int
+	if err := r.db.QueryRowContext(ctx, uniqueUsernameQuery, username).Scan(&c); err != nil {
+		return errors.Wrap(err, "scan error")
+	}
+
+	if c > 0 {
+		return reg.ErrUsernameExists
+	}
+
+	return nil
+}
+
+const uniqueEmailQuery = `SELECT COUNT(*) FROM users WHERE email = $1`
+
+// UniqueEmail checks that email address is unique.
+func (r *Repository) UniqueEmail(ctx context.Context, email string) error {
+	var c int
+	if err := r.db.QueryRowContext(ctx, uniqueEmailQuery, email).Scan(&c); err != nil {
+		return errors.Wrap(err, "scan error")
+	}
+
+	if c > 0 {
+		return reg.ErrEmailExists
+	}
+
+	return nil
+}
+
+const emailFindQuery = `SELECT id, username, email, password_hash, role, display_name, bio, avatar_url, verified_at, created_at, updated_at FROM users WHERE email = $1`
+
+// FindByEmail finds user by email.
+func (r *Repository) FindByEmail(ctx context.Context, email string, user *user.User) error {
+	if err := r.db.QueryRowContext(ctx, emailFindQuery, email).
+		Scan(&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.Role, &user.DisplayName, &user.Bio, &user.AvatarURL, &user.VerifiedAt, &user.CreatedAt, &user.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return auth.ErrNotFound
+		}
+		return errors.Wrap(err, "scan error")
+	}
+
+	return nil
+}
+
+const usernameFindQuery = `SELECT id, username, email, password_hash, role, display_name, bio, avatar_url, verified_at, created_at, updated_at FROM users WHERE username = $1`
+
+// FindByUsername finds user by username.
+func (r *Repository) FindByUsername(ctx context.Context, username string, u *user.User) error {
+	if err := r.db.QueryRowContext(ctx, usernameFindQuery, username).
+		Scan(&u.ID, &u.Username, &u.Email, &u.PasswordHash, &u.Role, &u.DisplayName, &u.Bio, &u.AvatarURL, &u.VerifiedAt, &u.CreatedAt, &u.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return user.ErrNotFound
+		}
+		return errors.Wrap(err, "scan error")
+	}
+
+	return nil
+}
+
+const idFindQuery = `SELECT id, username, email, password_hash, role, display_name, bio, avatar_url, verified_at, created_at, updated_at FROM users WHERE id = $1`
+
+// FindByID finds user by id.
+func (r *Repository) FindByID(ctx context.Context, id int, u *user.User) error {
+	if err := r.db.QueryRowContext(ctx, idFindQuery, id).
+		Scan(&u.ID, &u.Username, &u.Email, &u.PasswordHash, &u.Role, &u.DisplayName, &u.Bio, &u.AvatarURL, &u.VerifiedAt, &u.CreatedAt, &u.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return user.ErrNotFound
+		}
+		return errors.Wrap(err, "scan error")
+	}
+
+	return nil
+}
+
+const updateRoleQuery = `UPDATE users SET role = $2, updated_at = now() WHERE id = $1`
+
+// UpdateRole changes the role of the user.
+func (r *Repository) UpdateRole(ctx context.Context, userID int, role string) error {
+	if _, err := r.db.ExecContext(ctx, updateRoleQuery, userID, role); err != nil {
+		return errors.Wrap(err, "exec context")
+	}
+
+	return nil
+}
+
+const updateProfileQuery = `UPDATE users SET display_name = $2, bio = $3, avatar_url = $4, updated_at = now() WHERE id = $1`
+
+// UpdateProfile changes public fields of the user.
+func (r *Repository) UpdateProfile(ctx context.Context, userID int, p *user.Profile) error {
+	if _, err := r.db.ExecContext(ctx, updateProfileQuery, userID, p.DisplayName, p.Bio, p.AvatarURL); err != nil {
+		return errors.Wrap(err, "exec context")
+	}
+
+	return nil
+}
+
+const verifyUserQuery = `UPDATE users SET verified_at = now(), updated_at = now() WHERE id = $1 AND verified_at IS NULL`
+
+// VerifyUser marks the email of the user as verified.
+func (r *Repository) VerifyUser(ctx context.Context, userID int) error {
+	if _, err := r.db.ExecContext(ctx, verifyUserQuery, userID); err != nil {
+		return errors.Wrap(err, "exec context")
+	}
+
+	return nil
+}
+
+const updatePasswordQuery = `UPDATE users SET password_hash = $2, updated_at = now() WHERE id = $1`
+
+// UpdatePassword changes the password hash of the user.
+func (r *Repository) UpdatePassword(ctx context.Context, userID int, hash string) error {
+	if _, err := r.db.ExecContext(ctx, updatePasswordQuery, userID, hash); err != nil {
+		return errors.Wrap(err, "exec context")
+	}
+
+	return nil
+}
+
+const (
+	anonymizeUserQuery            = `UPDATE users SET username = '', email = '', password_hash = '', display_name = '', bio = '', avatar_url = '', verified_at = NULL, tokens_valid_after = now(), updated_at = now() WHERE id = $1`
+	deleteUserRefreshTokensQuery  = `DELETE FROM refresh_tokens WHERE user_id = $1`
+	deleteUserTOTPQuery           = `DELETE FROM totp WHERE user_id = $1`
+	deleteUserIdentitiesQuery     = `DELETE FROM identities WHERE user_id = $1`
+	deleteUserPersonalTokensQuery = `DELETE FROM personal_tokens WHERE user_id = $1`
+)
+
+// AnonymizeUser erases the personal data of the user and
+// keeps the row, so the content stays attributed to it.
+// The empty username and email can't sign in or be found.
+func (r *Repository) AnonymizeUser(ctx context.Context, userID int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin tx")
+	}
+
+	if _, err := tx.ExecContext(ctx, anonymizeUserQuery, userID); err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "anonymize user")
+	}
+
+	if _, err := tx.ExecContext(ctx, deleteUserRefreshTokensQuery, userID); err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "delete refresh tokens")
+	}
+
+	if _, err := tx.ExecContext(ctx, deleteUserTOTPQuery, userID); err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "delete totp")
+	}
+
+	if _, err := tx.ExecContext(ctx, clearRecoveryCodesQuery, userID); err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "delete recovery codes")
+	}
+
+	if _, err := tx.ExecContext(ctx, deleteUserIdentitiesQuery, userID); err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "delete identities")
+	}
+
+	if _, err := tx.ExecContext(ctx, deleteUserPersonalTokensQuery, userID); err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "delete personal tokens")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "commit tx")
+	}
+
+	return nil
+}
+
+// deleteUserQueries delete the content of the user before the user
+// itself. Comments, tags and revisions of the posts and refresh
+// tokens are deleted by the database cascade.
+var deleteUserQueries = []string{
+	`DELETE FROM revisions WHERE user_id = $1`,
+	`DELETE FROM comments WHERE user_id = $1`,
+	`DELETE FROM posts WHERE user_id = $1`,
+	`DELETE FROM users WHERE id = $1`,
+}
+
+// DeleteUser deletes the user with all posts,
+// comments and revisions.
+func (r *Repository) DeleteUser(ctx context.Context, userID int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin tx")
+	}
+
+	for _, query := range deleteUserQueries {
+		if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "exec context")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "commit tx")
+	}
+
+	return nil
+}
+
+const createRefreshTokenQuery = `INSERT INTO refresh_tokens (user_id, family, hash, expires_at) VALUES ($1, $2, $3, $4) RETURNING id, user_id, family, hash, expires_at, revoked_at, created_at`
+
+// CreateRefreshToken inserts a new refresh token into the database.
+func (r *Repository) CreateRefreshToken(ctx context.Context, f *token.NewRefresh, t *token.Refresh) error {
+	if err := r.db.QueryRowContext(ctx, createRefreshTokenQuery, f.UserID, f.Family, f.Hash, f.ExpiresAt).
+		Scan(&t.ID, &t.UserID, &t.Family, &t.Hash, &t.ExpiresAt, &t.RevokedAt, &t.CreatedAt); err != nil {
+		return errors.Wrap(err, "query row scan")
+	}
+
+	return nil
+}
+
+const findRefreshTokenQuery = `SELECT id, user_id, family, hash, expires_at, revoked_at, created_at FROM refresh_tokens WHERE hash = $1`
+
+// FindRefreshToken finds refresh token by its hash.
+func (r *Repository) FindRefreshToken(ctx context.Context, hash string) (*token.Refresh, error) {
+	var t token.Refresh
+	if err := r.db.QueryRowContext(ctx, findRefreshTokenQuery, hash).
+		Scan(&t.ID, &t.UserID, &t.Family, &t.Hash, &t.ExpiresAt, &t.RevokedAt, &t.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, token.ErrNotFound
+		}
+		return nil, errors.Wrap(err, "query row scan")
+	}
+
+	return &t, nil
+}
+
+const revokeRefreshTokenQuery = `UPDATE refresh_tokens SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL`
+
+// RevokeRefreshToken revokes refresh token by id. It returns
+// token.ErrNotFound when the token is already revoked.
+func (r *Repository) RevokeRefreshToken(ctx context.Context, id int) error {
+	res, err := r.db.ExecContext(ctx, revokeRefreshTokenQuery, id)
+	if err != nil {
+		return errors.Wrap(err, "exec context")
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "rows affected")
+	}
+	if n == 0 {
+		return token.ErrNotFound
+	}
+
+	return nil
+}
+
+const recentlyRotatedQuery = `SELECT EXISTS(SELECT 1 FROM refresh_tokens r WHERE r.id = $1 AND r.revoked_at > now() - $2 * interval '1 second'
+AND EXISTS(SELECT 1 FROM refresh_tokens a WHERE a.family = r.family AND a.revoked_at IS NULL AND a.expires_at > now()))`
+
+// RecentlyRotated reports whether the refresh token was revoked within
+// the grace period and another token of its family is still active.
+func (r *Repository) RecentlyRotated(ctx context.Context, id int, grace time.Duration) (bool, error) {
+	var rotated bool
+	if err := r.db.QueryRowContext(ctx, recentlyRotatedQuery, id, grace.Seconds()).Scan(&rotated); err != nil {
+		return false, errors.Wrap(err, "query row scan")
+	}
+
+	return rotated, nil
+}
+
+const revokeRefreshFamilyQuery = `UPDATE refresh_tokens SET revoked_at = now() WHERE family = $1 AND revoked_at IS NULL`
+
+// RevokeRefreshFamily revokes all refresh tokens of the family.
+func (r *Repository) RevokeRefreshFamily(ctx context.Context, family string) error {
+	if _, err := r.db.ExecContext(ctx, revokeRefreshFamilyQuery, family); err != nil {
+		return errors.Wrap(err, "exec context")
+	}
+
+	return nil
+}
+
+const revokeUserRefreshTokensQuery = `UPDATE refresh_tokens SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL`
+
+// RevokeUserRefreshTokens revokes all refresh tokens of the user.
+func (r *Repository) RevokeUserRefreshTokens(ctx context.Context, userID int) error {
+	if _, err := r.db.ExecContext(ctx, revokeUserRefreshTokensQuery, userID); err != nil {
+		return errors.Wrap(err, "exec context")
+	}
+
+	return nil
+}
+
+const listActiveRefreshTokensQuery = `SELECT id, user_id, family, hash, expires_at, revoked_at, created_at FROM refresh_tokens WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > now() ORDER BY created_at, id`
+
+// ListActiveRefreshTokens finds refresh tokens of the user which
+// can still be used, one for each signed in session.
+func (r *Repository) ListActiveRefreshTokens(ctx context.Context, userID int) ([]token.Refresh, error) {
+	rows, err := r.db.QueryxContext(ctx, listActiveRefreshTokensQuery, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "query rows")
+	}
+	defer rows.Close()
+
+	ts := make([]token.Refresh, 0)
+
+	for rows.Next() {
+		var t token.Refresh
+		if err := rows.Scan(&t.ID, &t.UserID, &t.Family, &t.Hash, &t.ExpiresAt, &t.RevokedAt, &t.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "query row scan on loop")
+		}
+		ts = append(ts, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+
+	return ts, nil
+}
+
+const createPersonalTokenQuery = `INSERT INTO personal_tokens (user_id, name, hash, scopes, expires_at, created_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, user_id, name, hash, scopes, last_used_at, expires_at, revoked_at, created_at`
+
+// CreatePersonalToken inserts a new personal access token into the database.
+func (r *Repository) CreatePersonalToken(ctx context.Context, f *token.NewPersonal, t *token.Personal) error {
+	var expiresAt *time.Time
+	if f.ExpiresAt != nil {
+		utc := f.ExpiresAt.UTC()
+		expiresAt = &utc
+	}
+
+	if err := r.db.QueryRowContext(ctx, createPersonalTokenQuery, f.UserID, f.Name, f.Hash, pq.Array(f.Scopes), expiresAt, f.CreatedAt.UTC()).
+		Scan(&t.ID, &t.UserID, &t.Name, &t.Hash, pq.Array(&t.Scopes), &t.LastUsedAt, &t.ExpiresAt, &t.RevokedAt, &t.CreatedAt); err != nil {
+		return errors.Wrap(err, "query row scan")
+	}
+
+	return nil
+}
+
+const findPersonalTokenQuery = `SELECT id, user_id, name, hash, scopes, last_used_at, expires_at, revoked_at, created_at FROM personal_tokens WHERE hash = $1`
+
+// FindPersonalToken finds personal access token by its hash.
+func (r *Repository) FindPersonalToken(ctx context.Context, hash string) (*token.Personal, error) {
+	var t token.Personal
+	if err := r.db.QueryRowContext(ctx, findPersonalTokenQuery, hash).
+		Scan(&t.ID, &t.UserID, &t.Name, &t.Hash, pq.Array(&t.Scopes), &t.LastUsedAt, &t.ExpiresAt, &t.RevokedAt, &t.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, token.ErrPersonalNotFound
+		}
+		return nil, errors.Wrap(err, "query row scan")
+	}
+
+	return &t, nil
+}
+
+const listPersonalTokensQuery = `SELECT id, user_id, name, hash, scopes, last_used_at, expires_at, revoked_at, created_at FROM personal_tokens WHERE user_id = $1 AND revoked_at IS NULL ORDER BY created_at, id`
+
+// ListPersonalTokens finds not revoked personal access tokens of the user.
+func (r *Repository) ListPersonalTokens(ctx context.Context, userID int) ([]token.Personal, error) {
+	rows, err := r.db.QueryxContext(ctx, listPersonalTokensQuery, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "query rows")
+	}
+	defer rows.Close()
+
+	ts := make([]token.Personal, 0)
+
+	for rows.Next() {
+		var t token.Personal
+		if err := rows.Scan(&t.ID, &t.UserID, &t.Name, &t.Hash, pq.Array(&t.Scopes), &t.LastUsedAt, &t.ExpiresAt, &t.RevokedAt, &t.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "query row scan on loop")
+		}
+		ts = append(ts, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+
+	return ts, nil
+}
+
+const revokePersonalTokenQuery = `UPDATE personal_tokens SET revoked_at = now() WHERE id = $2 AND user_id = $1 AND revoked_at IS NULL`
+
+// RevokePersonalToken revokes personal access token of the user by id.
+// It returns token.ErrPersonalNotFound when the token belongs to
+// someone else or is already revoked.
+func (r *Repository) RevokePersonalToken(ctx context.Context, userID, id int) error {
+	res, err := r.db.ExecContext(ctx, revokePersonalTokenQuery, userID, id)
+	if err != nil {
+		return errors.Wrap(err, "exec context")
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "rows affected")
+	}
+	if n == 0 {
+		return token.ErrPersonalNotFound
+	}
+
+	return nil
+}
+
+const touchPersonalTokenQuery = `UPDATE personal_tokens SET last_used_at = $2 WHERE id = $1`
+
+// TouchPersonalToken keeps the time the personal access token was last used.
+func (r *Repository) TouchPersonalToken(ctx context.Context, id int, now time.Time) error {
+	if _, err := r.db.ExecContext(ctx, touchPersonalTokenQuery, id, now.UTC()); err != nil {
+		return errors.Wrap(err, "exec context")
+	}
+
+	return nil
+}
+
+const (
+	revokeTokenQuery        = `INSERT INTO revoked_tokens (jti, expires_at) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	purgeRevokedTokensQuery = `DELETE FROM revoked_tokens WHERE expires_at < $1`
+)
+
+// RevokeToken revokes the access token until it expires.
+// Already expired tokens are cleaned up on the way.
+func (r *Repository) RevokeToken(ctx context.Context, id string, expiresAt time.Time) error {
+	if _, err := r.db.ExecContext(ctx, revokeTokenQuery, id, expiresAt.UTC()); err != nil {
+		return errors.Wrap(err, "exec context")
+	}
+
+	if _, err := r.db.ExecContext(ctx, purgeRevokedTokensQuery, time.Now().UTC()); err != nil {
+		return errors.Wrap(err, "purge revoked tokens")
+	}
+
+	return nil
+}
+
+const tokenRevokedQuery = `SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1)`
+
+// TokenRevoked checks that the access token is revoked.
+func (r *Repository) TokenRevoked(ctx context.Context, id string) (bool, error) {
+	var revoked bool
+	if err := r.db.QueryRowContext(ctx, tokenRevokedQuery, id).Scan(&revoked); err != nil {
+		return false, errors.Wrap(err, "query row scan")
+	}
+
+	return revoked, nil
+}
+
+const revokeSubjectQuery = `UPDATE users SET tokens_valid_after = $2 WHERE username = $1`
+
+// RevokeSubject revokes all access tokens of the user issued before after.
+func (r *Repository) RevokeSubject(ctx context.Context, subject string, after time.Time) error {
+	if _, err := r.db.ExecContext(ctx, revokeSubjectQuery, subject, after.UTC()); err != nil {
+		return errors.Wrap(err, "exec context")
+	}
+
+	return nil
+}
+
+const subjectValidAfterQuery = `SELECT tokens_valid_after FROM users WHERE username = $1`
+
+// SubjectValidAfter returns the time before which access tokens
+// of the user are revoked. Zero time means none are.
+func (r *Repository) SubjectValidAfter(ctx context.Context, subject string) (time.Time, error) {
+	var after *time.Time
+	if err := r.db.QueryRowContext(ctx, subjectValidAfterQuery, subject).Scan(&after); err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, nil
+		}
+		return time.Time{}, errors.Wrap(err, "query row scan")
+	}
+
+	if after == nil {
+		return time.Time{}, nil
+	}
+	return *after, nil
+}
+
+const (
+	failAttemptQuery = `INSERT INTO login_attempts (key, failures, last_failed_at, expires_at) VALUES ($1, 1, $2, $3)
+ON CONFLICT (key) DO UPDATE SET
+	failures = CASE WHEN login_attempts.expires_at > $2 THEN login_attempts.failures + 1 ELSE 1 END,
+	last_failed_at = $2, expires_at = $3
+RETURNING failures`
+	purgeAttemptsQuery = `DELETE FROM login_attempts WHERE expires_at < $1`
+)
+
+// FailAttempt records the failed attempt and returns the number of
+// failures of the key. Expired attempts are cleaned up on the way.
+// Times are kept in UTC, since the columns have no time zone.
+func (r *Repository) FailAttempt(ctx context.Context, key string, now, expiresAt time.Time) (int, error) {
+	now, expiresAt = now.UTC(), expiresAt.UTC()
+
+	var failures int
+	if err := r.db.QueryRowContext(ctx, failAttemptQuery, key, now, expiresAt).Scan(&failures); err != nil {
+		return 0, errors.Wrap(err, "query row scan")
+	}
+
+	if _, err := r.db.ExecContext(ctx, purgeAttemptsQuery, now); err != nil {
+		return 0, errors.Wrap(err, "purge attempts")
+	}
+
+	return failures, nil
+}
+
+const attemptsQuery = `SELECT failures, last_failed_at FROM login_attempts WHERE key = $1 AND expires_at > $2`
+
+// Attempts returns the number of failures of the key
+// and the time of the last one.
+func (r *Repository) Attempts(ctx context.Context, key string, now time.Time) (int, time.Time, error) {
+	var (
+		failures int
+		last     time.Time
+	)
+	if err := r.db.QueryRowContext(ctx, attemptsQuery, key, now.UTC()).Scan(&failures, &last); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, time.Time{}, nil
+		}
+		return 0, time.Time{}, errors.Wrap(err, "query row scan")
+	}
+
+	return failures, last, nil
+}
+
+const resetAttemptsQuery = `DELETE FROM login_attempts WHERE key = $1`
+
+// ResetAttempts forgets failures of the key.
+func (r *Repository) ResetAttempts(ctx context.Context, key string) error {
+	if _, err := r.db.ExecContext(ctx, resetAttemptsQuery, key); err != nil {
+		return errors.Wrap(err, "exec context")
+	}
+
+	return nil
+}
+
+const (
+	saveTOTPQuery = `INSERT INTO totp (user_id, secret) VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET secret = $2, last_step = 0, enabled_at = NULL, created_at = now()`
+	clearRecoveryCodesQuery = `DELETE FROM recovery_codes WHERE user_id = $1`
+	createRecoveryCodeQuery = `INSERT INTO recovery_codes (user_id, hash) VALUES ($1, $2)`
+)
+
+// SaveTOTP replaces the two-factor secret and recovery codes of the
+// user. The secret is disabled until it is enabled by a valid code.
+func (r *Repository) SaveTOTP(ctx context.Context, userID int, secret string, codeHashes []string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin tx")
+	}
+
+	if _, err := tx.ExecContext(ctx, saveTOTPQuery, userID, secret); err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "save totp")
+	}
+
+	if _, err := tx.ExecContext(ctx, clearRecoveryCodesQuery, userID); err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "clear recovery codes")
+	}
+
+	for _, hash := range codeHashes {
+		if _, err := tx.ExecContext(ctx, createRecoveryCodeQuery, userID, hash); err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "create recovery code")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "commit tx")
+	}
+
+	return nil
+}
+
+const findTOTPQuery = `SELECT user_id, secret, last_step, enabled_at, created_at FROM totp WHERE user_id = $1`
+
+// FindTOTP finds the two-factor secret of the user.
+func (r *Repository) FindTOTP(ctx context.Context, userID int) (*user.TOTP, error) {
+	var t user.TOTP
+	if err := r.db.QueryRowContext(ctx, findTOTPQuery, userID).
+		Scan(&t.UserID, &t.Secret, &t.LastStep, &t.EnabledAt, &t.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, user.ErrTOTPNotFound
+		}
+		return nil, errors.Wrap(err, "query row scan")
+	}
+
+	return &t, nil
+}
+
+const enableTOTPQuery = `UPDATE totp SET enabled_at = now(), last_step = $2 WHERE user_id = $1 AND enabled_at IS NULL`
+
+// EnableTOTP enables the two-factor secret of the user
+// with the step of the code which confirmed it.
+func (r *Repository) EnableTOTP(ctx context.Context, userID int, step int64) error {
+	if _, err := r.db.ExecContext(ctx, enableTOTPQuery, userID, step); err != nil {
+		return errors.Wrap(err, "exec context")
+	}
+
+	return nil
+}
+
+const useTOTPStepQuery = `UPDATE totp SET last_step = $2 WHERE user_id = $1 AND last_step < $2`
+
+// UseTOTPStep marks the step of the code as used. It returns
+// false when the step or a later one was already used.
+func (r *Repository) UseTOTPStep(ctx context.Context, userID int, step int64) (bool, error) {
+	res, err := r.db.ExecContext(ctx, useTOTPStepQuery, userID, step)
+	if err != nil {
+		return false, errors.Wrap(err, "exec context")
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "rows affected")
+	}
+
+	return n > 0, nil
+}
+
+const useRecoveryCodeQuery = `UPDATE recovery_codes SET used_at = now() WHERE id = (SELECT id FROM recovery_codes WHERE user_id = $1 AND hash = $2 AND used_at IS NULL LIMIT 1)`
+
+// UseRecoveryCode marks the recovery code as used. It returns
+// false when the user has no such unused code.
+func (r *Repository) UseRecoveryCode(ctx context.Context, userID int, hash string) (bool, error) {
+	res, err := r.db.ExecContext(ctx, useRecoveryCodeQuery, userID, hash)
+	if err != nil {
+		return false, errors.Wrap(err, "exec context")
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "rows affected")
+	}
+
+	return n > 0, nil
+}
+
+const (
+	saveLoginStateQuery   = `INSERT INTO login_states (state, provider, verifier, nonce, username, expires_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	purgeLoginStatesQuery = `DELETE FROM login_states WHERE expires_at < $1`
+)
+
+// SaveLoginState saves the pending sign in with the provider.
+// Expired states are cleaned up on the way.
+func (r *Repository) SaveLoginState(ctx context.Context, s *oidc.State) error {
+	if _, err := r.db.ExecContext(ctx, saveLoginStateQuery, s.State, s.Provider, s.Verifier, s.Nonce, s.Username, s.ExpiresAt.UTC()); err != nil {
+		return errors.Wrap(err, "exec context")
+	}
+
+	if _, err := r.db.ExecContext(ctx, purgeLoginStatesQuery, time.Now().UTC()); err != nil {
+		return errors.Wrap(err, "purge login states")
+	}
+
+	return nil
+}
+
+const takeLoginStateQuery = `DELETE FROM login_states WHERE state = $1 AND expires_at > $2 RETURNING state, provider, verifier, nonce, username, expires_at`
+
+// TakeLoginState deletes the pending sign in and returns it,
+// so every state is used once.
+func (r *Repository) TakeLoginState(ctx context.Context, state string, now time.Time) (*oidc.State, error) {
+	var s oidc.State
+	if err := r.db.QueryRowContext(ctx, takeLoginStateQuery, state, now.UTC()).
+		Scan(&s.State, &s.Provider, &s.Verifier, &s.Nonce, &s.Username, &s.ExpiresAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, oidc.ErrInvalidLogin
+		}
+		return nil, errors.Wrap(err, "query row scan")
+	}
+
+	return &s, nil
+}
+
+const findIdentityQuery = `SELECT id, user_id, provider, subject, email, created_at FROM identities WHERE provider = $1 AND subject = $2`
+
+// FindIdentity finds the identity of the provider by subject.
+func (r *Repository) FindIdentity(ctx context.Context, provider, subject string) (*user.Identity, error) {
+	var i user.Identity
+	if err := r.db.QueryRowContext(ctx, findIdentityQuery, provider, subject).
+		Scan(&i.ID, &i.UserID, &i.Provider, &i.Subject, &i.Email, &i.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, user.ErrIdentityNotFound
+		}
+		return nil, errors.Wrap(err, "query row scan")
+	}
+
+	return &i, nil
+}
+
+const listUserIdentitiesQuery = `SELECT id, user_id, provider, subject, email, created_at FROM identities WHERE user_id = $1 ORDER BY created_at, id`
+
+// ListUserIdentities finds identities linked to the user.
+func (r *Repository) ListUserIdentities(ctx context.Context, userID int) ([]user.Identity, error) {
+	rows, err := r.db.QueryxContext(ctx, listUserIdentitiesQuery, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "query rows")
+	}
+	defer rows.Close()
+
+	is := make([]user.Identity, 0)
+
+	for rows.Next() {
+		var i user.Identity
+		if err := rows.Scan(&i.ID, &i.UserID, &i.Provider, &i.Subject, &i.Email, &i.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "query row scan on loop")
+		}
+		is = append(is, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+
+	return is, nil
+}
+
+const createIdentityQuery = `INSERT INTO identities (user_id, provider, subject, email) VALUES ($1, $2, $3, $4)
+ON CONFLICT (provider, subject) DO NOTHING RETURNING id, created_at`
+
+// CreateIdentity links the identity to the user.
+func (r *Repository) CreateIdentity(ctx context.Context, i *user.Identity) error {
+	if err := r.db.QueryRowContext(ctx, createIdentityQuery, i.UserID, i.Provider, i.Subject, i.Email).
+		Scan(&i.ID, &i.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return user.ErrIdentityExists
+		}
+		return errors.Wrap(err, "query row scan")
+	}
+
+	return nil
+}
+
+const createCommentQuery = `INSERT INTO comments (post_id, user_id, parent_id, body) VALUES ($1, $2, NULLIF($3, 0), $4) RETURNING id, post_id, user_id, COALESCE(parent_id, 0), body, created_at, updated_at`
+
+// CreateComment inserts a comment into a database.
+func (r *Repository) CreateComment(ctx context.Context, f *comment.NewComment, c *comment.Comment) error {
+	if err := r.db.QueryRowContext(ctx, createCommentQuery, f.PostID, f.UserID, f.ParentID, f.Body).
+		Scan(&c.ID, &c.PostID, &c.UserID, &c.ParentID, &c.Body, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return errors.Wrap(err, "query scan error")
+	}
+
+	return nil
+}
+
+const findCommentQuery = `SELECT id, post_id, user_id, COALESCE(parent_id, 0), body, created_at, updated_at FROM comments WHERE id = $1`
+
+// FindComment finds comment by id.
+func (r *Repository) FindComment(ctx context.Context, id int) (*comment.Comment, error) {
+	var c comment.Comment
+	if err := r.db.QueryRowContext(ctx, findCommentQuery, id).
+		Scan(&c.ID, &c.PostID, &c.UserID, &c.ParentID, &c.Body, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, comment.ErrNotFound
+		}
+		return nil, errors.Wrap(err, "query row scan")
+	}
+	return &c, nil
+}
+
+const listCommentsQuery = `SELECT id, post_id, user_id, COALESCE(parent_id, 0), body, created_at, updated_at FROM comments WHERE post_id = $1 ORDER BY created_at, id`
+
+// ListComments shows all comments of the post.
+func (r *Repository) ListComments(ctx context.Context, postID int, cs *comment.Comments) error {
+	return r.listComments(ctx, cs, listCommentsQuery, postID)
+}
+
+const listUserCommentsQuery = `SELECT id, post_id, user_id, COALESCE(parent_id, 0), body, created_at, updated_at FROM comments WHERE user_id = $1 AND id > $2 ORDER BY id LIMIT $3`
+
+// ListUserComments shows a page of comments of the user
+// which follow the comment with afterID.
+func (r *Repository) ListUserComments(ctx context.Context, userID, afterID, limit int, cs *comment.Comments) error {
+	return r.listComments(ctx, cs, listUserCommentsQuery, userID, afterID, limit)
+}
+
+// listComments scans comments selected by the query.
+func (r *Repository) listComments(ctx context.Context, cs *comment.Comments, query string, args ...interface{}) error {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "query rows")
+	}
+	defer rows.Close()
+
+	comments := make([]comment.Comment, 0)
+
+	for rows.Next() {
+		var c comment.Comment
+		if err := rows.Scan(&c.ID, &c.PostID, &c.UserID, &c.ParentID, &c.Body, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return errors.Wrap(err, "query row scan on loop")
+		}
+		comments = append(comments, c)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "rows")
+	}
+	cs.Comments = comments
+
+	return nil
+}
+
+const updateCommentQuery = `UPDATE comments SET body=:body, updated_at=now() WHERE id=:id`
+
+// UpdateComment updates comment by id.
+func (r *Repository) UpdateComment(ctx context.Context, id int, c *comment.Comment) error {
+	stmt, err := r.db.PrepareNamed(updateCommentQuery)
+	if err != nil {
+		return errors.Wrap(err, "prepare named")
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, map[string]interface{}{
+		"id":   id,
+		"body": c.Body,
+	}); err != nil {
+		return errors.Wrap(err, "exec context")
+	}
+
+	return nil
+}
+
+const deleteCommentQuery = `DELETE FROM comments WHERE id=:id`
+
+// DeleteComment deletes comment by id. Replies are
+// deleted by the database cascade.
+func (r *Repository) DeleteComment(ctx context.Context, id int) error {
+	stmt, err := r.db.PrepareNamed(deleteCommentQuery)
+	if err != nil {
+		return errors.Wrap(err, "prepare named")
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, map[string]interface{}{
+		"id": id,
+	}); err != nil {
+		return errors.Wrap(err, "exec context")
+	}
 	return nil
 }
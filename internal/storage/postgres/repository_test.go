@@ -2,10 +2,19 @@ package postgres
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/dipress/blog/internal/comment"
+	"github.com/dipress/blog/internal/oidc"
 	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/revision"
+	"github.com/dipress/blog/internal/sitemap"
+	"github.com/dipress/blog/internal/tag"
+	"github.com/dipress/blog/internal/token"
 	"github.com/dipress/blog/internal/user"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -94,7 +103,7 @@ func TestUpdatePost(t *testing.T) {
 
 		t.Log("\ttest:0\tshould update the post into the database")
 		{
-			err := r.UpdatePost(ctx, 1, &post)
+			err := r.UpdatePost(ctx, 1, &post, nil)
 			if err != nil {
 				t.Errorf("unexpected error: %v", err)
 			}
@@ -102,6 +111,205 @@ func TestUpdatePost(t *testing.T) {
 	}
 }
 
+func TestPostFormat(t *testing.T) {
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		t.Log("\ttest:0\tshould default to the plain format")
+		{
+			np := post.NewPost{
+				UserID: 21,
+				Title:  "post title",
+				Body:   "post body",
+			}
+			var p post.Post
+			err := r.CreatePost(ctx, &np, &p)
+			assert.Nil(t, err)
+			assert.Equal(t, post.FormatPlain, p.Format)
+		}
+
+		t.Log("\ttest:1\tshould keep the rendered body")
+		{
+			np := post.NewPost{
+				UserID:   21,
+				Title:    "post title",
+				Body:     "*post* body",
+				Format:   post.FormatMarkdown,
+				BodyHTML: "<p><em>post</em> body</p>",
+			}
+			var p post.Post
+			err := r.CreatePost(ctx, &np, &p)
+			assert.Nil(t, err)
+
+			p.Body = "**post** body"
+			p.BodyHTML = "<p><strong>post</strong> body</p>"
+			err = r.UpdatePost(ctx, p.ID, &p, nil)
+			assert.Nil(t, err)
+
+			got, err := r.FindPost(ctx, p.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, post.FormatMarkdown, got.Format)
+			assert.Equal(t, "<p><strong>post</strong> body</p>", got.BodyHTML)
+		}
+	}
+}
+
+func TestPostSlugs(t *testing.T) {
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		create := func(title string) post.Post {
+			np := post.NewPost{
+				UserID: 22,
+				Title:  title,
+				Slug:   post.Slug(title),
+				Body:   "post body",
+			}
+			var p post.Post
+			err := r.CreatePost(ctx, &np, &p)
+			assert.Nil(t, err)
+			return p
+		}
+
+		t.Log("\ttest:0\tshould suffix taken slugs")
+		{
+			first := create("Slug title")
+			second := create("Slug title")
+			assert.Equal(t, "slug-title", first.Slug)
+			assert.Equal(t, "slug-title-2", second.Slug)
+		}
+
+		t.Log("\ttest:1\tshould find the post by the former slug")
+		{
+			p := create("Former title")
+			p.Title = "Current title"
+			p.Slug = post.Slug(p.Title)
+			err := r.UpdatePost(ctx, p.ID, &p, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, "current-title", p.Slug)
+
+			got, err := r.FindPostBySlug(ctx, "former-title")
+			assert.Nil(t, err)
+			assert.Equal(t, p.ID, got.ID)
+			assert.Equal(t, "current-title", got.Slug)
+
+			_, err = r.FindPostBySlug(ctx, "unknown-title")
+			assert.Equal(t, post.ErrNotFound, err)
+		}
+
+		t.Log("\ttest:2\tshould not give former slugs to other posts")
+		{
+			p := create("Former title")
+			assert.Equal(t, "former-title-2", p.Slug)
+		}
+
+		t.Log("\ttest:3\tshould give concurrently created posts different slugs")
+		{
+			slugs := make(chan string, slugAttempts)
+			var wg sync.WaitGroup
+			for i := 0; i < slugAttempts; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					slugs <- create("Race title").Slug
+				}()
+			}
+			wg.Wait()
+			close(slugs)
+
+			got := make(map[string]bool)
+			for s := range slugs {
+				got[s] = true
+			}
+			assert.Equal(t, map[string]bool{"race-title": true, "race-title-2": true, "race-title-3": true}, got)
+		}
+
+		t.Log("\ttest:4\tshould backfill slugs of titles in other alphabets")
+		{
+			p := create("Привет, мир")
+			_, err := db.Exec(`UPDATE posts SET slug = 'post' WHERE id = $1`, p.ID)
+			assert.Nil(t, err)
+			_, err = db.Exec(`INSERT INTO post_slugs_backfill (post_id) VALUES ($1)`, p.ID)
+			assert.Nil(t, err)
+
+			n, err := r.BackfillPostSlugs(ctx)
+			assert.Nil(t, err)
+			assert.Equal(t, int64(1), n)
+
+			got, err := r.FindPostBySlug(ctx, "post")
+			assert.Nil(t, err)
+			assert.Equal(t, p.ID, got.ID)
+			assert.Equal(t, "privet-mir", got.Slug)
+
+			n, err = r.BackfillPostSlugs(ctx)
+			assert.Nil(t, err)
+			assert.Equal(t, int64(0), n)
+		}
+	}
+}
+
+func TestSitemapPosts(t *testing.T) {
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		create := func(title, status, canonicalURL string) {
+			np := post.NewPost{
+				UserID:       23,
+				Title:        title,
+				Slug:         post.Slug(title),
+				Body:         "post body",
+				CanonicalURL: canonicalURL,
+				Status:       status,
+			}
+			var p post.Post
+			err := r.CreatePost(ctx, &np, &p)
+			assert.Nil(t, err)
+			assert.Equal(t, canonicalURL, p.CanonicalURL)
+		}
+
+		create("Sitemap published", post.StatusPublished, "")
+		create("Sitemap draft", post.StatusDraft, "")
+		create("Sitemap canonical", post.StatusPublished, "https://example.com/original")
+
+		t.Log("\ttest:0\tshould list published posts without canonical urls")
+		{
+			count, err := r.CountSitemapPosts(ctx)
+			assert.Nil(t, err)
+
+			var entries []sitemap.Entry
+			err = r.ListSitemapPosts(ctx, 0, count, &entries)
+			assert.Nil(t, err)
+			assert.Len(t, entries, count)
+
+			slugs := make([]string, 0, len(entries))
+			for _, e := range entries {
+				slugs = append(slugs, e.Slug)
+			}
+			assert.Contains(t, slugs, "sitemap-published")
+			assert.NotContains(t, slugs, "sitemap-draft")
+			assert.NotContains(t, slugs, "sitemap-canonical")
+		}
+	}
+}
+
 func TestDeletePost(t *testing.T) {
 	t.Log("with initialized repository")
 	{
@@ -110,66 +318,1160 @@ func TestDeletePost(t *testing.T) {
 		r := NewRepository(db)
 
 		np := post.NewPost{
-			UserID: 4,
+			UserID: 4,
+			Title:  "post title",
+			Body:   "post body",
+		}
+		var post post.Post
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		err := r.CreatePost(ctx, &np, &post)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould delete the post into the database")
+		{
+			err := r.DeletePost(ctx, 1)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}
+	}
+}
+
+func TestTrash(t *testing.T) {
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		np := post.NewPost{
+			UserID: 4,
+			Title:  "post title",
+			Body:   "post body",
+		}
+		var p post.Post
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		err := r.CreatePost(ctx, &np, &p)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould move the post to the trash")
+		{
+			err := r.DeletePost(ctx, p.ID)
+			assert.Nil(t, err)
+
+			_, err = r.FindPost(ctx, p.ID)
+			assert.Equal(t, post.ErrNotFound, err)
+
+			var posts post.Posts
+			err = r.ListPost(ctx, &post.Filter{UserID: 4, Deleted: true}, &posts)
+			assert.Nil(t, err)
+			assert.Len(t, posts.Posts, 1)
+			assert.NotNil(t, posts.Posts[0].DeletedAt)
+		}
+
+		t.Log("\ttest:1\tshould restore the post from the trash")
+		{
+			_, err := r.FindDeletedPost(ctx, p.ID)
+			assert.Nil(t, err)
+
+			err = r.RestorePost(ctx, p.ID)
+			assert.Nil(t, err)
+
+			_, err = r.FindPost(ctx, p.ID)
+			assert.Nil(t, err)
+		}
+
+		t.Log("\ttest:2\tshould purge old posts from the trash")
+		{
+			err := r.DeletePost(ctx, p.ID)
+			assert.Nil(t, err)
+
+			n, err := r.PurgePosts(ctx, time.Now().Add(time.Minute))
+			assert.Nil(t, err)
+			assert.Equal(t, int64(1), n)
+
+			_, err = r.FindDeletedPost(ctx, p.ID)
+			assert.Equal(t, post.ErrNotFound, err)
+		}
+	}
+}
+
+func TestListPost(t *testing.T) {
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		np := post.NewPost{
+			UserID: 5,
+			Title:  "post title",
+			Body:   "post body",
+		}
+		var p post.Post
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		err := r.CreatePost(ctx, &np, &p)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould show list of posts into the database")
+		{
+			var posts post.Posts
+			err := r.ListPost(ctx, &post.Filter{}, &posts)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if len(posts.Posts) == 0 {
+				t.Error("expected to slice of posts")
+			}
+		}
+
+		t.Log("\ttest:1\tshould filter posts by user")
+		{
+			var posts post.Posts
+			err := r.ListPost(ctx, &post.Filter{UserID: 5}, &posts)
+			assert.Nil(t, err)
+
+			for _, p := range posts.Posts {
+				assert.Equal(t, 5, p.UserID)
+			}
+		}
+
+		t.Log("\ttest:2\tshould show posts after cursor")
+		{
+			var posts post.Posts
+			err := r.ListPost(ctx, &post.Filter{
+				Limit:          1,
+				AfterCreatedAt: p.CreatedAt,
+				AfterID:        p.ID,
+			}, &posts)
+			assert.Nil(t, err)
+
+			for _, item := range posts.Posts {
+				assert.NotEqual(t, p.ID, item.ID)
+			}
+		}
+	}
+}
+
+func TestListTags(t *testing.T) {
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		np := post.NewPost{
+			UserID: 5,
+			Title:  "post title",
+			Body:   "post body",
+			Tags:   []string{"go", "web development"},
+		}
+		var p post.Post
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		err := r.CreatePost(ctx, &np, &p)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould show list of tags into the database")
+		{
+			var tags tag.Tags
+			err := r.ListTags(ctx, &tags)
+			assert.Nil(t, err)
+			assert.Len(t, tags.Tags, 2)
+			assert.Equal(t, "web-development", tags.Tags[1].Slug)
+			assert.Equal(t, 1, tags.Tags[1].Posts)
+		}
+
+		t.Log("\ttest:1\tshould filter posts by tag slug")
+		{
+			var posts post.Posts
+			err := r.ListPost(ctx, &post.Filter{Tag: "web-development"}, &posts)
+			assert.Nil(t, err)
+			assert.Len(t, posts.Posts, 1)
+			assert.Equal(t, []string{"go", "web development"}, posts.Posts[0].Tags)
+		}
+
+		t.Log("\ttest:2\tshould replace post tags on update")
+		{
+			p.Tags = []string{"rust"}
+			err := r.UpdatePost(ctx, p.ID, &p, nil)
+			assert.Nil(t, err)
+
+			found, err := r.FindPost(ctx, p.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"rust"}, found.Tags)
+		}
+
+		t.Log("\ttest:3\tshould keep tags with symbols apart")
+		{
+			p.Tags = []string{"c++", "c#", "c"}
+			err := r.UpdatePost(ctx, p.ID, &p, nil)
+			assert.Nil(t, err)
+
+			found, err := r.FindPost(ctx, p.ID)
+			assert.Nil(t, err)
+			assert.ElementsMatch(t, []string{"c++", "c#", "c"}, found.Tags)
+
+			var posts post.Posts
+			err = r.ListPost(ctx, &post.Filter{Tag: "c-sharp"}, &posts)
+			assert.Nil(t, err)
+			assert.Len(t, posts.Posts, 1)
+		}
+
+		t.Log("\ttest:4\tshould reject tags with the slug of another tag")
+		{
+			p.Tags = []string{"c plus plus"}
+			err := r.UpdatePost(ctx, p.ID, &p, nil)
+			assert.Equal(t, &tag.SlugError{Name: "c plus plus", Taken: "c++"}, errors.Cause(err))
+		}
+	}
+}
+
+func TestRevisions(t *testing.T) {
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		np := post.NewPost{
+			UserID: 5,
+			Title:  "post title",
+			Body:   "post body",
+		}
+		var p post.Post
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		err := r.CreatePost(ctx, &np, &p)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould number revisions of the post")
+		{
+			for i := 1; i <= 2; i++ {
+				nr := revision.NewRevision{
+					PostID: p.ID,
+					UserID: 5,
+					Title:  p.Title,
+					Body:   p.Body,
+				}
+				var rev revision.Revision
+				err := r.CreateRevision(ctx, &nr, &rev)
+				assert.Nil(t, err)
+				assert.Equal(t, i, rev.Number)
+			}
+		}
+
+		t.Log("\ttest:1\tshould show revisions newest first")
+		{
+			var revisions revision.Revisions
+			err := r.ListRevisions(ctx, p.ID, &revisions)
+			assert.Nil(t, err)
+			assert.Len(t, revisions.Revisions, 2)
+			assert.Equal(t, 2, revisions.Revisions[0].Number)
+		}
+
+		t.Log("\ttest:2\tshould find the revision by number")
+		{
+			rev, err := r.FindRevision(ctx, p.ID, 1)
+			assert.Nil(t, err)
+			assert.Equal(t, "post body", rev.Body)
+
+			_, err = r.FindRevision(ctx, p.ID, 3)
+			assert.Equal(t, revision.ErrNotFound, err)
+		}
+
+		t.Log("\ttest:3\tshould keep the revision only with the update")
+		{
+			nr := revision.NewRevision{
+				PostID: p.ID,
+				UserID: 5,
+				Title:  p.Title,
+				Body:   p.Body,
+			}
+
+			p.Tags = []string{"c++"}
+			err := r.UpdatePost(ctx, p.ID, &p, &nr)
+			assert.Nil(t, err)
+
+			p.Tags = []string{"c plus plus"}
+			err = r.UpdatePost(ctx, p.ID, &p, &nr)
+			assert.Error(t, err)
+
+			var revisions revision.Revisions
+			err = r.ListRevisions(ctx, p.ID, &revisions)
+			assert.Nil(t, err)
+			assert.Len(t, revisions.Revisions, 3)
+		}
+	}
+}
+
+func TestSearchPosts(t *testing.T) {
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		np := post.NewPost{
+			UserID: 5,
+			Title:  "Concurrency in Go",
+			Body:   "Goroutines and channels make concurrent programs simple.",
+		}
+		var p post.Post
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		err := r.CreatePost(ctx, &np, &p)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould find posts by keyword")
+		{
+			var hits post.Hits
+			err := r.SearchPosts(ctx, &post.SearchFilter{Query: "channels", Limit: 10}, &hits)
+			assert.Nil(t, err)
+			assert.Len(t, hits.Hits, 1)
+			assert.Equal(t, p.ID, hits.Hits[0].ID)
+			assert.Contains(t, hits.Hits[0].Snippet, "<mark>channels</mark>")
+		}
+
+		t.Log("\ttest:1\tshould not find posts without keyword")
+		{
+			var hits post.Hits
+			err := r.SearchPosts(ctx, &post.SearchFilter{Query: "python", Limit: 10}, &hits)
+			assert.Nil(t, err)
+			assert.Len(t, hits.Hits, 0)
+		}
+
+		t.Log("\ttest:2\tshould escape markup of the body in snippets")
+		{
+			np := post.NewPost{
+				UserID: 5,
+				Title:  "Markup",
+				Body:   `Selectors <script>alert(1)</script> <b>are</b> escaped.`,
+			}
+			var p post.Post
+			err := r.CreatePost(ctx, &np, &p)
+			assert.Nil(t, err)
+
+			var hits post.Hits
+			err = r.SearchPosts(ctx, &post.SearchFilter{Query: "selectors", Limit: 10}, &hits)
+			assert.Nil(t, err)
+			assert.Len(t, hits.Hits, 1)
+			assert.NotContains(t, hits.Hits[0].Snippet, "<script>")
+			assert.NotContains(t, hits.Hits[0].Snippet, "<b>")
+			assert.Contains(t, hits.Hits[0].Snippet, "&lt;script&gt;")
+			assert.Contains(t, hits.Hits[0].Snippet, "<mark>Selectors</mark>")
+		}
+	}
+}
+
+func TestPublishScheduled(t *testing.T) {
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		publishedAt := time.Now().Add(-time.Minute)
+		np := post.NewPost{
+			UserID:      5,
+			Title:       "post title",
+			Body:        "post body",
+			Status:      post.StatusScheduled,
+			PublishedAt: &publishedAt,
+		}
+		var p post.Post
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		err := r.CreatePost(ctx, &np, &p)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould publish scheduled posts")
+		{
+			n, err := r.PublishScheduled(ctx, time.Now())
+			assert.Nil(t, err)
+			assert.Equal(t, int64(1), n)
+
+			found, err := r.FindPost(ctx, p.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, post.StatusPublished, found.Status)
+		}
+
+		t.Log("\ttest:1\tshould hide drafts from published list")
+		{
+			np.Status = post.StatusDraft
+			np.PublishedAt = nil
+			var d post.Post
+			err := r.CreatePost(ctx, &np, &d)
+			assert.Nil(t, err)
+
+			var posts post.Posts
+			err = r.ListPost(ctx, &post.Filter{Status: post.StatusPublished}, &posts)
+			assert.Nil(t, err)
+
+			for _, item := range posts.Posts {
+				assert.NotEqual(t, d.ID, item.ID)
+			}
+		}
+
+		t.Log("\ttest:2\tshould publish scheduled posts of any time zone on time")
+		{
+			publishedAt := time.Now().Add(time.Hour).Truncate(time.Millisecond).In(time.FixedZone("UTC-10", -10*60*60))
+			np.Status = post.StatusScheduled
+			np.PublishedAt = &publishedAt
+			var s post.Post
+			err := r.CreatePost(ctx, &np, &s)
+			assert.Nil(t, err)
+			assert.True(t, publishedAt.Equal(*s.PublishedAt))
+
+			n, err := r.PublishScheduled(ctx, time.Now().UTC())
+			assert.Nil(t, err)
+			assert.Equal(t, int64(0), n)
+
+			n, err = r.PublishScheduled(ctx, publishedAt.Add(time.Minute).UTC())
+			assert.Nil(t, err)
+			assert.Equal(t, int64(1), n)
+		}
+	}
+}
+
+func TestCreateUser(t *testing.T) {
+	t.Parallel()
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		t.Log("\ttest:0\tshould insert a new user into the database")
+		{
+			nu := user.NewUser{
+				Username:     "username",
+				Email:        "username@example.com",
+				PasswordHash: "$2y$12$gwoUXq7kCxNcucd.eFxOp.vJYYmo6917fSGuuEowfyNf3E8KySrWC",
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var user user.User
+			_, _, err := r.CreateUser(ctx, &nu, &user)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if user.ID == 0 {
+				t.Error("expected to parse returned id")
+			}
+		}
+	}
+}
+
+func TestUniqueUsername(t *testing.T) {
+	t.Parallel()
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		nu := user.NewUser{
+			Username:     "username1",
+			Email:        "username1@example.com",
+			PasswordHash: "$2y$12$gwoUXq7kCxNcucd.eFxOp.vJYYmo6917fSGuuEowfyNf3E8KySrWC",
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var user user.User
+		_, _, err := r.CreateUser(ctx, &nu, &user)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		t.Log("\ttest:0\tshould return error")
+		{
+			err := r.UniqueUsername(ctx, "username1")
+			assert.Error(t, err, "username already exists")
+		}
+		t.Log("\ttest:1\tshould return nil")
+		{
+			err := r.UniqueUsername(ctx, "username2")
+			assert.Nil(t, err)
+		}
+	}
+}
+
+func TestUniqueEmail(t *testing.T) {
+	t.Parallel()
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		nu := user.NewUser{
+			Username:     "username2",
+			Email:        "username2@example.com",
+			PasswordHash: "$2y$12$gwoUXq7kCxNcucd.eFxOp.vJYYmo6917fSGuuEowfyNf3E8KySrWC",
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var user user.User
+		_, _, err := r.CreateUser(ctx, &nu, &user)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		t.Log("\ttest:0\tshould return error")
+		{
+			err := r.UniqueEmail(ctx, "username2@example.com")
+			assert.Error(t, err, "email already exists")
+		}
+		t.Log("\ttest:1\tshould return nil")
+		{
+			err := r.UniqueEmail(ctx, "username3@example.com")
+			assert.Nil(t, err)
+		}
+	}
+}
+
+func TestFindByEmail(t *testing.T) {
+	t.Parallel()
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		nu := user.NewUser{
+			Username:     "username5",
+			Email:        "username5@example.com",
+			PasswordHash: "$2y$12$gwoUXq7kCxNcucd.eFxOp.vJYYmo6917fSGuuEowfyNf3E8KySrWC",
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var user user.User
+		_, _, err := r.CreateUser(ctx, &nu, &user)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould find user by email")
+		{
+			err := r.FindByEmail(ctx, user.Email, &user)
+			assert.Nil(t, err)
+		}
+	}
+}
+
+func TestFindByUsername(t *testing.T) {
+	t.Parallel()
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		nu := user.NewUser{
+			Username:     "username6",
+			Email:        "username6@example.com",
+			PasswordHash: "$2y$12$gwoUXq7kCxNcucd.eFxOp.vJYYmo6917fSGuuEowfyNf3E8KySrWC",
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var user user.User
+		_, _, err := r.CreateUser(ctx, &nu, &user)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould find user by username")
+		{
+			err := r.FindByUsername(ctx, user.Username, &user)
+			assert.Nil(t, err)
+		}
+	}
+}
+
+func TestUpdateRole(t *testing.T) {
+	t.Parallel()
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		nu := user.NewUser{
+			Username:     "username9",
+			Email:        "username9@example.com",
+			PasswordHash: "$2y$12$gwoUXq7kCxNcucd.eFxOp.vJYYmo6917fSGuuEowfyNf3E8KySrWC",
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var u user.User
+		_, _, err := r.CreateUser(ctx, &nu, &u)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould create an author by default")
+		{
+			assert.Equal(t, user.RoleAuthor, u.Role)
+		}
+
+		t.Log("\ttest:1\tshould change the user role")
+		{
+			err := r.UpdateRole(ctx, u.ID, user.RoleEditor)
+			assert.Nil(t, err)
+
+			var got user.User
+			err = r.FindByUsername(ctx, u.Username, &got)
+			assert.Nil(t, err)
+			assert.Equal(t, user.RoleEditor, got.Role)
+		}
+	}
+}
+
+func TestVerifyUser(t *testing.T) {
+	t.Parallel()
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		nu := user.NewUser{
+			Username:     "username12",
+			Email:        "username12@example.com",
+			PasswordHash: "$2y$12$gwoUXq7kCxNcucd.eFxOp.vJYYmo6917fSGuuEowfyNf3E8KySrWC",
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var u user.User
+		_, _, err := r.CreateUser(ctx, &nu, &u)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould create an unverified user")
+		{
+			assert.Nil(t, u.VerifiedAt)
+		}
+
+		t.Log("\ttest:1\tshould verify the user")
+		{
+			err := r.VerifyUser(ctx, u.ID)
+			assert.Nil(t, err)
+
+			var got user.User
+			err = r.FindByID(ctx, u.ID, &got)
+			assert.Nil(t, err)
+			assert.NotNil(t, got.VerifiedAt)
+		}
+
+		t.Log("\ttest:2\tshould change the password hash")
+		{
+			err := r.UpdatePassword(ctx, u.ID, "hash")
+			assert.Nil(t, err)
+
+			var got user.User
+			err = r.FindByID(ctx, u.ID, &got)
+			assert.Nil(t, err)
+			assert.Equal(t, "hash", got.PasswordHash)
+		}
+
+		t.Log("\ttest:3\tshould revoke all refresh tokens of the user")
+		{
+			nr := token.NewRefresh{
+				UserID:    u.ID,
+				Family:    "family12",
+				Hash:      token.Hash("secret12"),
+				ExpiresAt: time.Now().Add(time.Hour),
+			}
+			var rt token.Refresh
+			err := r.CreateRefreshToken(ctx, &nr, &rt)
+			assert.Nil(t, err)
+
+			err = r.RevokeUserRefreshTokens(ctx, u.ID)
+			assert.Nil(t, err)
+
+			got, err := r.FindRefreshToken(ctx, token.Hash("secret12"))
+			assert.Nil(t, err)
+			assert.NotNil(t, got.RevokedAt)
+		}
+	}
+}
+
+func TestRefreshTokens(t *testing.T) {
+	t.Parallel()
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		nu := user.NewUser{
+			Username:     "username10",
+			Email:        "username10@example.com",
+			PasswordHash: "$2y$12$gwoUXq7kCxNcucd.eFxOp.vJYYmo6917fSGuuEowfyNf3E8KySrWC",
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var u user.User
+		_, _, err := r.CreateUser(ctx, &nu, &u)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		var rt token.Refresh
+		t.Log("\ttest:0\tshould insert a refresh token")
+		{
+			nr := token.NewRefresh{
+				UserID:    u.ID,
+				Family:    "family",
+				Hash:      token.Hash("secret"),
+				ExpiresAt: time.Now().Add(time.Hour),
+			}
+			err := r.CreateRefreshToken(ctx, &nr, &rt)
+			assert.Nil(t, err)
+			assert.NotZero(t, rt.ID)
+		}
+
+		t.Log("\ttest:1\tshould find the refresh token by hash")
+		{
+			got, err := r.FindRefreshToken(ctx, token.Hash("secret"))
+			assert.Nil(t, err)
+			assert.Equal(t, rt.ID, got.ID)
+			assert.Nil(t, got.RevokedAt)
+		}
+
+		t.Log("\ttest:2\tshould revoke the refresh token once")
+		{
+			ts, err := r.ListActiveRefreshTokens(ctx, u.ID)
+			assert.Nil(t, err)
+			assert.Len(t, ts, 1)
+
+			err = r.RevokeRefreshToken(ctx, rt.ID)
+			assert.Nil(t, err)
+
+			err = r.RevokeRefreshToken(ctx, rt.ID)
+			assert.Equal(t, token.ErrNotFound, err)
+
+			ts, err = r.ListActiveRefreshTokens(ctx, u.ID)
+			assert.Nil(t, err)
+			assert.Empty(t, ts)
+		}
+
+		t.Log("\ttest:3\tshould tell the token rotated while its family is active")
+		{
+			rotated, err := r.RecentlyRotated(ctx, rt.ID, time.Minute)
+			assert.Nil(t, err)
+			assert.False(t, rotated)
+
+			nr := token.NewRefresh{
+				UserID:    u.ID,
+				Family:    "family",
+				Hash:      token.Hash("next"),
+				ExpiresAt: time.Now().Add(time.Hour),
+			}
+			var next token.Refresh
+			err = r.CreateRefreshToken(ctx, &nr, &next)
+			assert.Nil(t, err)
+
+			rotated, err = r.RecentlyRotated(ctx, rt.ID, time.Minute)
+			assert.Nil(t, err)
+			assert.True(t, rotated)
+
+			rotated, err = r.RecentlyRotated(ctx, next.ID, time.Minute)
+			assert.Nil(t, err)
+			assert.False(t, rotated)
+
+			err = r.RevokeRefreshFamily(ctx, "family")
+			assert.Nil(t, err)
+
+			rotated, err = r.RecentlyRotated(ctx, rt.ID, time.Minute)
+			assert.Nil(t, err)
+			assert.False(t, rotated)
+		}
+
+		t.Log("\ttest:4\tshould find user by id")
+		{
+			var got user.User
+			err := r.FindByID(ctx, u.ID, &got)
+			assert.Nil(t, err)
+			assert.Equal(t, u.Username, got.Username)
+		}
+	}
+}
+
+func TestRevocations(t *testing.T) {
+	t.Parallel()
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		nu := user.NewUser{
+			Username:     "username11",
+			Email:        "username11@example.com",
+			PasswordHash: "$2y$12$gwoUXq7kCxNcucd.eFxOp.vJYYmo6917fSGuuEowfyNf3E8KySrWC",
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var u user.User
+		_, _, err := r.CreateUser(ctx, &nu, &u)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould revoke the token by id")
+		{
+			err := r.RevokeToken(ctx, "jti", time.Now().Add(time.Hour))
+			assert.Nil(t, err)
+
+			revoked, err := r.TokenRevoked(ctx, "jti")
+			assert.Nil(t, err)
+			assert.True(t, revoked)
+
+			revoked, err = r.TokenRevoked(ctx, "unknown")
+			assert.Nil(t, err)
+			assert.False(t, revoked)
+		}
+
+		t.Log("\ttest:1\tshould revoke all tokens of the user")
+		{
+			// Tokens issued a second before the user was created are revoked,
+			// the ones issued when it's created are valid.
+			after, err := r.SubjectValidAfter(ctx, u.Username)
+			assert.Nil(t, err)
+			assert.Equal(t, u.CreatedAt.Add(-time.Second).Unix(), after.Unix())
+
+			// Tokens are compared with revocation times in milliseconds.
+			now := time.Now().UTC().Truncate(time.Millisecond)
+			err = r.RevokeSubject(ctx, u.Username, now)
+			assert.Nil(t, err)
+
+			after, err = r.SubjectValidAfter(ctx, u.Username)
+			assert.Nil(t, err)
+			assert.True(t, now.Equal(after))
+		}
+
+		t.Log("\ttest:2\tshould keep revocation times of any time zone")
+		{
+			now := time.Now().Truncate(time.Second).In(time.FixedZone("UTC+3", 3*60*60))
+			err := r.RevokeSubject(ctx, u.Username, now)
+			assert.Nil(t, err)
+
+			after, err := r.SubjectValidAfter(ctx, u.Username)
+			assert.Nil(t, err)
+			assert.Equal(t, now.Unix(), after.Unix())
+		}
+	}
+}
+
+func TestCreateComment(t *testing.T) {
+	t.Parallel()
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		np := post.NewPost{
+			UserID: 6,
+			Title:  "post title",
+			Body:   "post body",
+		}
+		var p post.Post
+		if err := r.CreatePost(ctx, &np, &p); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		var parent comment.Comment
+		t.Log("\ttest:0\tshould insert a new comment into the database")
+		{
+			nc := comment.NewComment{
+				PostID: p.ID,
+				UserID: 6,
+				Body:   "comment body",
+			}
+
+			err := r.CreateComment(ctx, &nc, &parent)
+			assert.Nil(t, err)
+			assert.NotZero(t, parent.ID)
+			assert.Zero(t, parent.ParentID)
+		}
+
+		t.Log("\ttest:1\tshould insert a reply into the database")
+		{
+			nc := comment.NewComment{
+				PostID:   p.ID,
+				UserID:   7,
+				ParentID: parent.ID,
+				Body:     "reply body",
+			}
+
+			var reply comment.Comment
+			err := r.CreateComment(ctx, &nc, &reply)
+			assert.Nil(t, err)
+			assert.Equal(t, parent.ID, reply.ParentID)
+		}
+	}
+}
+
+func TestFindComment(t *testing.T) {
+	t.Parallel()
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		np := post.NewPost{
+			UserID: 6,
+			Title:  "post title",
+			Body:   "post body",
+		}
+		var p post.Post
+		if err := r.CreatePost(ctx, &np, &p); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		nc := comment.NewComment{
+			PostID: p.ID,
+			UserID: 6,
+			Body:   "comment body",
+		}
+		var c comment.Comment
+		if err := r.CreateComment(ctx, &nc, &c); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould find the comment into the database")
+		{
+			_, err := r.FindComment(ctx, c.ID)
+			assert.Nil(t, err)
+		}
+
+		t.Log("\ttest:1\tshould return not found error")
+		{
+			_, err := r.FindComment(ctx, c.ID+1)
+			assert.Equal(t, comment.ErrNotFound, err)
+		}
+	}
+}
+
+func TestListComments(t *testing.T) {
+	t.Parallel()
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		np := post.NewPost{
+			UserID: 6,
+			Title:  "post title",
+			Body:   "post body",
+		}
+		var p post.Post
+		if err := r.CreatePost(ctx, &np, &p); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		nc := comment.NewComment{
+			PostID: p.ID,
+			UserID: 6,
+			Body:   "comment body",
+		}
+		var c comment.Comment
+		if err := r.CreateComment(ctx, &nc, &c); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould show list of post comments")
+		{
+			var comments comment.Comments
+			err := r.ListComments(ctx, p.ID, &comments)
+			assert.Nil(t, err)
+			assert.Len(t, comments.Comments, 1)
+		}
+	}
+}
+
+func TestUpdateComment(t *testing.T) {
+	t.Parallel()
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		np := post.NewPost{
+			UserID: 6,
 			Title:  "post title",
 			Body:   "post body",
 		}
-		var post post.Post
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
+		var p post.Post
+		if err := r.CreatePost(ctx, &np, &p); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
 
-		err := r.CreatePost(ctx, &np, &post)
-		if err != nil {
+		nc := comment.NewComment{
+			PostID: p.ID,
+			UserID: 6,
+			Body:   "comment body",
+		}
+		var c comment.Comment
+		if err := r.CreateComment(ctx, &nc, &c); err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
 
-		t.Log("\ttest:0\tshould delete the post into the database")
+		t.Log("\ttest:0\tshould update the comment into the database")
 		{
-			err := r.DeletePost(ctx, 1)
-			if err != nil {
-				t.Errorf("unexpected error: %v", err)
-			}
+			c.Body = "new comment body"
+			err := r.UpdateComment(ctx, c.ID, &c)
+			assert.Nil(t, err)
 		}
 	}
 }
 
-func TestListPost(t *testing.T) {
+func TestDeleteComment(t *testing.T) {
+	t.Parallel()
 	t.Log("with initialized repository")
 	{
 		db, teardown := postgresDB(t)
 		defer teardown()
 		r := NewRepository(db)
 
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
 		np := post.NewPost{
-			UserID: 5,
+			UserID: 6,
 			Title:  "post title",
 			Body:   "post body",
 		}
 		var p post.Post
+		if err := r.CreatePost(ctx, &np, &p); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		nc := comment.NewComment{
+			PostID: p.ID,
+			UserID: 6,
+			Body:   "comment body",
+		}
+		var c comment.Comment
+		if err := r.CreateComment(ctx, &nc, &c); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		t.Log("\ttest:0\tshould delete the comment from the database")
+		{
+			err := r.DeleteComment(ctx, c.ID)
+			assert.Nil(t, err)
+
+			_, err = r.FindComment(ctx, c.ID)
+			assert.Equal(t, comment.ErrNotFound, err)
+		}
+	}
+}
+
+func TestUpdateProfile(t *testing.T) {
+	t.Parallel()
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		nu := user.NewUser{
+			Username:     "username13",
+			Email:        "username13@example.com",
+			PasswordHash: "$2y$12$gwoUXq7kCxNcucd.eFxOp.vJYYmo6917fSGuuEowfyNf3E8KySrWC",
+		}
+
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
-		err := r.CreatePost(ctx, &np, &p)
+		var u user.User
+		_, _, err := r.CreateUser(ctx, &nu, &u)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
 
-		t.Log("\ttest:0\tshould show list of posts into the database")
+		t.Log("\ttest:0\tshould update the profile of the user")
 		{
-			var posts post.Posts
-			err := r.ListPost(ctx, &posts)
-			if err != nil {
-				t.Errorf("unexpected error: %v", err)
+			p := user.Profile{
+				DisplayName: "John",
+				Bio:         "About me",
+				AvatarURL:   "https://example.com/avatar.png",
 			}
+			err := r.UpdateProfile(ctx, u.ID, &p)
+			assert.Nil(t, err)
 
-			if len(posts.Posts) == 0 {
-				t.Error("expected to slice of posts")
-			}
+			var got user.User
+			err = r.FindByUsername(ctx, "username13", &got)
+			assert.Nil(t, err)
+			assert.Equal(t, p.DisplayName, got.DisplayName)
+			assert.Equal(t, p.Bio, got.Bio)
+			assert.Equal(t, p.AvatarURL, got.AvatarURL)
 		}
 	}
 }
 
-func TestCreateUser(t *testing.T) {
+func TestDeleteUser(t *testing.T) {
 	t.Parallel()
 	t.Log("with initialized repository")
 	{
@@ -177,30 +1479,100 @@ func TestCreateUser(t *testing.T) {
 		defer teardown()
 		r := NewRepository(db)
 
-		t.Log("\ttest:0\tshould insert a new user into the database")
-		{
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		create := func(username string) (*user.User, *post.Post) {
 			nu := user.NewUser{
-				Username:     "username",
-				Email:        "username@example.com",
+				Username:     username,
+				Email:        username + "@example.com",
 				PasswordHash: "$2y$12$gwoUXq7kCxNcucd.eFxOp.vJYYmo6917fSGuuEowfyNf3E8KySrWC",
 			}
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
 
-			var user user.User
-			_, _, err := r.CreateUser(ctx, &nu, &user)
-			if err != nil {
-				t.Errorf("unexpected error: %v", err)
+			var u user.User
+			if _, _, err := r.CreateUser(ctx, &nu, &u); err != nil {
+				t.Fatalf("unexpected error: %v", err)
 			}
 
-			if user.ID == 0 {
-				t.Error("expected to parse returned id")
+			np := post.NewPost{
+				UserID: u.ID,
+				Title:  "Title of " + username,
+				Body:   "Body of " + username,
+			}
+
+			var p post.Post
+			if err := r.CreatePost(ctx, &np, &p); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			nc := comment.NewComment{
+				PostID: p.ID,
+				UserID: u.ID,
+				Body:   "Comment of " + username,
 			}
+
+			var c comment.Comment
+			if err := r.CreateComment(ctx, &nc, &c); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			return &u, &p
+		}
+
+		t.Log("\ttest:0\tshould list comments of the user")
+		{
+			u, _ := create("username14")
+
+			var cs comment.Comments
+			err := r.ListUserComments(ctx, u.ID, 0, 10, &cs)
+			assert.Nil(t, err)
+			assert.Len(t, cs.Comments, 1)
+		}
+
+		t.Log("\ttest:1\tshould anonymize the user and keep the content")
+		{
+			u, p := create("username15")
+
+			err := r.AnonymizeUser(ctx, u.ID)
+			assert.Nil(t, err)
+
+			var got user.User
+			err = r.FindByID(ctx, u.ID, &got)
+			assert.Nil(t, err)
+			assert.Empty(t, got.Username)
+			assert.Empty(t, got.Email)
+			assert.Empty(t, got.PasswordHash)
+
+			err = r.FindByUsername(ctx, "username15", &got)
+			assert.Equal(t, user.ErrNotFound, err)
+
+			_, err = r.FindPost(ctx, p.ID)
+			assert.Nil(t, err)
+		}
+
+		t.Log("\ttest:2\tshould delete the user with the content")
+		{
+			u, p := create("username16")
+
+			err := r.DeleteUser(ctx, u.ID)
+			assert.Nil(t, err)
+
+			var got user.User
+			err = r.FindByID(ctx, u.ID, &got)
+			assert.Equal(t, user.ErrNotFound, err)
+
+			_, err = r.FindPost(ctx, p.ID)
+			assert.Equal(t, post.ErrNotFound, err)
+
+			var cs comment.Comments
+			err = r.ListUserComments(ctx, u.ID, 0, 10, &cs)
+			assert.Nil(t, err)
+			assert.Empty(t, cs.Comments)
 		}
 	}
 }
 
-func TestUniqueUsername(t *testing.T) {
+func TestAttempts(t *testing.T) {
 	t.Parallel()
 	t.Log("with initialized repository")
 	{
@@ -208,34 +1580,51 @@ func TestUniqueUsername(t *testing.T) {
 		defer teardown()
 		r := NewRepository(db)
 
-		nu := user.NewUser{
-			Username:     "username1",
-			Email:        "username1@example.com",
-			PasswordHash: "$2y$12$gwoUXq7kCxNcucd.eFxOp.vJYYmo6917fSGuuEowfyNf3E8KySrWC",
-		}
-
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
-		var user user.User
-		_, _, err := r.CreateUser(ctx, &nu, &user)
-		if err != nil {
-			t.Errorf("unexpected error: %v", err)
+		now := time.Now().UTC().Truncate(time.Second)
+
+		t.Log("\ttest:0\tshould count failed attempts")
+		{
+			failures, err := r.FailAttempt(ctx, "account:username17@example.com", now, now.Add(time.Hour))
+			assert.Nil(t, err)
+			assert.Equal(t, 1, failures)
+
+			failures, err = r.FailAttempt(ctx, "account:username17@example.com", now.Add(time.Second), now.Add(time.Hour))
+			assert.Nil(t, err)
+			assert.Equal(t, 2, failures)
+
+			failures, last, err := r.Attempts(ctx, "account:username17@example.com", now.Add(time.Minute))
+			assert.Nil(t, err)
+			assert.Equal(t, 2, failures)
+			assert.Equal(t, now.Add(time.Second).Unix(), last.Unix())
 		}
-		t.Log("\ttest:0\tshould return error")
+
+		t.Log("\ttest:1\tshould forget expired attempts")
 		{
-			err := r.UniqueUsername(ctx, "username1")
-			assert.Error(t, err, "username already exists")
+			failures, _, err := r.Attempts(ctx, "account:username17@example.com", now.Add(2*time.Hour))
+			assert.Nil(t, err)
+			assert.Equal(t, 0, failures)
+
+			failures, err = r.FailAttempt(ctx, "account:username17@example.com", now.Add(2*time.Hour), now.Add(3*time.Hour))
+			assert.Nil(t, err)
+			assert.Equal(t, 1, failures)
 		}
-		t.Log("\ttest:1\tshould return nil")
+
+		t.Log("\ttest:2\tshould reset attempts")
 		{
-			err := r.UniqueUsername(ctx, "username2")
+			err := r.ResetAttempts(ctx, "account:username17@example.com")
+			assert.Nil(t, err)
+
+			failures, _, err := r.Attempts(ctx, "account:username17@example.com", now)
 			assert.Nil(t, err)
+			assert.Equal(t, 0, failures)
 		}
 	}
 }
 
-func TestUniqueEmail(t *testing.T) {
+func TestTOTP(t *testing.T) {
 	t.Parallel()
 	t.Log("with initialized repository")
 	{
@@ -244,33 +1633,74 @@ func TestUniqueEmail(t *testing.T) {
 		r := NewRepository(db)
 
 		nu := user.NewUser{
-			Username:     "username2",
-			Email:        "username2@example.com",
+			Username:     "username18",
+			Email:        "username18@example.com",
 			PasswordHash: "$2y$12$gwoUXq7kCxNcucd.eFxOp.vJYYmo6917fSGuuEowfyNf3E8KySrWC",
 		}
 
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
-		var user user.User
-		_, _, err := r.CreateUser(ctx, &nu, &user)
+		var u user.User
+		_, _, err := r.CreateUser(ctx, &nu, &u)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
-		t.Log("\ttest:0\tshould return error")
+
+		t.Log("\ttest:0\tshould not find the secret")
 		{
-			err := r.UniqueEmail(ctx, "username2@example.com")
-			assert.Error(t, err, "email already exists")
+			_, err := r.FindTOTP(ctx, u.ID)
+			assert.Equal(t, user.ErrTOTPNotFound, err)
 		}
-		t.Log("\ttest:1\tshould return nil")
+
+		t.Log("\ttest:1\tshould save and enable the secret")
 		{
-			err := r.UniqueEmail(ctx, "username3@example.com")
+			err := r.SaveTOTP(ctx, u.ID, "secret", []string{"hash1", "hash2"})
+			assert.Nil(t, err)
+
+			got, err := r.FindTOTP(ctx, u.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, "secret", got.Secret)
+			assert.Nil(t, got.EnabledAt)
+
+			err = r.EnableTOTP(ctx, u.ID, 100)
+			assert.Nil(t, err)
+
+			got, err = r.FindTOTP(ctx, u.ID)
+			assert.Nil(t, err)
+			assert.NotNil(t, got.EnabledAt)
+			assert.Equal(t, int64(100), got.LastStep)
+		}
+
+		t.Log("\ttest:2\tshould use every step once")
+		{
+			used, err := r.UseTOTPStep(ctx, u.ID, 100)
+			assert.Nil(t, err)
+			assert.False(t, used)
+
+			used, err = r.UseTOTPStep(ctx, u.ID, 101)
+			assert.Nil(t, err)
+			assert.True(t, used)
+		}
+
+		t.Log("\ttest:3\tshould use every recovery code once")
+		{
+			used, err := r.UseRecoveryCode(ctx, u.ID, "hash1")
+			assert.Nil(t, err)
+			assert.True(t, used)
+
+			used, err = r.UseRecoveryCode(ctx, u.ID, "hash1")
+			assert.Nil(t, err)
+			assert.False(t, used)
+
+			used, err = r.UseRecoveryCode(ctx, u.ID, "unknown")
 			assert.Nil(t, err)
+			assert.False(t, used)
 		}
 	}
 }
 
-func TestFindByEmail(t *testing.T) {
+func TestIdentities(t *testing.T) {
 	t.Parallel()
 	t.Log("with initialized repository")
 	{
@@ -279,29 +1709,62 @@ func TestFindByEmail(t *testing.T) {
 		r := NewRepository(db)
 
 		nu := user.NewUser{
-			Username:     "username5",
-			Email:        "username5@example.com",
+			Username:     "username19",
+			Email:        "username19@example.com",
 			PasswordHash: "$2y$12$gwoUXq7kCxNcucd.eFxOp.vJYYmo6917fSGuuEowfyNf3E8KySrWC",
 		}
 
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
-		var user user.User
-		_, _, err := r.CreateUser(ctx, &nu, &user)
+		var u user.User
+		_, _, err := r.CreateUser(ctx, &nu, &u)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
 
-		t.Log("\ttest:0\tshould find user by email")
+		t.Log("\ttest:0\tshould link the identity")
 		{
-			err := r.FindByEmail(ctx, user.Email, &user)
+			_, err := r.FindIdentity(ctx, "example", "1234")
+			assert.Equal(t, user.ErrIdentityNotFound, err)
+
+			i := user.Identity{
+				UserID:   u.ID,
+				Provider: "example",
+				Subject:  "1234",
+				Email:    "username19@example.com",
+			}
+			err = r.CreateIdentity(ctx, &i)
+			assert.Nil(t, err)
+			assert.NotZero(t, i.ID)
+
+			got, err := r.FindIdentity(ctx, "example", "1234")
+			assert.Nil(t, err)
+			assert.Equal(t, u.ID, got.UserID)
+		}
+
+		t.Log("\ttest:1\tshould link the identity once")
+		{
+			i := user.Identity{
+				UserID:   u.ID,
+				Provider: "example",
+				Subject:  "1234",
+			}
+			err := r.CreateIdentity(ctx, &i)
+			assert.Equal(t, user.ErrIdentityExists, err)
+		}
+
+		t.Log("\ttest:2\tshould list identities of the user")
+		{
+			is, err := r.ListUserIdentities(ctx, u.ID)
 			assert.Nil(t, err)
+			assert.Len(t, is, 1)
+			assert.Equal(t, "username19@example.com", is[0].Email)
 		}
 	}
 }
 
-func TestFindByUsername(t *testing.T) {
+func TestPersonalTokens(t *testing.T) {
 	t.Parallel()
 	t.Log("with initialized repository")
 	{
@@ -310,24 +1773,133 @@ func TestFindByUsername(t *testing.T) {
 		r := NewRepository(db)
 
 		nu := user.NewUser{
-			Username:     "username6",
-			Email:        "username6@example.com",
+			Username:     "username20",
+			Email:        "username20@example.com",
 			PasswordHash: "$2y$12$gwoUXq7kCxNcucd.eFxOp.vJYYmo6917fSGuuEowfyNf3E8KySrWC",
 		}
 
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
-		var user user.User
-		_, _, err := r.CreateUser(ctx, &nu, &user)
+		var u user.User
+		_, _, err := r.CreateUser(ctx, &nu, &u)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
 
-		t.Log("\ttest:0\tshould find user by username")
+		expiresAt := time.Now().Add(time.Hour)
+		np := token.NewPersonal{
+			UserID:    u.ID,
+			Name:      "deploy",
+			Hash:      token.Hash("pat_username20"),
+			Scopes:    []string{token.ScopePostsWrite, token.ScopeCommentsWrite},
+			ExpiresAt: &expiresAt,
+			CreatedAt: time.Now().Truncate(time.Millisecond),
+		}
+
+		var p token.Personal
+
+		t.Log("\ttest:0\tshould create and find the token")
 		{
-			err := r.FindByUsername(ctx, user.Username, &user)
+			err := r.CreatePersonalToken(ctx, &np, &p)
+			assert.Nil(t, err)
+			assert.NotZero(t, p.ID)
+
+			got, err := r.FindPersonalToken(ctx, np.Hash)
+			assert.Nil(t, err)
+			assert.Equal(t, np.Scopes, got.Scopes)
+			assert.Nil(t, got.LastUsedAt)
+			assert.Equal(t, expiresAt.Unix(), got.ExpiresAt.Unix())
+			assert.True(t, np.CreatedAt.Equal(got.CreatedAt))
+		}
+
+		t.Log("\ttest:1\tshould keep the last use")
+		{
+			err := r.TouchPersonalToken(ctx, p.ID, time.Now())
+			assert.Nil(t, err)
+
+			got, err := r.FindPersonalToken(ctx, np.Hash)
+			assert.Nil(t, err)
+			assert.NotNil(t, got.LastUsedAt)
+		}
+
+		t.Log("\ttest:2\tshould list tokens of the user")
+		{
+			ts, err := r.ListPersonalTokens(ctx, u.ID)
+			assert.Nil(t, err)
+			assert.Len(t, ts, 1)
+		}
+
+		t.Log("\ttest:3\tshould revoke the token of the user once")
+		{
+			err := r.RevokePersonalToken(ctx, u.ID+1, p.ID)
+			assert.Equal(t, token.ErrPersonalNotFound, err)
+
+			err = r.RevokePersonalToken(ctx, u.ID, p.ID)
+			assert.Nil(t, err)
+
+			err = r.RevokePersonalToken(ctx, u.ID, p.ID)
+			assert.Equal(t, token.ErrPersonalNotFound, err)
+
+			ts, err := r.ListPersonalTokens(ctx, u.ID)
+			assert.Nil(t, err)
+			assert.Len(t, ts, 0)
+		}
+
+		t.Log("\ttest:4\tshould not find unknown token")
+		{
+			_, err := r.FindPersonalToken(ctx, token.Hash("unknown"))
+			assert.Equal(t, token.ErrPersonalNotFound, err)
+		}
+	}
+}
+
+func TestLoginStates(t *testing.T) {
+	t.Parallel()
+	t.Log("with initialized repository")
+	{
+		db, teardown := postgresDB(t)
+		defer teardown()
+		r := NewRepository(db)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		now := time.Now()
+
+		t.Log("\ttest:0\tshould take the state once")
+		{
+			s := oidc.State{
+				State:     "state",
+				Provider:  "example",
+				Verifier:  "verifier",
+				Nonce:     "nonce",
+				ExpiresAt: now.Add(time.Minute),
+			}
+			err := r.SaveLoginState(ctx, &s)
+			assert.Nil(t, err)
+
+			got, err := r.TakeLoginState(ctx, "state", now)
+			assert.Nil(t, err)
+			assert.Equal(t, "verifier", got.Verifier)
+			assert.Equal(t, "nonce", got.Nonce)
+
+			_, err = r.TakeLoginState(ctx, "state", now)
+			assert.Equal(t, oidc.ErrInvalidLogin, err)
+		}
+
+		t.Log("\ttest:1\tshould not take expired states")
+		{
+			s := oidc.State{
+				State:     "expired",
+				Provider:  "example",
+				ExpiresAt: now.Add(time.Minute),
+			}
+			err := r.SaveLoginState(ctx, &s)
 			assert.Nil(t, err)
+
+			_, err = r.TakeLoginState(ctx, "expired", now.Add(2*time.Minute))
+			assert.Equal(t, oidc.ErrInvalidLogin, err)
 		}
 	}
 }
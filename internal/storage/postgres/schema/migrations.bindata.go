@@ -4,6 +4,56 @@
 // migrations/1549100465_posts.up.sql
 // migrations/1557063976_users.down.sql
 // migrations/1557063976_users.up.sql
+// migrations/1562142000_posts_index.down.sql
+// migrations/1562142000_posts_index.up.sql
+// migrations/1562228400_comments.down.sql
+// migrations/1562228400_comments.up.sql
+// migrations/1562315000_tags.down.sql
+// migrations/1562315000_tags.up.sql
+// migrations/1562401000_posts_status.down.sql
+// migrations/1562401000_posts_status.up.sql
+// migrations/1562487000_posts_search.down.sql
+// migrations/1562487000_posts_search.up.sql
+// migrations/1562573400_revisions.down.sql
+// migrations/1562573400_revisions.up.sql
+// migrations/1562659800_posts_deleted_at.down.sql
+// migrations/1562659800_posts_deleted_at.up.sql
+// migrations/1562746200_users_role.down.sql
+// migrations/1562746200_users_role.up.sql
+// migrations/1562832600_refresh_tokens.down.sql
+// migrations/1562832600_refresh_tokens.up.sql
+// migrations/1562919000_token_revocations.down.sql
+// migrations/1562919000_token_revocations.up.sql
+// migrations/1563005400_users_verified.down.sql
+// migrations/1563005400_users_verified.up.sql
+// migrations/1563091800_users_profile.down.sql
+// migrations/1563091800_users_profile.up.sql
+// migrations/1563178200_users_tokens_valid_after_default.down.sql
+// migrations/1563178200_users_tokens_valid_after_default.up.sql
+// migrations/1563264600_login_attempts.down.sql
+// migrations/1563264600_login_attempts.up.sql
+// migrations/1563351000_totp.down.sql
+// migrations/1563351000_totp.up.sql
+// migrations/1563437400_identities.down.sql
+// migrations/1563437400_identities.up.sql
+// migrations/1563523800_personal_tokens.down.sql
+// migrations/1563523800_personal_tokens.up.sql
+// migrations/1563610200_posts_format.down.sql
+// migrations/1563610200_posts_format.up.sql
+// migrations/1563696600_posts_slugs.down.sql
+// migrations/1563696600_posts_slugs.up.sql
+// migrations/1563783000_posts_seo.down.sql
+// migrations/1563783000_posts_seo.up.sql
+// migrations/1563786600_revocations_timestamptz.down.sql
+// migrations/1563786600_revocations_timestamptz.up.sql
+// migrations/1563790200_personal_tokens_timestamptz.down.sql
+// migrations/1563790200_personal_tokens_timestamptz.up.sql
+// migrations/1563793800_posts_timestamptz.down.sql
+// migrations/1563793800_posts_timestamptz.up.sql
+// migrations/1563797400_tags_symbol_slugs.down.sql
+// migrations/1563797400_tags_symbol_slugs.up.sql
+// migrations/1563801000_post_slugs_backfill.down.sql
+// migrations/1563801000_post_slugs_backfill.up.sql
 // DO NOT EDIT!
 
 package schema
@@ -151,6 +201,1006 @@ func _1557063976_usersUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __1562142000_posts_indexDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x56\x00\xa9\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x70\x6f\x73\x74\x73\x5f\x75\x73\x65\x72\x5f\x69\x64\x5f\x69\x64\x78\x3b\x0a\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x70\x6f\x73\x74\x73\x5f\x63\x72\x65\x61\x74\x65\x64\x5f\x61\x74\x5f\x69\x64\x5f\x69\x64\x78\x3b\x0a\x03\x00\xf4\x49\x3b\xa4\x56\x00\x00\x00")
+
+func _1562142000_posts_indexDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562142000_posts_indexDownSql,
+		"1562142000_posts_index.down.sql",
+	)
+}
+
+func _1562142000_posts_indexDownSql() (*asset, error) {
+	bytes, err := _1562142000_posts_indexDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562142000_posts_index.down.sql", size: 86, mode: os.FileMode(420), modTime: time.Unix(1792300148, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562142000_posts_indexUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\xf0\xf3\x0f\x51\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xc8\x2f\x2e\x29\x8e\x4f\x2e\x4a\x4d\x2c\x49\x4d\x89\x4f\x2c\x89\xcf\x4c\x89\xcf\x4c\xa9\x50\xf0\xf7\x83\x48\x29\x68\x20\xe4\x14\x5c\x5c\x83\x9d\x75\x14\x32\x53\xc0\x0c\x4d\x6b\x2e\x82\xe6\x96\x16\xa7\x16\x61\x9a\x58\x5a\x9c\x5a\x14\x9f\x99\xa2\x69\xcd\x05\x18\x00\xb5\x83\xdd\x4b\x99\x00\x00\x00")
+
+func _1562142000_posts_indexUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562142000_posts_indexUpSql,
+		"1562142000_posts_index.up.sql",
+	)
+}
+
+func _1562142000_posts_indexUpSql() (*asset, error) {
+	bytes, err := _1562142000_posts_indexUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562142000_posts_index.up.sql", size: 153, mode: os.FileMode(420), modTime: time.Unix(1792300148, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562228400_commentsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\xce\xcf\xcd\x4d\xcd\x2b\x29\xb6\xe6\x02\x0c\x00\x47\x59\x33\xa2\x1f\x00\x00\x00")
+
+func _1562228400_commentsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562228400_commentsDownSql,
+		"1562228400_comments.down.sql",
+	)
+}
+
+func _1562228400_commentsDownSql() (*asset, error) {
+	bytes, err := _1562228400_commentsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562228400_comments.down.sql", size: 31, mode: os.FileMode(420), modTime: time.Unix(1792300335, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562228400_commentsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x90\xb1\x6e\x83\x30\x18\x84\x67\xfb\x29\xfe\x31\x44\x48\x79\x00\x26\xd7\xfc\xa8\x56\x8d\x41\xbf\x4d\x95\x4c\x88\xc6\x1e\x18\x08\x08\x5c\xa9\x7d\xfb\x8a\x2a\x0d\x51\xa4\x2c\x9d\xef\xee\xd3\xdd\xe5\x54\xd5\xe0\xc4\x8b\x46\x50\x05\xe0\x51\x59\x67\xe1\x3c\x0e\x43\xb8\xc4\x25\xe3\x92\x50\x38\xdc\x0c\xa6\x72\x8f\x26\xd8\x71\xd6\x7b\x66\x91\x94\xd0\x50\x93\x2a\x05\x9d\xe0\x0d\x4f\x29\x67\xd3\xb8\xc4\xb6\xf7\x4c\x19\xf7\x1b\x35\x8d\xd6\x40\x58\x20\xa1\x91\x68\x61\xd5\x17\xd8\xf5\x3e\x81\xca\x40\x8e\x1a\x1d\x82\x14\x56\x8a\x1c\x53\xce\x3e\x97\x30\x3f\xc6\x57\x6a\x37\x87\xcb\x8d\x7b\x87\xdb\x2a\x3d\x23\x7e\x8c\xfe\x9b\xbd\x0b\x92\xaf\x82\xee\x90\x9c\x1d\xf6\x10\xfb\x21\x2c\xb1\x1b\x26\xd8\x1f\x38\x3b\xcf\xa1\x8b\xc1\xb7\x5d\x64\x4e\x95\x68\x9d\x28\xeb\x6d\x43\x8e\x85\x68\xb4\x03\xd9\x10\xa1\x71\xed\xcd\xb2\xb6\x9e\xfc\x7f\x92\x3c\xc9\xf8\xdf\xdf\xca\xe4\x78\x7c\xf2\x77\x7b\x3d\xb5\xed\xfd\xd7\xba\x71\x1b\x7d\x15\x52\xd8\xba\x27\x19\xff\x19\x00\x79\xeb\x36\x84\xe2\x01\x00\x00")
+
+func _1562228400_commentsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562228400_commentsUpSql,
+		"1562228400_comments.up.sql",
+	)
+}
+
+func _1562228400_commentsUpSql() (*asset, error) {
+	bytes, err := _1562228400_commentsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562228400_comments.up.sql", size: 482, mode: os.FileMode(420), modTime: time.Unix(1792300335, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562315000_tagsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xc8\x2f\x2e\x29\x8e\x2f\x49\x4c\x2f\xb6\xe6\xc2\xaa\x00\x22\x05\x18\x00\x6f\xa2\x07\x47\x3c\x00\x00\x00")
+
+func _1562315000_tagsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562315000_tagsDownSql,
+		"1562315000_tags.down.sql",
+	)
+}
+
+func _1562315000_tagsDownSql() (*asset, error) {
+	bytes, err := _1562315000_tagsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562315000_tags.down.sql", size: 60, mode: os.FileMode(420), modTime: time.Unix(1792300503, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562315000_tagsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x90\xc1\x6a\x84\x30\x10\x86\xcf\xc9\x53\xcc\x51\x17\x61\x0b\x85\x5e\xf6\x94\xc6\x91\x86\xc6\x68\xc7\x58\x76\x4f\x21\x54\x11\xa1\xb6\x4b\x4d\xa1\x8f\x5f\xac\xb2\x7a\xe8\xee\x31\xfc\xff\x37\x93\x6f\x52\x2a\x4a\xb0\xe2\x51\x23\xa8\x0c\xf0\xa8\x2a\x5b\xc1\xf9\x73\x0c\xa3\x0b\xbe\x1b\x0f\xfc\xdf\xc2\x1c\x49\x42\x61\x71\x0d\x4d\x61\xb7\x05\x88\x38\xeb\x1b\x56\x21\x29\xa1\xa1\x24\x95\x0b\x3a\xc1\x33\x9e\x12\xce\x3e\xfc\xd0\xb2\x57\x41\xf2\x49\x50\x74\x7f\x17\xff\xb1\xa6\xd6\x3a\xe1\x6c\x7c\xff\xee\x2e\xd9\xc3\x26\x83\xda\xa8\x97\x1a\x13\xce\xd9\x7e\x07\xa1\x1f\xda\x31\xf8\xe1\x0c\xbb\x3d\x67\x6f\x5f\xad\x0f\x6d\xe3\x7c\x60\x56\xe5\x58\x59\x91\x97\x2b\x98\x62\x26\x6a\x6d\x41\xd6\x44\x68\xac\xbb\x54\x78\x7c\xe0\xb7\x3c\xd6\x4b\x4c\x36\xd3\xcb\xf5\x0d\x53\xc6\xae\xb3\x09\x33\x24\x34\x12\x97\x36\x44\x7d\x13\x43\x61\x20\x45\x8d\x16\x41\x8a\x4a\x8a\x14\x13\xce\x82\xef\x6e\xd1\xf3\x96\x6b\xf0\xe6\x7e\x10\x2d\x1f\x49\x60\x1e\x19\x6f\x35\x94\x49\xf1\x78\x55\xc3\xcd\x84\xeb\x9b\x9f\x69\xcd\xd6\x6f\x99\x75\xe0\xbf\x03\x00\x76\xb2\xab\x71\x14\x02\x00\x00")
+
+func _1562315000_tagsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562315000_tagsUpSql,
+		"1562315000_tags.up.sql",
+	)
+}
+
+func _1562315000_tagsUpSql() (*asset, error) {
+	bytes, err := _1562315000_tagsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562315000_tags.up.sql", size: 532, mode: os.FileMode(420), modTime: time.Unix(1792300503, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562401000_posts_statusDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xc8\x2f\x2e\x29\x8e\x2f\x2e\x49\x2c\x29\x2d\x8e\x2f\x28\x4d\xca\xc9\x2c\xce\x48\x4d\x89\x4f\x2c\x89\xcf\x4c\xa9\xb0\xe6\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x85\x28\xe5\xe2\x04\x1b\xe3\xec\xef\x13\xea\xeb\x87\x6c\x0e\x92\x56\x1d\x5c\x8a\x20\xd6\x58\x73\x01\x06\x00\x5e\xc7\x95\x8b\x8a\x00\x00\x00")
+
+func _1562401000_posts_statusDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562401000_posts_statusDownSql,
+		"1562401000_posts_status.down.sql",
+	)
+}
+
+func _1562401000_posts_statusDownSql() (*asset, error) {
+	bytes, err := _1562401000_posts_statusDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562401000_posts_status.down.sql", size: 138, mode: os.FileMode(420), modTime: time.Unix(1792300703, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562401000_posts_statusUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x8f\xc1\x6a\x84\x30\x14\x45\xd7\xe6\x2b\xde\x2e\x0a\x6e\xba\xe9\x46\xba\x48\xf5\x0d\x86\xc6\x38\x24\xb1\x9d\x9d\xa4\x26\x45\x61\xa0\x83\x89\xa5\x9f\x5f\x66\x94\x32\x76\xd1\x65\xb8\x39\xf7\xbe\xc3\x84\x41\x05\x86\x3d\x0b\x84\xcb\x67\x88\x81\x24\xac\xaa\xa0\x6c\x45\xd7\x48\xe0\x07\x90\xad\x01\x3c\x71\x6d\x34\x84\x68\xe3\x12\x92\x57\xa6\xca\x9a\xa9\xf4\xe1\x31\xbb\xa5\xb2\x13\x02\x2a\x3c\xb0\x4e\x18\xa0\x97\xe5\xfd\x3c\x85\xd1\x3b\x4a\x92\xa4\xac\xb1\x7c\x81\x74\x05\x81\x4b\x48\xa9\x9b\xed\x47\xa4\x39\xd0\x30\x8c\xde\x2d\x67\xef\x68\x7e\x4f\xe5\x40\xed\x3c\x8c\xd3\x97\x77\x34\xcb\xf2\x7f\xce\xf9\x65\x7a\x1b\x13\xc3\x1b\xd4\x86\x35\xc7\x82\x90\xee\x58\x31\xb3\xe9\x80\x46\xb3\xfb\x09\x4f\x30\xcc\xde\xc6\xf5\xf1\x56\xa3\xc2\x7d\xce\xf5\xcd\xa8\x20\xa4\x54\x78\xed\xe1\xb2\xc2\xd3\xdf\xed\x6b\x77\xbf\x7a\xf5\xf7\x78\x3f\xb9\x6f\x68\xe5\x36\xbe\x99\xe7\xbb\x85\xac\x20\x3f\x03\x00\xd2\xa2\xcd\x8e\x76\x01\x00\x00")
+
+func _1562401000_posts_statusUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562401000_posts_statusUpSql,
+		"1562401000_posts_status.up.sql",
+	)
+}
+
+func _1562401000_posts_statusUpSql() (*asset, error) {
+	bytes, err := _1562401000_posts_statusUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562401000_posts_status.up.sql", size: 374, mode: os.FileMode(420), modTime: time.Unix(1792300703, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562487000_posts_searchDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xc8\x2f\x2e\x29\x8e\x2f\x4e\x4d\x2c\x4a\xce\x88\xcf\x4c\xa9\xb0\xe6\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x85\xc8\x2a\x80\x35\x3a\xfb\xfb\x84\xfa\xfa\x21\xe9\x84\xe8\xb1\xe6\x02\x0c\x00\x94\x82\x9e\x4b\x57\x00\x00\x00")
+
+func _1562487000_posts_searchDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562487000_posts_searchDownSql,
+		"1562487000_posts_search.down.sql",
+	)
+}
+
+func _1562487000_posts_searchDownSql() (*asset, error) {
+	bytes, err := _1562487000_posts_searchDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562487000_posts_search.down.sql", size: 87, mode: os.FileMode(420), modTime: time.Unix(1792300781, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562487000_posts_searchUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x94\xcf\xbd\x6a\xc3\x30\x1c\x04\xf0\xd9\x7a\x8a\xdb\x24\x81\xdf\x20\x93\x62\xfd\x6b\x04\xae\x04\x92\xd2\xa6\x93\x49\x1d\x11\x1b\x4c\x55\x22\xd1\x0f\xc8\xc3\x17\x6a\xe8\xd0\x2d\xeb\x1d\x77\xf0\x53\x43\x24\x8f\xa8\xf6\x03\xe1\x3d\x97\x5a\x58\xa3\xb4\x46\xe7\x86\xc3\xa3\x85\x79\x80\x75\x11\x74\x34\x21\x06\x94\x74\xba\x4e\x73\x13\xc3\x13\x75\xd1\x79\xf4\x64\xc9\xab\x48\x1a\x6a\x78\x56\x2f\x01\x2a\x40\xb0\xa6\x29\xa9\x7e\xa6\xe5\x32\x57\x51\xf3\x58\xcb\x47\x9a\x6a\xbe\x0a\x9e\xde\x2e\xeb\x52\x66\xde\x62\xca\xa7\x35\x95\x29\x89\xba\xd4\x35\xb5\xe0\x5c\xca\x16\x5c\x71\x89\xdb\xed\xae\x83\xd7\x7c\xfe\xfe\xdb\xef\xb9\x64\x8d\x44\x88\xce\x93\xde\x31\xd6\x79\x52\x91\x60\xac\xa6\xe3\x3f\xca\x2f\x75\xdc\x40\xe3\x72\xfe\x82\xb3\x5b\x86\x43\x30\xb6\x47\x6f\x2c\xc4\x56\xcb\x1d\xfb\x19\x00\x36\xe9\xb8\xa0\x25\x01\x00\x00")
+
+func _1562487000_posts_searchUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562487000_posts_searchUpSql,
+		"1562487000_posts_search.up.sql",
+	)
+}
+
+func _1562487000_posts_searchUpSql() (*asset, error) {
+	bytes, err := _1562487000_posts_searchUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562487000_posts_search.up.sql", size: 293, mode: os.FileMode(420), modTime: time.Unix(1792300781, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562573400_revisionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x4a\x2d\xcb\x2c\xce\xcc\xcf\x2b\xb6\xe6\x02\x0c\x00\x3a\xd3\xd4\x1c\x20\x00\x00\x00")
+
+func _1562573400_revisionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562573400_revisionsDownSql,
+		"1562573400_revisions.down.sql",
+	)
+}
+
+func _1562573400_revisionsDownSql() (*asset, error) {
+	bytes, err := _1562573400_revisionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562573400_revisions.down.sql", size: 32, mode: os.FileMode(420), modTime: time.Unix(1792300909, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562573400_revisionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6c\x90\x5f\x4b\xf3\x30\x14\x87\xaf\x93\x4f\x71\xee\xf6\x87\xc2\x3e\xc0\xae\xf2\xa6\xa7\xbc\xc1\x34\xad\xc9\xa9\x6c\x88\x94\xce\x06\x09\xd8\x75\x34\x99\x20\xe2\x77\x97\xa9\x4c\xd9\xbc\x7d\x7e\xcf\x13\xc8\xc9\x6d\x55\x03\x89\x7f\x1a\x41\x15\x80\x1b\xe5\xc8\xc1\xe4\x5f\x42\x0c\xe3\x3e\xae\xb9\xb4\x28\x08\x7f\x0c\x53\xd1\x95\x05\x73\xce\x42\xcf\x1c\x5a\x25\x34\xd4\x56\x95\xc2\x6e\xe1\x06\xb7\x19\x67\x87\x31\xa6\x36\xf4\x4c\x19\xfa\x6c\x4d\xa3\x35\x58\x2c\xd0\xa2\x91\xe8\xe0\xb4\x47\x98\x87\x7e\x01\x95\x81\x1c\x35\x12\x82\x14\x4e\x8a\x1c\x33\xce\x8e\xd1\x4f\x97\x79\xc6\xd9\xfe\x38\xec\xfc\x74\x49\x53\x48\xcf\x9e\xdd\x09\x2b\xff\x0b\xfb\x7b\xd8\x8d\xfd\xeb\x5f\x3c\x75\x4f\x91\x11\x6e\xe8\xfe\xe1\x8c\x21\xc7\x42\x34\x9a\x60\xf6\xf6\x3e\xcb\x38\x67\xab\x25\xa4\x30\xf8\x98\xba\xe1\x00\xcb\x15\x67\x8f\x93\xef\x92\xef\xdb\x2e\x31\x52\x25\x3a\x12\x65\x7d\x9d\xcb\xc6\x5a\x34\xd4\x9e\x95\xd3\x5b\x8d\x51\xb7\x0d\xc2\xfc\xfb\x2a\x19\x7c\x7d\x64\xc1\x17\x6b\xfe\x31\x00\x17\x26\x7d\xf7\x8a\x01\x00\x00")
+
+func _1562573400_revisionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562573400_revisionsUpSql,
+		"1562573400_revisions.up.sql",
+	)
+}
+
+func _1562573400_revisionsUpSql() (*asset, error) {
+	bytes, err := _1562573400_revisionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562573400_revisions.up.sql", size: 394, mode: os.FileMode(420), modTime: time.Unix(1792300909, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562659800_posts_deleted_atDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xc8\x2f\x2e\x29\x8e\x4f\x49\xcd\x49\x2d\x49\x4d\x89\x4f\x2c\x89\xcf\x4c\xa9\xb0\xe6\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x85\xa8\x50\x00\x6b\x76\xf6\xf7\x09\xf5\xf5\x43\xd2\x8d\xd0\x67\xcd\x05\x18\x00\xdd\x2d\x0f\xab\x5f\x00\x00\x00")
+
+func _1562659800_posts_deleted_atDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562659800_posts_deleted_atDownSql,
+		"1562659800_posts_deleted_at.down.sql",
+	)
+}
+
+func _1562659800_posts_deleted_atDownSql() (*asset, error) {
+	bytes, err := _1562659800_posts_deleted_atDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562659800_posts_deleted_at.down.sql", size: 95, mode: os.FileMode(420), modTime: time.Unix(1792301051, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562659800_posts_deleted_atUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5c\x8d\xb1\x0a\x02\x31\x10\x05\x6b\xf3\x15\xaf\xd4\x6f\xb8\x2a\x5e\x56\x0c\x6c\x36\x92\xec\xe1\x75\x41\x48\x0a\x41\x50\xb8\x14\x7e\xbe\x70\x08\x27\xd6\xc3\xcc\x58\x56\x4a\x50\x7b\x64\xc2\xeb\xb9\xf4\x05\xd6\x39\x8c\x91\xa7\x20\xf0\x27\x48\x54\xd0\xec\xb3\x66\xd4\xf6\x68\xbd\xd5\x72\xeb\x3b\xf5\x81\xb2\xda\x70\x19\x8c\x19\x13\x59\x25\x78\x71\x34\xff\x19\x6b\xb0\x6c\x5e\xb9\xd7\x37\xa2\x7c\x47\xfb\x0d\x1c\x70\x3d\x53\xa2\x9f\x05\x7c\x5e\x4b\x32\x31\x0f\xe6\x33\x00\x75\x03\x1f\xbb\xa6\x00\x00\x00")
+
+func _1562659800_posts_deleted_atUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562659800_posts_deleted_atUpSql,
+		"1562659800_posts_deleted_at.up.sql",
+	)
+}
+
+func _1562659800_posts_deleted_atUpSql() (*asset, error) {
+	bytes, err := _1562659800_posts_deleted_atUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562659800_posts_deleted_at.up.sql", size: 166, mode: os.FileMode(420), modTime: time.Unix(1792301051, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562746200_users_roleDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x2d\x4e\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xca\xcf\x49\xb5\xe6\x02\x0c\x00\x4d\x53\xd3\x10\x2e\x00\x00\x00")
+
+func _1562746200_users_roleDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562746200_users_roleDownSql,
+		"1562746200_users_role.down.sql",
+	)
+}
+
+func _1562746200_users_roleDownSql() (*asset, error) {
+	bytes, err := _1562746200_users_roleDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562746200_users_role.down.sql", size: 46, mode: os.FileMode(420), modTime: time.Unix(1792301377, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562746200_users_roleUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x3c\x8b\xb1\xca\xc2\x30\x14\x46\xe7\xff\x7f\x8a\x6f\xbb\x2d\x74\x71\x71\x71\xba\xa6\x29\x0d\x5e\x53\x48\x13\x71\x0d\x24\x60\x41\x2d\xa4\xed\xfb\x8b\x0a\x6e\x07\xce\x39\x2c\x5e\x3b\x78\x3e\x8a\xc6\xb6\xe4\xb2\x80\xdb\x16\x6a\x90\x70\xb6\x30\x1d\xec\xe0\xa1\xaf\x66\xf4\x23\xca\x7c\xcf\x7f\x17\x76\xaa\x67\x57\xed\xf6\xf5\xc7\xd9\x20\x82\x56\x77\x1c\xc4\x83\xe2\xb6\xde\xe6\x42\x50\xbd\x56\x27\x54\xef\x03\xc6\xa2\xa2\x92\x63\xca\x85\x9a\x5f\xd2\x80\x72\x9a\xd6\x2f\xc5\xf4\x98\x9e\x54\xd7\x87\xff\xd7\x00\xb9\x39\xa6\x3e\x8f\x00\x00\x00")
+
+func _1562746200_users_roleUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562746200_users_roleUpSql,
+		"1562746200_users_role.up.sql",
+	)
+}
+
+func _1562746200_users_roleUpSql() (*asset, error) {
+	bytes, err := _1562746200_users_roleUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562746200_users_role.up.sql", size: 143, mode: os.FileMode(420), modTime: time.Unix(1792301377, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562832600_refresh_tokensDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x4a\x4d\x2b\x4a\x2d\xce\x88\x2f\xc9\xcf\x4e\xcd\x2b\xb6\xe6\x02\x0c\x00\x32\x0f\x3f\x2a\x25\x00\x00\x00")
+
+func _1562832600_refresh_tokensDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562832600_refresh_tokensDownSql,
+		"1562832600_refresh_tokens.down.sql",
+	)
+}
+
+func _1562832600_refresh_tokensDownSql() (*asset, error) {
+	bytes, err := _1562832600_refresh_tokensDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562832600_refresh_tokens.down.sql", size: 37, mode: os.FileMode(420), modTime: time.Unix(1792301578, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562832600_refresh_tokensUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x90\x4f\x4b\xc3\x30\x18\x87\xcf\xc9\xa7\x78\x8f\xeb\x28\xec\x22\x5e\x76\x8a\xe9\x5b\x0c\xa6\xd9\x4c\x52\xd9\x4e\xa1\x98\x8c\x86\x59\x37\x92\x2a\xf3\xdb\x4b\x9d\x0c\xff\xe1\xf9\x79\x7f\x0f\xbc\x0f\xd7\xc8\x2c\x82\x65\x37\x12\x41\xd4\xa0\x56\x16\x70\x23\x8c\x35\x90\xc2\x2e\x85\xdc\xbb\xf1\xb0\x0f\xcf\x19\x66\x94\x44\x4f\x0c\x6a\xc1\x24\xac\xb5\x68\x98\xde\xc2\x1d\x6e\x4b\x4a\x5e\x72\x48\x2e\x7a\x22\x94\xfd\x10\xa8\x56\x4a\xd0\x58\xa3\x46\xc5\xd1\xc0\xc4\x33\xcc\xa2\x2f\x60\xa5\xa0\x42\x89\x16\x81\x33\xc3\x59\x85\x25\x25\xbb\x6e\x88\x4f\x6f\xe4\x81\x69\x7e\xcb\xf4\xec\xfa\xaa\xb8\x58\x4a\x4a\xfa\x2e\xf7\xe4\x17\x80\x56\x89\xfb\x76\x5a\x87\xd3\x31\xa6\x90\x5d\x37\x12\x2b\x1a\x34\x96\x35\xeb\xaf\xfb\x14\x5e\x0f\xfb\xe0\xbf\xf1\x92\x52\xb2\x98\xc3\x18\x87\x90\xc7\x6e\x38\xc2\x7c\x41\xc9\x63\x0a\xdd\x18\xfc\xdf\x22\xa8\xb0\x66\xad\xb4\xc0\x5b\xad\x51\x59\x77\x39\xa1\xc5\x92\xd2\xcf\x8c\x42\x55\xb8\xf9\x37\xa3\x3b\x3f\xeb\xa2\x3f\x4d\x2d\x7e\x36\x3e\xd3\x62\x49\xdf\x07\x00\x16\xff\xbf\xf0\x98\x01\x00\x00")
+
+func _1562832600_refresh_tokensUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562832600_refresh_tokensUpSql,
+		"1562832600_refresh_tokens.up.sql",
+	)
+}
+
+func _1562832600_refresh_tokensUpSql() (*asset, error) {
+	bytes, err := _1562832600_refresh_tokensUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562832600_refresh_tokens.up.sql", size: 408, mode: os.FileMode(420), modTime: time.Unix(1792301578, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562919000_token_revocationsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x2d\x4e\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xc9\xcf\x4e\xcd\x2b\x8e\x2f\x4b\xcc\xc9\x4c\x89\x4f\x4c\x2b\x49\x2d\xb2\xe6\x02\xab\x84\x68\x45\x28\x2c\x4a\x2d\xcb\xcf\x4e\x4d\x89\x87\x68\xb0\xe6\x02\x0c\x00\x2d\xee\xdc\xf8\x61\x00\x00\x00")
+
+func _1562919000_token_revocationsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562919000_token_revocationsDownSql,
+		"1562919000_token_revocations.down.sql",
+	)
+}
+
+func _1562919000_token_revocationsDownSql() (*asset, error) {
+	bytes, err := _1562919000_token_revocationsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562919000_token_revocations.down.sql", size: 97, mode: os.FileMode(420), modTime: time.Unix(1792301750, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1562919000_token_revocationsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\xcf\xc1\x4b\x80\x30\x14\xc7\xf1\xf3\xf6\x57\xbc\xa3\x42\xc7\xe8\xe2\xe9\xa5\x2f\x1a\x6d\x53\xe6\x0c\x3d\x0d\xc1\x05\xcb\xc8\xd8\x96\xf8\xe7\x47\x21\x08\x05\xdd\x1f\xdf\xcf\xef\xd5\x86\xd0\x12\x58\xbc\x97\x04\xe2\x01\x74\x6b\x81\x46\xd1\xdb\x1e\xa2\xdf\xb7\xd5\x2f\x2e\x6f\xab\x7f\x4f\x50\x70\xf6\x9a\x03\x7b\x46\x53\x3f\xa2\x29\xee\x6e\x4b\xe8\x8c\x50\x68\x26\x78\xa2\xe9\x86\x33\x7f\x7c\x84\xe8\x93\x9b\x33\xb3\x42\x51\x6f\x51\x75\x3f\x3d\x3d\x48\xc9\xcb\x8a\xf3\x13\x13\xba\xa1\xf1\x5f\xcc\x5d\x2d\x17\x96\x03\x5a\xfd\x67\xcd\x75\xf1\x5d\x46\x69\xc9\x9c\x5f\x7c\x26\x1f\x13\x60\xd3\x40\xdd\xca\x41\xe9\x5f\xd2\x29\xec\xf3\x5b\x58\xdc\xfc\x92\x7d\xbc\xe6\x56\xfc\x6b\x00\x70\xbf\xaf\x62\x10\x01\x00\x00")
+
+func _1562919000_token_revocationsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1562919000_token_revocationsUpSql,
+		"1562919000_token_revocations.up.sql",
+	)
+}
+
+func _1562919000_token_revocationsUpSql() (*asset, error) {
+	bytes, err := _1562919000_token_revocationsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1562919000_token_revocations.up.sql", size: 272, mode: os.FileMode(420), modTime: time.Unix(1792301750, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563005400_users_verifiedDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x2d\x4e\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x4b\x2d\xca\x4c\xcb\x4c\x4d\x89\x4f\x2c\xb1\xe6\x02\x0c\x00\x9d\x4f\x00\x41\x35\x00\x00\x00")
+
+func _1563005400_users_verifiedDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563005400_users_verifiedDownSql,
+		"1563005400_users_verified.down.sql",
+	)
+}
+
+func _1563005400_users_verifiedDownSql() (*asset, error) {
+	bytes, err := _1563005400_users_verifiedDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563005400_users_verified.down.sql", size: 53, mode: os.FileMode(420), modTime: time.Unix(1792302391, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563005400_users_verifiedUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\x8d\x4d\x6a\xc3\x30\x14\x84\xd7\xd5\x29\xe6\x02\xee\x05\x4c\x17\x6a\xfd\x4a\x04\xf2\x0f\xd6\x33\xc9\x2e\x28\xf2\x33\xf1\xc6\x06\x49\xce\xf9\x83\x21\x86\x64\x39\x33\xcc\xf7\x69\xcb\xd4\x83\xf5\xaf\x25\x6c\x49\x62\x82\xae\x2a\xfc\xb5\x76\xa8\x1b\x98\x7f\x34\x2d\x83\x2e\xc6\xb1\xc3\x43\xe2\x3c\xcd\x32\x5e\x7d\xfe\x62\x53\x93\x63\x5d\x77\xa5\x52\x45\x01\x1d\xc2\xba\x2d\x39\x21\x44\xf1\x59\x46\xdc\x64\x5a\xa3\x20\xdf\xe5\x75\x0b\x3e\xcf\xeb\x02\xbf\x97\x71\x4b\x59\xc6\x6f\x35\x74\x95\xe6\x43\xeb\x88\xdf\x0d\xf8\x39\x58\x7b\x38\x9f\xa8\xa7\x8f\xd9\x38\x34\x83\xb5\xa5\x7a\x0e\x00\x35\x8a\x39\x93\xc1\x00\x00\x00")
+
+func _1563005400_users_verifiedUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563005400_users_verifiedUpSql,
+		"1563005400_users_verified.up.sql",
+	)
+}
+
+func _1563005400_users_verifiedUpSql() (*asset, error) {
+	bytes, err := _1563005400_users_verifiedUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563005400_users_verified.up.sql", size: 193, mode: os.FileMode(420), modTime: time.Unix(1792302391, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563091800_users_profileDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x2d\x4e\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\x2c\x4b\x2c\x49\x2c\x8a\x2f\x2d\xca\xb1\xe6\x22\x56\x4f\x52\x66\x3e\xf1\x8a\x53\x32\x8b\x0b\x72\x12\x2b\xe3\xf3\x12\x73\x53\xad\xb9\x00\x03\x00\x60\x2a\x66\xa7\x97\x00\x00\x00")
+
+func _1563091800_users_profileDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563091800_users_profileDownSql,
+		"1563091800_users_profile.down.sql",
+	)
+}
+
+func _1563091800_users_profileDownSql() (*asset, error) {
+	bytes, err := _1563091800_users_profileDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563091800_users_profile.down.sql", size: 151, mode: os.FileMode(420), modTime: time.Unix(1792302557, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563091800_users_profileUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x2d\x4e\x2d\x2a\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\xf0\xf3\x0f\x51\x70\x8d\xf0\x0c\x0e\x09\x56\x48\xc9\x2c\x2e\xc8\x49\xac\x8c\xcf\x4b\xcc\x4d\xe5\x0c\x73\x0c\x72\xf6\x70\x0c\xd2\x30\x35\xd0\x04\xab\xf1\x0b\xf5\xf1\x51\x70\x71\x75\x73\x0c\xf5\x09\x51\x50\x57\xb7\xe6\x22\xc1\xe0\xa4\xcc\x7c\xce\x10\xd7\x88\x10\x8a\x0d\x4a\x2c\x4b\x2c\x49\x2c\x8a\x2f\x2d\xca\x81\xbb\xcf\xc8\xc0\xc4\x02\x87\x0b\x01\x03\x00\x71\x67\xaa\x81\xfb\x00\x00\x00")
+
+func _1563091800_users_profileUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563091800_users_profileUpSql,
+		"1563091800_users_profile.up.sql",
+	)
+}
+
+func _1563091800_users_profileUpSql() (*asset, error) {
+	bytes, err := _1563091800_users_profileUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563091800_users_profile.up.sql", size: 251, mode: os.FileMode(420), modTime: time.Unix(1792302557, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563178200_users_tokens_valid_after_defaultDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x2d\x4e\x2d\x2a\x56\x80\x88\x38\xfb\xfb\x84\xfa\xfa\x29\x94\xe4\x67\xa7\xe6\x15\xc7\x97\x25\xe6\x64\xa6\xc4\x27\xa6\x95\xa4\x16\x29\xb8\x04\xf9\x07\x28\xb8\xb8\xba\x39\x86\xfa\x84\x58\x73\x01\x06\x00\x81\x68\xf0\x6b\x40\x00\x00\x00")
+
+func _1563178200_users_tokens_valid_after_defaultDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563178200_users_tokens_valid_after_defaultDownSql,
+		"1563178200_users_tokens_valid_after_default.down.sql",
+	)
+}
+
+func _1563178200_users_tokens_valid_after_defaultDownSql() (*asset, error) {
+	bytes, err := _1563178200_users_tokens_valid_after_defaultDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563178200_users_tokens_valid_after_default.down.sql", size: 64, mode: os.FileMode(420), modTime: time.Unix(1792303195, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563178200_users_tokens_valid_after_defaultUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\x8f\xc1\x6a\xe3\x30\x14\x45\xf7\xf9\x8a\xbb\xcb\x66\x1c\x98\xf5\xac\x3c\xa9\x0b\x01\x27\x2d\x8e\xd2\x6d\x78\x96\xae\x6b\x35\xae\x04\xd2\x73\x42\xff\xbe\xd8\x0e\x25\xdd\x3e\x2e\xe7\x9c\x57\x14\x30\xf1\xc2\x90\xe1\x73\x1e\xe9\xd0\xb2\x8b\x89\xd0\x9e\x10\x6b\xe3\x18\x14\x37\xc9\xb0\x89\xa2\x74\x90\x44\x24\x7e\xd0\x2a\xdd\x1f\xe4\x08\x41\xe0\x6d\x55\x14\x3f\x6b\x2b\x61\xad\x68\x89\xec\xdf\x03\x1d\x7c\x40\xfb\x05\x5d\x24\xb1\x83\xc0\x71\xe0\xc4\x8a\x81\xb8\x79\xed\xe1\x35\x63\xcc\x4c\x41\x3e\xb9\x99\x58\xa6\x27\x1c\x3b\x19\x07\x85\xcf\x10\x64\xda\x18\x7e\xc5\xcd\x41\x3e\x86\x39\x62\x3a\x74\x3e\x65\x7d\xf0\x68\xcf\xc7\xac\x29\xfc\x2a\x83\x77\xe0\x95\x01\xbe\x5b\x28\x43\xb4\x97\xfb\x1a\x4e\x54\x5a\xc9\x5c\x94\xad\x57\x48\x4f\x71\x9b\x55\x59\x9b\xaa\x81\x29\xff\xd7\xd5\xdc\x99\xb1\x5c\xb6\x2f\xf5\x69\x7f\xb8\x3b\xcf\x33\xfd\x2c\x9d\x32\xe1\x58\x19\x3c\x55\xcf\xe5\xa9\x36\xd8\x9e\x9a\xa6\x3a\x98\xb3\xd9\xed\xab\xa3\x29\xf7\xaf\x28\xb0\x3b\x98\xaa\x79\x2b\x6b\xac\xff\xde\x7f\x5b\xff\x5b\x7d\x0f\x00\x6c\x3c\xcf\x5f\x8c\x01\x00\x00")
+
+func _1563178200_users_tokens_valid_after_defaultUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563178200_users_tokens_valid_after_defaultUpSql,
+		"1563178200_users_tokens_valid_after_default.up.sql",
+	)
+}
+
+func _1563178200_users_tokens_valid_after_defaultUpSql() (*asset, error) {
+	bytes, err := _1563178200_users_tokens_valid_after_defaultUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563178200_users_tokens_valid_after_default.up.sql", size: 396, mode: os.FileMode(420), modTime: time.Unix(1792303195, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563264600_login_attemptsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\xc8\xc9\x4f\xcf\xcc\x8b\x4f\x2c\x29\x49\xcd\x2d\x28\x29\xb6\xe6\x02\x0c\x00\xee\xdf\x7c\xa1\x25\x00\x00\x00")
+
+func _1563264600_login_attemptsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563264600_login_attemptsDownSql,
+		"1563264600_login_attempts.down.sql",
+	)
+}
+
+func _1563264600_login_attemptsDownSql() (*asset, error) {
+	bytes, err := _1563264600_login_attemptsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563264600_login_attempts.down.sql", size: 37, mode: os.FileMode(420), modTime: time.Unix(1792303416, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563264600_login_attemptsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x8f\xc1\x8a\xc2\x30\x14\x45\xd7\xc9\x57\xbc\x65\x0b\x5d\x0c\x33\xcb\xae\x32\x35\x62\xb0\x4d\x4b\xfa\x94\x76\x15\x02\x8d\x12\xac\x5a\x9a\x08\xf5\xef\x45\x11\x2a\x28\xae\xef\xe1\x70\x6e\xa6\x38\x43\x0e\xc8\xfe\x73\x0e\x62\x09\xb2\x44\xe0\x8d\xa8\xb1\x86\xfe\xbc\x77\x27\x6d\x42\xb0\xc7\x21\x78\x88\x28\x39\xd8\x2b\xd9\x32\x95\xad\x98\x8a\xfe\x7e\x7f\x62\xa8\x94\x28\x98\x6a\x61\xcd\xdb\x84\x92\x9d\x71\xfd\x65\xb4\x9e\x08\x89\x0f\x91\xdc\xe4\x79\x42\x49\x6f\x7c\xd0\xf7\xd1\x76\xda\x04\x82\xa2\xe0\x35\xb2\xa2\x7a\x65\xec\x34\xb8\xd1\xfa\xcf\x3b\x8d\x53\x4a\x9f\xa5\x42\x2e\x78\xf3\xb5\x54\xcf\x2e\xed\xba\x09\x4a\xf9\x76\x65\x26\xe2\x94\xde\x06\x00\xbc\xe9\x30\x93\x03\x01\x00\x00")
+
+func _1563264600_login_attemptsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563264600_login_attemptsUpSql,
+		"1563264600_login_attempts.up.sql",
+	)
+}
+
+func _1563264600_login_attemptsUpSql() (*asset, error) {
+	bytes, err := _1563264600_login_attemptsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563264600_login_attempts.up.sql", size: 259, mode: os.FileMode(420), modTime: time.Unix(1792303416, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563351000_totpDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x4a\x4d\xce\x2f\x4b\x2d\xaa\x8c\x4f\xce\x4f\x49\x2d\xb6\xe6\xc2\xaa\xa8\x24\xbf\xa4\xc0\x9a\x0b\x30\x00\x3b\xe4\x32\x7c\x40\x00\x00\x00")
+
+func _1563351000_totpDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563351000_totpDownSql,
+		"1563351000_totp.down.sql",
+	)
+}
+
+func _1563351000_totpDownSql() (*asset, error) {
+	bytes, err := _1563351000_totpDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563351000_totp.down.sql", size: 64, mode: os.FileMode(420), modTime: time.Unix(1792303652, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563351000_totpUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x90\x41\x6b\x32\x31\x10\x86\xcf\x93\x5f\x31\x47\x57\x16\xfc\x0e\x1f\xbd\x78\x8a\xbb\x63\x1b\x1a\xa3\x24\xb1\xe8\x29\xa4\x9b\x80\x0b\x5a\x65\x13\x4b\xfb\xef\xcb\x16\x2b\xd6\x16\xe9\x79\x5e\xe6\x79\xdf\xa7\xd2\xc4\x2d\xa1\xe5\x13\x49\x28\xa6\xa8\xe6\x16\x69\x25\x8c\x35\x98\xf7\xf9\x80\x03\x06\xc7\x14\x3b\xd7\x06\x10\xca\xe2\x42\x8b\x19\xd7\x6b\x7c\xa4\x35\x6a\x9a\x92\x26\x55\x91\xc1\x3e\x92\x70\xd0\x86\x02\xe7\x0a\x6b\x92\x64\x09\x2b\x6e\x2a\x5e\x53\xc9\x20\xc5\xa6\x8b\x19\x9e\xb8\xae\x1e\xb8\x1e\xdc\xfd\x2f\x3e\x39\x6a\x29\x65\xc9\x60\xeb\x53\x76\x29\xc7\x03\x4c\xc4\x7d\x0f\xf9\xba\x61\x4d\x53\xbe\x94\x16\xff\x95\x0c\xe2\x8b\x7f\xde\xc6\xe0\x7c\x06\x2b\x66\x64\x2c\x9f\x2d\x4a\xc6\x60\x34\xc4\xdc\xee\x62\xca\x7e\x77\xc0\xe1\x88\x41\xd3\x45\x9f\xaf\x82\x3f\x5f\x56\x4b\xad\x49\x59\x77\x8e\xb0\x62\xcc\xd8\x0d\x1b\x5d\x6c\xf6\xaf\xb1\x7b\x77\xcd\x3e\xc4\xd4\x7b\x69\x03\x18\xd2\x82\xcb\x4b\x2b\xe5\x77\x5f\x67\xee\xdf\x65\x6d\x7c\xda\xc0\x6f\x9e\x8e\xe9\x6a\xd5\x65\x65\xa1\x6a\x5a\xdd\xac\xec\x4e\xbd\x5c\x1b\xde\x7a\xf0\xf5\xa0\xd3\xb9\x18\xb3\x8f\x01\x00\x44\x2f\xb4\x8a\x14\x02\x00\x00")
+
+func _1563351000_totpUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563351000_totpUpSql,
+		"1563351000_totp.up.sql",
+	)
+}
+
+func _1563351000_totpUpSql() (*asset, error) {
+	bytes, err := _1563351000_totpUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563351000_totp.up.sql", size: 532, mode: os.FileMode(420), modTime: time.Unix(1792303652, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563437400_identitiesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\xc8\xc9\x4f\xcf\xcc\x8b\x2f\x2e\x49\x2c\x49\x2d\xb6\xe6\xc2\xaa\x24\x33\x25\x35\xaf\x24\xb3\x24\x13\xa4\x00\x30\x00\x50\x89\x33\x50\x44\x00\x00\x00")
+
+func _1563437400_identitiesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563437400_identitiesDownSql,
+		"1563437400_identities.down.sql",
+	)
+}
+
+func _1563437400_identitiesDownSql() (*asset, error) {
+	bytes, err := _1563437400_identitiesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563437400_identities.down.sql", size: 68, mode: os.FileMode(420), modTime: time.Unix(1792304329, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563437400_identitiesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x84\x91\xd1\x8e\xa2\x30\x14\x86\xaf\xdb\xa7\x38\x77\x82\x21\x71\xd7\xac\x9b\x4d\xbc\xea\xc2\x31\xdb\x2c\x56\xb7\x94\x8d\x5e\x11\x46\x3a\x93\x4e\x04\x0d\x54\xe3\xe3\x4f\x70\x10\x61\x86\x89\x77\x24\xdf\x39\x3f\xa7\xdf\xef\x4b\x64\x0a\x41\xb1\xdf\x21\x02\x5f\x80\x58\x29\xc0\x0d\x8f\x54\x04\x26\xd3\x85\x35\xd6\xe8\x0a\x1c\x4a\x4c\x46\x22\x94\x9c\x85\xb0\x96\x7c\xc9\xe4\x16\xfe\xe2\xd6\xa3\xe4\x54\xe9\x32\x31\x19\xe1\x42\x5d\x97\x45\x1c\x86\x20\x71\x81\x12\x85\x8f\x11\xd4\xbc\x02\xc7\x64\x2e\xac\x04\x04\x18\xa2\x42\xf0\x59\xe4\xb3\x00\x3d\x4a\x8e\xe5\xe1\x6c\x32\x5d\x92\xff\x4c\xfa\x7f\x98\x74\x66\xdf\xdc\x36\xc7\xa3\xa4\x3a\x3d\xbd\xea\x9d\x6d\xf1\x74\x36\xeb\x71\x9d\xa7\x66\x3f\x4c\x21\xc0\x05\x8b\x43\x05\xa3\x91\x47\x29\x99\x8c\xc1\x9a\x5c\x57\x36\xcd\x8f\x30\x9e\x50\xb2\x2b\x75\x6a\x75\x96\xa4\x96\x28\xbe\xc4\x48\xb1\xe5\xfa\xf3\xb2\x1f\x4b\x89\x42\x25\xed\x48\x9d\x15\x0b\xfe\x2f\x46\x70\x6e\xd7\x7b\xd0\xdc\xe9\x52\x77\x4e\x69\x23\x95\x8b\x00\x37\x5f\x4a\x4d\x1a\x73\x89\xc9\x2e\xb5\x9a\xae\xee\x06\x75\xb2\x86\x0a\xda\x1f\x5e\x4c\x91\x54\x36\xb5\xef\x15\x5d\xbf\x5a\x17\x3f\x7f\xb8\x1f\xaa\x7a\xe4\xfa\xac\x4b\xf3\x6c\x3a\xfc\xfb\xf4\x57\x6f\xa0\x38\x14\xbb\xfe\x0f\x3a\xb0\xbe\xb9\x48\x73\x3d\x98\xde\xeb\x82\xe8\xcb\xd1\x94\xba\x1a\x36\xff\xc8\x60\xf7\xd5\xc9\x3d\xe9\xa6\xb1\x2f\xe5\xce\xdd\x39\x7d\x1b\x00\xd9\x8b\xac\xdc\xec\x02\x00\x00")
+
+func _1563437400_identitiesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563437400_identitiesUpSql,
+		"1563437400_identities.up.sql",
+	)
+}
+
+func _1563437400_identitiesUpSql() (*asset, error) {
+	bytes, err := _1563437400_identitiesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563437400_identities.up.sql", size: 748, mode: os.FileMode(420), modTime: time.Unix(1792304329, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563523800_personal_tokensDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x48\x2d\x2a\xce\xcf\x4b\xcc\x89\x2f\xc9\xcf\x4e\xcd\x2b\xb6\xe6\x02\x0c\x00\xf2\x7f\x21\xaf\x26\x00\x00\x00")
+
+func _1563523800_personal_tokensDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563523800_personal_tokensDownSql,
+		"1563523800_personal_tokens.down.sql",
+	)
+}
+
+func _1563523800_personal_tokensDownSql() (*asset, error) {
+	bytes, err := _1563523800_personal_tokensDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563523800_personal_tokens.down.sql", size: 38, mode: os.FileMode(420), modTime: time.Unix(1792304701, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563523800_personal_tokensUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x91\x4d\x6b\xc2\x40\x10\x86\xcf\xb3\xbf\x62\x6e\x1a\x09\xd8\x43\xdb\x8b\xa7\x6d\x32\xd2\xa5\x71\xb5\x9b\x49\x51\x4a\x59\x16\xb3\x60\xf0\x23\x21\x1b\x8b\x50\xfa\xdf\x8b\x22\x0a\x0a\xbd\xce\xf3\xce\xcb\xf0\x4c\x62\x48\x32\x21\xcb\x97\x8c\x50\x8d\x51\x4f\x19\x69\xae\x72\xce\xb1\xf1\x6d\xa8\x77\x6e\x63\xbb\x7a\xed\x77\x01\xfb\x02\xaa\x12\x72\x32\x4a\x66\x38\x33\x6a\x22\xcd\x02\xdf\x68\x11\x0b\xd8\x07\xdf\xda\xaa\x04\xa5\xf9\xd4\xa0\x8b\x2c\x43\x43\x63\x32\xa4\x13\xca\xf1\xc8\x03\xf6\xab\x32\xc2\xa9\xc6\x94\x32\x62\xc2\x44\xe6\x89\x4c\x29\x16\xb0\x73\x5b\x0f\x1f\xd2\x24\xaf\xd2\xf4\x9f\x1e\xa2\x4b\x47\x2c\x60\xe5\xc2\x0a\x4e\xe0\xf9\xf1\x0a\xb0\xd0\xea\xbd\x38\xee\x86\x65\xdd\xf8\x00\x4c\x73\xfe\xfc\xba\xf2\x94\xc6\xb2\xc8\x18\x7b\x3f\xbf\xbd\x58\xc0\xc6\x85\xce\xee\x83\x2f\xad\xeb\x80\xd5\x84\x72\x96\x93\x59\x2c\xc0\x1f\x9a\xaa\xf5\xe1\x76\xdc\xfa\xef\x7a\x7d\x97\x16\x30\x1c\x60\x57\x6d\x7d\xe8\xdc\xb6\xc1\xc1\x50\xc0\xb2\xf5\xae\xbb\x09\xde\x5f\x91\x14\xc6\x90\x66\x7b\x89\x88\x68\x24\xc4\xd9\xbd\xd2\x29\xcd\xff\x77\x6f\xcf\x82\x6d\x55\x1e\x8e\x06\xef\x5e\x73\xe6\xd1\x48\xfc\x0d\x00\x4d\x39\x18\xce\xd1\x01\x00\x00")
+
+func _1563523800_personal_tokensUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563523800_personal_tokensUpSql,
+		"1563523800_personal_tokens.up.sql",
+	)
+}
+
+func _1563523800_personal_tokensUpSql() (*asset, error) {
+	bytes, err := _1563523800_personal_tokensUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563523800_personal_tokens.up.sql", size: 465, mode: os.FileMode(420), modTime: time.Unix(1792304701, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563610200_posts_formatDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x4a\x2d\xcb\x2c\xce\xcc\xcf\x2b\xe6\xe2\x74\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\xcb\x2f\xca\x4d\x2c\xb1\xe6\x42\xd6\x54\x90\x5f\x5c\x82\x53\x43\x52\x7e\x4a\x65\x7c\x46\x49\x6e\x8e\x0e\x21\x23\x01\x03\x00\x32\x3a\xb4\xe2\x88\x00\x00\x00")
+
+func _1563610200_posts_formatDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563610200_posts_formatDownSql,
+		"1563610200_posts_format.down.sql",
+	)
+}
+
+func _1563610200_posts_formatDownSql() (*asset, error) {
+	bytes, err := _1563610200_posts_formatDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563610200_posts_format.down.sql", size: 136, mode: os.FileMode(420), modTime: time.Unix(1792305143, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563610200_posts_formatUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xac\x91\x5f\x6b\xab\x40\x10\xc5\x9f\xdd\x4f\x31\xdc\x0b\x19\x05\x93\xcb\xe5\x5e\x0a\x45\x2b\x18\xdd\x90\x50\x6b\x8a\xd1\xd2\x87\x40\xd9\xd4\x6d\x22\xf5\x1f\xbb\x4b\x13\x21\x1f\xbe\xb8\x5a\xda\x87\x52\xfa\xd0\x27\xf9\x79\x66\xe6\xec\x9c\xf1\xa3\x94\x26\x90\xfa\xf3\x88\x42\xdb\x48\x25\x89\xe1\x87\x21\x04\xeb\x28\xbb\x89\x61\xb5\x80\x78\x9d\x02\xbd\x5f\x6d\xd2\x0d\x3c\x35\xa2\x62\xca\xb8\xf3\x93\x60\xe9\x27\xe6\xdf\x0b\x4b\xab\x71\x16\x45\x10\xd2\x85\x9f\x45\x29\x60\x5b\xb2\xa2\x46\x62\x18\xc1\x92\x06\xd7\x60\x0e\x4d\xb0\x8a\xc1\x1c\x35\x1b\xb0\x62\xe2\x39\x6f\x8e\x35\x5a\x96\xfd\x85\xe1\xae\xc9\xbb\x87\x83\xaa\xca\x37\xcf\x4f\xfc\xd0\x21\xe4\xe3\x12\x82\xbf\x14\xb2\x68\xea\x1f\x5a\xc4\x21\x64\x3a\x05\x7a\x2a\xa4\x2a\xea\xfd\x10\x11\x30\xc1\x41\xcb\xa0\xf8\x49\xd9\xa0\x0e\xbc\xd3\x3f\x05\xaf\x73\x2e\x78\xde\xf7\xa8\x03\x07\xc9\x2a\x0e\x47\xd6\xe9\xbe\x59\xa2\xd5\x79\x93\x77\x63\xa1\x1c\xa7\xec\x9a\xbc\xe0\x72\x46\xb2\xdb\xd0\x4f\xc7\x3b\xc0\x86\xa6\xef\x01\xc0\x15\xa0\xdb\x7a\x08\xe7\x33\x08\xde\x96\xec\x91\x9b\xdf\xfd\xf6\x43\x6c\x62\xe0\xa4\x4f\x7e\xc2\xaa\xd6\x41\xcb\x06\x44\xd4\xfc\xfb\xdf\xe5\xc0\xae\xc6\x52\x0d\xe4\x69\xda\x8f\xf4\x6b\x2c\xfd\xaf\x91\xe2\xb6\xbf\x22\x45\x77\x27\xbc\x6d\x8d\x56\xff\x2a\x74\xff\xb4\x1e\x3a\xe4\x75\x00\xb5\x35\x7c\xc7\x51\x02\x00\x00")
+
+func _1563610200_posts_formatUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563610200_posts_formatUpSql,
+		"1563610200_posts_format.up.sql",
+	)
+}
+
+func _1563610200_posts_formatUpSql() (*asset, error) {
+	bytes, err := _1563610200_posts_formatUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563610200_posts_format.up.sql", size: 593, mode: os.FileMode(420), modTime: time.Unix(1792305143, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563696600_posts_slugsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xc8\x2f\x2e\x89\x2f\xce\x29\x4d\x2f\xb6\xe6\x72\xf4\x09\x71\x0d\x82\xaa\x00\x89\x17\x73\x71\x82\xf5\x38\xfb\xfb\x84\xfa\xfa\x21\x69\x02\xa9\xb7\xe6\x02\x0c\x00\x76\x2c\xe9\x73\x50\x00\x00\x00")
+
+func _1563696600_posts_slugsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563696600_posts_slugsDownSql,
+		"1563696600_posts_slugs.down.sql",
+	)
+}
+
+func _1563696600_posts_slugsDownSql() (*asset, error) {
+	bytes, err := _1563696600_posts_slugsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563696600_posts_slugs.down.sql", size: 80, mode: os.FileMode(420), modTime: time.Unix(1792305319, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563696600_posts_slugsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x53\x5d\x6f\xab\x46\x14\x7c\xde\xfd\x15\xf3\x60\x09\x48\x70\x3e\xde\xda\xa2\x3c\x60\x38\xae\x51\x31\xb8\xcb\xba\x49\x54\xb5\x16\x09\x6b\x77\x15\x1b\x5b\xb0\x51\x9d\x2b\xff\xf8\xab\xc5\xc4\xb6\x74\x6f\xde\x0e\xcb\xcc\x9c\x9d\xb3\x73\xc2\x54\x92\x80\x0c\x47\x29\x61\xb7\x6d\x4d\xcb\x59\x18\xc7\x88\xf2\x74\x3e\xcd\x90\x8c\x91\xe5\x12\xf4\x94\x14\xb2\x40\xbb\x7e\x5f\xb1\xbf\x42\x11\x4d\x42\x11\x70\xfe\x25\x35\x2b\xa4\x08\x93\x4c\x1e\x4f\x17\x96\xb6\x78\x53\x1f\x98\x67\xc9\x9f\x73\x82\x6b\x0f\xbc\x80\xf3\xe1\x10\xb4\xd7\xad\xd1\xf5\xea\x08\xc5\x4a\x99\xae\x4b\x8b\xed\x12\xe6\x3f\xa5\x1b\x18\x6d\xd6\xaa\x85\xae\xa1\x2b\x6c\x9b\x4a\x35\x3e\xca\x0e\x64\xf9\xa6\x7c\x53\x35\x5e\x3e\x50\xd6\x50\x65\xb3\xd6\xaa\xe9\xa4\xac\x52\x6b\x15\xb0\xd4\x4d\x6b\xb0\x6c\x94\x42\xfd\xbe\x79\x51\x0d\xda\xf7\xe5\x52\xef\x6f\x78\x9c\x63\x30\xe0\x31\x45\x69\x28\x88\xb3\x1d\x13\x14\xe5\x22\x0e\x38\x7b\x2d\xeb\x4a\x57\xa5\x51\x67\xb7\xac\x66\x49\x26\x03\x3e\xa2\xdf\x93\x8c\xb3\x71\x2e\xb0\x43\x92\xa1\xa0\x94\x22\x09\x5d\xf9\x88\xf2\x30\xa5\x22\x22\x37\x9b\xa7\x69\x32\x76\x4d\xa3\x37\xee\x28\x97\x13\x38\x43\x07\x63\x91\x4f\xb1\x56\x4b\xe3\x72\xc6\x1a\xb5\x52\xfb\xdd\xa2\x51\xbb\x75\xf9\xaa\xdc\xf5\xf6\x7f\xd5\xb8\x9d\x53\xcf\x87\xf3\xf7\xbf\xe5\xf0\xdb\xdd\xf0\xd7\x7f\xae\x1d\xdf\x72\x7d\x38\x2b\xc7\xf3\xf1\xcb\x9d\x67\x7f\xdb\xd2\xb1\x26\x1d\x0f\x61\x81\x97\xb2\x55\x9c\xb1\x4e\xff\x38\xc5\x5c\xc4\x24\x30\x7a\x86\xae\x38\x4b\xf3\x7c\xc6\xd9\xd9\x12\x7e\x7b\xc0\xee\xc6\x92\x02\xce\x58\x6d\x3f\xef\x6d\xf5\x38\x49\x52\xfa\x7c\x69\xb7\xb7\x75\x8f\x0b\xd9\xc7\x09\x09\xea\x26\x8f\x07\x9c\xf4\x3c\xf4\x1d\x8e\x5a\x35\xae\x8f\x7a\x3f\xeb\x88\xc3\xa1\x9b\xc5\xe1\x80\xda\x62\x28\x8b\x3b\xb6\xad\xe7\xb3\x38\x94\x7d\x90\x50\x90\xfc\xa1\x51\xdf\x5f\x57\xb0\x72\xba\x0a\xf8\x05\xdf\x56\x83\xc1\x17\xa1\xec\x8e\xfa\x44\x77\xaa\x56\xde\xe6\xda\x3e\x54\xc0\x79\x2c\xf2\x59\x4f\x49\xc6\x9f\x23\xb0\xe4\x2e\xba\x6d\xc0\x23\x41\xf6\x6e\x27\xc8\xc5\x4e\x9c\x61\x70\x39\xbb\xdc\x10\xcc\x44\x32\x0d\xc5\x33\xfe\xa0\x67\x9f\xb3\x0e\xa8\x2b\x9b\xa2\x53\x6f\x08\x1a\x93\xa0\x2c\xa2\xa2\x37\xee\xea\xca\x43\x9e\x21\xa6\x94\x24\x21\x0a\x8b\x28\x8c\xc9\xe7\x9c\xdd\x5e\xc1\xe8\x8d\x6a\x4d\xb9\xd9\xe1\xea\x96\xb3\xd7\x46\x95\x46\x55\x8b\xd2\x30\x99\x4c\xa9\x90\xe1\x74\x76\x56\x8e\x69\x1c\xce\x53\x89\x68\x2e\x04\x65\x72\x71\x82\x70\xbb\x79\xbd\xa1\x24\x8b\xe9\xe9\x4b\x43\x8b\xfe\xca\x0b\x5d\xed\xed\x9d\x2e\xad\xf6\xbf\xbc\x80\x7f\x1f\x00\xfc\x00\x5c\xe5\x41\x04\x00\x00")
+
+func _1563696600_posts_slugsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563696600_posts_slugsUpSql,
+		"1563696600_posts_slugs.up.sql",
+	)
+}
+
+func _1563696600_posts_slugsUpSql() (*asset, error) {
+	bytes, err := _1563696600_posts_slugsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563696600_posts_slugs.up.sql", size: 1089, mode: os.FileMode(420), modTime: time.Unix(1792305319, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563783000_posts_seoDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xc8\x2f\x2e\x29\xe6\xe2\x74\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\xc8\xcc\x4d\x4c\x4f\x8d\x2f\x2d\xca\xd1\xc1\xa5\x22\x39\x31\x2f\x3f\x2f\x33\x39\x31\x07\xaf\xaa\x94\xd4\xe2\xe4\xa2\xcc\x82\x92\xcc\xfc\x3c\x6b\x2e\xc0\x00\x4a\x63\x44\xd4\x7e\x00\x00\x00")
+
+func _1563783000_posts_seoDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563783000_posts_seoDownSql,
+		"1563783000_posts_seo.down.sql",
+	)
+}
+
+func _1563783000_posts_seoDownSql() (*asset, error) {
+	bytes, err := _1563783000_posts_seoDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563783000_posts_seo.down.sql", size: 126, mode: os.FileMode(420), modTime: time.Unix(1792306071, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563783000_posts_seoUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xc8\x2f\x2e\x29\xe6\xe2\x74\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\xf0\xf3\x0f\x51\x70\x8d\xf0\x0c\x0e\x09\x56\x48\x49\x2d\x4e\x2e\xca\x2c\x28\xc9\xcc\xcf\xe3\x0c\x73\x0c\x72\xf6\x70\x0c\x02\x4b\xfb\x85\xfa\xf8\x28\xb8\xb8\xba\x39\x86\xfa\x84\x28\xa8\xab\xeb\xe0\x31\x22\x39\x31\x2f\x3f\x2f\x33\x39\x31\x27\xbe\xb4\x28\x87\x5c\x43\x32\x73\x13\xd3\x53\x09\x19\x60\xcd\x05\x18\x00\x8f\x8c\x16\x5a\xdb\x00\x00\x00")
+
+func _1563783000_posts_seoUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563783000_posts_seoUpSql,
+		"1563783000_posts_seo.up.sql",
+	)
+}
+
+func _1563783000_posts_seoUpSql() (*asset, error) {
+	bytes, err := _1563783000_posts_seoUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563783000_posts_seo.up.sql", size: 219, mode: os.FileMode(420), modTime: time.Unix(1792306071, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563786600_revocations_timestamptzDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x4a\x2d\xcb\xcf\x4e\x4d\x89\x2f\xc9\xcf\x4e\xcd\x2b\x56\x80\x48\x39\xfb\xfb\x84\xfa\xfa\x29\xa4\x56\x14\x64\x16\xa5\x16\xc7\x27\x96\x28\x84\x44\x06\xb8\x2a\x84\x78\xfa\xba\x06\x87\x38\xfa\x06\x28\x84\x06\x7b\xfa\xb9\x23\xcb\x3b\x86\x80\x65\x15\xa2\xfc\xfd\x5c\x15\xd4\x43\x43\x9c\xd5\xad\xb9\x90\xad\x29\x2d\x4e\x2d\x42\x33\x1d\x62\x63\x7c\x59\x62\x4e\x66\x4a\x7c\x62\x5a\x49\x6a\x11\x76\x5b\xb0\xa8\xc3\x66\x1b\x60\x00\x46\x8d\x4f\x5a\xd5\x00\x00\x00")
+
+func _1563786600_revocations_timestamptzDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563786600_revocations_timestamptzDownSql,
+		"1563786600_revocations_timestamptz.down.sql",
+	)
+}
+
+func _1563786600_revocations_timestamptzDownSql() (*asset, error) {
+	bytes, err := _1563786600_revocations_timestamptzDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563786600_revocations_timestamptz.down.sql", size: 213, mode: os.FileMode(420), modTime: time.Unix(1792307479, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563786600_revocations_timestamptzUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x8d\xbd\x6e\xc2\x40\x10\x84\x7b\x9e\x62\x3a\x9a\x38\x2f\x90\xca\x41\x56\x84\x64\x1b\x04\xe7\x22\x34\xd6\x85\x1b\x64\x8b\x70\x6b\xdd\x6d\x9c\x9f\xa7\x8f\x7c\x34\xb1\x02\xdd\xce\xee\x37\xfb\x65\x19\x76\x1c\xe5\x68\xb5\x17\x0f\xed\x2f\x8c\xb0\x81\x38\xca\x65\xb0\x81\x0e\x9f\xbd\x76\xd0\x8e\xe9\x06\x39\xa5\x39\x32\x8c\x0c\x0f\x8b\x2c\x9b\xe2\x77\x6a\x44\x95\xff\xfc\x8f\x78\x42\x05\x5e\x14\x8e\x03\xbd\xc3\xa4\xe9\x08\xf1\x9c\xea\xb3\x87\x90\x90\x92\xb3\x6a\xdf\x6c\xe4\xe3\x22\x2f\x4d\xb1\x83\xc9\x9f\xcb\x02\x1f\x91\x21\xe2\xba\x59\x6d\xca\xa6\xaa\xa1\x72\xa6\x8f\xed\x68\xdf\x7b\xd7\xda\x93\x32\xc0\xbc\x6e\x0b\x98\x75\x55\xec\x4d\x5e\x6d\xcd\x01\xcd\x7e\x5d\xbf\xdc\x22\x73\x93\x38\x1c\x36\x75\x81\x65\x63\x56\xcb\xa7\x99\x2f\x70\x94\x33\x5d\x7b\xad\xce\xc5\xfc\x1a\xfa\xc0\xd8\x5a\xbd\x27\xfc\x43\xdc\x12\xfd\x0e\x00\x5b\x7e\x34\x3c\x78\x01\x00\x00")
+
+func _1563786600_revocations_timestamptzUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563786600_revocations_timestamptzUpSql,
+		"1563786600_revocations_timestamptz.up.sql",
+	)
+}
+
+func _1563786600_revocations_timestamptzUpSql() (*asset, error) {
+	bytes, err := _1563786600_revocations_timestamptzUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563786600_revocations_timestamptz.up.sql", size: 376, mode: os.FileMode(420), modTime: time.Unix(1792307479, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563790200_personal_tokens_timestamptzDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x48\x2d\x2a\xce\xcf\x4b\xcc\x89\x2f\xc9\xcf\x4e\xcd\x2b\xe6\xe2\x84\x48\x3a\xfb\xfb\x84\xfa\xfa\x29\x24\x17\xa5\x26\x96\xa4\xa6\xc4\x27\x96\x28\x84\x44\x06\xb8\x2a\x84\x78\xfa\xba\x06\x87\x38\xfa\x06\x28\x84\x06\x7b\xfa\xb9\x23\xc9\x5b\x59\xc1\xe5\x74\xd0\x0c\x29\x4a\x2d\xcb\xcf\xc6\x63\x08\x42\x1e\x8f\x21\x39\x89\xc5\x25\xf1\xa5\xc5\x78\x8c\x41\x51\xe1\x18\x02\x96\x57\x88\xf2\xf7\x73\x55\x50\x0f\x0d\x71\x56\x47\x37\x30\xb5\xa2\x20\xb3\x28\xb5\x18\xa7\x71\x48\xf2\x98\x86\x59\x73\x01\x06\x00\xb2\x3b\xb7\xc2\x44\x01\x00\x00")
+
+func _1563790200_personal_tokens_timestamptzDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563790200_personal_tokens_timestamptzDownSql,
+		"1563790200_personal_tokens_timestamptz.down.sql",
+	)
+}
+
+func _1563790200_personal_tokens_timestamptzDownSql() (*asset, error) {
+	bytes, err := _1563790200_personal_tokens_timestamptzDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563790200_personal_tokens_timestamptz.down.sql", size: 324, mode: os.FileMode(420), modTime: time.Unix(1792309895, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563790200_personal_tokens_timestamptzUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\xd0\xcd\x6e\xb2\x40\x14\xc6\xf1\xf5\xcb\x55\x3c\x3b\x37\xe0\x05\xf8\xae\x28\x21\x8d\x09\xa0\xa9\xc3\xa2\x6e\xc8\x08\x27\x71\x22\xce\x90\x39\xa7\xf6\xe3\xea\x1b\xa6\xa9\xa2\x4d\x58\x3f\xbf\xf3\x27\x4c\x92\x20\xf3\xa4\xc5\x38\x0b\x31\x67\x62\x68\x4f\x68\xdd\x79\xd0\x9e\x3a\xbc\x1b\x39\xc2\xd3\xc5\xb5\x13\x12\x43\xf7\xfd\x4d\x47\x49\x02\x16\x77\xe5\x72\xa4\x30\xe2\xcb\x59\x5a\x42\x05\xc7\x24\x38\x7c\x86\xad\xd3\xa2\x0f\x9a\x29\x7c\xc9\x58\x18\xe1\x31\x71\x3d\x89\x83\x72\xf6\xfe\x8a\xc9\x5f\xc8\xff\xde\xd4\x2a\x5b\x46\x69\xa1\xf2\x17\xa8\xf4\xa9\xc8\x31\x90\x67\x67\x75\xdf\x88\x3b\x91\xe5\xe8\xdf\xcf\x98\x6d\x8a\xba\xac\xd0\x8e\xbf\x48\x5d\xa3\x05\xea\x75\x9b\x43\xad\xcb\x7c\xa7\xd2\x72\xab\xf6\xa8\x77\xeb\xea\x79\x22\x56\xab\xc9\x1a\x3f\x84\xc6\xa7\x38\xcd\x86\x6e\x62\x36\xd4\x6b\x96\xe6\x8d\x67\x53\x77\x26\x55\x41\x60\xbf\xa9\x72\x2c\x6a\x95\x2d\x1e\x93\xf4\x31\x18\x4f\x3c\x13\x9c\x88\xbf\xb9\xff\xd1\xf7\x00\xe0\x76\x30\x81\x0c\x02\x00\x00")
+
+func _1563790200_personal_tokens_timestamptzUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563790200_personal_tokens_timestamptzUpSql,
+		"1563790200_personal_tokens_timestamptz.up.sql",
+	)
+}
+
+func _1563790200_personal_tokens_timestamptzUpSql() (*asset, error) {
+	bytes, err := _1563790200_personal_tokens_timestamptzUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563790200_personal_tokens_timestamptz.up.sql", size: 524, mode: os.FileMode(420), modTime: time.Unix(1792309895, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563793800_posts_timestamptzDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xc8\x2f\x2e\x29\xe6\xe2\x84\x08\x39\xfb\xfb\x84\xfa\xfa\x29\x14\x94\x26\xe5\x64\x16\x67\xa4\xa6\xc4\x27\x96\x28\x84\x44\x06\xb8\x2a\x84\x78\xfa\xba\x06\x87\x38\xfa\x06\x28\x84\x06\x7b\xfa\xb9\xa3\xaa\x70\x0c\x01\xcb\x2b\x44\xf9\xfb\xb9\x2a\xa8\x87\x86\x38\xab\xeb\xa0\x19\x98\x5c\x94\x9a\x58\x82\xc7\x38\x84\xbc\x95\x15\x5c\x0e\xdd\x90\xd2\x82\x14\xbc\x86\x20\xe4\xf1\x18\x92\x92\x9a\x93\x8a\xcf\x10\x84\x3c\x92\x21\xd6\x5c\x80\x01\x00\x1c\x70\x09\x46\x32\x01\x00\x00")
+
+func _1563793800_posts_timestamptzDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563793800_posts_timestamptzDownSql,
+		"1563793800_posts_timestamptz.down.sql",
+	)
+}
+
+func _1563793800_posts_timestamptzDownSql() (*asset, error) {
+	bytes, err := _1563793800_posts_timestamptzDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563793800_posts_timestamptz.down.sql", size: 306, mode: os.FileMode(420), modTime: time.Unix(1792309969, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563793800_posts_timestamptzUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x84\x8e\xc1\x6a\x83\x40\x10\x86\xcf\xf5\x29\xe6\x96\x8b\xe6\x01\xd2\x93\x15\x29\x01\x35\xd2\x8c\x87\xe6\x52\x56\x77\x82\x0b\x66\x57\x9c\x09\x25\x7d\xfa\xe2\x5a\xd8\x26\x05\x7b\xfd\xe7\xfb\xbf\xf9\x93\x04\xea\x6b\x3b\x98\x4e\x89\x71\x16\xc4\x5c\x88\xc1\x9d\xa1\x1b\x0c\x59\x61\x50\x56\x87\x50\x7a\x82\xb3\x19\x84\x26\x06\x35\x11\x74\xee\x32\xaa\x89\x74\x94\x24\xf0\x69\xa4\xf7\x80\xb3\x0b\x3c\x3a\x16\x8e\xe7\xe8\xe6\x61\x16\x37\x91\x0e\xdc\x6c\x85\x2f\x67\x69\x0b\xe8\x1f\x30\xc9\x2c\x6a\x6f\xfe\xac\x95\xa8\x56\x31\xf9\xae\xb1\x60\x84\x43\x25\x86\xf1\xcf\xe8\x1f\xae\xc1\x6c\x1b\xa5\x05\xe6\x6f\x80\xe9\x4b\x91\x2f\x3b\xa2\xa7\x25\xca\x0e\x45\x53\x56\x4b\x9b\x7b\xd2\x1f\x4a\x00\xdf\xeb\x1c\x70\x5f\xe6\x47\x4c\xcb\x1a\x4f\xd0\x1c\xf7\xd5\xeb\x3d\x93\xa2\x27\xe0\x74\xa8\x72\xd8\x34\x98\x6d\xe2\x07\x65\x37\x91\x92\x55\x61\x20\x76\xbb\x5f\xd7\x47\xd1\x75\xd4\xff\x88\x02\xb1\x2a\xd2\x34\xd0\xba\x28\x10\x77\xa2\xe7\xe8\x7b\x00\x1c\xcd\x35\x49\x16\x02\x00\x00")
+
+func _1563793800_posts_timestamptzUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563793800_posts_timestamptzUpSql,
+		"1563793800_posts_timestamptz.up.sql",
+	)
+}
+
+func _1563793800_posts_timestamptzUpSql() (*asset, error) {
+	bytes, err := _1563793800_posts_timestamptzUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563793800_posts_timestamptz.up.sql", size: 534, mode: os.FileMode(420), modTime: time.Unix(1792309969, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563797400_tags_symbol_slugsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\x90\x5f\x6b\xb3\x30\x14\xc6\xaf\x93\x4f\xf1\xc0\x7b\x91\x48\x7d\x85\xde\x76\xf4\xc2\xae\x19\x15\x36\x53\x62\xf6\x0f\x71\xc5\xd5\x20\x82\xda\x12\x95\xee\x6a\x9f\x7d\x24\x76\x63\xbb\xcb\x81\xe7\xf7\x7b\x72\xce\xe3\x7e\x1b\x6b\x81\xb1\xac\x07\x64\x42\x63\x68\xa7\x1a\x6b\x0c\x91\x7b\xd0\x3b\x25\x1f\xc0\x29\xc9\xc4\xbd\xb8\xd5\x68\xaa\xd0\x07\x42\xd8\xd3\xe5\xd0\x4f\xdd\xbb\xb1\x3c\x80\x7c\x12\x0a\x7c\x1f\x2b\x9d\xe8\x44\xa6\xd8\xbc\xce\x1a\xa9\xb6\x42\xb9\xa9\xa9\x02\xc4\x19\x7a\x4a\xbe\x85\xbf\x8d\xa3\x6d\x3a\xbe\x91\x7a\x07\xf6\x9f\xc1\x27\xac\xa9\xcd\xc7\xf9\x60\xcd\xb9\x2d\x8f\x86\xb7\xa7\x8b\xb1\xbc\x2f\x3b\x13\x84\x60\xf9\x5b\xbe\x2a\xdb\x7e\xea\x56\x45\xb1\x60\xa1\xa3\x42\xb0\x9a\x05\xbe\xc4\xff\x9b\xcc\x45\x6e\x2b\x4a\xc8\xf3\x4e\x28\x01\x87\xe3\x13\x2c\x5f\xfc\x8b\x0a\x46\x89\x4f\x1f\xe9\x0c\xd1\x39\xe3\x80\xa8\xa9\xfc\x01\x9a\x0a\x71\xba\xc5\x10\xf5\x58\x63\x49\x89\x1b\x52\xa9\x21\x5e\x92\x4c\x67\xe0\xd7\x0d\x96\xf8\xa9\xc2\x88\xab\x26\xfa\x73\xc6\xe0\x86\x7e\x0d\x00\xd8\x91\x5c\x77\x67\x01\x00\x00")
+
+func _1563797400_tags_symbol_slugsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563797400_tags_symbol_slugsDownSql,
+		"1563797400_tags_symbol_slugs.down.sql",
+	)
+}
+
+func _1563797400_tags_symbol_slugsDownSql() (*asset, error) {
+	bytes, err := _1563797400_tags_symbol_slugsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563797400_tags_symbol_slugs.down.sql", size: 359, mode: os.FileMode(420), modTime: time.Unix(1792310212, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563797400_tags_symbol_slugsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5c\x51\x4d\x6f\x9c\x30\x10\x3d\xe3\x5f\xf1\xa4\x3d\x18\xb4\x80\x94\x6b\xaa\x1c\x36\x5d\x57\x59\xa9\x5d\x22\x70\xbf\xb4\xda\x46\x0e\x4c\x01\xc5\x18\x84\x41\xdb\x5c\xfa\xdb\x2b\xdb\x49\xd5\xe6\x02\xf3\xc6\xef\xbd\x19\xbd\xc9\x32\x54\x7a\x6d\x2d\xec\x44\x5a\x63\xe9\x08\xf6\x79\x78\x1c\xb5\xc5\xf8\x13\x8b\x6a\x61\xd4\x40\x36\x75\xa5\x85\x5d\xeb\x0e\xca\xa2\xde\x6e\xa1\x4c\x83\x7a\x83\x4e\x35\x2c\xcb\x82\x50\xaf\xad\x57\x75\x04\x4d\xcb\x42\xb3\xc5\x68\xf4\x73\x0e\xe9\xc4\x4f\x44\x93\x7f\x1b\x75\x13\xb8\x7d\xe0\x1a\xba\x60\x34\xe4\x6c\x7a\x8b\x45\x3d\x91\xc9\xd9\xe7\xfb\xfd\x4e\x8a\x30\xb6\x12\x32\x08\x6e\x60\x73\x57\xb0\x0f\x65\xf1\x09\x31\x8b\x2a\xf1\x51\xbc\x97\xe8\x9b\xd4\x13\x52\xcc\xe3\xe5\xc1\xac\xc3\x23\xcd\x71\x82\xe2\x8b\x28\x11\xdf\xef\x4a\x79\x90\x87\xe2\x88\xdb\xef\xc1\xa6\x28\xf7\xa2\x74\xa8\x6f\x12\xec\x2a\x18\x16\xbd\x1a\xfe\xeb\xb8\xcc\xfd\x10\xdf\x16\xf2\x0e\x3c\xe3\xf0\x8c\x99\x5a\xfa\x35\x3d\xcc\x34\x69\x55\x93\xe3\x47\xaf\xf5\xdb\xbf\x1e\x2f\x34\xc7\x2e\xbd\x24\x05\xdf\xf2\x14\x3c\x9b\xf4\x6a\x33\xee\xf0\xc6\x63\xdb\xa9\x79\x0a\x8d\xdc\x37\x9a\x71\x71\xd0\xf9\xf2\xd3\x8f\xd3\xb5\xd2\x66\x1d\xae\xcf\xe7\x20\x77\x9f\x96\x27\x7e\x67\x1f\x43\x14\xf6\x76\x21\xb1\x28\xfa\x7a\x27\x4a\xe1\xef\x85\xdf\xe0\xa7\xed\x26\x3f\x73\x16\x79\x76\xcd\x82\x88\x05\x8e\x13\xe4\x7d\xe3\xf3\xec\x1b\xec\x8e\x7b\xd8\xdc\xe0\x06\x57\x2c\x72\xe0\x58\x48\x88\x6f\x87\x4a\x56\x88\x5f\x02\xb9\xc2\xdf\x51\x58\xf0\x62\x93\xff\x77\x95\xe4\x1d\xfb\x33\x00\x3f\xdf\xd4\xf8\x4f\x02\x00\x00")
+
+func _1563797400_tags_symbol_slugsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563797400_tags_symbol_slugsUpSql,
+		"1563797400_tags_symbol_slugs.up.sql",
+	)
+}
+
+func _1563797400_tags_symbol_slugsUpSql() (*asset, error) {
+	bytes, err := _1563797400_tags_symbol_slugsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563797400_tags_symbol_slugs.up.sql", size: 591, mode: os.FileMode(420), modTime: time.Unix(1792310212, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563801000_post_slugs_backfillDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xc8\x2f\x2e\x89\x2f\xce\x29\x4d\x2f\x8e\x4f\x4a\x4c\xce\x4e\xcb\xcc\xc9\xb1\xe6\x02\x0c\x00\xa7\x82\x41\xf6\x2a\x00\x00\x00")
+
+func _1563801000_post_slugs_backfillDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563801000_post_slugs_backfillDownSql,
+		"1563801000_post_slugs_backfill.down.sql",
+	)
+}
+
+func _1563801000_post_slugs_backfillDownSql() (*asset, error) {
+	bytes, err := _1563801000_post_slugs_backfillDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563801000_post_slugs_backfill.down.sql", size: 42, mode: os.FileMode(420), modTime: time.Unix(1792310371, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1563801000_post_slugs_backfillUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6c\x51\x6f\x8b\xd3\x30\x1c\x7e\xdd\x7c\x8a\xe7\x85\x90\x16\x5b\xb9\x97\xca\xa9\xd0\xeb\x32\x57\xec\xb5\xd2\x46\xf4\x38\xbc\x91\x75\x59\x1b\xcc\xd6\x92\xe4\x3c\x91\xe1\x67\x97\x64\x55\x10\xee\x4d\xc8\xef\x49\x9e\x7f\x49\x96\xa1\xd3\x8f\x83\xc5\x4e\xf4\xdf\x0f\x4a\x6b\xb9\xc7\xc1\x4c\x47\x38\xe5\xb4\xb4\x78\x52\x6e\x84\x96\xce\x49\x63\x31\x1d\x30\xb9\x51\x1a\x08\x3d\x8f\x62\x27\x9d\x85\x9e\xac\x23\x59\x06\x37\xca\x63\xea\x57\x88\x79\xd6\xaa\x17\x4e\x4d\x27\x0c\xea\x87\xb4\xb0\x8f\xfd\x88\x79\xb2\xce\x86\x0b\x36\xf8\x4d\x07\x3f\x28\xb3\x18\x79\x0d\x31\x08\x75\x82\x38\xed\x61\xe4\x71\xf2\x4c\xaf\xba\xc4\x19\x25\xb4\xb2\xee\x15\x29\x5a\x96\x73\x06\x9e\xdf\x54\x0c\xe5\x1a\x75\xc3\xc1\xbe\x96\x1d\xef\x82\xc7\x36\xc8\x6f\xff\xd6\x41\x4c\xa2\x00\xab\x7d\x54\xd6\x1c\x9f\xda\xf2\x36\x6f\xef\xf0\x91\xdd\xa1\x65\x6b\xd6\xb2\xba\x60\xdd\x92\x2e\x56\xfb\x04\x4d\x8d\x15\xab\x18\x67\x28\xf2\xae\xc8\x57\x8c\x24\xd7\x84\x94\x75\xc7\x5a\x8e\xb2\xe6\xcd\xf3\x36\x8b\x49\x42\x3a\x56\xb1\x82\x43\xed\xb1\x6e\x9b\x5b\xef\xff\x0f\x49\x43\xf7\x14\x45\x93\x57\xac\x2b\x58\x5c\x7f\xae\xaa\x72\x1d\x3b\xa3\x8e\xf1\x4d\xc3\x37\xa0\x19\xbd\xd0\xb4\x3c\xb8\x98\x44\x91\x91\x83\xfc\x39\x6f\x8d\x9c\xb5\xe8\x65\xac\xa7\x27\x69\xe2\xf0\x64\x49\x0a\x7a\xff\x20\xb2\x5f\x57\xd9\x9b\x6f\x2f\x69\xea\xb9\x29\xe8\x40\x93\x14\xaf\xaf\x12\x7f\xec\xb7\xd4\x07\xa3\x09\xf2\x0e\x3b\x61\x25\x89\x82\x7c\xe8\x4b\xa2\x2f\x1b\xd6\x32\x4c\xbd\x93\x6e\xab\xe5\x69\x70\xe3\xa2\x8d\xb7\xef\xd1\x8f\xc2\xfc\x8f\x92\x20\x33\x93\x0b\xcd\x77\xc1\xbb\xa0\x8a\xa6\xbd\x8c\xbf\x11\xd3\x07\x8a\xf3\xf9\x02\x9f\xcf\xa0\xd9\x7d\x08\xf8\x82\x26\xa4\xa9\x51\x34\xf5\xba\x2a\x0b\x8e\x55\xe3\x7f\x6e\x53\xd6\x1f\xae\xc9\x9f\x01\x00\xea\x35\x81\xd8\x85\x02\x00\x00")
+
+func _1563801000_post_slugs_backfillUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1563801000_post_slugs_backfillUpSql,
+		"1563801000_post_slugs_backfill.up.sql",
+	)
+}
+
+func _1563801000_post_slugs_backfillUpSql() (*asset, error) {
+	bytes, err := _1563801000_post_slugs_backfillUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1563801000_post_slugs_backfill.up.sql", size: 645, mode: os.FileMode(420), modTime: time.Unix(1792310371, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -207,6 +1257,56 @@ var _bindata = map[string]func() (*asset, error){
 	"1549100465_posts.up.sql": _1549100465_postsUpSql,
 	"1557063976_users.down.sql": _1557063976_usersDownSql,
 	"1557063976_users.up.sql": _1557063976_usersUpSql,
+	"1562142000_posts_index.down.sql": _1562142000_posts_indexDownSql,
+	"1562142000_posts_index.up.sql": _1562142000_posts_indexUpSql,
+	"1562228400_comments.down.sql": _1562228400_commentsDownSql,
+	"1562228400_comments.up.sql": _1562228400_commentsUpSql,
+	"1562315000_tags.down.sql": _1562315000_tagsDownSql,
+	"1562315000_tags.up.sql": _1562315000_tagsUpSql,
+	"1562401000_posts_status.down.sql": _1562401000_posts_statusDownSql,
+	"1562401000_posts_status.up.sql": _1562401000_posts_statusUpSql,
+	"1562487000_posts_search.down.sql": _1562487000_posts_searchDownSql,
+	"1562487000_posts_search.up.sql": _1562487000_posts_searchUpSql,
+	"1562573400_revisions.down.sql": _1562573400_revisionsDownSql,
+	"1562573400_revisions.up.sql": _1562573400_revisionsUpSql,
+	"1562659800_posts_deleted_at.down.sql": _1562659800_posts_deleted_atDownSql,
+	"1562659800_posts_deleted_at.up.sql": _1562659800_posts_deleted_atUpSql,
+	"1562746200_users_role.down.sql": _1562746200_users_roleDownSql,
+	"1562746200_users_role.up.sql": _1562746200_users_roleUpSql,
+	"1562832600_refresh_tokens.down.sql": _1562832600_refresh_tokensDownSql,
+	"1562832600_refresh_tokens.up.sql": _1562832600_refresh_tokensUpSql,
+	"1562919000_token_revocations.down.sql": _1562919000_token_revocationsDownSql,
+	"1562919000_token_revocations.up.sql": _1562919000_token_revocationsUpSql,
+	"1563005400_users_verified.down.sql": _1563005400_users_verifiedDownSql,
+	"1563005400_users_verified.up.sql": _1563005400_users_verifiedUpSql,
+	"1563091800_users_profile.down.sql": _1563091800_users_profileDownSql,
+	"1563091800_users_profile.up.sql": _1563091800_users_profileUpSql,
+	"1563178200_users_tokens_valid_after_default.down.sql": _1563178200_users_tokens_valid_after_defaultDownSql,
+	"1563178200_users_tokens_valid_after_default.up.sql": _1563178200_users_tokens_valid_after_defaultUpSql,
+	"1563264600_login_attempts.down.sql": _1563264600_login_attemptsDownSql,
+	"1563264600_login_attempts.up.sql": _1563264600_login_attemptsUpSql,
+	"1563351000_totp.down.sql": _1563351000_totpDownSql,
+	"1563351000_totp.up.sql": _1563351000_totpUpSql,
+	"1563437400_identities.down.sql": _1563437400_identitiesDownSql,
+	"1563437400_identities.up.sql": _1563437400_identitiesUpSql,
+	"1563523800_personal_tokens.down.sql": _1563523800_personal_tokensDownSql,
+	"1563523800_personal_tokens.up.sql": _1563523800_personal_tokensUpSql,
+	"1563610200_posts_format.down.sql": _1563610200_posts_formatDownSql,
+	"1563610200_posts_format.up.sql": _1563610200_posts_formatUpSql,
+	"1563696600_posts_slugs.down.sql": _1563696600_posts_slugsDownSql,
+	"1563696600_posts_slugs.up.sql": _1563696600_posts_slugsUpSql,
+	"1563783000_posts_seo.down.sql": _1563783000_posts_seoDownSql,
+	"1563783000_posts_seo.up.sql": _1563783000_posts_seoUpSql,
+	"1563786600_revocations_timestamptz.down.sql": _1563786600_revocations_timestamptzDownSql,
+	"1563786600_revocations_timestamptz.up.sql": _1563786600_revocations_timestamptzUpSql,
+	"1563790200_personal_tokens_timestamptz.down.sql": _1563790200_personal_tokens_timestamptzDownSql,
+	"1563790200_personal_tokens_timestamptz.up.sql": _1563790200_personal_tokens_timestamptzUpSql,
+	"1563793800_posts_timestamptz.down.sql": _1563793800_posts_timestamptzDownSql,
+	"1563793800_posts_timestamptz.up.sql": _1563793800_posts_timestamptzUpSql,
+	"1563797400_tags_symbol_slugs.down.sql": _1563797400_tags_symbol_slugsDownSql,
+	"1563797400_tags_symbol_slugs.up.sql": _1563797400_tags_symbol_slugsUpSql,
+	"1563801000_post_slugs_backfill.down.sql": _1563801000_post_slugs_backfillDownSql,
+	"1563801000_post_slugs_backfill.up.sql": _1563801000_post_slugs_backfillUpSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -253,6 +1353,56 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"1549100465_posts.up.sql": &bintree{_1549100465_postsUpSql, map[string]*bintree{}},
 	"1557063976_users.down.sql": &bintree{_1557063976_usersDownSql, map[string]*bintree{}},
 	"1557063976_users.up.sql": &bintree{_1557063976_usersUpSql, map[string]*bintree{}},
+	"1562142000_posts_index.down.sql": &bintree{_1562142000_posts_indexDownSql, map[string]*bintree{}},
+	"1562142000_posts_index.up.sql": &bintree{_1562142000_posts_indexUpSql, map[string]*bintree{}},
+	"1562228400_comments.down.sql": &bintree{_1562228400_commentsDownSql, map[string]*bintree{}},
+	"1562228400_comments.up.sql": &bintree{_1562228400_commentsUpSql, map[string]*bintree{}},
+	"1562315000_tags.down.sql": &bintree{_1562315000_tagsDownSql, map[string]*bintree{}},
+	"1562315000_tags.up.sql": &bintree{_1562315000_tagsUpSql, map[string]*bintree{}},
+	"1562401000_posts_status.down.sql": &bintree{_1562401000_posts_statusDownSql, map[string]*bintree{}},
+	"1562401000_posts_status.up.sql": &bintree{_1562401000_posts_statusUpSql, map[string]*bintree{}},
+	"1562487000_posts_search.down.sql": &bintree{_1562487000_posts_searchDownSql, map[string]*bintree{}},
+	"1562487000_posts_search.up.sql": &bintree{_1562487000_posts_searchUpSql, map[string]*bintree{}},
+	"1562573400_revisions.down.sql": &bintree{_1562573400_revisionsDownSql, map[string]*bintree{}},
+	"1562573400_revisions.up.sql": &bintree{_1562573400_revisionsUpSql, map[string]*bintree{}},
+	"1562659800_posts_deleted_at.down.sql": &bintree{_1562659800_posts_deleted_atDownSql, map[string]*bintree{}},
+	"1562659800_posts_deleted_at.up.sql": &bintree{_1562659800_posts_deleted_atUpSql, map[string]*bintree{}},
+	"1562746200_users_role.down.sql": &bintree{_1562746200_users_roleDownSql, map[string]*bintree{}},
+	"1562746200_users_role.up.sql": &bintree{_1562746200_users_roleUpSql, map[string]*bintree{}},
+	"1562832600_refresh_tokens.down.sql": &bintree{_1562832600_refresh_tokensDownSql, map[string]*bintree{}},
+	"1562832600_refresh_tokens.up.sql": &bintree{_1562832600_refresh_tokensUpSql, map[string]*bintree{}},
+	"1562919000_token_revocations.down.sql": &bintree{_1562919000_token_revocationsDownSql, map[string]*bintree{}},
+	"1562919000_token_revocations.up.sql": &bintree{_1562919000_token_revocationsUpSql, map[string]*bintree{}},
+	"1563005400_users_verified.down.sql": &bintree{_1563005400_users_verifiedDownSql, map[string]*bintree{}},
+	"1563005400_users_verified.up.sql": &bintree{_1563005400_users_verifiedUpSql, map[string]*bintree{}},
+	"1563091800_users_profile.down.sql": &bintree{_1563091800_users_profileDownSql, map[string]*bintree{}},
+	"1563091800_users_profile.up.sql": &bintree{_1563091800_users_profileUpSql, map[string]*bintree{}},
+	"1563178200_users_tokens_valid_after_default.down.sql": &bintree{_1563178200_users_tokens_valid_after_defaultDownSql, map[string]*bintree{}},
+	"1563178200_users_tokens_valid_after_default.up.sql": &bintree{_1563178200_users_tokens_valid_after_defaultUpSql, map[string]*bintree{}},
+	"1563264600_login_attempts.down.sql": &bintree{_1563264600_login_attemptsDownSql, map[string]*bintree{}},
+	"1563264600_login_attempts.up.sql": &bintree{_1563264600_login_attemptsUpSql, map[string]*bintree{}},
+	"1563351000_totp.down.sql": &bintree{_1563351000_totpDownSql, map[string]*bintree{}},
+	"1563351000_totp.up.sql": &bintree{_1563351000_totpUpSql, map[string]*bintree{}},
+	"1563437400_identities.down.sql": &bintree{_1563437400_identitiesDownSql, map[string]*bintree{}},
+	"1563437400_identities.up.sql": &bintree{_1563437400_identitiesUpSql, map[string]*bintree{}},
+	"1563523800_personal_tokens.down.sql": &bintree{_1563523800_personal_tokensDownSql, map[string]*bintree{}},
+	"1563523800_personal_tokens.up.sql": &bintree{_1563523800_personal_tokensUpSql, map[string]*bintree{}},
+	"1563610200_posts_format.down.sql": &bintree{_1563610200_posts_formatDownSql, map[string]*bintree{}},
+	"1563610200_posts_format.up.sql": &bintree{_1563610200_posts_formatUpSql, map[string]*bintree{}},
+	"1563696600_posts_slugs.down.sql": &bintree{_1563696600_posts_slugsDownSql, map[string]*bintree{}},
+	"1563696600_posts_slugs.up.sql": &bintree{_1563696600_posts_slugsUpSql, map[string]*bintree{}},
+	"1563783000_posts_seo.down.sql": &bintree{_1563783000_posts_seoDownSql, map[string]*bintree{}},
+	"1563783000_posts_seo.up.sql": &bintree{_1563783000_posts_seoUpSql, map[string]*bintree{}},
+	"1563786600_revocations_timestamptz.down.sql": &bintree{_1563786600_revocations_timestamptzDownSql, map[string]*bintree{}},
+	"1563786600_revocations_timestamptz.up.sql": &bintree{_1563786600_revocations_timestamptzUpSql, map[string]*bintree{}},
+	"1563790200_personal_tokens_timestamptz.down.sql": &bintree{_1563790200_personal_tokens_timestamptzDownSql, map[string]*bintree{}},
+	"1563790200_personal_tokens_timestamptz.up.sql": &bintree{_1563790200_personal_tokens_timestamptzUpSql, map[string]*bintree{}},
+	"1563793800_posts_timestamptz.down.sql": &bintree{_1563793800_posts_timestamptzDownSql, map[string]*bintree{}},
+	"1563793800_posts_timestamptz.up.sql": &bintree{_1563793800_posts_timestamptzUpSql, map[string]*bintree{}},
+	"1563797400_tags_symbol_slugs.down.sql": &bintree{_1563797400_tags_symbol_slugsDownSql, map[string]*bintree{}},
+	"1563797400_tags_symbol_slugs.up.sql": &bintree{_1563797400_tags_symbol_slugsUpSql, map[string]*bintree{}},
+	"1563801000_post_slugs_backfill.down.sql": &bintree{_1563801000_post_slugs_backfillDownSql, map[string]*bintree{}},
+	"1563801000_post_slugs_backfill.up.sql": &bintree{_1563801000_post_slugs_backfillUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS posts_created_at_id_idx ON posts (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS posts_user_id_idx ON posts (user_id);
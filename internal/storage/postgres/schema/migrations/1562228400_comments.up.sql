@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS comments;
+CREATE TABLE IF NOT EXISTS comments (
+	id	SERIAL PRIMARY KEY,
+	post_id	INT NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+	user_id	INT NOT NULL,
+	parent_id	INT REFERENCES comments (id) ON DELETE CASCADE,
+	body	VARCHAR NOT NULL,
+
+	/* timestamp */
+	created_at	TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at	TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS comments_post_id_idx ON comments (post_id, created_at);
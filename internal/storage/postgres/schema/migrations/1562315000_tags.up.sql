@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS posts_tags;
+DROP TABLE IF EXISTS tags;
+CREATE TABLE IF NOT EXISTS tags (
+	id	SERIAL PRIMARY KEY,
+	name	VARCHAR(30) NOT NULL,
+	slug	VARCHAR(60) NOT NULL UNIQUE,
+
+	/* timestamp */
+	created_at	TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS posts_tags (
+	post_id	INT NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+	tag_id	INT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+	PRIMARY KEY (post_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS posts_tags_tag_id_idx ON posts_tags (tag_id);
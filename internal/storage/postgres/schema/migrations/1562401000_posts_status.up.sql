@@ -0,0 +1,8 @@
+ALTER TABLE posts
+	ADD COLUMN IF NOT EXISTS status	VARCHAR(16) NOT NULL DEFAULT 'published'
+		CHECK (status IN ('draft', 'scheduled', 'published', 'archived')),
+	ADD COLUMN IF NOT EXISTS published_at	TIMESTAMP;
+
+UPDATE posts SET published_at = created_at WHERE published_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS posts_status_published_at_idx ON posts (status, published_at);
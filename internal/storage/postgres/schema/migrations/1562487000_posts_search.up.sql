@@ -0,0 +1,7 @@
+ALTER TABLE posts
+	ADD COLUMN IF NOT EXISTS search	TSVECTOR GENERATED ALWAYS AS (
+		setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce(body, '')), 'B')
+	) STORED;
+
+CREATE INDEX IF NOT EXISTS posts_search_idx ON posts USING GIN (search);
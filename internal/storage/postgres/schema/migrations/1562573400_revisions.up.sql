@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS revisions;
+CREATE TABLE IF NOT EXISTS revisions (
+	id	SERIAL PRIMARY KEY,
+	post_id	INT NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+	user_id	INT NOT NULL,
+	number	INT NOT NULL,
+	title	VARCHAR NOT NULL,
+	body	VARCHAR NOT NULL,
+	tags	TEXT[] NOT NULL DEFAULT '{}',
+
+	/* timestamp */
+	created_at	TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+	UNIQUE (post_id, number)
+);
@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role	VARCHAR(16) NOT NULL DEFAULT 'author' CHECK (role IN ('reader', 'author', 'editor', 'admin'));
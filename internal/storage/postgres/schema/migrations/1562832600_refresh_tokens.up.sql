@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+	id	SERIAL PRIMARY KEY,
+	user_id	INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	family	VARCHAR(64) NOT NULL,
+	hash	CHAR(64) NOT NULL UNIQUE,
+	expires_at	TIMESTAMP NOT NULL,
+	revoked_at	TIMESTAMP,
+
+	/* timestamp */
+	created_at	TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS refresh_tokens_family_idx ON refresh_tokens (family);
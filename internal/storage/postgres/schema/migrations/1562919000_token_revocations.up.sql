@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+	jti	VARCHAR(64) PRIMARY KEY,
+	expires_at	TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS revoked_tokens_expires_at_idx ON revoked_tokens (expires_at);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tokens_valid_after	TIMESTAMP;
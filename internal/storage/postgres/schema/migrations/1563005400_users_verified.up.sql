@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS verified_at	TIMESTAMP;
+
+-- Accounts created before the verification are trusted.
+UPDATE users SET verified_at = created_at WHERE verified_at IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE users ALTER COLUMN tokens_valid_after DROP DEFAULT;
@@ -0,0 +1,5 @@
+-- Tokens issued before the account was created are rejected, so a new
+-- account can't be signed in by tokens of a deleted one with its username.
+-- The default is a second before the creation, so the first tokens of the
+-- account are valid even if the clock of the database is a bit ahead.
+ALTER TABLE users ALTER COLUMN tokens_valid_after SET DEFAULT CURRENT_TIMESTAMP - INTERVAL '1 second';
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS login_attempts (
+	key	VARCHAR(320) PRIMARY KEY,
+	failures	INT NOT NULL,
+	last_failed_at	TIMESTAMP NOT NULL,
+	expires_at	TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS login_attempts_expires_at_idx ON login_attempts (expires_at);
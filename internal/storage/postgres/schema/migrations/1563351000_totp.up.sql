@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS totp (
+	user_id	INT PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+	secret	VARCHAR(64) NOT NULL,
+	last_step	BIGINT NOT NULL DEFAULT 0,
+	enabled_at	TIMESTAMP,
+
+	/* timestamp */
+	created_at	TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS recovery_codes (
+	id	SERIAL PRIMARY KEY,
+	user_id	INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	hash	CHAR(64) NOT NULL,
+	used_at	TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS recovery_codes_user_id_idx ON recovery_codes (user_id);
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS identities (
+	id	SERIAL PRIMARY KEY,
+	user_id	INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	provider	VARCHAR(50) NOT NULL,
+	subject	VARCHAR(255) NOT NULL,
+	email	VARCHAR(255) NOT NULL DEFAULT '',
+
+	/* timestamp */
+	created_at	TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+	UNIQUE (provider, subject)
+);
+
+CREATE INDEX IF NOT EXISTS identities_user_id_idx ON identities (user_id);
+
+CREATE TABLE IF NOT EXISTS login_states (
+	state	VARCHAR(64) PRIMARY KEY,
+	provider	VARCHAR(50) NOT NULL,
+	verifier	VARCHAR(128) NOT NULL,
+	nonce	VARCHAR(64) NOT NULL,
+	username	VARCHAR(50) NOT NULL DEFAULT '',
+	expires_at	TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS login_states_expires_at_idx ON login_states (expires_at);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS personal_tokens (
+	id	SERIAL PRIMARY KEY,
+	user_id	INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	name	VARCHAR(50) NOT NULL,
+	hash	CHAR(64) NOT NULL UNIQUE,
+	scopes	TEXT[] NOT NULL DEFAULT '{}',
+	last_used_at	TIMESTAMP,
+	expires_at	TIMESTAMP,
+	revoked_at	TIMESTAMP,
+
+	/* timestamp */
+	created_at	TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS personal_tokens_user_id_idx ON personal_tokens (user_id);
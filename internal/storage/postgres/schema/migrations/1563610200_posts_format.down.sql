@@ -0,0 +1,5 @@
+ALTER TABLE revisions
+	DROP COLUMN IF EXISTS format;
+ALTER TABLE posts
+	DROP COLUMN IF EXISTS body_html,
+	DROP COLUMN IF EXISTS format;
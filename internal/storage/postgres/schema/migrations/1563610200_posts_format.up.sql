@@ -0,0 +1,12 @@
+ALTER TABLE posts
+	ADD COLUMN IF NOT EXISTS format	VARCHAR(16) NOT NULL DEFAULT 'plain'
+		CHECK (format IN ('plain', 'markdown')),
+	ADD COLUMN IF NOT EXISTS body_html	VARCHAR NOT NULL DEFAULT '';
+
+ALTER TABLE revisions
+	ADD COLUMN IF NOT EXISTS format	VARCHAR(16) NOT NULL DEFAULT 'plain';
+
+-- Existing posts are plain text, they are rendered
+-- the same way post.RenderBody renders plain bodies.
+UPDATE posts SET body_html = '<p>' || replace(replace(replace(replace(replace(replace(body,
+	'&', '&amp;'), '''', '&#39;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), E'\n', E'<br>\n') || '</p>';
@@ -0,0 +1,41 @@
+ALTER TABLE posts
+	ADD COLUMN IF NOT EXISTS slug	VARCHAR;
+
+ALTER TABLE posts
+	ADD CONSTRAINT posts_slug_key UNIQUE (slug);
+
+-- Existing posts get slugs of their titles in id order, a slug
+-- taken by an earlier post gets the first free number suffix.
+DO $$
+DECLARE
+	p	RECORD;
+	candidate	VARCHAR;
+	n	INT;
+BEGIN
+	FOR p IN SELECT id, COALESCE(NULLIF(trim(BOTH '-' FROM left(
+		regexp_replace(lower(title), '[^a-z0-9]+', '-', 'g'), 80)), ''), 'post') AS base
+		FROM posts ORDER BY id
+	LOOP
+		candidate := p.base;
+		n := 1;
+		WHILE EXISTS (SELECT 1 FROM posts WHERE slug = candidate) LOOP
+			n := n + 1;
+			candidate := p.base || '-' || n;
+		END LOOP;
+		UPDATE posts SET slug = candidate WHERE id = p.id;
+	END LOOP;
+END $$;
+
+ALTER TABLE posts
+	ALTER COLUMN slug SET NOT NULL;
+
+DROP TABLE IF EXISTS post_slugs;
+CREATE TABLE IF NOT EXISTS post_slugs (
+	slug	VARCHAR PRIMARY KEY,
+	post_id	INT NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+
+	/* timestamp */
+	created_at	TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS post_slugs_post_id_idx ON post_slugs (post_id);
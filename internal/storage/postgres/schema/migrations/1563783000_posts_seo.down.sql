@@ -0,0 +1,4 @@
+ALTER TABLE posts
+	DROP COLUMN IF EXISTS image_url,
+	DROP COLUMN IF EXISTS canonical_url,
+	DROP COLUMN IF EXISTS description;
@@ -0,0 +1,4 @@
+ALTER TABLE posts
+	ADD COLUMN IF NOT EXISTS description	VARCHAR NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS canonical_url	VARCHAR NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS image_url	VARCHAR NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE revoked_tokens ALTER COLUMN expires_at TYPE TIMESTAMP USING expires_at AT TIME ZONE 'UTC';
+ALTER TABLE users ALTER COLUMN tokens_valid_after TYPE TIMESTAMP USING tokens_valid_after AT TIME ZONE 'UTC';
@@ -0,0 +1,5 @@
+-- Revocation times are compared with the time of the server,
+-- they are stored with the time zone to not depend on the one
+-- of the server or the database.
+ALTER TABLE users ALTER COLUMN tokens_valid_after TYPE TIMESTAMPTZ USING tokens_valid_after AT TIME ZONE 'UTC';
+ALTER TABLE revoked_tokens ALTER COLUMN expires_at TYPE TIMESTAMPTZ USING expires_at AT TIME ZONE 'UTC';
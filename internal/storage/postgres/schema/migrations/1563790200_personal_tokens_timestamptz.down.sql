@@ -0,0 +1,5 @@
+ALTER TABLE personal_tokens
+	ALTER COLUMN created_at TYPE TIMESTAMP USING created_at::TIMESTAMP,
+	ALTER COLUMN revoked_at TYPE TIMESTAMP USING revoked_at::TIMESTAMP,
+	ALTER COLUMN last_used_at TYPE TIMESTAMP USING last_used_at AT TIME ZONE 'UTC',
+	ALTER COLUMN expires_at TYPE TIMESTAMP USING expires_at AT TIME ZONE 'UTC';
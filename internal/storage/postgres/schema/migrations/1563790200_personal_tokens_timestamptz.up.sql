@@ -0,0 +1,8 @@
+-- Creation times are compared with revocation times, all times are
+-- stored with the time zone. Times set by the database are in its
+-- time zone, the ones set by the server are in UTC.
+ALTER TABLE personal_tokens
+	ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at::TIMESTAMPTZ,
+	ALTER COLUMN revoked_at TYPE TIMESTAMPTZ USING revoked_at::TIMESTAMPTZ,
+	ALTER COLUMN last_used_at TYPE TIMESTAMPTZ USING last_used_at AT TIME ZONE 'UTC',
+	ALTER COLUMN expires_at TYPE TIMESTAMPTZ USING expires_at AT TIME ZONE 'UTC';
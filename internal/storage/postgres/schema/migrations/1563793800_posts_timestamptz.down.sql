@@ -0,0 +1,5 @@
+ALTER TABLE posts
+	ALTER COLUMN published_at TYPE TIMESTAMP USING published_at AT TIME ZONE 'UTC',
+	ALTER COLUMN created_at TYPE TIMESTAMP USING created_at::TIMESTAMP,
+	ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at::TIMESTAMP,
+	ALTER COLUMN deleted_at TYPE TIMESTAMP USING deleted_at::TIMESTAMP;
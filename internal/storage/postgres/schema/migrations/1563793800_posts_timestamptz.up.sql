@@ -0,0 +1,8 @@
+-- Publication times of clients and times of the filters are compared
+-- with the ones of posts, they are stored with the time zone. Times set
+-- by the database are in its time zone, publication times are in UTC.
+ALTER TABLE posts
+	ALTER COLUMN published_at TYPE TIMESTAMPTZ USING published_at AT TIME ZONE 'UTC',
+	ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at::TIMESTAMPTZ,
+	ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at::TIMESTAMPTZ,
+	ALTER COLUMN deleted_at TYPE TIMESTAMPTZ USING deleted_at::TIMESTAMPTZ;
@@ -0,0 +1,11 @@
+UPDATE tags SET slug = s.slug
+FROM (
+	SELECT id, slug, row_number() OVER (PARTITION BY slug ORDER BY id) AS n
+	FROM (
+		SELECT id, trim(BOTH '-' FROM regexp_replace(lower(name), '[^[:alnum:]]+', '-', 'g')) AS slug
+		FROM tags
+		WHERE name ~ '[+#.]'
+	) AS c
+) AS s
+WHERE tags.id = s.id AND s.n = 1
+	AND NOT EXISTS (SELECT 1 FROM tags t WHERE t.slug = s.slug);
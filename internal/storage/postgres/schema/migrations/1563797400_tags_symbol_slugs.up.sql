@@ -0,0 +1,16 @@
+-- Slugs spell the symbols of tag names, tags such as c++ and c# had
+-- the slug of the letters only. Tags keep the old slug if the new one
+-- is taken.
+UPDATE tags SET slug = s.slug
+FROM (
+	SELECT id, slug, row_number() OVER (PARTITION BY slug ORDER BY id) AS n
+	FROM (
+		SELECT id, trim(BOTH '-' FROM regexp_replace(
+			replace(replace(replace(lower(name), '+', '-plus-'), '#', '-sharp-'), '.', '-dot-'),
+			'[^[:alnum:]]+', '-', 'g')) AS slug
+		FROM tags
+		WHERE name ~ '[+#.]'
+	) AS c
+) AS s
+WHERE tags.id = s.id AND s.n = 1
+	AND NOT EXISTS (SELECT 1 FROM tags t WHERE t.slug = s.slug);
@@ -0,0 +1,16 @@
+-- Slugs backfilled from titles with letters of other alphabets lost
+-- them, the application gives such posts the slugs of their titles
+-- again and removes them from the list.
+CREATE TABLE IF NOT EXISTS post_slugs_backfill (
+	post_id	INT PRIMARY KEY REFERENCES posts (id) ON DELETE CASCADE
+);
+
+INSERT INTO post_slugs_backfill (post_id)
+SELECT id FROM (
+	SELECT id, slug, COALESCE(NULLIF(trim(BOTH '-' FROM left(
+		regexp_replace(lower(title), '[^a-z0-9]+', '-', 'g'), 80)), ''), 'post') AS base
+	FROM posts
+	WHERE octet_length(title) <> char_length(title)
+) AS p
+WHERE slug = base OR slug ~ ('^' || base || '-[0-9]+$')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,37 @@
+package list
+
+import (
+	"context"
+
+	"github.com/dipress/blog/internal/tag"
+	"github.com/pkg/errors"
+)
+
+// Repository allows to work with the database.
+type Repository interface {
+	ListTags(ctx context.Context, ts *tag.Tags) error
+}
+
+// Service is a use case for tags showing.
+type Service struct {
+	Repository
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository) *Service {
+	s := Service{
+		Repository: r,
+	}
+
+	return &s
+}
+
+// List shows all tags with the number of posts.
+func (s *Service) List(ctx context.Context) (*tag.Tags, error) {
+	var tags tag.Tags
+	if err := s.Repository.ListTags(ctx, &tags); err != nil {
+		return nil, errors.Wrap(err, "list tags")
+	}
+
+	return &tags, nil
+}
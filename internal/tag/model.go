@@ -0,0 +1,21 @@
+package tag
+
+import (
+	"time"
+)
+
+// easyjson -all model.go
+
+// Tag contains all tag field.
+type Tag struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	Posts     int       `json:"posts"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Tags contains slice of tags.
+type Tags struct {
+	Tags []Tag `json:"tags"`
+}
@@ -0,0 +1,77 @@
+package tag
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SlugError returns when the tag name has the slug
+// of an existing tag with a different name.
+type SlugError struct {
+	Name  string
+	Taken string
+}
+
+// Error implements error interface.
+func (e *SlugError) Error() string {
+	return fmt.Sprintf("tag %q has the same link as the existing tag %q", e.Name, e.Taken)
+}
+
+// Normalize trims and lowercases tag names
+// and drops blank and duplicated ones.
+func Normalize(names []string) []string {
+	seen := make(map[string]struct{}, len(names))
+	tags := make([]string, 0, len(names))
+
+	for _, name := range names {
+		name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		tags = append(tags, name)
+	}
+
+	return tags
+}
+
+// symbolWords spell the symbols allowed in tag names, so
+// tags such as c++, c# and c get different slugs.
+var symbolWords = map[rune]string{
+	'+': "plus",
+	'#': "sharp",
+	'.': "dot",
+}
+
+// Slug returns url friendly representation of the tag name.
+// Words and spelled symbols are separated by dashes.
+func Slug(name string) string {
+	var (
+		parts []string
+		word  strings.Builder
+	)
+	flush := func() {
+		if word.Len() > 0 {
+			parts = append(parts, word.String())
+			word.Reset()
+		}
+	}
+
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			word.WriteRune(r)
+			continue
+		}
+		flush()
+		if w, ok := symbolWords[r]; ok {
+			parts = append(parts, w)
+		}
+	}
+	flush()
+
+	return strings.Join(parts, "-")
+}
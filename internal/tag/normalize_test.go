@@ -0,0 +1,95 @@
+package tag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name   string
+		tags   []string
+		expect []string
+	}{
+		{
+			name:   "empty",
+			expect: []string{},
+		},
+		{
+			name:   "trim and lowercase",
+			tags:   []string{"  Go ", "Web   Development"},
+			expect: []string{"go", "web development"},
+		},
+		{
+			name:   "duplicates and blanks",
+			tags:   []string{"go", "GO", " ", ""},
+			expect: []string{"go"},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expect, Normalize(tc.tags))
+		})
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		name   string
+		tag    string
+		expect string
+	}{
+		{
+			name:   "single word",
+			tag:    "go",
+			expect: "go",
+		},
+		{
+			name:   "spaces",
+			tag:    "web development",
+			expect: "web-development",
+		},
+		{
+			name:   "punctuation",
+			tag:    "c & rust!",
+			expect: "c-rust",
+		},
+		{
+			name:   "plus",
+			tag:    "c++",
+			expect: "c-plus-plus",
+		},
+		{
+			name:   "sharp",
+			tag:    "c#",
+			expect: "c-sharp",
+		},
+		{
+			name:   "letter",
+			tag:    "c",
+			expect: "c",
+		},
+		{
+			name:   "dot",
+			tag:    "node.js",
+			expect: "node-dot-js",
+		},
+		{
+			name:   "dash",
+			tag:    "objective-c",
+			expect: "objective-c",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expect, Slug(tc.tag))
+		})
+	}
+}
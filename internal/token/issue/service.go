@@ -0,0 +1,63 @@
+package issue
+
+import (
+	"context"
+	"time"
+
+	"github.com/dipress/blog/internal/token"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=service.go -package=issue -destination=service.mock.go
+
+// Repository allows to work with the database.
+type Repository interface {
+	CreateRefreshToken(ctx context.Context, f *token.NewRefresh, t *token.Refresh) error
+}
+
+// Service is a use case for refresh tokens issuing.
+type Service struct {
+	Repository
+	ExpireAfter time.Duration
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, exp time.Duration) *Service {
+	s := Service{
+		Repository:  r,
+		ExpireAfter: exp,
+	}
+
+	return &s
+}
+
+// Issue issues a refresh token for the user and stores its hash.
+// A new family is started when the family is empty.
+func (s *Service) Issue(ctx context.Context, userID int, family string) (string, error) {
+	if family == "" {
+		f, err := token.Generate()
+		if err != nil {
+			return "", errors.Wrap(err, "generate family")
+		}
+		family = f
+	}
+
+	secret, err := token.Generate()
+	if err != nil {
+		return "", errors.Wrap(err, "generate secret")
+	}
+
+	nr := token.NewRefresh{
+		UserID:    userID,
+		Family:    family,
+		Hash:      token.Hash(secret),
+		ExpiresAt: time.Now().Add(s.ExpireAfter),
+	}
+
+	var t token.Refresh
+	if err := s.Repository.CreateRefreshToken(ctx, &nr, &t); err != nil {
+		return "", errors.Wrap(err, "repository create refresh token")
+	}
+
+	return secret, nil
+}
@@ -0,0 +1,79 @@
+package issue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dipress/blog/internal/token"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceIssue(t *testing.T) {
+	tests := []struct {
+		name           string
+		family         string
+		repositoryFunc func(mock *MockRepository)
+		wantErr        bool
+	}{
+		{
+			name: "new family",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, f *token.NewRefresh, t *token.Refresh) error {
+						if f.Family == "" {
+							return errors.New("empty family")
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name:   "same family",
+			family: "family",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, f *token.NewRefresh, t *token.Refresh) error {
+						if f.Family != "family" {
+							return errors.New("unexpected family")
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name: "create error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepository(ctrl)
+			tc.repositoryFunc(repo)
+
+			s := NewService(repo, time.Hour)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			secret, err := s.Issue(ctx, 1, tc.family)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.NotEmpty(t, secret)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package token
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNotFound raises when refresh token not found in the database.
+	ErrNotFound = errors.New("refresh token not found")
+	// ErrPersonalNotFound raises when personal access
+	// token not found in the database.
+	ErrPersonalNotFound = errors.New("personal access token not found")
+)
+
+// Refresh is a stored refresh token. Only the hash
+// of the token is kept, the token itself is given
+// to the client once. Rotated tokens share the family.
+type Refresh struct {
+	ID        int
+	UserID    int
+	Family    string
+	Hash      string
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// NewRefresh contains the information which needs to create a new Refresh.
+type NewRefresh struct {
+	UserID    int
+	Family    string
+	Hash      string
+	ExpiresAt time.Time
+}
+
+// Scopes of personal access tokens. Tokens with
+// a scope are allowed to change that data only.
+const (
+	ScopePostsWrite    = "posts:write"
+	ScopeCommentsWrite = "comments:write"
+	ScopeProfileWrite  = "profile:write"
+)
+
+// Personal is a stored personal access token. It's long-lived,
+// limited by scopes and is used by the automation of the user.
+// Only the hash of the token is kept.
+type Personal struct {
+	ID         int
+	UserID     int
+	Name       string
+	Hash       string
+	Scopes     []string
+	LastUsedAt *time.Time
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// NewPersonal contains the information which needs to create a new Personal.
+// CreatedAt is the time of the server, it's compared with revocation times.
+type NewPersonal struct {
+	UserID    int
+	Name      string
+	Hash      string
+	Scopes    []string
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,107 @@
+package onetime
+
+import (
+	"context"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=service.go -package=onetime -destination=service.mock.go
+
+var (
+	// ErrInvalidToken returns when the token is malformed, expired,
+	// already used or is issued for another purpose.
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// TokenGenerator is the behavior we need in our
+// Issue to sign tokens.
+type TokenGenerator interface {
+	GenerateToken(ctx context.Context, claims jwt.Claims) (string, error)
+}
+
+// TokenParser is the behavior we need in our
+// Redeem to verify tokens.
+type TokenParser interface {
+	ParseClaims(ctx context.Context, tknStr string) (auth.Claims, error)
+}
+
+// Revoker keeps used tokens, so they can't be redeemed twice.
+type Revoker interface {
+	RevokeToken(ctx context.Context, id string, expiresAt time.Time) error
+	TokenRevoked(ctx context.Context, id string) (bool, error)
+	SubjectValidAfter(ctx context.Context, subject string) (time.Time, error)
+}
+
+// Service is a use case for single-use tokens sent to users
+// by email. Tokens are signed JWTs with the purpose kept in the
+// audience claim, so access tokens can't be redeemed and these
+// tokens can't authenticate requests.
+type Service struct {
+	TokenGenerator
+	TokenParser
+	Revoker
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(tg TokenGenerator, tp TokenParser, r Revoker) *Service {
+	s := Service{
+		TokenGenerator: tg,
+		TokenParser:    tp,
+		Revoker:        r,
+	}
+
+	return &s
+}
+
+// Issue issues a token for the subject valid for the purpose.
+func (s *Service) Issue(ctx context.Context, subject, purpose string, exp time.Duration) (string, error) {
+	claims := auth.NewClaims(subject, time.Now(), exp)
+	claims.Audience = purpose
+
+	tknStr, err := s.TokenGenerator.GenerateToken(ctx, claims)
+	if err != nil {
+		return "", errors.Wrap(err, "generate token")
+	}
+
+	return tknStr, nil
+}
+
+// Redeem checks the token is issued for the purpose and
+// marks it as used. It returns the subject of the token.
+func (s *Service) Redeem(ctx context.Context, tknStr, purpose string) (string, error) {
+	claims, err := s.TokenParser.ParseClaims(ctx, tknStr)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+
+	if claims.Audience != purpose || claims.Id == "" {
+		return "", ErrInvalidToken
+	}
+
+	revoked, err := s.Revoker.TokenRevoked(ctx, claims.Id)
+	if err != nil {
+		return "", errors.Wrap(err, "token revoked")
+	}
+	if revoked {
+		return "", ErrInvalidToken
+	}
+
+	// Changing the password revokes all tokens of the user.
+	after, err := s.Revoker.SubjectValidAfter(ctx, claims.Subject)
+	if err != nil {
+		return "", errors.Wrap(err, "subject valid after")
+	}
+	if claims.IssuedBefore(after) {
+		return "", ErrInvalidToken
+	}
+
+	if err := s.Revoker.RevokeToken(ctx, claims.Id, time.Unix(claims.ExpiresAt, 0)); err != nil {
+		return "", errors.Wrap(err, "revoke token")
+	}
+
+	return claims.Subject, nil
+}
@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package onetime is a generated GoMock package.
+package onetime
+
+import (
+	context "context"
+	jwt "github.com/dgrijalva/jwt-go"
+	auth "github.com/dipress/blog/kit/auth"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+	time "time"
+)
+
+// MockTokenGenerator is a mock of TokenGenerator interface
+type MockTokenGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenGeneratorMockRecorder
+}
+
+// MockTokenGeneratorMockRecorder is the mock recorder for MockTokenGenerator
+type MockTokenGeneratorMockRecorder struct {
+	mock *MockTokenGenerator
+}
+
+// NewMockTokenGenerator creates a new mock instance
+func NewMockTokenGenerator(ctrl *gomock.Controller) *MockTokenGenerator {
+	mock := &MockTokenGenerator{ctrl: ctrl}
+	mock.recorder = &MockTokenGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTokenGenerator) EXPECT() *MockTokenGeneratorMockRecorder {
+	return m.recorder
+}
+
+// GenerateToken mocks base method
+func (m *MockTokenGenerator) GenerateToken(ctx context.Context, claims jwt.Claims) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateToken", ctx, claims)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateToken indicates an expected call of GenerateToken
+func (mr *MockTokenGeneratorMockRecorder) GenerateToken(ctx, claims interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockTokenGenerator)(nil).GenerateToken), ctx, claims)
+}
+
+// MockTokenParser is a mock of TokenParser interface
+type MockTokenParser struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenParserMockRecorder
+}
+
+// MockTokenParserMockRecorder is the mock recorder for MockTokenParser
+type MockTokenParserMockRecorder struct {
+	mock *MockTokenParser
+}
+
+// NewMockTokenParser creates a new mock instance
+func NewMockTokenParser(ctrl *gomock.Controller) *MockTokenParser {
+	mock := &MockTokenParser{ctrl: ctrl}
+	mock.recorder = &MockTokenParserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTokenParser) EXPECT() *MockTokenParserMockRecorder {
+	return m.recorder
+}
+
+// ParseClaims mocks base method
+func (m *MockTokenParser) ParseClaims(ctx context.Context, tknStr string) (auth.Claims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseClaims", ctx, tknStr)
+	ret0, _ := ret[0].(auth.Claims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseClaims indicates an expected call of ParseClaims
+func (mr *MockTokenParserMockRecorder) ParseClaims(ctx, tknStr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseClaims", reflect.TypeOf((*MockTokenParser)(nil).ParseClaims), ctx, tknStr)
+}
+
+// MockRevoker is a mock of Revoker interface
+type MockRevoker struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevokerMockRecorder
+}
+
+// MockRevokerMockRecorder is the mock recorder for MockRevoker
+type MockRevokerMockRecorder struct {
+	mock *MockRevoker
+}
+
+// NewMockRevoker creates a new mock instance
+func NewMockRevoker(ctrl *gomock.Controller) *MockRevoker {
+	mock := &MockRevoker{ctrl: ctrl}
+	mock.recorder = &MockRevokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRevoker) EXPECT() *MockRevokerMockRecorder {
+	return m.recorder
+}
+
+// RevokeToken mocks base method
+func (m *MockRevoker) RevokeToken(ctx context.Context, id string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, id, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken
+func (mr *MockRevokerMockRecorder) RevokeToken(ctx, id, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockRevoker)(nil).RevokeToken), ctx, id, expiresAt)
+}
+
+// TokenRevoked mocks base method
+func (m *MockRevoker) TokenRevoked(ctx context.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokenRevoked", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TokenRevoked indicates an expected call of TokenRevoked
+func (mr *MockRevokerMockRecorder) TokenRevoked(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenRevoked", reflect.TypeOf((*MockRevoker)(nil).TokenRevoked), ctx, id)
+}
+
+// SubjectValidAfter mocks base method
+func (m *MockRevoker) SubjectValidAfter(ctx context.Context, subject string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubjectValidAfter", ctx, subject)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubjectValidAfter indicates an expected call of SubjectValidAfter
+func (mr *MockRevokerMockRecorder) SubjectValidAfter(ctx, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubjectValidAfter", reflect.TypeOf((*MockRevoker)(nil).SubjectValidAfter), ctx, subject)
+}
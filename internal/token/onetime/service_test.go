@@ -0,0 +1,188 @@
+package onetime
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceIssue(t *testing.T) {
+	tests := []struct {
+		name          string
+		generatorFunc func(mock *MockTokenGenerator)
+		wantErr       bool
+	}{
+		{
+			name: "ok",
+			generatorFunc: func(m *MockTokenGenerator) {
+				m.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, c jwt.Claims) (string, error) {
+					claims := c.(auth.Claims)
+					if claims.Audience != "verify-email" || claims.Subject != "username" {
+						return "", errors.New("unexpected claims")
+					}
+					return "token", nil
+				})
+			},
+		},
+		{
+			name: "generate error",
+			generatorFunc: func(m *MockTokenGenerator) {
+				m.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).Return("", errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			generator := NewMockTokenGenerator(ctrl)
+			tc.generatorFunc(generator)
+
+			s := NewService(generator, NewMockTokenParser(ctrl), NewMockRevoker(ctrl))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			tkn, err := s.Issue(ctx, "username", "verify-email", time.Hour)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, "token", tkn)
+		})
+	}
+}
+
+func TestServiceRedeem(t *testing.T) {
+	now := time.Now()
+	valid := auth.Claims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        "jti",
+			Subject:   "username",
+			Audience:  "verify-email",
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(time.Hour).Unix(),
+		},
+		IssuedAtMs: now.UnixNano() / int64(time.Millisecond),
+	}
+	access := valid
+	access.Audience = ""
+
+	tests := []struct {
+		name        string
+		parserFunc  func(mock *MockTokenParser)
+		revokerFunc func(mock *MockRevoker)
+		wantErr     bool
+	}{
+		{
+			name: "ok",
+			parserFunc: func(m *MockTokenParser) {
+				m.EXPECT().ParseClaims(gomock.Any(), "token").Return(valid, nil)
+			},
+			revokerFunc: func(m *MockRevoker) {
+				m.EXPECT().TokenRevoked(gomock.Any(), "jti").Return(false, nil)
+				m.EXPECT().SubjectValidAfter(gomock.Any(), "username").Return(time.Time{}, nil)
+				m.EXPECT().RevokeToken(gomock.Any(), "jti", time.Unix(valid.ExpiresAt, 0)).Return(nil)
+			},
+		},
+		{
+			name: "parse error",
+			parserFunc: func(m *MockTokenParser) {
+				m.EXPECT().ParseClaims(gomock.Any(), gomock.Any()).Return(auth.Claims{}, errors.New("mock error"))
+			},
+			revokerFunc: func(m *MockRevoker) {},
+			wantErr:     true,
+		},
+		{
+			name: "access token",
+			parserFunc: func(m *MockTokenParser) {
+				m.EXPECT().ParseClaims(gomock.Any(), gomock.Any()).Return(access, nil)
+			},
+			revokerFunc: func(m *MockRevoker) {},
+			wantErr:     true,
+		},
+		{
+			name: "used token",
+			parserFunc: func(m *MockTokenParser) {
+				m.EXPECT().ParseClaims(gomock.Any(), gomock.Any()).Return(valid, nil)
+			},
+			revokerFunc: func(m *MockRevoker) {
+				m.EXPECT().TokenRevoked(gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "revoked subject",
+			parserFunc: func(m *MockTokenParser) {
+				m.EXPECT().ParseClaims(gomock.Any(), gomock.Any()).Return(valid, nil)
+			},
+			revokerFunc: func(m *MockRevoker) {
+				m.EXPECT().TokenRevoked(gomock.Any(), gomock.Any()).Return(false, nil)
+				m.EXPECT().SubjectValidAfter(gomock.Any(), gomock.Any()).Return(now.Add(time.Minute), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "issued right after subject revocation",
+			parserFunc: func(m *MockTokenParser) {
+				m.EXPECT().ParseClaims(gomock.Any(), gomock.Any()).Return(valid, nil)
+			},
+			revokerFunc: func(m *MockRevoker) {
+				m.EXPECT().TokenRevoked(gomock.Any(), gomock.Any()).Return(false, nil)
+				m.EXPECT().SubjectValidAfter(gomock.Any(), gomock.Any()).Return(now.Add(-time.Millisecond), nil)
+				m.EXPECT().RevokeToken(gomock.Any(), "jti", time.Unix(valid.ExpiresAt, 0)).Return(nil)
+			},
+		},
+		{
+			name: "revoke error",
+			parserFunc: func(m *MockTokenParser) {
+				m.EXPECT().ParseClaims(gomock.Any(), gomock.Any()).Return(valid, nil)
+			},
+			revokerFunc: func(m *MockRevoker) {
+				m.EXPECT().TokenRevoked(gomock.Any(), gomock.Any()).Return(false, nil)
+				m.EXPECT().SubjectValidAfter(gomock.Any(), gomock.Any()).Return(time.Time{}, nil)
+				m.EXPECT().RevokeToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			parser := NewMockTokenParser(ctrl)
+			revoker := NewMockRevoker(ctrl)
+			tc.parserFunc(parser)
+			tc.revokerFunc(revoker)
+
+			s := NewService(NewMockTokenGenerator(ctrl), parser, revoker)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			subject, err := s.Redeem(ctx, "token", "verify-email")
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, "username", subject)
+		})
+	}
+}
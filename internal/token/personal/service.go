@@ -0,0 +1,256 @@
+package personal
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+// easyjson service.go
+//go:generate mockgen -source=service.go -package=personal -destination=service.mock.go
+
+const (
+	// prefix tells personal access tokens apart from JWTs.
+	prefix = "pat_"
+	// touchInterval limits how often the last use is saved,
+	// so busy automation doesn't write on every request.
+	touchInterval = time.Minute
+)
+
+var (
+	// ErrInvalidToken returns when personal access token
+	// is unknown, expired or revoked.
+	ErrInvalidToken = errors.New("invalid personal access token")
+)
+
+// Validater validates personal access token fields.
+type Validater interface {
+	Validate(context.Context, *Form) error
+}
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	FindByID(ctx context.Context, id int, u *user.User) error
+	CreatePersonalToken(ctx context.Context, f *token.NewPersonal, t *token.Personal) error
+	FindPersonalToken(ctx context.Context, hash string) (*token.Personal, error)
+	ListPersonalTokens(ctx context.Context, userID int) ([]token.Personal, error)
+	RevokePersonalToken(ctx context.Context, userID, id int) error
+	TouchPersonalToken(ctx context.Context, id int, now time.Time) error
+}
+
+// Form is a personal access token form.
+//easyjson:json
+type Form struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// Token holds personal access token data. The secret
+// is given to the client once, when the token is created.
+//easyjson:json
+type Token struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	Secret     string     `json:"token,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Tokens holds personal access tokens of the user.
+//easyjson:json
+type Tokens struct {
+	Tokens []Token `json:"tokens"`
+}
+
+// Service is a use case for personal access tokens.
+type Service struct {
+	Repository
+	Validater
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, v Validater) *Service {
+	s := Service{
+		Repository: r,
+		Validater:  v,
+	}
+
+	return &s
+}
+
+// IsPersonal reports whether the token string
+// looks like a personal access token.
+func IsPersonal(tknStr string) bool {
+	return strings.HasPrefix(tknStr, prefix)
+}
+
+// Create creates a personal access token for the signed in user.
+// Tokens can't create other tokens.
+func (s *Service) Create(ctx context.Context, f *Form) (*Token, error) {
+	if err := auth.RequireSession(ctx); err != nil {
+		return nil, errors.Wrap(err, "require session")
+	}
+
+	if err := s.Validater.Validate(ctx, f); err != nil {
+		return nil, errors.Wrap(err, "validater validate")
+	}
+
+	claims, _ := auth.FromContext(ctx)
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+		return nil, errors.Wrap(err, "repository find user")
+	}
+
+	secret, err := token.Generate()
+	if err != nil {
+		return nil, errors.Wrap(err, "generate secret")
+	}
+	secret = prefix + secret
+
+	np := token.NewPersonal{
+		UserID:    u.ID,
+		Name:      f.Name,
+		Hash:      token.Hash(secret),
+		Scopes:    f.Scopes,
+		ExpiresAt: f.ExpiresAt,
+		CreatedAt: time.Now(),
+	}
+
+	var p token.Personal
+	if err := s.Repository.CreatePersonalToken(ctx, &np, &p); err != nil {
+		return nil, errors.Wrap(err, "repository create token")
+	}
+
+	t := newToken(&p)
+	t.Secret = secret
+
+	return &t, nil
+}
+
+// List lists not revoked personal access tokens of the signed in user.
+func (s *Service) List(ctx context.Context) (*Tokens, error) {
+	if err := auth.RequireSession(ctx); err != nil {
+		return nil, errors.Wrap(err, "require session")
+	}
+
+	claims, _ := auth.FromContext(ctx)
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+		return nil, errors.Wrap(err, "repository find user")
+	}
+
+	ps, err := s.Repository.ListPersonalTokens(ctx, u.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "repository list tokens")
+	}
+
+	ts := Tokens{Tokens: make([]Token, 0, len(ps))}
+	for i := range ps {
+		ts.Tokens = append(ts.Tokens, newToken(&ps[i]))
+	}
+
+	return &ts, nil
+}
+
+// Revoke revokes the personal access token of the signed in user.
+func (s *Service) Revoke(ctx context.Context, id int) error {
+	if err := auth.RequireSession(ctx); err != nil {
+		return errors.Wrap(err, "require session")
+	}
+
+	claims, _ := auth.FromContext(ctx)
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+		return errors.Wrap(err, "repository find user")
+	}
+
+	if err := s.Repository.RevokePersonalToken(ctx, u.ID, id); err != nil {
+		return errors.Wrap(err, "repository revoke token")
+	}
+
+	return nil
+}
+
+// Authenticate recreates the claims of the owner by the personal
+// access token. The claims are limited by the scopes of the token
+// and are issued at the time the token was created, so revoking
+// all tokens of the user revokes personal access tokens as well.
+func (s *Service) Authenticate(ctx context.Context, secret string) (auth.Claims, error) {
+	p, err := s.Repository.FindPersonalToken(ctx, token.Hash(secret))
+	if err != nil {
+		if errors.Cause(err) == token.ErrPersonalNotFound {
+			return auth.Claims{}, ErrInvalidToken
+		}
+		return auth.Claims{}, errors.Wrap(err, "repository find token")
+	}
+
+	now := time.Now()
+	if p.RevokedAt != nil || (p.ExpiresAt != nil && !now.Before(*p.ExpiresAt)) {
+		return auth.Claims{}, ErrInvalidToken
+	}
+
+	var u user.User
+	if err := s.Repository.FindByID(ctx, p.UserID, &u); err != nil {
+		if errors.Cause(err) == user.ErrNotFound {
+			return auth.Claims{}, ErrInvalidToken
+		}
+		return auth.Claims{}, errors.Wrap(err, "repository find user")
+	}
+
+	// Deleted accounts are anonymized and lose the username.
+	if u.Username == "" {
+		return auth.Claims{}, ErrInvalidToken
+	}
+
+	if p.LastUsedAt == nil || now.Sub(*p.LastUsedAt) >= touchInterval {
+		if err := s.Repository.TouchPersonalToken(ctx, p.ID, now); err != nil {
+			return auth.Claims{}, errors.Wrap(err, "repository touch token")
+		}
+	}
+
+	// The token is issued when it's created, so revoking
+	// all tokens of the user revokes the earlier ones.
+	claims := auth.Claims{
+		StandardClaims: jwt.StandardClaims{
+			Subject:  u.Username,
+			IssuedAt: p.CreatedAt.Unix(),
+		},
+		IssuedAtMs: p.CreatedAt.UnixNano() / int64(time.Millisecond),
+		Role:       u.Role,
+		Scopes:     make([]string, len(p.Scopes)),
+	}
+	copy(claims.Scopes, p.Scopes)
+
+	if p.ExpiresAt != nil {
+		claims.ExpiresAt = p.ExpiresAt.Unix()
+	}
+
+	return claims, nil
+}
+
+// newToken prepares the view of the personal access token.
+func newToken(p *token.Personal) Token {
+	t := Token{
+		ID:         p.ID,
+		Name:       p.Name,
+		Scopes:     p.Scopes,
+		LastUsedAt: p.LastUsedAt,
+		ExpiresAt:  p.ExpiresAt,
+		CreatedAt:  p.CreatedAt,
+	}
+
+	return t
+}
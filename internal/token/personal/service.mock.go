@@ -0,0 +1,174 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package personal is a generated GoMock package.
+package personal
+
+import (
+	context "context"
+	token "github.com/dipress/blog/internal/token"
+	user "github.com/dipress/blog/internal/user"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+	time "time"
+)
+
+// MockValidater is a mock of Validater interface
+type MockValidater struct {
+	ctrl     *gomock.Controller
+	recorder *MockValidaterMockRecorder
+}
+
+// MockValidaterMockRecorder is the mock recorder for MockValidater
+type MockValidaterMockRecorder struct {
+	mock *MockValidater
+}
+
+// NewMockValidater creates a new mock instance
+func NewMockValidater(ctrl *gomock.Controller) *MockValidater {
+	mock := &MockValidater{ctrl: ctrl}
+	mock.recorder = &MockValidaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockValidater) EXPECT() *MockValidaterMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method
+func (m *MockValidater) Validate(arg0 context.Context, arg1 *Form) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate
+func (mr *MockValidaterMockRecorder) Validate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockValidater)(nil).Validate), arg0, arg1)
+}
+
+// MockRepository is a mock of Repository interface
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByUsername mocks base method
+func (m *MockRepository) FindByUsername(ctx context.Context, username string, u *user.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUsername", ctx, username, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindByUsername indicates an expected call of FindByUsername
+func (mr *MockRepositoryMockRecorder) FindByUsername(ctx, username, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockRepository)(nil).FindByUsername), ctx, username, u)
+}
+
+// FindByID mocks base method
+func (m *MockRepository) FindByID(ctx context.Context, id int, u *user.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindByID indicates an expected call of FindByID
+func (mr *MockRepositoryMockRecorder) FindByID(ctx, id, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRepository)(nil).FindByID), ctx, id, u)
+}
+
+// CreatePersonalToken mocks base method
+func (m *MockRepository) CreatePersonalToken(ctx context.Context, f *token.NewPersonal, t *token.Personal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePersonalToken", ctx, f, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePersonalToken indicates an expected call of CreatePersonalToken
+func (mr *MockRepositoryMockRecorder) CreatePersonalToken(ctx, f, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePersonalToken", reflect.TypeOf((*MockRepository)(nil).CreatePersonalToken), ctx, f, t)
+}
+
+// FindPersonalToken mocks base method
+func (m *MockRepository) FindPersonalToken(ctx context.Context, hash string) (*token.Personal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPersonalToken", ctx, hash)
+	ret0, _ := ret[0].(*token.Personal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPersonalToken indicates an expected call of FindPersonalToken
+func (mr *MockRepositoryMockRecorder) FindPersonalToken(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPersonalToken", reflect.TypeOf((*MockRepository)(nil).FindPersonalToken), ctx, hash)
+}
+
+// ListPersonalTokens mocks base method
+func (m *MockRepository) ListPersonalTokens(ctx context.Context, userID int) ([]token.Personal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPersonalTokens", ctx, userID)
+	ret0, _ := ret[0].([]token.Personal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPersonalTokens indicates an expected call of ListPersonalTokens
+func (mr *MockRepositoryMockRecorder) ListPersonalTokens(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPersonalTokens", reflect.TypeOf((*MockRepository)(nil).ListPersonalTokens), ctx, userID)
+}
+
+// RevokePersonalToken mocks base method
+func (m *MockRepository) RevokePersonalToken(ctx context.Context, userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokePersonalToken", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokePersonalToken indicates an expected call of RevokePersonalToken
+func (mr *MockRepositoryMockRecorder) RevokePersonalToken(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokePersonalToken", reflect.TypeOf((*MockRepository)(nil).RevokePersonalToken), ctx, userID, id)
+}
+
+// TouchPersonalToken mocks base method
+func (m *MockRepository) TouchPersonalToken(ctx context.Context, id int, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchPersonalToken", ctx, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchPersonalToken indicates an expected call of TouchPersonalToken
+func (mr *MockRepositoryMockRecorder) TouchPersonalToken(ctx, id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchPersonalToken", reflect.TypeOf((*MockRepository)(nil).TouchPersonalToken), ctx, id, now)
+}
@@ -0,0 +1,298 @@
+package personal
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceCreate(t *testing.T) {
+	tests := []struct {
+		name           string
+		scopes         []string
+		validaterFunc  func(mock *MockValidater)
+		repositoryFunc func(mock *MockRepository)
+		wantErr        bool
+		wantScope      bool
+	}{
+		{
+			name: "ok",
+			validaterFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().CreatePersonalToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:           "personal token",
+			scopes:         []string{token.ScopePostsWrite},
+			validaterFunc:  func(m *MockValidater) {},
+			repositoryFunc: func(m *MockRepository) {},
+			wantErr:        true,
+			wantScope:      true,
+		},
+		{
+			name: "validation error",
+			validaterFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			repositoryFunc: func(m *MockRepository) {},
+			wantErr:        true,
+		},
+		{
+			name: "find user error",
+			validaterFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "create error",
+			validaterFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().CreatePersonalToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			validater := NewMockValidater(ctrl)
+			repo := NewMockRepository(ctrl)
+
+			tc.validaterFunc(validater)
+			tc.repositoryFunc(repo)
+
+			s := NewService(repo, validater)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			claims := auth.Claims{Scopes: tc.scopes}
+			newCtx := auth.ToContext(ctx, &claims)
+
+			f := Form{Name: "deploy", Scopes: []string{token.ScopePostsWrite}}
+			got, err := s.Create(newCtx, &f)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tc.wantScope, errors.Cause(err) == auth.ErrInsufficientScope)
+				return
+			}
+			assert.Nil(t, err)
+			assert.True(t, IsPersonal(got.Secret))
+		})
+	}
+}
+
+func TestServiceRevoke(t *testing.T) {
+	tests := []struct {
+		name           string
+		repositoryFunc func(mock *MockRepository)
+		wantErr        bool
+	}{
+		{
+			name: "ok",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().RevokePersonalToken(gomock.Any(), gomock.Any(), 1).Return(nil)
+			},
+		},
+		{
+			name: "not found",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().RevokePersonalToken(gomock.Any(), gomock.Any(), 1).Return(token.ErrPersonalNotFound)
+			},
+			wantErr: true,
+		},
+		{
+			name: "find user error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepository(ctrl)
+			tc.repositoryFunc(repo)
+
+			s := NewService(repo, NewMockValidater(ctrl))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			claims := auth.Claims{}
+			newCtx := auth.ToContext(ctx, &claims)
+
+			err := s.Revoke(newCtx, 1)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestServiceAuthenticate(t *testing.T) {
+	createdAt := time.Now().Add(-time.Hour)
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	recently := time.Now().Add(-time.Second)
+
+	tests := []struct {
+		name           string
+		repositoryFunc func(mock *MockRepository)
+		wantErr        bool
+		wantInvalid    bool
+	}{
+		{
+			name: "ok",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindPersonalToken(gomock.Any(), gomock.Any()).Return(&token.Personal{ID: 1, UserID: 1, Scopes: []string{token.ScopePostsWrite}, ExpiresAt: &future, CreatedAt: createdAt}, nil)
+				m.EXPECT().FindByID(gomock.Any(), 1, gomock.Any()).DoAndReturn(func(ctx context.Context, id int, u *user.User) error {
+					u.Username = "username"
+					u.Role = user.RoleAuthor
+					return nil
+				})
+				m.EXPECT().TouchPersonalToken(gomock.Any(), 1, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "recently used",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindPersonalToken(gomock.Any(), gomock.Any()).Return(&token.Personal{ID: 1, UserID: 1, Scopes: []string{token.ScopePostsWrite}, LastUsedAt: &recently, CreatedAt: createdAt}, nil)
+				m.EXPECT().FindByID(gomock.Any(), 1, gomock.Any()).DoAndReturn(func(ctx context.Context, id int, u *user.User) error {
+					u.Username = "username"
+					u.Role = user.RoleAuthor
+					return nil
+				})
+			},
+		},
+		{
+			name: "unknown",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindPersonalToken(gomock.Any(), gomock.Any()).Return(nil, token.ErrPersonalNotFound)
+			},
+			wantErr:     true,
+			wantInvalid: true,
+		},
+		{
+			name: "revoked",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindPersonalToken(gomock.Any(), gomock.Any()).Return(&token.Personal{RevokedAt: &past, CreatedAt: createdAt}, nil)
+			},
+			wantErr:     true,
+			wantInvalid: true,
+		},
+		{
+			name: "expired",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindPersonalToken(gomock.Any(), gomock.Any()).Return(&token.Personal{ExpiresAt: &past, CreatedAt: createdAt}, nil)
+			},
+			wantErr:     true,
+			wantInvalid: true,
+		},
+		{
+			name: "deleted user",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindPersonalToken(gomock.Any(), gomock.Any()).Return(&token.Personal{UserID: 1, CreatedAt: createdAt}, nil)
+				m.EXPECT().FindByID(gomock.Any(), 1, gomock.Any()).Return(nil)
+			},
+			wantErr:     true,
+			wantInvalid: true,
+		},
+		{
+			name: "find token error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindPersonalToken(gomock.Any(), gomock.Any()).Return(nil, errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "touch error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindPersonalToken(gomock.Any(), gomock.Any()).Return(&token.Personal{ID: 1, UserID: 1, CreatedAt: createdAt}, nil)
+				m.EXPECT().FindByID(gomock.Any(), 1, gomock.Any()).DoAndReturn(func(ctx context.Context, id int, u *user.User) error {
+					u.Username = "username"
+					return nil
+				})
+				m.EXPECT().TouchPersonalToken(gomock.Any(), 1, gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepository(ctrl)
+			tc.repositoryFunc(repo)
+
+			s := NewService(repo, NewMockValidater(ctrl))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			claims, err := s.Authenticate(ctx, "pat_secret")
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tc.wantInvalid, err == ErrInvalidToken)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, "username", claims.Subject)
+			assert.Equal(t, user.RoleAuthor, claims.Role)
+			assert.Equal(t, createdAt.Unix(), claims.IssuedAt)
+			// Revoking all tokens in the second the token is created
+			// revokes it only when the token was created earlier.
+			assert.True(t, claims.IssuedBefore(createdAt.Add(time.Millisecond)))
+			assert.False(t, claims.IssuedBefore(createdAt.Add(-time.Millisecond)))
+			assert.True(t, claims.HasScope(token.ScopePostsWrite))
+			assert.False(t, claims.HasScope(token.ScopeProfileWrite))
+		})
+	}
+}
+
+func TestIsPersonal(t *testing.T) {
+	assert.True(t, IsPersonal("pat_secret"))
+	assert.False(t, IsPersonal("eyJhbGciOiJSUzI1NiJ9.e30.sig"))
+	assert.False(t, IsPersonal(strings.ToUpper("pat_secret")))
+}
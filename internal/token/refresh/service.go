@@ -0,0 +1,159 @@
+package refresh
+
+import (
+	"context"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+// easyjson service.go
+//go:generate mockgen -source=service.go -package=refresh -destination=service.mock.go
+
+// ReuseGrace is how long a rotated refresh token is still exchanged,
+// so parallel requests of one client refreshing with the same token
+// are not taken for a reuse of a stolen token.
+const ReuseGrace = 10 * time.Second
+
+var (
+	// ErrInvalidToken returns when refresh token is unknown,
+	// expired or revoked.
+	ErrInvalidToken = errors.New("invalid refresh token")
+)
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindRefreshToken(ctx context.Context, hash string) (*token.Refresh, error)
+	RevokeRefreshToken(ctx context.Context, id int) error
+	RevokeRefreshFamily(ctx context.Context, family string) error
+	RecentlyRotated(ctx context.Context, id int, grace time.Duration) (bool, error)
+	FindByID(ctx context.Context, id int, u *user.User) error
+}
+
+// TokenGenerator is the behavior we need in our
+// Refresh to generate access tokens.
+type TokenGenerator interface {
+	GenerateToken(ctx context.Context, claims jwt.Claims) (string, error)
+}
+
+// Issuer issues refresh tokens.
+type Issuer interface {
+	Issue(ctx context.Context, userID int, family string) (string, error)
+}
+
+// Form is a refresh form.
+//easyjson:json
+type Form struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Token holds token data.
+//easyjson:json
+type Token struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Service is a use case for access token refreshing.
+type Service struct {
+	Repository
+	TokenGenerator
+	Issuer
+	ExpireAfter time.Duration
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, tg TokenGenerator, i Issuer, exp time.Duration) *Service {
+	s := Service{
+		Repository:     r,
+		TokenGenerator: tg,
+		Issuer:         i,
+		ExpireAfter:    exp,
+	}
+
+	return &s
+}
+
+// Refresh exchanges the refresh token for a new pair of tokens.
+// The given refresh token is revoked. A reuse of a revoked token
+// revokes the whole family since the token was probably stolen,
+// unless the token was rotated within ReuseGrace.
+func (s *Service) Refresh(ctx context.Context, f *Form, t *Token) error {
+	if f.RefreshToken == "" {
+		return ErrInvalidToken
+	}
+
+	rt, err := s.Repository.FindRefreshToken(ctx, token.Hash(f.RefreshToken))
+	if err != nil {
+		if errors.Cause(err) == token.ErrNotFound {
+			return ErrInvalidToken
+		}
+		return errors.Wrap(err, "repository find refresh token")
+	}
+
+	if rt.RevokedAt != nil {
+		if err := s.reused(ctx, rt); err != nil {
+			return err
+		}
+	} else {
+		if !rt.ExpiresAt.After(time.Now()) {
+			return ErrInvalidToken
+		}
+
+		if err := s.Repository.RevokeRefreshToken(ctx, rt.ID); err != nil {
+			if errors.Cause(err) != token.ErrNotFound {
+				return errors.Wrap(err, "repository revoke refresh token")
+			}
+
+			// The token was used concurrently.
+			if err := s.reused(ctx, rt); err != nil {
+				return err
+			}
+		}
+	}
+
+	var u user.User
+	if err := s.Repository.FindByID(ctx, rt.UserID, &u); err != nil {
+		return errors.Wrap(err, "repository find user")
+	}
+
+	claims := auth.NewClaims(u.Username, time.Now(), s.ExpireAfter)
+	claims.Role = u.Role
+
+	tknStr, err := s.TokenGenerator.GenerateToken(ctx, claims)
+	if err != nil {
+		return errors.Wrap(err, "generate token")
+	}
+
+	refresh, err := s.Issuer.Issue(ctx, u.ID, rt.Family)
+	if err != nil {
+		return errors.Wrap(err, "issue refresh token")
+	}
+
+	t.Token = tknStr
+	t.RefreshToken = refresh
+
+	return nil
+}
+
+// reused lets the revoked token through when it was rotated within
+// ReuseGrace and its family is still active. Otherwise the family
+// is revoked and ErrInvalidToken returned.
+func (s *Service) reused(ctx context.Context, rt *token.Refresh) error {
+	rotated, err := s.Repository.RecentlyRotated(ctx, rt.ID, ReuseGrace)
+	if err != nil {
+		return errors.Wrap(err, "repository recently rotated")
+	}
+	if rotated {
+		return nil
+	}
+
+	if err := s.Repository.RevokeRefreshFamily(ctx, rt.Family); err != nil {
+		return errors.Wrap(err, "repository revoke family")
+	}
+	return ErrInvalidToken
+}
@@ -0,0 +1,186 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package refresh is a generated GoMock package.
+package refresh
+
+import (
+	context "context"
+	jwt "github.com/dgrijalva/jwt-go"
+	token "github.com/dipress/blog/internal/token"
+	user "github.com/dipress/blog/internal/user"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+	time "time"
+)
+
+// MockRepository is a mock of Repository interface
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindRefreshToken mocks base method
+func (m *MockRepository) FindRefreshToken(ctx context.Context, hash string) (*token.Refresh, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRefreshToken", ctx, hash)
+	ret0, _ := ret[0].(*token.Refresh)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRefreshToken indicates an expected call of FindRefreshToken
+func (mr *MockRepositoryMockRecorder) FindRefreshToken(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRefreshToken", reflect.TypeOf((*MockRepository)(nil).FindRefreshToken), ctx, hash)
+}
+
+// RevokeRefreshToken mocks base method
+func (m *MockRepository) RevokeRefreshToken(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshToken", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshToken indicates an expected call of RevokeRefreshToken
+func (mr *MockRepositoryMockRecorder) RevokeRefreshToken(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshToken", reflect.TypeOf((*MockRepository)(nil).RevokeRefreshToken), ctx, id)
+}
+
+// RevokeRefreshFamily mocks base method
+func (m *MockRepository) RevokeRefreshFamily(ctx context.Context, family string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshFamily", ctx, family)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshFamily indicates an expected call of RevokeRefreshFamily
+func (mr *MockRepositoryMockRecorder) RevokeRefreshFamily(ctx, family interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshFamily", reflect.TypeOf((*MockRepository)(nil).RevokeRefreshFamily), ctx, family)
+}
+
+// RecentlyRotated mocks base method
+func (m *MockRepository) RecentlyRotated(ctx context.Context, id int, grace time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecentlyRotated", ctx, id, grace)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecentlyRotated indicates an expected call of RecentlyRotated
+func (mr *MockRepositoryMockRecorder) RecentlyRotated(ctx, id, grace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecentlyRotated", reflect.TypeOf((*MockRepository)(nil).RecentlyRotated), ctx, id, grace)
+}
+
+// FindByID mocks base method
+func (m *MockRepository) FindByID(ctx context.Context, id int, u *user.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindByID indicates an expected call of FindByID
+func (mr *MockRepositoryMockRecorder) FindByID(ctx, id, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRepository)(nil).FindByID), ctx, id, u)
+}
+
+// MockTokenGenerator is a mock of TokenGenerator interface
+type MockTokenGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenGeneratorMockRecorder
+}
+
+// MockTokenGeneratorMockRecorder is the mock recorder for MockTokenGenerator
+type MockTokenGeneratorMockRecorder struct {
+	mock *MockTokenGenerator
+}
+
+// NewMockTokenGenerator creates a new mock instance
+func NewMockTokenGenerator(ctrl *gomock.Controller) *MockTokenGenerator {
+	mock := &MockTokenGenerator{ctrl: ctrl}
+	mock.recorder = &MockTokenGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTokenGenerator) EXPECT() *MockTokenGeneratorMockRecorder {
+	return m.recorder
+}
+
+// GenerateToken mocks base method
+func (m *MockTokenGenerator) GenerateToken(ctx context.Context, claims jwt.Claims) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateToken", ctx, claims)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateToken indicates an expected call of GenerateToken
+func (mr *MockTokenGeneratorMockRecorder) GenerateToken(ctx, claims interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockTokenGenerator)(nil).GenerateToken), ctx, claims)
+}
+
+// MockIssuer is a mock of Issuer interface
+type MockIssuer struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssuerMockRecorder
+}
+
+// MockIssuerMockRecorder is the mock recorder for MockIssuer
+type MockIssuerMockRecorder struct {
+	mock *MockIssuer
+}
+
+// NewMockIssuer creates a new mock instance
+func NewMockIssuer(ctrl *gomock.Controller) *MockIssuer {
+	mock := &MockIssuer{ctrl: ctrl}
+	mock.recorder = &MockIssuerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockIssuer) EXPECT() *MockIssuerMockRecorder {
+	return m.recorder
+}
+
+// Issue mocks base method
+func (m *MockIssuer) Issue(ctx context.Context, userID int, family string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", ctx, userID, family)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Issue indicates an expected call of Issue
+func (mr *MockIssuerMockRecorder) Issue(ctx, userID, family interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockIssuer)(nil).Issue), ctx, userID, family)
+}
@@ -0,0 +1,191 @@
+package refresh
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dipress/blog/internal/token"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceRefresh(t *testing.T) {
+	now := time.Now()
+	active := func() *token.Refresh {
+		return &token.Refresh{ID: 1, UserID: 1, Family: "family", ExpiresAt: now.Add(time.Hour)}
+	}
+
+	tests := []struct {
+		name           string
+		form           Form
+		repositoryFunc func(mock *MockRepository)
+		generatorFunc  func(mock *MockTokenGenerator)
+		issuerFunc     func(mock *MockIssuer)
+		wantErr        bool
+	}{
+		{
+			name: "ok",
+			form: Form{RefreshToken: "secret"},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindRefreshToken(gomock.Any(), token.Hash("secret")).Return(active(), nil)
+				m.EXPECT().RevokeRefreshToken(gomock.Any(), 1).Return(nil)
+				m.EXPECT().FindByID(gomock.Any(), 1, gomock.Any()).Return(nil)
+			},
+			generatorFunc: func(m *MockTokenGenerator) {
+				m.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).Return("access", nil)
+			},
+			issuerFunc: func(m *MockIssuer) {
+				m.EXPECT().Issue(gomock.Any(), gomock.Any(), "family").Return("refresh", nil)
+			},
+		},
+		{
+			name:           "empty token",
+			repositoryFunc: func(m *MockRepository) {},
+			generatorFunc:  func(m *MockTokenGenerator) {},
+			issuerFunc:     func(m *MockIssuer) {},
+			wantErr:        true,
+		},
+		{
+			name: "unknown token",
+			form: Form{RefreshToken: "secret"},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(nil, token.ErrNotFound)
+			},
+			generatorFunc: func(m *MockTokenGenerator) {},
+			issuerFunc:    func(m *MockIssuer) {},
+			wantErr:       true,
+		},
+		{
+			name: "reused token",
+			form: Form{RefreshToken: "secret"},
+			repositoryFunc: func(m *MockRepository) {
+				rt := active()
+				rt.RevokedAt = &now
+				m.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(rt, nil)
+				m.EXPECT().RecentlyRotated(gomock.Any(), 1, ReuseGrace).Return(false, nil)
+				m.EXPECT().RevokeRefreshFamily(gomock.Any(), "family").Return(nil)
+			},
+			generatorFunc: func(m *MockTokenGenerator) {},
+			issuerFunc:    func(m *MockIssuer) {},
+			wantErr:       true,
+		},
+		{
+			name: "token rotated by a parallel request",
+			form: Form{RefreshToken: "secret"},
+			repositoryFunc: func(m *MockRepository) {
+				rt := active()
+				rt.RevokedAt = &now
+				m.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(rt, nil)
+				m.EXPECT().RecentlyRotated(gomock.Any(), 1, ReuseGrace).Return(true, nil)
+				m.EXPECT().FindByID(gomock.Any(), 1, gomock.Any()).Return(nil)
+			},
+			generatorFunc: func(m *MockTokenGenerator) {
+				m.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).Return("access", nil)
+			},
+			issuerFunc: func(m *MockIssuer) {
+				m.EXPECT().Issue(gomock.Any(), gomock.Any(), "family").Return("refresh", nil)
+			},
+		},
+		{
+			name: "expired token",
+			form: Form{RefreshToken: "secret"},
+			repositoryFunc: func(m *MockRepository) {
+				rt := active()
+				rt.ExpiresAt = now.Add(-time.Hour)
+				m.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(rt, nil)
+			},
+			generatorFunc: func(m *MockTokenGenerator) {},
+			issuerFunc:    func(m *MockIssuer) {},
+			wantErr:       true,
+		},
+		{
+			name: "concurrent use",
+			form: Form{RefreshToken: "secret"},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(active(), nil)
+				m.EXPECT().RevokeRefreshToken(gomock.Any(), 1).Return(token.ErrNotFound)
+				m.EXPECT().RecentlyRotated(gomock.Any(), 1, ReuseGrace).Return(false, nil)
+				m.EXPECT().RevokeRefreshFamily(gomock.Any(), "family").Return(nil)
+			},
+			generatorFunc: func(m *MockTokenGenerator) {},
+			issuerFunc:    func(m *MockIssuer) {},
+			wantErr:       true,
+		},
+		{
+			name: "concurrent use by the same client",
+			form: Form{RefreshToken: "secret"},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(active(), nil)
+				m.EXPECT().RevokeRefreshToken(gomock.Any(), 1).Return(token.ErrNotFound)
+				m.EXPECT().RecentlyRotated(gomock.Any(), 1, ReuseGrace).Return(true, nil)
+				m.EXPECT().FindByID(gomock.Any(), 1, gomock.Any()).Return(nil)
+			},
+			generatorFunc: func(m *MockTokenGenerator) {
+				m.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).Return("access", nil)
+			},
+			issuerFunc: func(m *MockIssuer) {
+				m.EXPECT().Issue(gomock.Any(), gomock.Any(), "family").Return("refresh", nil)
+			},
+		},
+		{
+			name: "find user error",
+			form: Form{RefreshToken: "secret"},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(active(), nil)
+				m.EXPECT().RevokeRefreshToken(gomock.Any(), 1).Return(nil)
+				m.EXPECT().FindByID(gomock.Any(), 1, gomock.Any()).Return(errors.New("mock error"))
+			},
+			generatorFunc: func(m *MockTokenGenerator) {},
+			issuerFunc:    func(m *MockIssuer) {},
+			wantErr:       true,
+		},
+		{
+			name: "issue error",
+			form: Form{RefreshToken: "secret"},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(active(), nil)
+				m.EXPECT().RevokeRefreshToken(gomock.Any(), 1).Return(nil)
+				m.EXPECT().FindByID(gomock.Any(), 1, gomock.Any()).Return(nil)
+			},
+			generatorFunc: func(m *MockTokenGenerator) {
+				m.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).Return("access", nil)
+			},
+			issuerFunc: func(m *MockIssuer) {
+				m.EXPECT().Issue(gomock.Any(), gomock.Any(), gomock.Any()).Return("", errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepository(ctrl)
+			generator := NewMockTokenGenerator(ctrl)
+			issuer := NewMockIssuer(ctrl)
+			tc.repositoryFunc(repo)
+			tc.generatorFunc(generator)
+			tc.issuerFunc(issuer)
+
+			s := NewService(repo, generator, issuer, time.Minute)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var got Token
+			err := s.Refresh(ctx, &tc.form, &got)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, Token{Token: "access", RefreshToken: "refresh"}, got)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package revoke
+
+import (
+	"context"
+	"time"
+
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+// easyjson service.go
+//go:generate mockgen -source=service.go -package=revoke -destination=service.mock.go
+
+var (
+	// ErrInvalidToken returns when refresh token is unknown.
+	ErrInvalidToken = errors.New("invalid refresh token")
+)
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindRefreshToken(ctx context.Context, hash string) (*token.Refresh, error)
+	RevokeRefreshFamily(ctx context.Context, family string) error
+}
+
+// TokenRevoker revokes access tokens.
+type TokenRevoker interface {
+	RevokeToken(ctx context.Context, id string, expiresAt time.Time) error
+}
+
+// Form is a signout form.
+//easyjson:json
+type Form struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Service is a use case for signing out.
+type Service struct {
+	Repository
+	TokenRevoker
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, tr TokenRevoker) *Service {
+	s := Service{
+		Repository:   r,
+		TokenRevoker: tr,
+	}
+
+	return &s
+}
+
+// Revoke revokes the whole family of the refresh token,
+// so none of the tokens issued in the session can be used.
+// The access token of the request is revoked as well.
+func (s *Service) Revoke(ctx context.Context, f *Form) error {
+	if f.RefreshToken == "" {
+		return ErrInvalidToken
+	}
+
+	rt, err := s.Repository.FindRefreshToken(ctx, token.Hash(f.RefreshToken))
+	if err != nil {
+		if errors.Cause(err) == token.ErrNotFound {
+			return ErrInvalidToken
+		}
+		return errors.Wrap(err, "repository find refresh token")
+	}
+
+	if err := s.Repository.RevokeRefreshFamily(ctx, rt.Family); err != nil {
+		return errors.Wrap(err, "repository revoke family")
+	}
+
+	if claims, ok := auth.FromContext(ctx); ok && claims.Id != "" {
+		if err := s.TokenRevoker.RevokeToken(ctx, claims.Id, time.Unix(claims.ExpiresAt, 0)); err != nil {
+			return errors.Wrap(err, "revoke access token")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package revoke is a generated GoMock package.
+package revoke
+
+import (
+	context "context"
+	token "github.com/dipress/blog/internal/token"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+	time "time"
+)
+
+// MockRepository is a mock of Repository interface
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindRefreshToken mocks base method
+func (m *MockRepository) FindRefreshToken(ctx context.Context, hash string) (*token.Refresh, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRefreshToken", ctx, hash)
+	ret0, _ := ret[0].(*token.Refresh)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRefreshToken indicates an expected call of FindRefreshToken
+func (mr *MockRepositoryMockRecorder) FindRefreshToken(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRefreshToken", reflect.TypeOf((*MockRepository)(nil).FindRefreshToken), ctx, hash)
+}
+
+// RevokeRefreshFamily mocks base method
+func (m *MockRepository) RevokeRefreshFamily(ctx context.Context, family string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshFamily", ctx, family)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshFamily indicates an expected call of RevokeRefreshFamily
+func (mr *MockRepositoryMockRecorder) RevokeRefreshFamily(ctx, family interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshFamily", reflect.TypeOf((*MockRepository)(nil).RevokeRefreshFamily), ctx, family)
+}
+
+// MockTokenRevoker is a mock of TokenRevoker interface
+type MockTokenRevoker struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenRevokerMockRecorder
+}
+
+// MockTokenRevokerMockRecorder is the mock recorder for MockTokenRevoker
+type MockTokenRevokerMockRecorder struct {
+	mock *MockTokenRevoker
+}
+
+// NewMockTokenRevoker creates a new mock instance
+func NewMockTokenRevoker(ctrl *gomock.Controller) *MockTokenRevoker {
+	mock := &MockTokenRevoker{ctrl: ctrl}
+	mock.recorder = &MockTokenRevokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTokenRevoker) EXPECT() *MockTokenRevokerMockRecorder {
+	return m.recorder
+}
+
+// RevokeToken mocks base method
+func (m *MockTokenRevoker) RevokeToken(ctx context.Context, id string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, id, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken
+func (mr *MockTokenRevokerMockRecorder) RevokeToken(ctx, id, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockTokenRevoker)(nil).RevokeToken), ctx, id, expiresAt)
+}
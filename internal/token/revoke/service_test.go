@@ -0,0 +1,115 @@
+package revoke
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceRevoke(t *testing.T) {
+	tests := []struct {
+		name           string
+		form           Form
+		claims         *auth.Claims
+		repositoryFunc func(mock *MockRepository)
+		revokerFunc    func(mock *MockTokenRevoker)
+		wantErr        bool
+	}{
+		{
+			name: "ok",
+			form: Form{RefreshToken: "secret"},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindRefreshToken(gomock.Any(), token.Hash("secret")).Return(&token.Refresh{Family: "family"}, nil)
+				m.EXPECT().RevokeRefreshFamily(gomock.Any(), "family").Return(nil)
+			},
+			revokerFunc: func(m *MockTokenRevoker) {},
+		},
+		{
+			name:   "with access token",
+			form:   Form{RefreshToken: "secret"},
+			claims: &auth.Claims{StandardClaims: jwt.StandardClaims{Id: "jti", ExpiresAt: 1}},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(&token.Refresh{Family: "family"}, nil)
+				m.EXPECT().RevokeRefreshFamily(gomock.Any(), "family").Return(nil)
+			},
+			revokerFunc: func(m *MockTokenRevoker) {
+				m.EXPECT().RevokeToken(gomock.Any(), "jti", time.Unix(1, 0)).Return(nil)
+			},
+		},
+		{
+			name:   "access token error",
+			form:   Form{RefreshToken: "secret"},
+			claims: &auth.Claims{StandardClaims: jwt.StandardClaims{Id: "jti", ExpiresAt: 1}},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(&token.Refresh{Family: "family"}, nil)
+				m.EXPECT().RevokeRefreshFamily(gomock.Any(), "family").Return(nil)
+			},
+			revokerFunc: func(m *MockTokenRevoker) {
+				m.EXPECT().RevokeToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+		{
+			name:           "empty token",
+			repositoryFunc: func(m *MockRepository) {},
+			revokerFunc:    func(m *MockTokenRevoker) {},
+			wantErr:        true,
+		},
+		{
+			name: "unknown token",
+			form: Form{RefreshToken: "secret"},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(nil, token.ErrNotFound)
+			},
+			revokerFunc: func(m *MockTokenRevoker) {},
+			wantErr:     true,
+		},
+		{
+			name: "revoke error",
+			form: Form{RefreshToken: "secret"},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(&token.Refresh{Family: "family"}, nil)
+				m.EXPECT().RevokeRefreshFamily(gomock.Any(), "family").Return(errors.New("mock error"))
+			},
+			revokerFunc: func(m *MockTokenRevoker) {},
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepository(ctrl)
+			revoker := NewMockTokenRevoker(ctrl)
+			tc.repositoryFunc(repo)
+			tc.revokerFunc(revoker)
+
+			s := NewService(repo, revoker)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			if tc.claims != nil {
+				ctx = auth.ToContext(ctx, tc.claims)
+			}
+
+			err := s.Revoke(ctx, &tc.form)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
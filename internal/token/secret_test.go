@@ -0,0 +1,45 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	a, err := Generate()
+	assert.Nil(t, err)
+
+	b, err := Generate()
+	assert.Nil(t, err)
+
+	assert.Len(t, a, 43)
+	assert.NotEqual(t, a, b)
+}
+
+func TestHash(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret string
+		expect string
+	}{
+		{
+			name:   "empty",
+			secret: "",
+			expect: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+		{
+			name:   "secret",
+			secret: "secret",
+			expect: "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expect, Hash(tc.secret))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package list
+
+import (
+	"context"
+
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=service.go -package=list -destination=service.mock.go
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	ListPost(ctx context.Context, f *post.Filter, pos *post.Posts) error
+}
+
+// Service is a use case for trash showing.
+type Service struct {
+	Repository
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository) *Service {
+	s := Service{
+		Repository: r,
+	}
+
+	return &s
+}
+
+// List shows deleted posts of the current user.
+func (s *Service) List(ctx context.Context) (*post.Posts, error) {
+	claims, _ := auth.FromContext(ctx)
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+		return nil, errors.Wrap(err, "repository find user")
+	}
+
+	filter := post.Filter{
+		UserID:  u.ID,
+		Deleted: true,
+	}
+
+	var posts post.Posts
+	if err := s.Repository.ListPost(ctx, &filter, &posts); err != nil {
+		return nil, errors.Wrap(err, "list posts")
+	}
+
+	return &posts, nil
+}
@@ -0,0 +1,63 @@
+package purge
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Repository allows to work with the database.
+type Repository interface {
+	PurgePosts(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Service is a use case for the trash purging.
+type Service struct {
+	Repository
+	retention time.Duration
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, retention time.Duration) *Service {
+	s := Service{
+		Repository: r,
+		retention:  retention,
+	}
+
+	return &s
+}
+
+// Purge permanently deletes posts which stay
+// in the trash longer than the retention period.
+func (s *Service) Purge(ctx context.Context, now time.Time) (int64, error) {
+	n, err := s.Repository.PurgePosts(ctx, now.Add(-s.retention))
+	if err != nil {
+		return 0, errors.Wrap(err, "repository purge posts")
+	}
+
+	return n, nil
+}
+
+// Run purges the trash every interval
+// until the context is done.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := s.Purge(ctx, time.Now())
+		if err != nil {
+			log.Printf("purge trash: %v\n", err)
+		} else if n > 0 {
+			log.Printf("purged %d posts from trash\n", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
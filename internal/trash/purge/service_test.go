@@ -0,0 +1,62 @@
+package purge
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServicePurge(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		repositoryFunc func(ctx context.Context, before time.Time) (int64, error)
+		wantErr        bool
+	}{
+		{
+			name: "ok",
+			repositoryFunc: func(ctx context.Context, before time.Time) (int64, error) {
+				if !before.Equal(now.Add(-time.Hour)) {
+					return 0, errors.New("mock error")
+				}
+				return 1, nil
+			},
+		},
+		{
+			name: "repository error",
+			repositoryFunc: func(ctx context.Context, before time.Time) (int64, error) {
+				return 0, errors.New("mock error")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewService(repositoryFunc(tc.repositoryFunc), time.Hour)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			_, err := s.Purge(ctx, now)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
+
+type repositoryFunc func(ctx context.Context, before time.Time) (int64, error)
+
+func (r repositoryFunc) PurgePosts(ctx context.Context, before time.Time) (int64, error) {
+	return r(ctx, before)
+}
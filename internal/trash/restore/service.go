@@ -0,0 +1,72 @@
+package restore
+
+import (
+	"context"
+
+	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=service.go -package=restore -destination=service.mock.go
+
+// Abillity checks permissions to restore posts.
+type Abillity interface {
+	CanRestore(u *user.User, post *post.Post) bool
+}
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	FindDeletedPost(ctx context.Context, id int) (*post.Post, error)
+	RestorePost(ctx context.Context, id int) error
+}
+
+// Service is a use case for post restoring from the trash.
+type Service struct {
+	Repository
+	Abillity
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, a Abillity) *Service {
+	s := Service{
+		Repository: r,
+		Abillity:   a,
+	}
+
+	return &s
+}
+
+// Restore takes the post out of the trash.
+func (s *Service) Restore(ctx context.Context, id int) (*post.Post, error) {
+	if err := auth.RequireScope(ctx, token.ScopePostsWrite); err != nil {
+		return nil, errors.Wrap(err, "require scope")
+	}
+
+	p, err := s.Repository.FindDeletedPost(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "find deleted post")
+	}
+
+	claims, _ := auth.FromContext(ctx)
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+		return nil, errors.Wrap(err, "repository find user")
+	}
+
+	ok := s.Abillity.CanRestore(&u, p)
+	if !ok {
+		return nil, post.ErrNotFound
+	}
+
+	if err := s.Repository.RestorePost(ctx, id); err != nil {
+		return nil, errors.Wrap(err, "restore post")
+	}
+
+	p.DeletedAt = nil
+	return p, nil
+}
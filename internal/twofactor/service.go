@@ -0,0 +1,252 @@
+package twofactor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+	"time"
+
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/dipress/blog/kit/totp"
+	"github.com/pkg/errors"
+)
+
+// easyjson service.go
+//go:generate mockgen -source=service.go -package=twofactor -destination=service.mock.go
+
+const (
+	// recoveryCodes is the number of recovery codes
+	// given to the user on enrollment.
+	recoveryCodes = 10
+	// skew is the number of periods codes stay valid
+	// before and after their own for clock drift.
+	skew = 1
+)
+
+var (
+	// ErrEnabled returns when two-factor
+	// authentication is already enabled.
+	ErrEnabled = errors.New("two-factor authentication is enabled")
+	// ErrNotEnrolled returns when the user
+	// has not enrolled to two-factor authentication.
+	ErrNotEnrolled = errors.New("two-factor authentication is not enrolled")
+	// ErrInvalidCode returns when the code is wrong,
+	// expired or already used.
+	ErrInvalidCode = errors.New("invalid code")
+)
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	SaveTOTP(ctx context.Context, userID int, secret string, codeHashes []string) error
+	FindTOTP(ctx context.Context, userID int) (*user.TOTP, error)
+	EnableTOTP(ctx context.Context, userID int, step int64) error
+	UseTOTPStep(ctx context.Context, userID int, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userID int, hash string) (bool, error)
+}
+
+// Enrollment contains the secret for authenticator apps
+// and recovery codes, which are shown to the user once.
+//easyjson:json
+type Enrollment struct {
+	Secret        string   `json:"secret"`
+	URI           string   `json:"uri"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// Form is a two-factor code form.
+//easyjson:json
+type Form struct {
+	Code string `json:"code"`
+}
+
+// Service is a use case for two-factor authentication
+// with time-based one-time passwords.
+type Service struct {
+	Repository
+	Issuer string
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, issuer string) *Service {
+	s := Service{
+		Repository: r,
+		Issuer:     issuer,
+	}
+
+	return &s
+}
+
+// Enroll generates a new secret and recovery codes for the current
+// user. Two-factor authentication is enabled once the user sends
+// a valid code, so a lost secret doesn't lock the user out.
+func (s *Service) Enroll(ctx context.Context) (*Enrollment, error) {
+	if err := auth.RequireSession(ctx); err != nil {
+		return nil, errors.Wrap(err, "require session")
+	}
+
+	claims, _ := auth.FromContext(ctx)
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+		return nil, errors.Wrap(err, "repository find user")
+	}
+
+	t, err := s.Repository.FindTOTP(ctx, u.ID)
+	if err != nil && errors.Cause(err) != user.ErrTOTPNotFound {
+		return nil, errors.Wrap(err, "repository find totp")
+	}
+	if t != nil && t.EnabledAt != nil {
+		return nil, ErrEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errors.Wrap(err, "generate secret")
+	}
+
+	codes := make([]string, recoveryCodes)
+	hashes := make([]string, recoveryCodes)
+	for i := range codes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, errors.Wrap(err, "generate recovery code")
+		}
+		codes[i] = code
+		hashes[i] = token.Hash(normalize(code))
+	}
+
+	if err := s.Repository.SaveTOTP(ctx, u.ID, secret, hashes); err != nil {
+		return nil, errors.Wrap(err, "repository save totp")
+	}
+
+	e := Enrollment{
+		Secret:        secret,
+		URI:           totp.URI(s.Issuer, u.Username, secret),
+		RecoveryCodes: codes,
+	}
+
+	return &e, nil
+}
+
+// Enable enables two-factor authentication of the current
+// user when the code matches the enrolled secret.
+func (s *Service) Enable(ctx context.Context, f *Form) error {
+	if err := auth.RequireSession(ctx); err != nil {
+		return errors.Wrap(err, "require session")
+	}
+
+	claims, _ := auth.FromContext(ctx)
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+		return errors.Wrap(err, "repository find user")
+	}
+
+	t, err := s.Repository.FindTOTP(ctx, u.ID)
+	if err != nil {
+		if errors.Cause(err) == user.ErrTOTPNotFound {
+			return ErrNotEnrolled
+		}
+		return errors.Wrap(err, "repository find totp")
+	}
+	if t.EnabledAt != nil {
+		return ErrEnabled
+	}
+
+	step, ok, err := totp.Validate(t.Secret, normalize(f.Code), time.Now(), skew)
+	if err != nil {
+		return errors.Wrap(err, "validate code")
+	}
+	if !ok {
+		return ErrInvalidCode
+	}
+
+	if err := s.Repository.EnableTOTP(ctx, u.ID, step); err != nil {
+		return errors.Wrap(err, "repository enable totp")
+	}
+
+	return nil
+}
+
+// Enabled checks that two-factor authentication of the user is enabled.
+func (s *Service) Enabled(ctx context.Context, userID int) (bool, error) {
+	t, err := s.Repository.FindTOTP(ctx, userID)
+	if err != nil {
+		if errors.Cause(err) == user.ErrTOTPNotFound {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "repository find totp")
+	}
+
+	return t.EnabledAt != nil, nil
+}
+
+// Check checks the code of the user. The code is either a current
+// code of the authenticator app or an unused recovery code. Both
+// can be used once.
+func (s *Service) Check(ctx context.Context, userID int, code string) error {
+	t, err := s.Repository.FindTOTP(ctx, userID)
+	if err != nil {
+		if errors.Cause(err) == user.ErrTOTPNotFound {
+			return ErrInvalidCode
+		}
+		return errors.Wrap(err, "repository find totp")
+	}
+	if t.EnabledAt == nil {
+		return ErrInvalidCode
+	}
+
+	code = normalize(code)
+
+	if len(code) == totp.Digits {
+		step, ok, err := totp.Validate(t.Secret, code, time.Now(), skew)
+		if err != nil {
+			return errors.Wrap(err, "validate code")
+		}
+		if !ok {
+			return ErrInvalidCode
+		}
+
+		used, err := s.Repository.UseTOTPStep(ctx, userID, step)
+		if err != nil {
+			return errors.Wrap(err, "repository use totp step")
+		}
+		if !used {
+			return ErrInvalidCode
+		}
+		return nil
+	}
+
+	used, err := s.Repository.UseRecoveryCode(ctx, userID, token.Hash(code))
+	if err != nil {
+		return errors.Wrap(err, "repository use recovery code")
+	}
+	if !used {
+		return ErrInvalidCode
+	}
+
+	return nil
+}
+
+// generateRecoveryCode returns a random code
+// of the format `xxxxx-xxxxx`.
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "read random")
+	}
+
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(b))
+	return code[:5] + "-" + code[5:], nil
+}
+
+// normalize removes separators and
+// spaces the user may type in the code.
+func normalize(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
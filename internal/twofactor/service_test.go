@@ -0,0 +1,276 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/dipress/blog/kit/totp"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const secret = "JBSWY3DPEHPK3PXP"
+
+func TestServiceEnroll(t *testing.T) {
+	enabledAt := time.Now()
+
+	tests := []struct {
+		name           string
+		repositoryFunc func(mock *MockRepository)
+		wantErr        bool
+	}{
+		{
+			name: "ok",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().FindTOTP(gomock.Any(), gomock.Any()).Return(nil, user.ErrTOTPNotFound)
+				m.EXPECT().SaveTOTP(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "reenroll",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().FindTOTP(gomock.Any(), gomock.Any()).Return(&user.TOTP{Secret: secret}, nil)
+				m.EXPECT().SaveTOTP(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "enabled",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().FindTOTP(gomock.Any(), gomock.Any()).Return(&user.TOTP{Secret: secret, EnabledAt: &enabledAt}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "find user error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "find totp error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().FindTOTP(gomock.Any(), gomock.Any()).Return(nil, errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "save totp error",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().FindTOTP(gomock.Any(), gomock.Any()).Return(nil, user.ErrTOTPNotFound)
+				m.EXPECT().SaveTOTP(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepository(ctrl)
+			tc.repositoryFunc(repo)
+
+			s := NewService(repo, "blog")
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			claims := auth.Claims{}
+			newCtx := auth.ToContext(ctx, &claims)
+
+			e, err := s.Enroll(newCtx)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Len(t, e.RecoveryCodes, recoveryCodes)
+			assert.True(t, strings.HasPrefix(e.URI, "otpauth://totp/"))
+		})
+	}
+}
+
+func TestServiceEnable(t *testing.T) {
+	enabledAt := time.Now()
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		code           string
+		repositoryFunc func(mock *MockRepository)
+		wantErr        error
+	}{
+		{
+			name: "ok",
+			code: code,
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().FindTOTP(gomock.Any(), gomock.Any()).Return(&user.TOTP{Secret: secret}, nil)
+				m.EXPECT().EnableTOTP(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "invalid code",
+			code: "abcdef",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().FindTOTP(gomock.Any(), gomock.Any()).Return(&user.TOTP{Secret: secret}, nil)
+			},
+			wantErr: ErrInvalidCode,
+		},
+		{
+			name: "not enrolled",
+			code: code,
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().FindTOTP(gomock.Any(), gomock.Any()).Return(nil, user.ErrTOTPNotFound)
+			},
+			wantErr: ErrNotEnrolled,
+		},
+		{
+			name: "enabled",
+			code: code,
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().FindTOTP(gomock.Any(), gomock.Any()).Return(&user.TOTP{Secret: secret, EnabledAt: &enabledAt}, nil)
+			},
+			wantErr: ErrEnabled,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepository(ctrl)
+			tc.repositoryFunc(repo)
+
+			s := NewService(repo, "blog")
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			claims := auth.Claims{}
+			newCtx := auth.ToContext(ctx, &claims)
+
+			err := s.Enable(newCtx, &Form{Code: tc.code})
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestServiceCheck(t *testing.T) {
+	enabledAt := time.Now()
+	enabled := user.TOTP{Secret: secret, EnabledAt: &enabledAt}
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		code           string
+		repositoryFunc func(mock *MockRepository)
+		wantErr        bool
+	}{
+		{
+			name: "totp code",
+			code: code,
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindTOTP(gomock.Any(), gomock.Any()).Return(&enabled, nil)
+				m.EXPECT().UseTOTPStep(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+		},
+		{
+			name: "reused totp code",
+			code: code,
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindTOTP(gomock.Any(), gomock.Any()).Return(&enabled, nil)
+				m.EXPECT().UseTOTPStep(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "recovery code",
+			code: "ABCDE-FGHIJ",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindTOTP(gomock.Any(), gomock.Any()).Return(&enabled, nil)
+				m.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Any(), token.Hash("abcdefghij")).Return(true, nil)
+			},
+		},
+		{
+			name: "unknown recovery code",
+			code: "abcde-fghij",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindTOTP(gomock.Any(), gomock.Any()).Return(&enabled, nil)
+				m.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "not enabled",
+			code: code,
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindTOTP(gomock.Any(), gomock.Any()).Return(&user.TOTP{Secret: secret}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "find totp error",
+			code: code,
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindTOTP(gomock.Any(), gomock.Any()).Return(nil, errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepository(ctrl)
+			tc.repositoryFunc(repo)
+
+			s := NewService(repo, "blog")
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			err := s.Check(ctx, 1, tc.code)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
@@ -2,8 +2,12 @@ package update
 
 import (
 	"context"
+	"time"
 
 	"github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/revision"
+	"github.com/dipress/blog/internal/tag"
+	"github.com/dipress/blog/internal/token"
 	"github.com/dipress/blog/internal/user"
 	"github.com/dipress/blog/kit/auth"
 	"github.com/pkg/errors"
@@ -14,7 +18,7 @@ import (
 
 // Abillity checks permissions to view posts.
 type Abillity interface {
-	CanUpdate(userID int, post *post.Post) bool
+	CanUpdate(u *user.User, post *post.Post) bool
 }
 
 // Validater validates post fields.
@@ -26,14 +30,21 @@ type Validater interface {
 type Repository interface {
 	FindByUsername(ctx context.Context, username string, u *user.User) error
 	FindPost(ctx context.Context, id int) (*post.Post, error)
-	UpdatePost(ctx context.Context, id int, p *post.Post) error
+	UpdatePost(ctx context.Context, id int, p *post.Post, nr *revision.NewRevision) error
 }
 
 // Form is a post form.
 //easyjson:json
 type Form struct {
-	Title string `json:"title"`
-	Body  string `json:"body"`
+	Title        string     `json:"title"`
+	Body         string     `json:"body"`
+	Format       string     `json:"format"`
+	Description  string     `json:"description"`
+	CanonicalURL string     `json:"canonical_url"`
+	ImageURL     string     `json:"image_url"`
+	Tags         []string   `json:"tags"`
+	Status       string     `json:"status"`
+	PublishedAt  *time.Time `json:"published_at"`
 }
 
 // Service is a use case for post validation and updation.
@@ -55,6 +66,10 @@ func NewService(r Repository, v Validater, a Abillity) *Service {
 
 // Update updates a post.
 func (s *Service) Update(ctx context.Context, id int, f *Form) (*post.Post, error) {
+	if err := auth.RequireScope(ctx, token.ScopePostsWrite); err != nil {
+		return nil, errors.Wrap(err, "require scope")
+	}
+
 	if err := s.Validater.Validate(ctx, f); err != nil {
 		return nil, errors.Wrap(err, "validater validate")
 	}
@@ -71,15 +86,46 @@ func (s *Service) Update(ctx context.Context, id int, f *Form) (*post.Post, erro
 		return nil, errors.Wrap(err, "repository find user")
 	}
 
-	ok := s.Abillity.CanUpdate(u.ID, p)
+	ok := s.Abillity.CanUpdate(&u, p)
 	if !ok {
 		return nil, post.ErrNotFound
 	}
 
+	// Keep the replaced version to be able to restore it.
+	nr := revision.NewRevision{
+		PostID: p.ID,
+		UserID: u.ID,
+		Title:  p.Title,
+		Body:   p.Body,
+		Format: p.Format,
+		Tags:   p.Tags,
+	}
+
+	// The slug follows the title, the old one keeps working.
+	if post.Slug(f.Title) != post.Slug(p.Title) {
+		p.Slug = post.Slug(f.Title)
+	}
 	p.Title = f.Title
 	p.Body = f.Body
+	p.Description = f.Description
+	p.CanonicalURL = f.CanonicalURL
+	p.ImageURL = f.ImageURL
+	p.Tags = tag.Normalize(f.Tags)
+
+	if f.Format != "" {
+		p.Format = f.Format
+	}
+	p.BodyHTML = post.RenderBody(p.Format, p.Body)
+
+	if f.Status != "" {
+		p.Status = f.Status
+	}
+	if f.PublishedAt != nil {
+		p.PublishedAt = f.PublishedAt
+	}
+	p.PublishedAt = post.PublishedAt(p.Status, p.PublishedAt, time.Now())
 
-	if err := s.Repository.UpdatePost(ctx, id, p); err != nil {
+	if err := s.Repository.UpdatePost(ctx, id, p, &nr); err != nil {
 		return nil, errors.Wrap(err, "update post")
 	}
 	return p, nil
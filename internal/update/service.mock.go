@@ -7,6 +7,7 @@ package update
 import (
 	context "context"
 	post "github.com/dipress/blog/internal/post"
+	revision "github.com/dipress/blog/internal/revision"
 	user "github.com/dipress/blog/internal/user"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
@@ -36,17 +37,17 @@ func (m *MockAbillity) EXPECT() *MockAbillityMockRecorder {
 }
 
 // CanUpdate mocks base method
-func (m *MockAbillity) CanUpdate(userID int, post *post.Post) bool {
+func (m *MockAbillity) CanUpdate(u *user.User, post *post.Post) bool {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CanUpdate", userID, post)
+	ret := m.ctrl.Call(m, "CanUpdate", u, post)
 	ret0, _ := ret[0].(bool)
 	return ret0
 }
 
 // CanUpdate indicates an expected call of CanUpdate
-func (mr *MockAbillityMockRecorder) CanUpdate(userID, post interface{}) *gomock.Call {
+func (mr *MockAbillityMockRecorder) CanUpdate(u, post interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanUpdate", reflect.TypeOf((*MockAbillity)(nil).CanUpdate), userID, post)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanUpdate", reflect.TypeOf((*MockAbillity)(nil).CanUpdate), u, post)
 }
 
 // MockValidater is a mock of Validater interface
@@ -139,15 +140,15 @@ func (mr *MockRepositoryMockRecorder) FindPost(ctx, id interface{}) *gomock.Call
 }
 
 // UpdatePost mocks base method
-func (m *MockRepository) UpdatePost(ctx context.Context, id int, p *post.Post) error {
+func (m *MockRepository) UpdatePost(ctx context.Context, id int, p *post.Post, nr *revision.NewRevision) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePost", ctx, id, p)
+	ret := m.ctrl.Call(m, "UpdatePost", ctx, id, p, nr)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdatePost indicates an expected call of UpdatePost
-func (mr *MockRepositoryMockRecorder) UpdatePost(ctx, id, p interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) UpdatePost(ctx, id, p, nr interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePost", reflect.TypeOf((*MockRepository)(nil).UpdatePost), ctx, id, p)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePost", reflect.TypeOf((*MockRepository)(nil).UpdatePost), ctx, id, p, nr)
 }
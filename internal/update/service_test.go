@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	post "github.com/dipress/blog/internal/post"
+	"github.com/dipress/blog/internal/revision"
 	"github.com/dipress/blog/kit/auth"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -27,7 +28,7 @@ func TestServiceUpdate(t *testing.T) {
 			repositoryFunc: func(m *MockRepository) {
 				m.EXPECT().FindPost(gomock.Any(), gomock.Any()).Return(&post.Post{}, nil)
 				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-				m.EXPECT().UpdatePost(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().UpdatePost(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 			abilityFunc: func(m *MockAbillity) {
 				m.EXPECT().CanUpdate(gomock.Any(), gomock.Any()).Return(true)
@@ -79,6 +80,25 @@ func TestServiceUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "keeps revision",
+			validateFunc: func(m *MockValidater) {
+				m.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindPost(gomock.Any(), gomock.Any()).Return(&post.Post{Title: "old title"}, nil)
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().UpdatePost(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, id int, p *post.Post, nr *revision.NewRevision) error {
+					if nr == nil || nr.Title != "old title" {
+						return errors.New("replaced version is not kept")
+					}
+					return nil
+				})
+			},
+			abilityFunc: func(m *MockAbillity) {
+				m.EXPECT().CanUpdate(gomock.Any(), gomock.Any()).Return(true)
+			},
+		},
 		{
 			name: "update error",
 			validateFunc: func(m *MockValidater) {
@@ -87,7 +107,7 @@ func TestServiceUpdate(t *testing.T) {
 			repositoryFunc: func(m *MockRepository) {
 				m.EXPECT().FindPost(gomock.Any(), gomock.Any()).Return(&post.Post{}, nil)
 				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-				m.EXPECT().UpdatePost(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+				m.EXPECT().UpdatePost(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
 			},
 			abilityFunc: func(m *MockAbillity) {
 				m.EXPECT().CanUpdate(gomock.Any(), gomock.Any()).Return(true)
@@ -119,17 +139,21 @@ func TestServiceUpdate(t *testing.T) {
 			newCtx := auth.ToContext(ctx, &claims)
 
 			form := Form{
-				Title: "update my awesome titie",
-				Body:  "update my awesome body",
+				Title:  "update my awesome titie",
+				Body:   "update *my* awesome body",
+				Format: post.FormatMarkdown,
 			}
 
-			_, err := s.Update(newCtx, 1, &form)
+			got, err := s.Update(newCtx, 1, &form)
 
 			if tc.wantErr {
 				assert.Error(t, err)
 				return
 			}
 			assert.Nil(t, err)
+			assert.Equal(t, "update-my-awesome-titie", got.Slug)
+			assert.Equal(t, post.FormatMarkdown, got.Format)
+			assert.Equal(t, "<p>update <em>my</em> awesome body</p>\n", got.BodyHTML)
 		})
 	}
 }
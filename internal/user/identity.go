@@ -0,0 +1,26 @@
+package user
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrIdentityNotFound raises when the external
+	// identity is not linked to any user.
+	ErrIdentityNotFound = errors.New("identity not found")
+	// ErrIdentityExists raises when the external
+	// identity is already linked to a user.
+	ErrIdentityExists = errors.New("identity already exists")
+)
+
+// Identity is an account of the user at an OpenID Connect
+// provider. Subject is the id of the account at the provider.
+type Identity struct {
+	ID        int
+	UserID    int
+	Provider  string
+	Subject   string
+	Email     string
+	CreatedAt time.Time
+}
@@ -12,14 +12,51 @@ var (
 	ErrNotFound = errors.New("user not found")
 )
 
+// User roles.
+const (
+	RoleReader = "reader"
+	RoleAuthor = "author"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+)
+
 // User contains all user field.
 type User struct {
-	ID           int       `json:"id"`
-	Username     string    `json:"username"`
-	Email        string    `json:"email"`
-	PasswordHash string    `json:"password_hash"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           int        `json:"id"`
+	Username     string     `json:"username"`
+	Email        string     `json:"email"`
+	PasswordHash string     `json:"-"`
+	Role         string     `json:"role"`
+	DisplayName  string     `json:"display_name"`
+	Bio          string     `json:"bio"`
+	AvatarURL    string     `json:"avatar_url"`
+	VerifiedAt   *time.Time `json:"verified_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// Profile contains public user fields.
+type Profile struct {
+	ID          int       `json:"id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	Bio         string    `json:"bio"`
+	AvatarURL   string    `json:"avatar_url"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Profile returns public fields of the user.
+func (u *User) Profile() *Profile {
+	p := Profile{
+		ID:          u.ID,
+		Username:    u.Username,
+		DisplayName: u.DisplayName,
+		Bio:         u.Bio,
+		AvatarURL:   u.AvatarURL,
+		CreatedAt:   u.CreatedAt,
+	}
+
+	return &p
 }
 
 // NewUser contains the information which needs to create a new User.
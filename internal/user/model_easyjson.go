@@ -7,6 +7,7 @@ import (
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
 )
 
 // suppress unused package warning
@@ -42,8 +43,26 @@ func easyjsonC80ae7adDecodeGithubComDipressBlogInternalUser(in *jlexer.Lexer, ou
 			out.Username = string(in.String())
 		case "email":
 			out.Email = string(in.String())
-		case "password_hash":
-			out.PasswordHash = string(in.String())
+		case "role":
+			out.Role = string(in.String())
+		case "display_name":
+			out.DisplayName = string(in.String())
+		case "bio":
+			out.Bio = string(in.String())
+		case "avatar_url":
+			out.AvatarURL = string(in.String())
+		case "verified_at":
+			if in.IsNull() {
+				in.Skip()
+				out.VerifiedAt = nil
+			} else {
+				if out.VerifiedAt == nil {
+					out.VerifiedAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.VerifiedAt).UnmarshalJSON(data))
+				}
+			}
 		case "created_at":
 			if data := in.Raw(); in.Ok() {
 				in.AddError((out.CreatedAt).UnmarshalJSON(data))
@@ -97,14 +116,58 @@ func easyjsonC80ae7adEncodeGithubComDipressBlogInternalUser(out *jwriter.Writer,
 		out.String(string(in.Email))
 	}
 	{
-		const prefix string = ",\"password_hash\":"
+		const prefix string = ",\"role\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.PasswordHash))
+		out.String(string(in.Role))
+	}
+	{
+		const prefix string = ",\"display_name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.DisplayName))
+	}
+	{
+		const prefix string = ",\"bio\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Bio))
+	}
+	{
+		const prefix string = ",\"avatar_url\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.AvatarURL))
+	}
+	{
+		const prefix string = ",\"verified_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.VerifiedAt == nil {
+			out.RawString("null")
+		} else {
+			out.Raw((*in.VerifiedAt).MarshalJSON())
+		}
 	}
 	{
 		const prefix string = ",\"created_at\":"
@@ -152,7 +215,140 @@ func (v *User) UnmarshalJSON(data []byte) error {
 func (v *User) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonC80ae7adDecodeGithubComDipressBlogInternalUser(l, v)
 }
-func easyjsonC80ae7adDecodeGithubComDipressBlogInternalUser1(in *jlexer.Lexer, out *NewUser) {
+func easyjsonC80ae7adDecodeGithubComDipressBlogInternalUser1(in *jlexer.Lexer, out *Profile) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "username":
+			out.Username = string(in.String())
+		case "display_name":
+			out.DisplayName = string(in.String())
+		case "bio":
+			out.Bio = string(in.String())
+		case "avatar_url":
+			out.AvatarURL = string(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC80ae7adEncodeGithubComDipressBlogInternalUser1(out *jwriter.Writer, in Profile) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"username\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Username))
+	}
+	{
+		const prefix string = ",\"display_name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.DisplayName))
+	}
+	{
+		const prefix string = ",\"bio\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Bio))
+	}
+	{
+		const prefix string = ",\"avatar_url\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.AvatarURL))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Profile) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC80ae7adEncodeGithubComDipressBlogInternalUser1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Profile) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC80ae7adEncodeGithubComDipressBlogInternalUser1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Profile) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC80ae7adDecodeGithubComDipressBlogInternalUser1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Profile) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC80ae7adDecodeGithubComDipressBlogInternalUser1(l, v)
+}
+func easyjsonC80ae7adDecodeGithubComDipressBlogInternalUser2(in *jlexer.Lexer, out *NewUser) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -187,7 +383,7 @@ func easyjsonC80ae7adDecodeGithubComDipressBlogInternalUser1(in *jlexer.Lexer, o
 		in.Consumed()
 	}
 }
-func easyjsonC80ae7adEncodeGithubComDipressBlogInternalUser1(out *jwriter.Writer, in NewUser) {
+func easyjsonC80ae7adEncodeGithubComDipressBlogInternalUser2(out *jwriter.Writer, in NewUser) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -227,23 +423,23 @@ func easyjsonC80ae7adEncodeGithubComDipressBlogInternalUser1(out *jwriter.Writer
 // MarshalJSON supports json.Marshaler interface
 func (v NewUser) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC80ae7adEncodeGithubComDipressBlogInternalUser1(&w, v)
+	easyjsonC80ae7adEncodeGithubComDipressBlogInternalUser2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v NewUser) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC80ae7adEncodeGithubComDipressBlogInternalUser1(w, v)
+	easyjsonC80ae7adEncodeGithubComDipressBlogInternalUser2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *NewUser) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC80ae7adDecodeGithubComDipressBlogInternalUser1(&r, v)
+	easyjsonC80ae7adDecodeGithubComDipressBlogInternalUser2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *NewUser) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC80ae7adDecodeGithubComDipressBlogInternalUser1(l, v)
+	easyjsonC80ae7adDecodeGithubComDipressBlogInternalUser2(l, v)
 }
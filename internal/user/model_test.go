@@ -0,0 +1,42 @@
+package user
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserProfile(t *testing.T) {
+	u := User{
+		ID:           1,
+		Username:     "username",
+		Email:        "username@example.com",
+		PasswordHash: "hash",
+		DisplayName:  "User Name",
+		Bio:          "bio",
+		AvatarURL:    "https://example.com/avatar.png",
+	}
+
+	p := u.Profile()
+	assert.Equal(t, u.ID, p.ID)
+	assert.Equal(t, u.Username, p.Username)
+	assert.Equal(t, u.DisplayName, p.DisplayName)
+	assert.Equal(t, u.Bio, p.Bio)
+	assert.Equal(t, u.AvatarURL, p.AvatarURL)
+
+	data, err := p.MarshalJSON()
+	assert.Nil(t, err)
+	assert.False(t, strings.Contains(string(data), u.Email))
+}
+
+func TestUserMarshalJSON(t *testing.T) {
+	u := User{
+		Username:     "username",
+		PasswordHash: "hash",
+	}
+
+	data, err := u.MarshalJSON()
+	assert.Nil(t, err)
+	assert.False(t, strings.Contains(string(data), "password_hash"))
+}
@@ -0,0 +1,23 @@
+package user
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrTOTPNotFound raises when the user has no
+	// two-factor secret in the database.
+	ErrTOTPNotFound = errors.New("totp not found")
+)
+
+// TOTP is a two-factor secret of the user. Only the secret is kept
+// in plain text, since codes are computed from it. LastStep is the
+// step of the last used code, so it can't be used twice.
+type TOTP struct {
+	UserID    int
+	Secret    string
+	LastStep  int64
+	EnabledAt *time.Time
+	CreatedAt time.Time
+}
@@ -2,10 +2,23 @@ package validation
 
 import (
 	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
 
+	commentCreate "github.com/dipress/blog/internal/comment/create"
+	commentUpdate "github.com/dipress/blog/internal/comment/update"
 	"github.com/dipress/blog/internal/create"
+	"github.com/dipress/blog/internal/password"
+	"github.com/dipress/blog/internal/post"
+	profileUpdate "github.com/dipress/blog/internal/profile/update"
 	"github.com/dipress/blog/internal/reg"
+	"github.com/dipress/blog/internal/role"
+	"github.com/dipress/blog/internal/token"
+	"github.com/dipress/blog/internal/token/personal"
 	"github.com/dipress/blog/internal/update"
+	"github.com/dipress/blog/internal/user"
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/go-ozzo/ozzo-validation/is"
 )
@@ -13,6 +26,19 @@ import (
 const (
 	mismatchMsg   = "mismatch"
 	validationMsg = "you have validation errors"
+	maxTags       = 10
+	maxTagLength  = 30
+	maxBodyLength = 100000
+	maxBodyLines  = 2000
+	futureMsg     = "must be in the future"
+	linesMsg      = "must have no more than 2000 lines"
+)
+
+var (
+	// tagRegexp allows tag names of letters, digits, spaces
+	// and + # . - symbols starting with a letter or a digit.
+	tagRegexp  = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N} +#.-]*$`)
+	httpRegexp = regexp.MustCompile(`^https?://`)
 )
 
 // Errors holds validation errors.
@@ -36,11 +62,47 @@ func (v *Create) Validate(ctx context.Context, f *create.Form) error {
 		ves["title"] = err.Error()
 	}
 
-	if err := validation.Validate(f.Body,
-		validation.Required); err != nil {
+	if err := validateBody(f.Body); err != nil {
 		ves["body"] = err.Error()
 	}
 
+	if err := validation.Validate(f.Format,
+		validation.In(post.FormatPlain, post.FormatMarkdown)); err != nil {
+		ves["format"] = err.Error()
+	}
+
+	if err := validation.Validate(f.Description,
+		validation.Length(0, 300)); err != nil {
+		ves["description"] = err.Error()
+	}
+
+	if err := validation.Validate(f.CanonicalURL,
+		validation.Length(0, 2048),
+		is.URL,
+		validation.Match(httpRegexp)); err != nil {
+		ves["canonical_url"] = err.Error()
+	}
+
+	if err := validation.Validate(f.ImageURL,
+		validation.Length(0, 2048),
+		is.URL,
+		validation.Match(httpRegexp)); err != nil {
+		ves["image_url"] = err.Error()
+	}
+
+	if err := validateTags(f.Tags); err != nil {
+		ves["tags"] = err.Error()
+	}
+
+	if err := validation.Validate(f.Status,
+		validation.In(post.StatusDraft, post.StatusScheduled, post.StatusPublished, post.StatusArchived)); err != nil {
+		ves["status"] = err.Error()
+	}
+
+	if err := validateSchedule(f.Status, f.PublishedAt); err != nil {
+		ves["published_at"] = err.Error()
+	}
+
 	if len(ves) > 0 {
 		return ves
 	}
@@ -61,17 +123,106 @@ func (v *Update) Validate(ctx context.Context, f *update.Form) error {
 		ves["title"] = err.Error()
 	}
 
-	if err := validation.Validate(f.Body,
-		validation.Required); err != nil {
+	if err := validateBody(f.Body); err != nil {
 		ves["body"] = err.Error()
 	}
 
+	if err := validation.Validate(f.Format,
+		validation.In(post.FormatPlain, post.FormatMarkdown)); err != nil {
+		ves["format"] = err.Error()
+	}
+
+	if err := validation.Validate(f.Description,
+		validation.Length(0, 300)); err != nil {
+		ves["description"] = err.Error()
+	}
+
+	if err := validation.Validate(f.CanonicalURL,
+		validation.Length(0, 2048),
+		is.URL,
+		validation.Match(httpRegexp)); err != nil {
+		ves["canonical_url"] = err.Error()
+	}
+
+	if err := validation.Validate(f.ImageURL,
+		validation.Length(0, 2048),
+		is.URL,
+		validation.Match(httpRegexp)); err != nil {
+		ves["image_url"] = err.Error()
+	}
+
+	if err := validateTags(f.Tags); err != nil {
+		ves["tags"] = err.Error()
+	}
+
+	if err := validation.Validate(f.Status,
+		validation.In(post.StatusDraft, post.StatusScheduled, post.StatusPublished, post.StatusArchived)); err != nil {
+		ves["status"] = err.Error()
+	}
+
+	if err := validateSchedule(f.Status, f.PublishedAt); err != nil {
+		ves["published_at"] = err.Error()
+	}
+
 	if len(ves) > 0 {
 		return ves
 	}
 	return nil
 }
 
+// validateBody limits the size of the body, revisions
+// of the post are compared line by line.
+func validateBody(body string) error {
+	if err := validation.Validate(body,
+		validation.Required,
+		validation.RuneLength(1, maxBodyLength)); err != nil {
+		return err
+	}
+
+	if strings.Count(body, "\n") >= maxBodyLines {
+		return errors.New(linesMsg)
+	}
+
+	return nil
+}
+
+// validateTags validates the number of tags and each tag name.
+func validateTags(tags []string) error {
+	if err := validation.Validate(tags,
+		validation.Length(0, maxTags)); err != nil {
+		return err
+	}
+
+	for _, t := range tags {
+		if err := validation.Validate(t,
+			validation.Required,
+			validation.Length(1, maxTagLength),
+			validation.Match(tagRegexp)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateSchedule requires a future publication time for scheduled posts.
+func validateSchedule(status string, publishedAt *time.Time) error {
+	if status != post.StatusScheduled {
+		return nil
+	}
+
+	if err := validation.Validate(publishedAt,
+		validation.Required); err != nil {
+		return err
+	}
+
+	if !publishedAt.After(time.Now()) {
+		return errors.New(futureMsg)
+	}
+
+	return nil
+}
+
 // Registrate holds create form validations.
 type Registrate struct{}
 
@@ -106,3 +257,172 @@ func (v *Registrate) ValidateUser(ctx context.Context, f *reg.Form) error {
 
 	return nil
 }
+
+// ResetPassword holds password reset form validations.
+type ResetPassword struct{}
+
+// Validate validates password reset form.
+func (v *ResetPassword) Validate(ctx context.Context, f *password.ResetForm) error {
+	ves := make(Errors)
+
+	if err := validation.Validate(f.Token,
+		validation.Required,
+	); err != nil {
+		ves["token"] = err.Error()
+	}
+
+	if err := validation.Validate(f.Password,
+		validation.Required,
+		validation.Length(10, 0),
+	); err != nil {
+		ves["password"] = err.Error()
+	}
+
+	if len(ves) > 0 {
+		return ves
+	}
+
+	return nil
+}
+
+// UpdateProfile holds profile form validations.
+type UpdateProfile struct{}
+
+// Validate validates profile form for the update.
+func (v *UpdateProfile) Validate(ctx context.Context, f *profileUpdate.Form) error {
+	ves := make(Errors)
+
+	if err := validation.Validate(f.DisplayName,
+		validation.Length(0, 50)); err != nil {
+		ves["display_name"] = err.Error()
+	}
+
+	if err := validation.Validate(f.Bio,
+		validation.Length(0, 500)); err != nil {
+		ves["bio"] = err.Error()
+	}
+
+	if err := validation.Validate(f.AvatarURL,
+		validation.Length(0, 2048),
+		is.URL,
+		validation.Match(httpRegexp)); err != nil {
+		ves["avatar_url"] = err.Error()
+	}
+
+	if len(ves) > 0 {
+		return ves
+	}
+
+	return nil
+}
+
+// AssignRole holds role form validations.
+type AssignRole struct{}
+
+// Validate validates role form for the assignment.
+func (v *AssignRole) Validate(ctx context.Context, f *role.Form) error {
+	ves := make(Errors)
+
+	if err := validation.Validate(f.Role,
+		validation.Required,
+		validation.In(user.RoleReader, user.RoleAuthor, user.RoleEditor, user.RoleAdmin)); err != nil {
+		ves["role"] = err.Error()
+	}
+
+	if len(ves) > 0 {
+		return ves
+	}
+
+	return nil
+}
+
+// CreateComment holds comment create form validations.
+type CreateComment struct{}
+
+// Validate validates comment form for the create.
+func (v *CreateComment) Validate(ctx context.Context, f *commentCreate.Form) error {
+	ves := make(Errors)
+
+	if err := validation.Validate(f.Body,
+		validation.Required,
+		validation.Length(1, 2000)); err != nil {
+		ves["body"] = err.Error()
+	}
+
+	if err := validation.Validate(f.ParentID,
+		validation.Min(0)); err != nil {
+		ves["parent_id"] = err.Error()
+	}
+
+	if len(ves) > 0 {
+		return ves
+	}
+
+	return nil
+}
+
+// UpdateComment holds comment update form validations.
+type UpdateComment struct{}
+
+// Validate validates comment form for the update.
+func (v *UpdateComment) Validate(ctx context.Context, f *commentUpdate.Form) error {
+	ves := make(Errors)
+
+	if err := validation.Validate(f.Body,
+		validation.Required,
+		validation.Length(1, 2000)); err != nil {
+		ves["body"] = err.Error()
+	}
+
+	if len(ves) > 0 {
+		return ves
+	}
+
+	return nil
+}
+
+// CreatePersonalToken holds personal access token create form validations.
+type CreatePersonalToken struct{}
+
+// Validate validates personal access token form for the create.
+func (v *CreatePersonalToken) Validate(ctx context.Context, f *personal.Form) error {
+	ves := make(Errors)
+
+	if err := validation.Validate(f.Name,
+		validation.Required,
+		validation.Length(1, 50)); err != nil {
+		ves["name"] = err.Error()
+	}
+
+	if err := validateScopes(f.Scopes); err != nil {
+		ves["scopes"] = err.Error()
+	}
+
+	if f.ExpiresAt != nil && !f.ExpiresAt.After(time.Now()) {
+		ves["expires_at"] = futureMsg
+	}
+
+	if len(ves) > 0 {
+		return ves
+	}
+
+	return nil
+}
+
+// validateScopes requires at least one scope and
+// allows the scopes of personal access tokens only.
+func validateScopes(scopes []string) error {
+	if err := validation.Validate(scopes,
+		validation.Required); err != nil {
+		return err
+	}
+
+	for _, s := range scopes {
+		if err := validation.Validate(s,
+			validation.In(token.ScopePostsWrite, token.ScopeCommentsWrite, token.ScopeProfileWrite)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -3,13 +3,23 @@ package validation
 import (
 	"context"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	commentCreate "github.com/dipress/blog/internal/comment/create"
 	"github.com/dipress/blog/internal/create"
+	"github.com/dipress/blog/internal/password"
+	"github.com/dipress/blog/internal/post"
+	profileUpdate "github.com/dipress/blog/internal/profile/update"
 	"github.com/dipress/blog/internal/reg"
+	"github.com/dipress/blog/internal/role"
+	"github.com/dipress/blog/internal/token/personal"
 )
 
 func TestCreateValidate(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
 	tests := []struct {
 		name    string
 		form    create.Form
@@ -76,6 +86,215 @@ func TestCreateValidate(t *testing.T) {
 				"body": "cannot be blank",
 			},
 		},
+		{
+			name: "valid tags",
+			form: create.Form{
+				Title: "title",
+				Body:  "body",
+				Tags:  []string{"go", "web development"},
+			},
+		},
+		{
+			name: "too many tags",
+			form: create.Form{
+				Title: "title",
+				Body:  "body",
+				Tags:  []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"},
+			},
+			wantErr: true,
+			expect: Errors{
+				"tags": "the length must be no more than 10",
+			},
+		},
+		{
+			name: "blank tag",
+			form: create.Form{
+				Title: "title",
+				Body:  "body",
+				Tags:  []string{"go", ""},
+			},
+			wantErr: true,
+			expect: Errors{
+				"tags": "cannot be blank",
+			},
+		},
+		{
+			name: "draft",
+			form: create.Form{
+				Title:  "title",
+				Body:   "body",
+				Status: post.StatusDraft,
+			},
+		},
+		{
+			name: "markdown",
+			form: create.Form{
+				Title:  "title",
+				Body:   "# body",
+				Format: post.FormatMarkdown,
+			},
+		},
+		{
+			name: "unknown format",
+			form: create.Form{
+				Title:  "title",
+				Body:   "body",
+				Format: "html",
+			},
+			wantErr: true,
+			expect: Errors{
+				"format": "must be a valid value",
+			},
+		},
+		{
+			name: "unknown status",
+			form: create.Form{
+				Title:  "title",
+				Body:   "body",
+				Status: "hidden",
+			},
+			wantErr: true,
+			expect: Errors{
+				"status": "must be a valid value",
+			},
+		},
+		{
+			name: "scheduled without time",
+			form: create.Form{
+				Title:  "title",
+				Body:   "body",
+				Status: post.StatusScheduled,
+			},
+			wantErr: true,
+			expect: Errors{
+				"published_at": "cannot be blank",
+			},
+		},
+		{
+			name: "scheduled in the past",
+			form: create.Form{
+				Title:       "title",
+				Body:        "body",
+				Status:      post.StatusScheduled,
+				PublishedAt: &past,
+			},
+			wantErr: true,
+			expect: Errors{
+				"published_at": "must be in the future",
+			},
+		},
+		{
+			name: "tag without letters",
+			form: create.Form{
+				Title: "title",
+				Body:  "body",
+				Tags:  []string{"!!!"},
+			},
+			wantErr: true,
+			expect: Errors{
+				"tags": "must be in a valid format",
+			},
+		},
+		{
+			name: "tags with symbols",
+			form: create.Form{
+				Title: "title",
+				Body:  "body",
+				Tags:  []string{"c++", "c#", "node.js", "objective-c"},
+			},
+		},
+		{
+			name: "tag starting with a symbol",
+			form: create.Form{
+				Title: "title",
+				Body:  "body",
+				Tags:  []string{".net"},
+			},
+			wantErr: true,
+			expect: Errors{
+				"tags": "must be in a valid format",
+			},
+		},
+		{
+			name: "tag with markup",
+			form: create.Form{
+				Title: "title",
+				Body:  "body",
+				Tags:  []string{"<b>go</b>"},
+			},
+			wantErr: true,
+			expect: Errors{
+				"tags": "must be in a valid format",
+			},
+		},
+		{
+			name: "long body",
+			form: create.Form{
+				Title: "title",
+				Body:  strings.Repeat("a", 100001),
+			},
+			wantErr: true,
+			expect: Errors{
+				"body": "the length must be between 1 and 100000",
+			},
+		},
+		{
+			name: "too many lines",
+			form: create.Form{
+				Title: "title",
+				Body:  strings.Repeat("line\n", 2000),
+			},
+			wantErr: true,
+			expect: Errors{
+				"body": "must have no more than 2000 lines",
+			},
+		},
+		{
+			name: "seo fields",
+			form: create.Form{
+				Title:        "title",
+				Body:         "body",
+				Description:  "description",
+				CanonicalURL: "https://example.com/posts/title",
+				ImageURL:     "https://example.com/title.png",
+			},
+		},
+		{
+			name: "long description",
+			form: create.Form{
+				Title:       "title",
+				Body:        "body",
+				Description: strings.Repeat("a", 301),
+			},
+			wantErr: true,
+			expect: Errors{
+				"description": "the length must be no more than 300",
+			},
+		},
+		{
+			name: "invalid canonical url",
+			form: create.Form{
+				Title:        "title",
+				Body:         "body",
+				CanonicalURL: "not a url",
+			},
+			wantErr: true,
+			expect: Errors{
+				"canonical_url": "must be a valid URL",
+			},
+		},
+		{
+			name: "image url without http",
+			form: create.Form{
+				Title:    "title",
+				Body:     "body",
+				ImageURL: "ftp://example.com/title.png",
+			},
+			wantErr: true,
+			expect: Errors{
+				"image_url": "must be in a valid format",
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -249,3 +468,402 @@ func TestCreateUserValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateCommentValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		form    commentCreate.Form
+		wantErr bool
+		expect  Errors
+	}{
+		{
+			name: "valid",
+			form: commentCreate.Form{
+				Body: "body",
+			},
+		},
+		{
+			name: "valid reply",
+			form: commentCreate.Form{
+				ParentID: 1,
+				Body:     "body",
+			},
+		},
+		{
+			name:    "missing body",
+			form:    commentCreate.Form{},
+			wantErr: true,
+			expect: Errors{
+				"body": "cannot be blank",
+			},
+		},
+		{
+			name: "negative parent",
+			form: commentCreate.Form{
+				ParentID: -1,
+				Body:     "body",
+			},
+			wantErr: true,
+			expect: Errors{
+				"parent_id": "must be no less than 0",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var v CreateComment
+			err := v.Validate(ctx, &tc.form)
+
+			if tc.wantErr {
+				got, ok := err.(Errors)
+				if !ok {
+					t.Errorf("unknown error: %v", err)
+					return
+				}
+
+				if !reflect.DeepEqual(tc.expect, got) {
+					t.Errorf("expected: %+#v got: %+#v", tc.expect, got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestAssignRoleValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		form    role.Form
+		wantErr bool
+		expect  Errors
+	}{
+		{
+			name: "valid",
+			form: role.Form{
+				Role: "editor",
+			},
+		},
+		{
+			name:    "missing role",
+			form:    role.Form{},
+			wantErr: true,
+			expect: Errors{
+				"role": "cannot be blank",
+			},
+		},
+		{
+			name: "unknown role",
+			form: role.Form{
+				Role: "owner",
+			},
+			wantErr: true,
+			expect: Errors{
+				"role": "must be a valid value",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var v AssignRole
+			err := v.Validate(ctx, &tc.form)
+
+			if tc.wantErr {
+				got, ok := err.(Errors)
+				if !ok {
+					t.Errorf("unknown error: %v", err)
+					return
+				}
+
+				if !reflect.DeepEqual(tc.expect, got) {
+					t.Errorf("expected: %+#v got: %+#v", tc.expect, got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestResetPasswordValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		form    password.ResetForm
+		wantErr bool
+		expect  Errors
+	}{
+		{
+			name: "valid",
+			form: password.ResetForm{
+				Token:    "token",
+				Password: "password123",
+			},
+		},
+		{
+			name:    "missing fields",
+			form:    password.ResetForm{},
+			wantErr: true,
+			expect: Errors{
+				"token":    "cannot be blank",
+				"password": "cannot be blank",
+			},
+		},
+		{
+			name: "short password",
+			form: password.ResetForm{
+				Token:    "token",
+				Password: "short",
+			},
+			wantErr: true,
+			expect: Errors{
+				"password": "the length must be no less than 10",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var v ResetPassword
+			err := v.Validate(ctx, &tc.form)
+
+			if tc.wantErr {
+				got, ok := err.(Errors)
+				if !ok {
+					t.Errorf("unknown error: %v", err)
+					return
+				}
+
+				if !reflect.DeepEqual(tc.expect, got) {
+					t.Errorf("expected: %+#v got: %+#v", tc.expect, got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdateProfileValidate(t *testing.T) {
+	str := func(s string) *string {
+		return &s
+	}
+
+	tests := []struct {
+		name    string
+		form    profileUpdate.Form
+		wantErr bool
+		expect  Errors
+	}{
+		{
+			name: "valid",
+			form: profileUpdate.Form{
+				DisplayName: str("User Name"),
+				Bio:         str("my bio"),
+				AvatarURL:   str("https://example.com/avatar.png"),
+			},
+		},
+		{
+			name: "empty",
+			form: profileUpdate.Form{},
+		},
+		{
+			name: "clear fields",
+			form: profileUpdate.Form{
+				DisplayName: str(""),
+				AvatarURL:   str(""),
+			},
+		},
+		{
+			name: "too long",
+			form: profileUpdate.Form{
+				DisplayName: str(strings.Repeat("a", 51)),
+				Bio:         str(strings.Repeat("a", 501)),
+			},
+			wantErr: true,
+			expect: Errors{
+				"display_name": "the length must be no more than 50",
+				"bio":          "the length must be no more than 500",
+			},
+		},
+		{
+			name: "not http avatar",
+			form: profileUpdate.Form{
+				AvatarURL: str("ftp://example.com/avatar.png"),
+			},
+			wantErr: true,
+			expect: Errors{
+				"avatar_url": "must be in a valid format",
+			},
+		},
+		{
+			name: "invalid avatar",
+			form: profileUpdate.Form{
+				AvatarURL: str("not a url"),
+			},
+			wantErr: true,
+			expect: Errors{
+				"avatar_url": "must be a valid URL",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var v UpdateProfile
+			err := v.Validate(ctx, &tc.form)
+
+			if tc.wantErr {
+				got, ok := err.(Errors)
+				if !ok {
+					t.Errorf("unknown error: %v", err)
+					return
+				}
+
+				if !reflect.DeepEqual(tc.expect, got) {
+					t.Errorf("expected: %+#v got: %+#v", tc.expect, got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCreatePersonalTokenValidate(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		form    personal.Form
+		wantErr bool
+		expect  Errors
+	}{
+		{
+			name: "valid",
+			form: personal.Form{
+				Name:   "deploy",
+				Scopes: []string{"posts:write", "comments:write"},
+			},
+		},
+		{
+			name: "valid with expiration",
+			form: personal.Form{
+				Name:      "deploy",
+				Scopes:    []string{"profile:write"},
+				ExpiresAt: &future,
+			},
+		},
+		{
+			name:    "missing fields",
+			form:    personal.Form{},
+			wantErr: true,
+			expect: Errors{
+				"name":   "cannot be blank",
+				"scopes": "cannot be blank",
+			},
+		},
+		{
+			name: "unknown scope",
+			form: personal.Form{
+				Name:   "deploy",
+				Scopes: []string{"posts:write", "users:admin"},
+			},
+			wantErr: true,
+			expect: Errors{
+				"scopes": "must be a valid value",
+			},
+		},
+		{
+			name: "long name",
+			form: personal.Form{
+				Name:   strings.Repeat("a", 51),
+				Scopes: []string{"posts:write"},
+			},
+			wantErr: true,
+			expect: Errors{
+				"name": "the length must be between 1 and 50",
+			},
+		},
+		{
+			name: "expired",
+			form: personal.Form{
+				Name:      "deploy",
+				Scopes:    []string{"posts:write"},
+				ExpiresAt: &past,
+			},
+			wantErr: true,
+			expect: Errors{
+				"expires_at": "must be in the future",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var v CreatePersonalToken
+			err := v.Validate(ctx, &tc.form)
+
+			if tc.wantErr {
+				got, ok := err.(Errors)
+				if !ok {
+					t.Errorf("unknown error: %v", err)
+					return
+				}
+
+				if !reflect.DeepEqual(tc.expect, got) {
+					t.Errorf("expected: %+#v got: %+#v", tc.expect, got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dipress/blog/internal/notify"
+	"github.com/dipress/blog/internal/token/onetime"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+// easyjson service.go
+//go:generate mockgen -source=service.go -package=verify -destination=service.mock.go
+
+// purpose is the audience of email verification tokens.
+const purpose = "verify-email"
+
+var (
+	// ErrInvalidToken returns when verification token is
+	// malformed, expired or already used.
+	ErrInvalidToken = errors.New("invalid verification token")
+	// ErrVerified returns when the email of the user
+	// is already verified.
+	ErrVerified = errors.New("email already verified")
+)
+
+// Repository allows to work with the database.
+type Repository interface {
+	FindByUsername(ctx context.Context, username string, u *user.User) error
+	VerifyUser(ctx context.Context, userID int) error
+}
+
+// Tokens issues and redeems single-use tokens.
+type Tokens interface {
+	Issue(ctx context.Context, subject, purpose string, exp time.Duration) (string, error)
+	Redeem(ctx context.Context, tknStr, purpose string) (string, error)
+}
+
+// Notifier sends messages to users.
+type Notifier interface {
+	Notify(ctx context.Context, m *notify.Message) error
+}
+
+// Form is an email verification form.
+//easyjson:json
+type Form struct {
+	Token string `json:"token"`
+}
+
+// Service is a use case for email verification.
+type Service struct {
+	Repository
+	Tokens
+	Notifier
+	ExpireAfter time.Duration
+}
+
+// NewService factory prepares service for all futher operations.
+func NewService(r Repository, t Tokens, n Notifier, exp time.Duration) *Service {
+	s := Service{
+		Repository:  r,
+		Tokens:      t,
+		Notifier:    n,
+		ExpireAfter: exp,
+	}
+
+	return &s
+}
+
+// Send sends the verification token to the email of the user.
+func (s *Service) Send(ctx context.Context, u *user.User) error {
+	tknStr, err := s.Tokens.Issue(ctx, u.Username, purpose, s.ExpireAfter)
+	if err != nil {
+		return errors.Wrap(err, "issue token")
+	}
+
+	m := notify.Message{
+		To:      u.Email,
+		Subject: "Verify your email",
+		Body:    fmt.Sprintf("Hi %s,\n\nuse the token below to verify your email:\n\n%s\n\nIt expires in %s.", u.Username, tknStr, s.ExpireAfter),
+	}
+
+	if err := s.Notifier.Notify(ctx, &m); err != nil {
+		return errors.Wrap(err, "notify")
+	}
+
+	return nil
+}
+
+// Resend sends the verification token to the email
+// of the current user once more.
+func (s *Service) Resend(ctx context.Context) error {
+	claims, _ := auth.FromContext(ctx)
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, claims.Subject, &u); err != nil {
+		return errors.Wrap(err, "repository find user")
+	}
+	if u.VerifiedAt != nil {
+		return ErrVerified
+	}
+
+	if err := s.Send(ctx, &u); err != nil {
+		return errors.Wrap(err, "send")
+	}
+
+	return nil
+}
+
+// Verify marks the email of the token owner as verified.
+func (s *Service) Verify(ctx context.Context, f *Form) error {
+	username, err := s.Tokens.Redeem(ctx, f.Token, purpose)
+	if err != nil {
+		if errors.Cause(err) == onetime.ErrInvalidToken {
+			return ErrInvalidToken
+		}
+		return errors.Wrap(err, "redeem token")
+	}
+
+	var u user.User
+	if err := s.Repository.FindByUsername(ctx, username, &u); err != nil {
+		if errors.Cause(err) == user.ErrNotFound {
+			return ErrInvalidToken
+		}
+		return errors.Wrap(err, "repository find user")
+	}
+
+	if err := s.Repository.VerifyUser(ctx, u.ID); err != nil {
+		return errors.Wrap(err, "repository verify user")
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package verify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dipress/blog/internal/token/onetime"
+	"github.com/dipress/blog/internal/user"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceSend(t *testing.T) {
+	tests := []struct {
+		name         string
+		tokensFunc   func(mock *MockTokens)
+		notifierFunc func(mock *MockNotifier)
+		wantErr      bool
+	}{
+		{
+			name: "ok",
+			tokensFunc: func(m *MockTokens) {
+				m.EXPECT().Issue(gomock.Any(), "username", purpose, time.Hour).Return("token", nil)
+			},
+			notifierFunc: func(m *MockNotifier) {
+				m.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "issue error",
+			tokensFunc: func(m *MockTokens) {
+				m.EXPECT().Issue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("", errors.New("mock error"))
+			},
+			notifierFunc: func(m *MockNotifier) {},
+			wantErr:      true,
+		},
+		{
+			name: "notify error",
+			tokensFunc: func(m *MockTokens) {
+				m.EXPECT().Issue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("token", nil)
+			},
+			notifierFunc: func(m *MockNotifier) {
+				m.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tokens := NewMockTokens(ctrl)
+			notifier := NewMockNotifier(ctrl)
+			tc.tokensFunc(tokens)
+			tc.notifierFunc(notifier)
+
+			s := NewService(NewMockRepository(ctrl), tokens, notifier, time.Hour)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			u := user.User{
+				Username: "username",
+				Email:    "username@example.com",
+			}
+
+			err := s.Send(ctx, &u)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestServiceResend(t *testing.T) {
+	tests := []struct {
+		name           string
+		repositoryFunc func(mock *MockRepository)
+		tokensFunc     func(mock *MockTokens)
+		notifierFunc   func(mock *MockNotifier)
+		wantErr        error
+	}{
+		{
+			name: "ok",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), "username", gomock.Any()).DoAndReturn(
+					func(ctx context.Context, username string, u *user.User) error {
+						u.Username = username
+						u.Email = "username@example.com"
+						return nil
+					})
+			},
+			tokensFunc: func(m *MockTokens) {
+				m.EXPECT().Issue(gomock.Any(), "username", purpose, time.Hour).Return("token", nil)
+			},
+			notifierFunc: func(m *MockNotifier) {
+				m.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "already verified",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, username string, u *user.User) error {
+						now := time.Now()
+						u.VerifiedAt = &now
+						return nil
+					})
+			},
+			tokensFunc:   func(m *MockTokens) {},
+			notifierFunc: func(m *MockNotifier) {},
+			wantErr:      ErrVerified,
+		},
+		{
+			name: "unknown user",
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(user.ErrNotFound)
+			},
+			tokensFunc:   func(m *MockTokens) {},
+			notifierFunc: func(m *MockNotifier) {},
+			wantErr:      user.ErrNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockRepository(ctrl)
+			tokens := NewMockTokens(ctrl)
+			notifier := NewMockNotifier(ctrl)
+			tc.repositoryFunc(repo)
+			tc.tokensFunc(tokens)
+			tc.notifierFunc(notifier)
+
+			s := NewService(repo, tokens, notifier, time.Hour)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			claims := auth.Claims{}
+			claims.Subject = "username"
+			newCtx := auth.ToContext(ctx, &claims)
+
+			err := s.Resend(newCtx)
+			if tc.wantErr != nil {
+				assert.Equal(t, tc.wantErr, errors.Cause(err))
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestServiceVerify(t *testing.T) {
+	tests := []struct {
+		name           string
+		tokensFunc     func(mock *MockTokens)
+		repositoryFunc func(mock *MockRepository)
+		wantErr        bool
+	}{
+		{
+			name: "ok",
+			tokensFunc: func(m *MockTokens) {
+				m.EXPECT().Redeem(gomock.Any(), "token", purpose).Return("username", nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), "username", gomock.Any()).Return(nil)
+				m.EXPECT().VerifyUser(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "invalid token",
+			tokensFunc: func(m *MockTokens) {
+				m.EXPECT().Redeem(gomock.Any(), gomock.Any(), gomock.Any()).Return("", onetime.ErrInvalidToken)
+			},
+			repositoryFunc: func(m *MockRepository) {},
+			wantErr:        true,
+		},
+		{
+			name: "unknown user",
+			tokensFunc: func(m *MockTokens) {
+				m.EXPECT().Redeem(gomock.Any(), gomock.Any(), gomock.Any()).Return("username", nil)
+			},
+			repositoryFunc: func(m *MockRepository) {
+				m.EXPECT().FindByUsername(gomock.Any(), gomock.Any(), gomock.Any()).Return(user.ErrNotFound)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tokens := NewMockTokens(ctrl)
+			repo := NewMockRepository(ctrl)
+			tc.tokensFunc(tokens)
+			tc.repositoryFunc(repo)
+
+			s := NewService(repo, tokens, NewMockNotifier(ctrl), time.Hour)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			err := s.Verify(ctx, &Form{Token: "token"})
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// AttemptStore keeps the number of failed attempts by key, e.g. the
+// sign in attempts of an account or from an address. Failures are
+// forgotten after they expire.
+type AttemptStore interface {
+	FailAttempt(ctx context.Context, key string, now, expiresAt time.Time) (int, error)
+	Attempts(ctx context.Context, key string, now time.Time) (int, time.Time, error)
+	ResetAttempts(ctx context.Context, key string) error
+}
+
+// MemoryAttempts is an in-memory AttemptStore. It keeps a limited
+// number of keys, so it suits a single instance.
+type MemoryAttempts struct {
+	mu    sync.Mutex
+	size  int
+	items map[string]attempt
+}
+
+type attempt struct {
+	failures  int
+	lastAt    time.Time
+	expiresAt time.Time
+}
+
+// NewMemoryAttempts creates a store which holds up to size keys.
+func NewMemoryAttempts(size int) *MemoryAttempts {
+	s := MemoryAttempts{
+		size:  size,
+		items: make(map[string]attempt),
+	}
+
+	return &s
+}
+
+// FailAttempt records the failed attempt and returns the number of
+// failures of the key. Failures are counted anew after they expire.
+func (s *MemoryAttempts) FailAttempt(ctx context.Context, key string, now, expiresAt time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.items[key]
+	if !ok || !a.expiresAt.After(now) {
+		a = attempt{}
+		if len(s.items) >= s.size {
+			s.evict(now)
+		}
+	}
+
+	a.failures++
+	a.lastAt = now
+	a.expiresAt = expiresAt
+	s.items[key] = a
+
+	return a.failures, nil
+}
+
+// Attempts returns the number of failures of the key
+// and the time of the last one.
+func (s *MemoryAttempts) Attempts(ctx context.Context, key string, now time.Time) (int, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.items[key]
+	if !ok {
+		return 0, time.Time{}, nil
+	}
+
+	if !a.expiresAt.After(now) {
+		delete(s.items, key)
+		return 0, time.Time{}, nil
+	}
+
+	return a.failures, a.lastAt, nil
+}
+
+// ResetAttempts forgets failures of the key.
+func (s *MemoryAttempts) ResetAttempts(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.items, key)
+	return nil
+}
+
+// evict removes expired keys. When none are expired
+// it removes the key which expires first.
+func (s *MemoryAttempts) evict(now time.Time) {
+	var (
+		first string
+		found bool
+	)
+	for key, a := range s.items {
+		if !a.expiresAt.After(now) {
+			delete(s.items, key)
+			continue
+		}
+		if !found || a.expiresAt.Before(s.items[first].expiresAt) {
+			first, found = key, true
+		}
+	}
+
+	if len(s.items) >= s.size && found {
+		delete(s.items, first)
+	}
+}
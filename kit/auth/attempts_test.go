@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryAttempts(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryAttempts(2)
+	now := time.Now()
+
+	failures, err := s.FailAttempt(ctx, "a", now, now.Add(time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, failures)
+
+	failures, err = s.FailAttempt(ctx, "a", now.Add(time.Second), now.Add(time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, failures)
+
+	failures, last, err := s.Attempts(ctx, "a", now.Add(time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, failures)
+	assert.Equal(t, now.Add(time.Second), last)
+
+	// Failures are counted anew after they expire.
+	failures, err = s.FailAttempt(ctx, "a", now.Add(2*time.Hour), now.Add(3*time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, failures)
+
+	assert.Nil(t, s.ResetAttempts(ctx, "a"))
+
+	failures, _, err = s.Attempts(ctx, "a", now)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, failures)
+}
+
+func TestMemoryAttemptsEviction(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryAttempts(2)
+	now := time.Now()
+
+	_, err := s.FailAttempt(ctx, "a", now, now.Add(time.Minute))
+	assert.Nil(t, err)
+	_, err = s.FailAttempt(ctx, "b", now, now.Add(time.Hour))
+	assert.Nil(t, err)
+	_, err = s.FailAttempt(ctx, "c", now, now.Add(time.Hour))
+	assert.Nil(t, err)
+
+	tests := []struct {
+		key      string
+		failures int
+	}{
+		{key: "a", failures: 0},
+		{key: "b", failures: 1},
+		{key: "c", failures: 1},
+	}
+
+	for _, tc := range tests {
+		failures, _, err := s.Attempts(ctx, tc.key, now)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.failures, failures, tc.key)
+	}
+}
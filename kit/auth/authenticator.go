@@ -2,7 +2,9 @@ package auth
 
 import (
 	"context"
-	"crypto/rsa"
+	"crypto"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"time"
 
@@ -14,26 +16,51 @@ var (
 	contextKeyClaims = contextKey("claims")
 )
 
+// ErrInsufficientScope returns when the token
+// doesn't allow the operation.
+var ErrInsufficientScope = errors.New("insufficient scope")
+
 // Claims represents the authorization claims transmitted via a JWT.
 type Claims struct {
 	jwt.StandardClaims
+	// IssuedAtMs is the time the token is issued at in milliseconds,
+	// since the standard issued at claim tells only the second.
+	IssuedAtMs int64  `json:"iat_ms,omitempty"`
+	Role       string `json:"role,omitempty"`
+	// Scopes limit what the token allows. They are set for tokens
+	// which are not JWTs, such as personal access tokens, so they
+	// are never transmitted. Claims without scopes allow everything.
+	Scopes []string `json:"-"`
 }
 
 // NewClaims constructs a Claims value for the identified user. The Claims
-// expire within a specified duration of the provided time. Additional fields
-// of the Claims can be set after calling NewClaims is desired.
+// expire within a specified duration of the provided time and get a unique
+// id (jti), so the token can be revoked. Additional fields of the Claims can
+// be set after calling NewClaims is desired.
 func NewClaims(subject string, now time.Time, expires time.Duration) Claims {
 	c := Claims{
 		StandardClaims: jwt.StandardClaims{
+			Id:        newTokenID(now),
 			Subject:   subject,
 			IssuedAt:  now.Unix(),
 			ExpiresAt: now.Add(expires).Unix(),
 		},
+		IssuedAtMs: unixMilli(now),
 	}
 
 	return c
 }
 
+// newTokenID returns a random token id. It falls back
+// to the time based id if random source fails.
+func newTokenID(now time.Time) string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", now.UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
 // Valid is called during the parsing of a token.
 func (c Claims) Valid() error {
 	if err := c.StandardClaims.Valid(); err != nil {
@@ -42,6 +69,54 @@ func (c Claims) Valid() error {
 	return nil
 }
 
+// IssuedBefore checks that the token was issued before t, so it's
+// revoked by the revocation at t. Tokens without the time in
+// milliseconds are issued before t when issued in its second.
+func (c Claims) IssuedBefore(t time.Time) bool {
+	if c.IssuedAtMs == 0 {
+		return c.IssuedAt <= t.Unix()
+	}
+	return c.IssuedAtMs < unixMilli(t)
+}
+
+// unixMilli returns t as milliseconds since the Unix epoch.
+func unixMilli(t time.Time) int64 {
+	return t.Unix()*1000 + int64(t.Nanosecond())/int64(time.Millisecond)
+}
+
+// HasScope checks that the claims allow the scope.
+func (c Claims) HasScope(scope string) bool {
+	if c.Scopes == nil {
+		return true
+	}
+
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireScope returns ErrInsufficientScope when
+// the claims of the context don't allow the scope.
+func RequireScope(ctx context.Context, scope string) error {
+	if claims, ok := FromContext(ctx); ok && !claims.HasScope(scope) {
+		return ErrInsufficientScope
+	}
+	return nil
+}
+
+// RequireSession returns ErrInsufficientScope when the claims of
+// the context have scopes, so only tokens of signed in users
+// are allowed. Such tokens can't manage credentials of the user.
+func RequireSession(ctx context.Context) error {
+	if claims, ok := FromContext(ctx); ok && claims.Scopes != nil {
+		return ErrInsufficientScope
+	}
+	return nil
+}
+
 // KeyFunc is used to map a JWT key id (kid) to the corresponding public key.
 // It is a requirement for creating an Authenticator.
 //
@@ -51,13 +126,13 @@ func (c Claims) Valid() error {
 //
 // * Key-id-to-public-key resolution is usually accomplished via a public JWKS
 // endpoint. See https://auth0.com/docs/jwks for more details.
-type KeyFunc func(keyID string) (*rsa.PublicKey, error)
+type KeyFunc func(keyID string) (crypto.PublicKey, error)
 
 // NewSingleKeyFunc is a simple implementation of KeyFunc that only ever
 // supports one key. This is easy for development but in production should be
 // replaced with a caching layer that calls a JWKS endpoint.
-func NewSingleKeyFunc(id string, key *rsa.PublicKey) KeyFunc {
-	return func(kid string) (*rsa.PublicKey, error) {
+func NewSingleKeyFunc(id string, key crypto.PublicKey) KeyFunc {
+	return func(kid string) (crypto.PublicKey, error) {
 		if id != kid {
 			return nil, fmt.Errorf("unrecognized kid %q", kid)
 		}
@@ -65,14 +140,31 @@ func NewSingleKeyFunc(id string, key *rsa.PublicKey) KeyFunc {
 	}
 }
 
+// Signer provides the private key to sign tokens with and its id.
+type Signer interface {
+	SigningKey() (string, crypto.Signer)
+}
+
+// staticSigner always signs with the same key.
+type staticSigner struct {
+	keyID string
+	key   crypto.Signer
+}
+
+// SigningKey implements Signer interface.
+func (s staticSigner) SigningKey() (string, crypto.Signer) {
+	return s.keyID, s.key
+}
+
 // Authenticator is used to authenticate clients. It can generate a token for a
 // set of user claims and recreate the claims by parsing the token.
 type Authenticator struct {
-	privateKey *rsa.PrivateKey
-	keyID      string
-	algorithm  string
-	kf         KeyFunc
-	parser     *jwt.Parser
+	signer Signer
+	ring   *KeyRing
+	// algorithm is empty when it depends on the type of the signing key.
+	algorithm string
+	kf        KeyFunc
+	parser    *jwt.Parser
 }
 
 // NewAuthenticator creates an *Authenticator for use. It will error if:
@@ -80,7 +172,8 @@ type Authenticator struct {
 // - The public key func is nil.
 // - The key ID is blank.
 // - The specified algorithm is unsupported.
-func NewAuthenticator(key *rsa.PrivateKey, keyID, algorithm string, publicKeyFunc KeyFunc) (*Authenticator, error) {
+// - The private key type does not match the algorithm.
+func NewAuthenticator(key crypto.Signer, keyID, algorithm string, publicKeyFunc KeyFunc) (*Authenticator, error) {
 	if key == nil {
 		return nil, errors.New("private key cannot be nil")
 	}
@@ -93,6 +186,9 @@ func NewAuthenticator(key *rsa.PrivateKey, keyID, algorithm string, publicKeyFun
 	if jwt.GetSigningMethod(algorithm) == nil {
 		return nil, errors.Errorf("unknown algorithm %v", algorithm)
 	}
+	if err := checkKey(algorithm, key.Public()); err != nil {
+		return nil, errors.Wrap(err, "check key")
+	}
 
 	// Create the token parser to use. The algorithm used to sign the JWT must be
 	// validated to avoid a critical vulnerability:
@@ -102,24 +198,68 @@ func NewAuthenticator(key *rsa.PrivateKey, keyID, algorithm string, publicKeyFun
 	}
 
 	a := Authenticator{
-		privateKey: key,
-		keyID:      keyID,
-		algorithm:  algorithm,
-		kf:         publicKeyFunc,
-		parser:     &parser,
+		signer:    staticSigner{keyID: keyID, key: key},
+		algorithm: algorithm,
+		kf:        publicKeyFunc,
+		parser:    &parser,
+	}
+
+	return &a, nil
+}
+
+// NewRingAuthenticator creates an *Authenticator which signs tokens with
+// the active key of the ring and verifies them with any valid key of it.
+// The algorithm is chosen by the type of each key, so the ring can mix
+// RSA, ECDSA and Ed25519 keys. It will error if the ring is nil.
+func NewRingAuthenticator(ring *KeyRing) (*Authenticator, error) {
+	if ring == nil {
+		return nil, errors.New("key ring cannot be nil")
+	}
+
+	// Tokens are checked to be signed with the algorithm
+	// of their key on the lookup.
+	parser := jwt.Parser{
+		ValidMethods: []string{AlgorithmRS256, AlgorithmES256, AlgorithmEdDSA},
+	}
+
+	a := Authenticator{
+		signer: ring,
+		ring:   ring,
+		kf:     ring.PublicKey,
+		parser: &parser,
 	}
 
 	return &a, nil
 }
 
+// JWKS returns the set of public keys which verify tokens.
+func (a *Authenticator) JWKS() JWKS {
+	if a.ring != nil {
+		return NewJWKS(a.algorithm, a.ring.PublicKeys())
+	}
+
+	kid, key := a.signer.SigningKey()
+	return NewJWKS(a.algorithm, map[string]crypto.PublicKey{kid: key.Public()})
+}
+
 // GenerateToken generates a signed JWT token string representing the user Claims.
 func (a *Authenticator) GenerateToken(ctx context.Context, claims jwt.Claims) (string, error) {
-	method := jwt.GetSigningMethod(a.algorithm)
+	kid, key := a.signer.SigningKey()
+
+	algorithm := a.algorithm
+	if algorithm == "" {
+		alg, err := AlgorithmForKey(key.Public())
+		if err != nil {
+			return "", errors.Wrap(err, "signing algorithm")
+		}
+		algorithm = alg
+	}
+	method := jwt.GetSigningMethod(algorithm)
 
 	tkn := jwt.NewWithClaims(method, claims)
-	tkn.Header["kid"] = a.keyID
+	tkn.Header["kid"] = kid
 
-	str, err := tkn.SignedString(a.privateKey)
+	str, err := tkn.SignedString(key)
 	if err != nil {
 		return "", errors.Wrap(err, "signing token")
 	}
@@ -144,7 +284,18 @@ func (a *Authenticator) ParseClaims(ctx context.Context, tknStr string) (Claims,
 			return nil, errors.New("token key id (kid) must be string")
 		}
 
-		return a.kf(kidStr)
+		key, err := a.kf(kidStr)
+		if err != nil {
+			return nil, err
+		}
+
+		// The key must match the algorithm of the token, so
+		// a key of one type cannot verify tokens of another.
+		if err := checkKey(t.Method.Alg(), key); err != nil {
+			return nil, err
+		}
+
+		return key, nil
 	}
 
 	var claims Claims
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaimsScopes(t *testing.T) {
+	ctx := context.Background()
+
+	session := ToContext(ctx, &Claims{})
+	personal := ToContext(ctx, &Claims{Scopes: []string{"posts:write"}})
+	unscoped := ToContext(ctx, &Claims{Scopes: []string{}})
+
+	assert.Nil(t, RequireScope(ctx, "posts:write"))
+	assert.Nil(t, RequireScope(session, "posts:write"))
+	assert.Nil(t, RequireScope(personal, "posts:write"))
+	assert.Equal(t, ErrInsufficientScope, RequireScope(personal, "profile:write"))
+	assert.Equal(t, ErrInsufficientScope, RequireScope(unscoped, "posts:write"))
+
+	assert.Nil(t, RequireSession(session))
+	assert.Equal(t, ErrInsufficientScope, RequireSession(personal))
+	assert.Equal(t, ErrInsufficientScope, RequireSession(unscoped))
+}
+
+func TestClaimsIssuedBefore(t *testing.T) {
+	revokedAt := time.Unix(100, int64(500*time.Millisecond))
+
+	before := NewClaims("john", revokedAt.Add(-time.Millisecond), time.Hour)
+	after := NewClaims("john", revokedAt.Add(time.Millisecond), time.Hour)
+	assert.True(t, before.IssuedBefore(revokedAt))
+	assert.False(t, after.IssuedBefore(revokedAt))
+	assert.False(t, after.IssuedBefore(time.Time{}))
+
+	// Tokens of the second of the revocation without
+	// the time in milliseconds are revoked.
+	seconds := after
+	seconds.IssuedAtMs = 0
+	assert.True(t, seconds.IssuedBefore(revokedAt))
+	assert.False(t, seconds.IssuedBefore(revokedAt.Add(-time.Second)))
+}
@@ -0,0 +1,171 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// easyjson jwks.go
+
+// JWK is a public JSON Web Key.
+//easyjson:json
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set.
+//easyjson:json
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewJWKS builds a key set of given public keys sorted by key id.
+// The algorithm of every key is derived from its type unless
+// the algorithm is given. Keys of unsupported types are skipped.
+func NewJWKS(algorithm string, keys map[string]crypto.PublicKey) JWKS {
+	set := JWKS{
+		Keys: make([]JWK, 0, len(keys)),
+	}
+
+	for kid, key := range keys {
+		alg := algorithm
+		if alg == "" {
+			a, err := AlgorithmForKey(key)
+			if err != nil {
+				continue
+			}
+			alg = a
+		}
+
+		jwk := JWK{
+			Use: "sig",
+			Alg: alg,
+			Kid: kid,
+		}
+
+		switch k := key.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = encodeSegment(k.N.Bytes())
+			jwk.E = encodeSegment(big.NewInt(int64(k.E)).Bytes())
+		case *ecdsa.PublicKey:
+			size := (k.Curve.Params().BitSize + 7) / 8
+			jwk.Kty = "EC"
+			jwk.Crv = k.Curve.Params().Name
+			jwk.X = encodeSegment(padBytes(k.X.Bytes(), size))
+			jwk.Y = encodeSegment(padBytes(k.Y.Bytes(), size))
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = encodeSegment(k)
+		default:
+			continue
+		}
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	sort.Slice(set.Keys, func(i, j int) bool {
+		return set.Keys[i].Kid < set.Keys[j].Kid
+	})
+
+	return set
+}
+
+// PublicKey decodes the public key of the JWK.
+func (k JWK) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeSegment(k.N)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode modulus")
+		}
+		e, err := decodeSegment(k.E)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode exponent")
+		}
+		key := rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+		return &key, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := decodeSegment(k.X)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode x")
+		}
+		y, err := decodeSegment(k.Y)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode y")
+		}
+		key := ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, errors.New("point is not on the curve")
+		}
+		return &key, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, errors.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := decodeSegment(k.X)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode x")
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid key size")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, errors.Errorf("unsupported key type %s", k.Kty)
+	}
+}
+
+func encodeSegment(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeSegment(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+// padBytes left pads b with zeros up to size, as
+// coordinates of EC keys must have the full length.
+func padBytes(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	p := make([]byte, size)
+	copy(p[size-len(b):], b)
+	return p
+}
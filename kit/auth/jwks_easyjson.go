@@ -0,0 +1,289 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package auth
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonA454815fDecodeGithubComDipressBlogKitAuth(in *jlexer.Lexer, out *JWKS) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "keys":
+			if in.IsNull() {
+				in.Skip()
+				out.Keys = nil
+			} else {
+				in.Delim('[')
+				if out.Keys == nil {
+					if !in.IsDelim(']') {
+						out.Keys = make([]JWK, 0, 1)
+					} else {
+						out.Keys = []JWK{}
+					}
+				} else {
+					out.Keys = (out.Keys)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 JWK
+					(v1).UnmarshalEasyJSON(in)
+					out.Keys = append(out.Keys, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA454815fEncodeGithubComDipressBlogKitAuth(out *jwriter.Writer, in JWKS) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"keys\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Keys == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Keys {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v JWKS) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA454815fEncodeGithubComDipressBlogKitAuth(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v JWKS) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA454815fEncodeGithubComDipressBlogKitAuth(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *JWKS) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA454815fDecodeGithubComDipressBlogKitAuth(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *JWKS) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA454815fDecodeGithubComDipressBlogKitAuth(l, v)
+}
+func easyjsonA454815fDecodeGithubComDipressBlogKitAuth1(in *jlexer.Lexer, out *JWK) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "kty":
+			out.Kty = string(in.String())
+		case "use":
+			out.Use = string(in.String())
+		case "alg":
+			out.Alg = string(in.String())
+		case "kid":
+			out.Kid = string(in.String())
+		case "n":
+			out.N = string(in.String())
+		case "e":
+			out.E = string(in.String())
+		case "crv":
+			out.Crv = string(in.String())
+		case "x":
+			out.X = string(in.String())
+		case "y":
+			out.Y = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA454815fEncodeGithubComDipressBlogKitAuth1(out *jwriter.Writer, in JWK) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"kty\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Kty))
+	}
+	{
+		const prefix string = ",\"use\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Use))
+	}
+	{
+		const prefix string = ",\"alg\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Alg))
+	}
+	{
+		const prefix string = ",\"kid\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Kid))
+	}
+	if in.N != "" {
+		const prefix string = ",\"n\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.N))
+	}
+	if in.E != "" {
+		const prefix string = ",\"e\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.E))
+	}
+	if in.Crv != "" {
+		const prefix string = ",\"crv\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Crv))
+	}
+	if in.X != "" {
+		const prefix string = ",\"x\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.X))
+	}
+	if in.Y != "" {
+		const prefix string = ",\"y\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Y))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v JWK) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA454815fEncodeGithubComDipressBlogKitAuth1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v JWK) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA454815fEncodeGithubComDipressBlogKitAuth1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *JWK) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA454815fDecodeGithubComDipressBlogKitAuth1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *JWK) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA454815fDecodeGithubComDipressBlogKitAuth1(l, v)
+}
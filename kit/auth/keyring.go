@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"crypto"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// keyExts are extensions of private key files in the key ring directory.
+var keyExts = map[string]bool{
+	".rsa": true,
+	".pem": true,
+}
+
+// KeyRing holds private keys loaded from a directory. Every
+// `<kid>.rsa` or `<kid>.pem` file there is a PEM encoded RSA,
+// ECDSA or Ed25519 key with the key id given by its name. Tokens are signed with the most recently
+// modified key. The rest of keys are superseded and can verify
+// tokens for the grace period after the active key appeared,
+// so tokens signed before the rotation stay valid until expiration.
+type KeyRing struct {
+	mu     sync.RWMutex
+	dir    string
+	grace  time.Duration
+	active string
+	keys   map[string]ringKey
+}
+
+type ringKey struct {
+	private   crypto.Signer
+	expiresAt time.Time
+}
+
+// NewKeyRing creates a key ring and loads keys from the directory.
+func NewKeyRing(dir string, grace time.Duration) (*KeyRing, error) {
+	k := KeyRing{
+		dir:   dir,
+		grace: grace,
+	}
+
+	if err := k.Load(); err != nil {
+		return nil, errors.Wrap(err, "load keys")
+	}
+
+	return &k, nil
+}
+
+// Load reloads keys from the directory. The key ring
+// is kept as is if the directory has no valid keys.
+func (k *KeyRing) Load() error {
+	files, err := ioutil.ReadDir(k.dir)
+	if err != nil {
+		return errors.Wrap(err, "read dir")
+	}
+
+	var (
+		keys      = make(map[string]ringKey)
+		active    string
+		activeMod time.Time
+	)
+
+	for _, f := range files {
+		ext := filepath.Ext(f.Name())
+		if f.IsDir() || !keyExts[ext] {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(k.dir, f.Name()))
+		if err != nil {
+			return errors.Wrapf(err, "read key %s", f.Name())
+		}
+
+		key, err := ParsePrivateKey(data)
+		if err != nil {
+			return errors.Wrapf(err, "parse key %s", f.Name())
+		}
+
+		kid := strings.TrimSuffix(f.Name(), ext)
+		keys[kid] = ringKey{private: key}
+
+		mod := f.ModTime()
+		if active == "" || mod.After(activeMod) || (mod.Equal(activeMod) && kid > active) {
+			active = kid
+			activeMod = mod
+		}
+	}
+
+	if active == "" {
+		return errors.Errorf("no keys in %s", k.dir)
+	}
+
+	for kid, key := range keys {
+		if kid != active {
+			key.expiresAt = activeMod.Add(k.grace)
+			keys[kid] = key
+		}
+	}
+
+	k.mu.Lock()
+	k.active = active
+	k.keys = keys
+	k.mu.Unlock()
+
+	return nil
+}
+
+// SigningKey returns the active key and its id.
+func (k *KeyRing) SigningKey() (string, crypto.Signer) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.active, k.keys[k.active].private
+}
+
+// PublicKey returns the public key to verify tokens by the key id.
+// It satisfies KeyFunc. Superseded keys are rejected after the grace period.
+func (k *KeyRing) PublicKey(kid string) (crypto.PublicKey, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unrecognized kid %q", kid)
+	}
+
+	if !key.expiresAt.IsZero() && key.expiresAt.Before(time.Now()) {
+		return nil, fmt.Errorf("expired kid %q", kid)
+	}
+
+	return key.private.Public(), nil
+}
+
+// PublicKeys returns public keys which can verify tokens by their ids.
+func (k *KeyRing) PublicKeys() map[string]crypto.PublicKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	now := time.Now()
+	keys := make(map[string]crypto.PublicKey, len(k.keys))
+	for kid, key := range k.keys {
+		if !key.expiresAt.IsZero() && key.expiresAt.Before(now) {
+			continue
+		}
+		keys[kid] = key.private.Public()
+	}
+
+	return keys
+}
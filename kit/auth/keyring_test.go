@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeKey(t *testing.T, dir, kid string, mod time.Time) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	data := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+
+	path := filepath.Join(dir, kid+".rsa")
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	if err := os.Chtimes(path, mod, mod); err != nil {
+		t.Fatalf("change key times: %v", err)
+	}
+
+	return key
+}
+
+func TestKeyRing(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keyring")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	writeKey(t, dir, "old", now.Add(-48*time.Hour))
+	writeKey(t, dir, "prev", now.Add(-2*time.Hour))
+	writeKey(t, dir, "cur", now.Add(-time.Hour))
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "cur.rsa.pub"), []byte("public"), 0600); err != nil {
+		t.Fatalf("write public key: %v", err)
+	}
+
+	ring, err := NewKeyRing(dir, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Log("\ttest:0\tshould sign with the newest key")
+	{
+		kid, key := ring.SigningKey()
+		assert.Equal(t, "cur", kid)
+		assert.NotNil(t, key)
+	}
+
+	t.Log("\ttest:1\tshould verify with keys in the grace period")
+	{
+		_, err := ring.PublicKey("cur")
+		assert.Nil(t, err)
+
+		_, err = ring.PublicKey("prev")
+		assert.Nil(t, err)
+
+		// Superseded keys are valid for the grace period after
+		// the active key appeared, not after they were created.
+		_, err = ring.PublicKey("old")
+		assert.Nil(t, err)
+
+		_, err = ring.PublicKey("unknown")
+		assert.Error(t, err)
+	}
+
+	t.Log("\ttest:2\tshould rotate keys on load")
+	{
+		writeKey(t, dir, "next", now)
+		assert.Nil(t, ring.Load())
+
+		kid, _ := ring.SigningKey()
+		assert.Equal(t, "next", kid)
+		assert.Len(t, ring.PublicKeys(), 4)
+	}
+
+	t.Log("\ttest:3\tshould expire superseded keys after the grace period")
+	{
+		ring.grace = 0
+		assert.Nil(t, ring.Load())
+
+		_, err := ring.PublicKey("cur")
+		assert.Error(t, err)
+
+		keys := ring.PublicKeys()
+		assert.Len(t, keys, 1)
+		assert.Contains(t, keys, "next")
+	}
+}
+
+func TestKeyRingEmptyDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keyring")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	_, err = NewKeyRing(dir, time.Hour)
+	assert.Error(t, err)
+}
+
+func TestRingAuthenticator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keyring")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	writeKey(t, dir, "first", now.Add(-time.Hour))
+
+	ring, err := NewKeyRing(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	a, err := NewRingAuthenticator(ring)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	tkn, err := a.GenerateToken(ctx, NewClaims("john", now, time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	writeKey(t, dir, "second", now)
+	if err := ring.Load(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Log("\ttest:0\tshould verify tokens signed before rotation")
+	{
+		claims, err := a.ParseClaims(ctx, tkn)
+		assert.Nil(t, err)
+		assert.Equal(t, "john", claims.Subject)
+	}
+
+	t.Log("\ttest:1\tshould publish all valid keys")
+	{
+		set := a.JWKS()
+		assert.Len(t, set.Keys, 2)
+		assert.Equal(t, "first", set.Keys[0].Kid)
+		assert.Equal(t, "RSA", set.Keys[0].Kty)
+		assert.Equal(t, "AQAB", set.Keys[0].E)
+	}
+
+	t.Log("\ttest:2\tshould rotate to a key of another type")
+	{
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("generate key: %v", err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, "third.pem"), encodeKey(t, key), 0600); err != nil {
+			t.Fatalf("write key: %v", err)
+		}
+		if err := os.Chtimes(filepath.Join(dir, "third.pem"), now.Add(time.Hour), now.Add(time.Hour)); err != nil {
+			t.Fatalf("change key times: %v", err)
+		}
+		assert.Nil(t, ring.Load())
+
+		edTkn, err := a.GenerateToken(ctx, NewClaims("john", now, time.Hour))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err = a.ParseClaims(ctx, edTkn)
+		assert.Nil(t, err)
+
+		_, err = a.ParseClaims(ctx, tkn)
+		assert.Nil(t, err)
+
+		set := a.JWKS()
+		assert.Len(t, set.Keys, 3)
+		assert.Equal(t, "OKP", set.Keys[2].Kty)
+		assert.Equal(t, AlgorithmEdDSA, set.Keys[2].Alg)
+	}
+}
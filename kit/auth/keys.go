@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Supported signing algorithms by key type.
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// ParsePrivateKey parses a PEM encoded RSA, ECDSA or Ed25519
+// private key in PKCS#1, SEC 1 or PKCS#8 form.
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("key must be PEM encoded")
+	}
+
+	var (
+		key interface{}
+		err error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, errors.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "parse key")
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.Errorf("unsupported key type %T", key)
+	}
+
+	if _, err := AlgorithmForKey(signer.Public()); err != nil {
+		return nil, err
+	}
+
+	return signer, nil
+}
+
+// AlgorithmForKey returns the signing algorithm for the public key.
+// RSA keys use RS256, ECDSA P-256 keys use ES256 and Ed25519 keys use EdDSA.
+func AlgorithmForKey(key crypto.PublicKey) (string, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return AlgorithmRS256, nil
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return "", errors.Errorf("unsupported curve %s", k.Curve.Params().Name)
+		}
+		return AlgorithmES256, nil
+	case ed25519.PublicKey:
+		return AlgorithmEdDSA, nil
+	default:
+		return "", errors.Errorf("unsupported key type %T", key)
+	}
+}
+
+// checkKey checks that the public key can be used with the algorithm.
+func checkKey(algorithm string, key crypto.PublicKey) error {
+	alg, err := AlgorithmForKey(key)
+	if err != nil {
+		return err
+	}
+
+	// All of RSA algorithms work with RSA keys.
+	if alg == AlgorithmRS256 && (strings.HasPrefix(algorithm, "RS") || strings.HasPrefix(algorithm, "PS")) {
+		return nil
+	}
+
+	if alg != algorithm {
+		return errors.Errorf("%s key cannot be used with %s algorithm", alg, algorithm)
+	}
+
+	return nil
+}
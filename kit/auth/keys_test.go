@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeKey(t *testing.T, key crypto.Signer) []byte {
+	var block pem.Block
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		block = pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}
+	case *ecdsa.PrivateKey:
+		b, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			t.Fatalf("marshal key: %v", err)
+		}
+		block = pem.Block{Type: "EC PRIVATE KEY", Bytes: b}
+	default:
+		b, err := x509.MarshalPKCS8PrivateKey(k)
+		if err != nil {
+			t.Fatalf("marshal key: %v", err)
+		}
+		block = pem.Block{Type: "PRIVATE KEY", Bytes: b}
+	}
+
+	return pem.EncodeToMemory(&block)
+}
+
+func generateKeys(t *testing.T) map[string]crypto.Signer {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	return map[string]crypto.Signer{
+		AlgorithmRS256: rsaKey,
+		AlgorithmES256: ecKey,
+		AlgorithmEdDSA: edKey,
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	for alg, key := range generateKeys(t) {
+		alg, key := alg, key
+		t.Run(alg, func(t *testing.T) {
+			parsed, err := ParsePrivateKey(encodeKey(t, key))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, err := AlgorithmForKey(parsed.Public())
+			assert.Nil(t, err)
+			assert.Equal(t, alg, got)
+		})
+	}
+
+	t.Run("unsupported curve", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		if err != nil {
+			t.Fatalf("generate key: %v", err)
+		}
+
+		_, err = ParsePrivateKey(encodeKey(t, key))
+		assert.Error(t, err)
+	})
+
+	t.Run("not PEM", func(t *testing.T) {
+		_, err := ParsePrivateKey([]byte("key"))
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthenticatorAlgorithms(t *testing.T) {
+	keys := generateKeys(t)
+	ctx := context.Background()
+
+	for alg, key := range keys {
+		alg, key := alg, key
+		t.Run(alg, func(t *testing.T) {
+			a, err := NewAuthenticator(key, "kid", alg, NewSingleKeyFunc("kid", key.Public()))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			tkn, err := a.GenerateToken(ctx, NewClaims("john", time.Now(), time.Hour))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			claims, err := a.ParseClaims(ctx, tkn)
+			assert.Nil(t, err)
+			assert.Equal(t, "john", claims.Subject)
+
+			set := a.JWKS()
+			assert.Len(t, set.Keys, 1)
+			assert.Equal(t, alg, set.Keys[0].Alg)
+		})
+	}
+
+	t.Run("mismatched key", func(t *testing.T) {
+		_, err := NewAuthenticator(keys[AlgorithmEdDSA], "kid", AlgorithmES256, NewSingleKeyFunc("kid", keys[AlgorithmEdDSA].Public()))
+		assert.Error(t, err)
+	})
+}
+
+func TestNewJWKS(t *testing.T) {
+	keys := generateKeys(t)
+
+	set := NewJWKS("", map[string]crypto.PublicKey{
+		"ec": keys[AlgorithmES256].Public(),
+		"ed": keys[AlgorithmEdDSA].Public(),
+	})
+	assert.Len(t, set.Keys, 2)
+
+	ec := set.Keys[0]
+	assert.Equal(t, "EC", ec.Kty)
+	assert.Equal(t, "P-256", ec.Crv)
+	assert.Equal(t, AlgorithmES256, ec.Alg)
+	assert.Len(t, ec.X, 43)
+	assert.Len(t, ec.Y, 43)
+
+	ed := set.Keys[1]
+	assert.Equal(t, "OKP", ed.Kty)
+	assert.Equal(t, "Ed25519", ed.Crv)
+	assert.Equal(t, AlgorithmEdDSA, ed.Alg)
+	assert.Len(t, ed.X, 43)
+	assert.Empty(t, ed.N)
+}
+
+func TestJWKPublicKey(t *testing.T) {
+	keys := generateKeys(t)
+
+	for alg, key := range keys {
+		set := NewJWKS("", map[string]crypto.PublicKey{"kid": key.Public()})
+		if !assert.Len(t, set.Keys, 1, alg) {
+			continue
+		}
+
+		got, err := set.Keys[0].PublicKey()
+		assert.Nil(t, err, alg)
+		assert.Equal(t, key.Public(), got, alg)
+	}
+
+	t.Run("unsupported key type", func(t *testing.T) {
+		_, err := JWK{Kty: "oct"}.PublicKey()
+		assert.Error(t, err)
+	})
+
+	t.Run("point not on the curve", func(t *testing.T) {
+		_, err := JWK{Kty: "EC", Crv: "P-256", X: "AQ", Y: "AQ"}.PublicKey()
+		assert.Error(t, err)
+	})
+}
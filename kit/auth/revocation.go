@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// RevocationStore keeps revoked token ids and the times
+// before which all tokens of a subject are revoked.
+type RevocationStore interface {
+	RevokeToken(ctx context.Context, id string, expiresAt time.Time) error
+	TokenRevoked(ctx context.Context, id string) (bool, error)
+	RevokeSubject(ctx context.Context, subject string, after time.Time) error
+	SubjectValidAfter(ctx context.Context, subject string) (time.Time, error)
+}
+
+// MemoryStore is an in-memory RevocationStore. It keeps a limited
+// number of recently revoked entries and forgets the least recently
+// used ones, so it suits a single instance with a short token lifetime.
+type MemoryStore struct {
+	mu       sync.Mutex
+	tokens   *lru
+	subjects *lru
+}
+
+// NewMemoryStore creates a store which holds up to size
+// revoked tokens and size revoked subjects.
+func NewMemoryStore(size int) *MemoryStore {
+	s := MemoryStore{
+		tokens:   newLRU(size),
+		subjects: newLRU(size),
+	}
+
+	return &s
+}
+
+// RevokeToken revokes the token until it expires.
+func (s *MemoryStore) RevokeToken(ctx context.Context, id string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens.add(id, expiresAt)
+	return nil
+}
+
+// TokenRevoked checks that the token is revoked.
+func (s *MemoryStore) TokenRevoked(ctx context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.tokens.get(id)
+	if !ok {
+		return false, nil
+	}
+
+	// Expired tokens are rejected anyway.
+	if expiresAt.Before(time.Now()) {
+		s.tokens.remove(id)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// RevokeSubject revokes all tokens of the subject issued before after.
+func (s *MemoryStore) RevokeSubject(ctx context.Context, subject string, after time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.subjects.add(subject, after)
+	return nil
+}
+
+// SubjectValidAfter returns the time before which tokens
+// of the subject are revoked. Zero time means none are.
+func (s *MemoryStore) SubjectValidAfter(ctx context.Context, subject string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	after, _ := s.subjects.get(subject)
+	return after, nil
+}
+
+// lru is a least recently used cache of times.
+// It is not safe for concurrent use.
+type lru struct {
+	size  int
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value time.Time
+}
+
+func newLRU(size int) *lru {
+	l := lru{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+
+	return &l
+}
+
+func (l *lru) add(key string, value time.Time) {
+	if e, ok := l.items[key]; ok {
+		l.ll.MoveToFront(e)
+		e.Value.(*lruEntry).value = value
+		return
+	}
+
+	l.items[key] = l.ll.PushFront(&lruEntry{key: key, value: value})
+	if l.ll.Len() > l.size {
+		oldest := l.ll.Back()
+		l.ll.Remove(oldest)
+		delete(l.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+func (l *lru) get(key string) (time.Time, bool) {
+	e, ok := l.items[key]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	l.ll.MoveToFront(e)
+	return e.Value.(*lruEntry).value, true
+}
+
+func (l *lru) remove(key string) {
+	if e, ok := l.items[key]; ok {
+		l.ll.Remove(e)
+		delete(l.items, key)
+	}
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreTokens(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(2)
+	exp := time.Now().Add(time.Hour)
+
+	assert.Nil(t, s.RevokeToken(ctx, "a", exp))
+	assert.Nil(t, s.RevokeToken(ctx, "b", exp))
+
+	// Touch a, so b becomes the least recently used.
+	revoked, err := s.TokenRevoked(ctx, "a")
+	assert.Nil(t, err)
+	assert.True(t, revoked)
+
+	assert.Nil(t, s.RevokeToken(ctx, "c", exp))
+
+	tests := []struct {
+		id      string
+		revoked bool
+	}{
+		{id: "a", revoked: true},
+		{id: "b", revoked: false},
+		{id: "c", revoked: true},
+		{id: "d", revoked: false},
+	}
+
+	for _, tc := range tests {
+		revoked, err := s.TokenRevoked(ctx, tc.id)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.revoked, revoked, tc.id)
+	}
+}
+
+func TestMemoryStoreExpiredToken(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(2)
+
+	assert.Nil(t, s.RevokeToken(ctx, "a", time.Now().Add(-time.Second)))
+
+	revoked, err := s.TokenRevoked(ctx, "a")
+	assert.Nil(t, err)
+	assert.False(t, revoked)
+}
+
+func TestMemoryStoreSubjects(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(2)
+	now := time.Now()
+
+	after, err := s.SubjectValidAfter(ctx, "john")
+	assert.Nil(t, err)
+	assert.True(t, after.IsZero())
+
+	assert.Nil(t, s.RevokeSubject(ctx, "john", now))
+
+	after, err = s.SubjectValidAfter(ctx, "john")
+	assert.Nil(t, err)
+	assert.Equal(t, now, after)
+}
+
+func TestNewClaimsID(t *testing.T) {
+	now := time.Now()
+	a := NewClaims("john", now, time.Hour)
+	b := NewClaims("john", now, time.Hour)
+
+	assert.Len(t, a.Id, 32)
+	assert.NotEqual(t, a.Id, b.Id)
+}
@@ -0,0 +1,25 @@
+// Package markdown renders markdown written by users to HTML
+// which is safe to embed into pages.
+package markdown
+
+import (
+	"github.com/russross/blackfriday/v2"
+)
+
+// flags of the HTML renderer. Links of unknown protocols
+// are not rendered and links of users are not endorsed.
+const flags = blackfriday.CommonHTMLFlags | blackfriday.Safelink | blackfriday.NofollowLinks
+
+// Render converts the markdown source to HTML. Raw HTML
+// of the source is allowed, the output is sanitized anyway.
+func Render(src string) string {
+	r := blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+		Flags: flags,
+	})
+
+	out := blackfriday.Run([]byte(src),
+		blackfriday.WithRenderer(r),
+		blackfriday.WithExtensions(blackfriday.CommonExtensions))
+
+	return Sanitize(string(out))
+}
@@ -0,0 +1,49 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		expect string
+	}{
+		{
+			name:   "paragraph",
+			src:    "Some *text* and **bold**.",
+			expect: "<p>Some <em>text</em> and <strong>bold</strong>.</p>\n",
+		},
+		{
+			name:   "link",
+			src:    "[link](https://example.com)",
+			expect: "<p><a href=\"https://example.com\" rel=\"nofollow\">link</a></p>\n",
+		},
+		{
+			name:   "code",
+			src:    "```go\nfmt.Println(\"<b>\")\n```",
+			expect: "<pre><code class=\"language-go\">fmt.Println(&#34;&lt;b&gt;&#34;)\n</code></pre>\n",
+		},
+		{
+			name:   "script",
+			src:    "<script>alert(1)</script>hi",
+			expect: "<p>hi</p>\n",
+		},
+		{
+			name:   "unsafe link",
+			src:    "<a href=\" javascript:alert(1)\">x</a>",
+			expect: "<p><a rel=\"nofollow\">x</a></p>\n",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expect, Render(tc.src))
+		})
+	}
+}
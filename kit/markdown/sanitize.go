@@ -0,0 +1,208 @@
+package markdown
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// elements lists allowed elements with their allowed attributes.
+// Everything else is dropped keeping the text inside.
+var elements = map[string][]string{
+	"a":          {"href", "title"},
+	"blockquote": nil,
+	"br":         nil,
+	"code":       {"class"},
+	"dd":         nil,
+	"del":        nil,
+	"dl":         nil,
+	"dt":         nil,
+	"em":         nil,
+	"h1":         nil,
+	"h2":         nil,
+	"h3":         nil,
+	"h4":         nil,
+	"h5":         nil,
+	"h6":         nil,
+	"hr":         nil,
+	"img":        {"src", "alt", "title"},
+	"li":         nil,
+	"ol":         {"start"},
+	"p":          nil,
+	"pre":        nil,
+	"strong":     nil,
+	"sub":        nil,
+	"sup":        nil,
+	"table":      nil,
+	"tbody":      nil,
+	"td":         {"align"},
+	"th":         {"align"},
+	"thead":      nil,
+	"tr":         nil,
+	"ul":         nil,
+}
+
+// voids are the allowed elements without content.
+var voids = map[string]bool{
+	"br":  true,
+	"hr":  true,
+	"img": true,
+}
+
+// hidden are the elements dropped together with their content.
+var hidden = map[string]bool{
+	"iframe":   true,
+	"noscript": true,
+	"object":   true,
+	"script":   true,
+	"style":    true,
+	"template": true,
+	"textarea": true,
+	"title":    true,
+}
+
+// schemes lists allowed schemes of links and images.
+// Links without scheme are relative to the page.
+var schemes = map[string]bool{
+	"":       true,
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+}
+
+var (
+	classRegexp  = regexp.MustCompile(`^language-[\w+#-]+$`)
+	numberRegexp = regexp.MustCompile(`^[0-9]{1,9}$`)
+	alignRegexp  = regexp.MustCompile(`^(left|center|right)$`)
+)
+
+// Sanitize drops elements and attributes which are not allowed from
+// the HTML, so it can't run scripts or change the page around it.
+// Open elements are closed, so the output is always balanced.
+func Sanitize(s string) string {
+	var b strings.Builder
+	var open []string
+	var skip int
+
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			for i := len(open) - 1; i >= 0; i-- {
+				b.WriteString("</" + open[i] + ">")
+			}
+			return b.String()
+		case html.TextToken:
+			if skip == 0 {
+				b.WriteString(html.EscapeString(string(z.Text())))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			t := z.Token()
+			if hidden[t.Data] {
+				if tt == html.StartTagToken {
+					skip++
+				}
+				continue
+			}
+			if skip > 0 {
+				continue
+			}
+
+			attrs, ok := elements[t.Data]
+			if !ok {
+				continue
+			}
+
+			b.WriteString("<" + t.Data)
+			for _, a := range t.Attr {
+				if v, ok := attr(t.Data, a, attrs); ok {
+					b.WriteString(" " + a.Key + `="` + html.EscapeString(v) + `"`)
+				}
+			}
+			if t.Data == "a" {
+				b.WriteString(` rel="nofollow"`)
+			}
+			b.WriteString(">")
+
+			if !voids[t.Data] {
+				open = append(open, t.Data)
+			}
+		case html.EndTagToken:
+			t := z.Token()
+			if hidden[t.Data] {
+				if skip > 0 {
+					skip--
+				}
+				continue
+			}
+			if skip > 0 {
+				continue
+			}
+
+			// Close the elements opened after this one as well,
+			// the end tags without start tags are dropped.
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] != t.Data {
+					continue
+				}
+				for j := len(open) - 1; j >= i; j-- {
+					b.WriteString("</" + open[j] + ">")
+				}
+				open = open[:i]
+				break
+			}
+		}
+	}
+}
+
+// attr returns the value of the attribute if it's allowed.
+func attr(element string, a html.Attribute, allowed []string) (string, bool) {
+	if a.Namespace != "" {
+		return "", false
+	}
+
+	found := false
+	for _, key := range allowed {
+		if key == a.Key {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return "", false
+	}
+
+	switch a.Key {
+	case "href", "src":
+		return safeURL(a.Val)
+	case "class":
+		return a.Val, element == "code" && classRegexp.MatchString(a.Val)
+	case "start":
+		return a.Val, numberRegexp.MatchString(a.Val)
+	case "align":
+		return a.Val, alignRegexp.MatchString(a.Val)
+	}
+
+	return a.Val, true
+}
+
+// safeURL returns the url if its scheme is allowed.
+// Browsers ignore spaces around urls, so they are
+// trimmed before the scheme is checked.
+func safeURL(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", false
+	}
+
+	if !schemes[strings.ToLower(u.Scheme)] {
+		return "", false
+	}
+
+	return s, true
+}
@@ -0,0 +1,79 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name   string
+		html   string
+		expect string
+	}{
+		{
+			name:   "allowed",
+			html:   `<p>a <strong>b</strong> <a href="/posts/1" title="t">c</a></p>`,
+			expect: `<p>a <strong>b</strong> <a href="/posts/1" title="t" rel="nofollow">c</a></p>`,
+		},
+		{
+			name:   "unknown element",
+			html:   `<div><span>text</span></div>`,
+			expect: `text`,
+		},
+		{
+			name:   "hidden element",
+			html:   `<p>a<style>p { color: red }</style><script>alert(1)</script>b</p>`,
+			expect: `<p>ab</p>`,
+		},
+		{
+			name:   "event handler",
+			html:   `<img src="https://example.com/a.png" onerror="alert(1)">`,
+			expect: `<img src="https://example.com/a.png">`,
+		},
+		{
+			name:   "javascript url",
+			html:   `<a href="JavaScript:alert(1)">a</a><a href="jav&#x09;ascript:alert(1)">b</a>`,
+			expect: `<a rel="nofollow">a</a><a rel="nofollow">b</a>`,
+		},
+		{
+			name:   "data url",
+			html:   `<img src="data:image/svg+xml;base64,PHN2Zz4=">`,
+			expect: `<img>`,
+		},
+		{
+			name:   "code class",
+			html:   `<code class="language-go">a</code><code class="x">b</code><p class="x">c</p>`,
+			expect: `<code class="language-go">a</code><code>b</code><p>c</p>`,
+		},
+		{
+			name:   "unbalanced",
+			html:   `<p><em>a</p>b</strong>`,
+			expect: `<p><em>a</em></p>b`,
+		},
+		{
+			name:   "unclosed",
+			html:   `<blockquote><p>a`,
+			expect: `<blockquote><p>a</p></blockquote>`,
+		},
+		{
+			name:   "comment",
+			html:   `a<!-- <script>alert(1)</script> -->b`,
+			expect: `ab`,
+		},
+		{
+			name:   "text",
+			html:   `1 &lt; 2 & "3"`,
+			expect: `1 &lt; 2 &amp; &#34;3&#34;`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expect, Sanitize(tc.html))
+		})
+	}
+}
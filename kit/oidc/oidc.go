@@ -0,0 +1,387 @@
+// Package oidc implements the authorization code flow with PKCE
+// of OpenID Connect relying parties.
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/pkg/errors"
+)
+
+// easyjson oidc.go
+
+const (
+	// discoveryPath is the path of the provider configuration
+	// relative to the issuer.
+	discoveryPath = "/.well-known/openid-configuration"
+	// keysRefresh is how often keys are fetched again
+	// at most when a token is signed by an unknown key.
+	keysRefresh = time.Minute
+	// timeout limits requests to providers.
+	timeout = 10 * time.Second
+)
+
+// DefaultScopes are requested unless scopes of the provider are set.
+var DefaultScopes = []string{"openid", "email", "profile"}
+
+var (
+	// ErrInvalidGrant returns when the provider
+	// rejects the authorization code.
+	ErrInvalidGrant = errors.New("invalid grant")
+	// ErrInvalidToken returns when the id token is malformed,
+	// expired, signed by an unknown key or issued for
+	// another client or nonce.
+	ErrInvalidToken = errors.New("invalid id token")
+)
+
+// Configuration is the discovery document of a provider.
+//easyjson:json
+type Configuration struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// TokenResponse is a response of the token endpoint.
+//easyjson:json
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+}
+
+// Audience is the aud claim, which
+// is either a string or an array.
+type Audience []string
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*a = Audience{s}
+		return nil
+	}
+
+	var ss []string
+	if err := json.Unmarshal(data, &ss); err != nil {
+		return errors.Wrap(err, "unmarshal audience")
+	}
+	*a = ss
+	return nil
+}
+
+// Contains checks that the audience contains the client.
+func (a Audience) Contains(clientID string) bool {
+	for _, aud := range a {
+		if aud == clientID {
+			return true
+		}
+	}
+	return false
+}
+
+// Claims are claims of id tokens.
+type Claims struct {
+	Issuer            string   `json:"iss"`
+	Subject           string   `json:"sub"`
+	Audience          Audience `json:"aud"`
+	ExpiresAt         int64    `json:"exp"`
+	IssuedAt          int64    `json:"iat"`
+	Nonce             string   `json:"nonce"`
+	Email             string   `json:"email"`
+	EmailVerified     bool     `json:"email_verified"`
+	PreferredUsername string   `json:"preferred_username"`
+	Name              string   `json:"name"`
+}
+
+// Valid implements jwt.Claims interface.
+func (c *Claims) Valid() error {
+	if time.Now().Unix() > c.ExpiresAt {
+		return errors.New("token is expired")
+	}
+	return nil
+}
+
+// Provider is an OpenID Connect provider. The configuration and
+// keys are discovered on the first use, so the provider being
+// down doesn't prevent the start of the application.
+type Provider struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	Client       *http.Client
+
+	mu        sync.Mutex
+	config    *Configuration
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// NewProvider prepares the provider of the issuer for the client.
+func NewProvider(issuer, clientID, clientSecret, redirectURL string) *Provider {
+	p := Provider{
+		Issuer:       strings.TrimSuffix(issuer, "/"),
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       DefaultScopes,
+		Client:       &http.Client{Timeout: timeout},
+	}
+
+	return &p
+}
+
+// GenerateVerifier generates a PKCE code verifier. It's random
+// enough to be used as state and nonce values as well.
+func GenerateVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "read random")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Challenge returns the S256 code challenge of the verifier.
+func Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AuthCodeURL returns the URL of the provider
+// where the user is redirected to sign in.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	config, err := p.discover(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "discover")
+	}
+
+	u, err := url.Parse(config.AuthorizationEndpoint)
+	if err != nil {
+		return "", errors.Wrap(err, "parse authorization endpoint")
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.ClientID)
+	q.Set("redirect_uri", p.RedirectURL)
+	q.Set("scope", strings.Join(p.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", Challenge(verifier))
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// Exchange exchanges the authorization code
+// for tokens and returns the id token.
+func (p *Provider) Exchange(ctx context.Context, code, verifier string) (string, error) {
+	config, err := p.discover(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "discover")
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.RedirectURL},
+		"client_id":     {p.ClientID},
+		"code_verifier": {verifier},
+	}
+
+	req, err := http.NewRequest("POST", config.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", errors.Wrap(err, "new request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.ClientID), url.QueryEscape(p.ClientSecret))
+	}
+
+	data, status, err := p.do(req.WithContext(ctx))
+	if err != nil {
+		return "", errors.Wrap(err, "token request")
+	}
+	switch {
+	case status == http.StatusBadRequest || status == http.StatusUnauthorized:
+		return "", ErrInvalidGrant
+	case status != http.StatusOK:
+		return "", errors.Errorf("token endpoint responded with %d", status)
+	}
+
+	var t TokenResponse
+	if err := t.UnmarshalJSON(data); err != nil {
+		return "", errors.Wrap(err, "unmarshal token response")
+	}
+	if t.IDToken == "" {
+		return "", ErrInvalidToken
+	}
+
+	return t.IDToken, nil
+}
+
+// Verify verifies the signature and claims of the id token
+// issued by the provider for the client with the nonce.
+func (p *Provider) Verify(ctx context.Context, rawIDToken, nonce string) (*Claims, error) {
+	config, err := p.discover(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "discover")
+	}
+
+	// Only asymmetric algorithms are allowed, so the
+	// client secret can't be used to forge tokens.
+	parser := jwt.Parser{
+		ValidMethods: []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"},
+	}
+
+	var keyErr error
+	f := func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+
+		key, err := p.key(ctx, config, kid)
+		if err != nil {
+			keyErr = err
+			return nil, err
+		}
+		return key, nil
+	}
+
+	var claims Claims
+	if _, err := parser.ParseWithClaims(rawIDToken, &claims, f); err != nil {
+		if keyErr != nil && errors.Cause(keyErr) != ErrInvalidToken {
+			return nil, errors.Wrap(keyErr, "key")
+		}
+		return nil, ErrInvalidToken
+	}
+
+	if claims.Issuer != config.Issuer || !claims.Audience.Contains(p.ClientID) ||
+		claims.Nonce != nonce || claims.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return &claims, nil
+}
+
+// discover fetches the configuration of the provider once.
+func (p *Provider) discover(ctx context.Context) (*Configuration, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.config != nil {
+		return p.config, nil
+	}
+
+	req, err := http.NewRequest("GET", p.Issuer+discoveryPath, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "new request")
+	}
+
+	data, status, err := p.do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "discovery request")
+	}
+	if status != http.StatusOK {
+		return nil, errors.Errorf("discovery responded with %d", status)
+	}
+
+	var config Configuration
+	if err := config.UnmarshalJSON(data); err != nil {
+		return nil, errors.Wrap(err, "unmarshal configuration")
+	}
+
+	if strings.TrimSuffix(config.Issuer, "/") != p.Issuer {
+		return nil, errors.Errorf("unexpected issuer %s", config.Issuer)
+	}
+
+	p.config = &config
+	return p.config, nil
+}
+
+// key returns the public key by id. Keys are fetched again when
+// the key is unknown, as providers rotate them.
+func (p *Provider) key(ctx context.Context, config *Configuration, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+
+	if time.Since(p.fetchedAt) < keysRefresh {
+		return nil, ErrInvalidToken
+	}
+
+	req, err := http.NewRequest("GET", config.JWKSURI, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "new request")
+	}
+
+	data, status, err := p.do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "keys request")
+	}
+	if status != http.StatusOK {
+		return nil, errors.Errorf("keys responded with %d", status)
+	}
+
+	var set auth.JWKS
+	if err := set.UnmarshalJSON(data); err != nil {
+		return nil, errors.Wrap(err, "unmarshal keys")
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	p.keys = keys
+	p.fetchedAt = time.Now()
+
+	key, ok := p.keys[kid]
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	return key, nil
+}
+
+// do sends the request and reads the response.
+func (p *Provider) do(req *http.Request) ([]byte, int, error) {
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "read body")
+	}
+
+	return data, resp.StatusCode, nil
+}
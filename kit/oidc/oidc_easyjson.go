@@ -0,0 +1,221 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package oidc
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonA689914bDecodeGithubComDipressBlogKitOidc(in *jlexer.Lexer, out *TokenResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "access_token":
+			out.AccessToken = string(in.String())
+		case "token_type":
+			out.TokenType = string(in.String())
+		case "id_token":
+			out.IDToken = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA689914bEncodeGithubComDipressBlogKitOidc(out *jwriter.Writer, in TokenResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"access_token\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.AccessToken))
+	}
+	{
+		const prefix string = ",\"token_type\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.TokenType))
+	}
+	{
+		const prefix string = ",\"id_token\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.IDToken))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TokenResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA689914bEncodeGithubComDipressBlogKitOidc(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TokenResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA689914bEncodeGithubComDipressBlogKitOidc(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TokenResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA689914bDecodeGithubComDipressBlogKitOidc(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TokenResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA689914bDecodeGithubComDipressBlogKitOidc(l, v)
+}
+func easyjsonA689914bDecodeGithubComDipressBlogKitOidc1(in *jlexer.Lexer, out *Configuration) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "issuer":
+			out.Issuer = string(in.String())
+		case "authorization_endpoint":
+			out.AuthorizationEndpoint = string(in.String())
+		case "token_endpoint":
+			out.TokenEndpoint = string(in.String())
+		case "jwks_uri":
+			out.JWKSURI = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA689914bEncodeGithubComDipressBlogKitOidc1(out *jwriter.Writer, in Configuration) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"issuer\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Issuer))
+	}
+	{
+		const prefix string = ",\"authorization_endpoint\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.AuthorizationEndpoint))
+	}
+	{
+		const prefix string = ",\"token_endpoint\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.TokenEndpoint))
+	}
+	{
+		const prefix string = ",\"jwks_uri\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.JWKSURI))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Configuration) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA689914bEncodeGithubComDipressBlogKitOidc1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Configuration) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA689914bEncodeGithubComDipressBlogKitOidc1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Configuration) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA689914bDecodeGithubComDipressBlogKitOidc1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Configuration) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA689914bDecodeGithubComDipressBlogKitOidc1(l, v)
+}
@@ -0,0 +1,128 @@
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/dipress/blog/kit/oidc"
+	"github.com/dipress/blog/kit/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+)
+
+const redirectURL = "http://127.0.0.1/callback"
+
+// authorize follows the authorization URL and returns the code.
+func authorize(t *testing.T, authURL string) string {
+	client := http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("unexpected status code: %d expected: %d", resp.StatusCode, http.StatusFound)
+	}
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return location.Query().Get("code")
+}
+
+func TestProvider(t *testing.T) {
+	srv := oidctest.NewServer("client", "secret")
+	defer srv.Close()
+
+	srv.SignIn(oidctest.User{
+		Subject:       "1234",
+		Email:         "user@example.com",
+		EmailVerified: true,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := oidc.NewProvider(srv.URL, "client", "secret", redirectURL)
+
+	verifier, err := oidc.GenerateVerifier()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		authURL, err := p.AuthCodeURL(ctx, "state", "nonce", verifier)
+		assert.Nil(t, err)
+
+		idToken, err := p.Exchange(ctx, authorize(t, authURL), verifier)
+		assert.Nil(t, err)
+
+		claims, err := p.Verify(ctx, idToken, "nonce")
+		assert.Nil(t, err)
+		assert.Equal(t, "1234", claims.Subject)
+		assert.Equal(t, "user@example.com", claims.Email)
+		assert.True(t, claims.EmailVerified)
+	})
+
+	t.Run("wrong verifier", func(t *testing.T) {
+		authURL, err := p.AuthCodeURL(ctx, "state", "nonce", verifier)
+		assert.Nil(t, err)
+
+		_, err = p.Exchange(ctx, authorize(t, authURL), "wrong verifier")
+		assert.Equal(t, oidc.ErrInvalidGrant, err)
+	})
+
+	t.Run("used code", func(t *testing.T) {
+		authURL, err := p.AuthCodeURL(ctx, "state", "nonce", verifier)
+		assert.Nil(t, err)
+
+		code := authorize(t, authURL)
+		_, err = p.Exchange(ctx, code, verifier)
+		assert.Nil(t, err)
+
+		_, err = p.Exchange(ctx, code, verifier)
+		assert.Equal(t, oidc.ErrInvalidGrant, err)
+	})
+
+	t.Run("wrong nonce", func(t *testing.T) {
+		authURL, err := p.AuthCodeURL(ctx, "state", "nonce", verifier)
+		assert.Nil(t, err)
+
+		idToken, err := p.Exchange(ctx, authorize(t, authURL), verifier)
+		assert.Nil(t, err)
+
+		_, err = p.Verify(ctx, idToken, "other nonce")
+		assert.Equal(t, oidc.ErrInvalidToken, err)
+	})
+
+	t.Run("other client", func(t *testing.T) {
+		authURL, err := p.AuthCodeURL(ctx, "state", "nonce", verifier)
+		assert.Nil(t, err)
+
+		idToken, err := p.Exchange(ctx, authorize(t, authURL), verifier)
+		assert.Nil(t, err)
+
+		other := oidc.NewProvider(srv.URL, "other", "secret", redirectURL)
+		_, err = other.Verify(ctx, idToken, "nonce")
+		assert.Equal(t, oidc.ErrInvalidToken, err)
+	})
+
+	t.Run("malformed token", func(t *testing.T) {
+		_, err := p.Verify(ctx, "malformed", "nonce")
+		assert.Equal(t, oidc.ErrInvalidToken, err)
+	})
+}
+
+func TestChallenge(t *testing.T) {
+	// The example of RFC 7636 appendix B.
+	got := oidc.Challenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", got)
+}
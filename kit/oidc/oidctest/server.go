@@ -0,0 +1,209 @@
+// Package oidctest provides a stand-in OpenID Connect provider for tests.
+// It signs in the configured user on every authorization request.
+package oidctest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/dipress/blog/kit/auth"
+	"github.com/dipress/blog/kit/oidc"
+)
+
+const keyID = "oidctest"
+
+// User is the user signed in by the provider.
+type User struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	PreferredUsername string
+	Name              string
+}
+
+// grant is an issued authorization code.
+type grant struct {
+	clientID    string
+	redirectURI string
+	challenge   string
+	nonce       string
+	user        User
+}
+
+// Server is a stand-in provider. The issuer is the URL of the server.
+type Server struct {
+	*httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	key *rsa.PrivateKey
+
+	mu     sync.Mutex
+	user   User
+	grants map[string]grant
+}
+
+// NewServer starts the provider for the client. Close it when done.
+func NewServer(clientID, clientSecret string) *Server {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	s := Server{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		key:          key,
+		grants:       make(map[string]grant),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.configuration)
+	mux.HandleFunc("/keys", s.keys)
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	s.Server = httptest.NewServer(mux)
+
+	return &s
+}
+
+// SignIn sets the user signed in by next authorization requests.
+func (s *Server) SignIn(u User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.user = u
+}
+
+func (s *Server) configuration(w http.ResponseWriter, r *http.Request) {
+	config := oidc.Configuration{
+		Issuer:                s.URL,
+		AuthorizationEndpoint: s.URL + "/authorize",
+		TokenEndpoint:         s.URL + "/token",
+		JWKSURI:               s.URL + "/keys",
+	}
+
+	data, _ := config.MarshalJSON()
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func (s *Server) keys(w http.ResponseWriter, r *http.Request) {
+	set := auth.NewJWKS(auth.AlgorithmRS256, map[string]crypto.PublicKey{keyID: s.key.Public()})
+
+	data, _ := set.MarshalJSON()
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+// authorize redirects back to the client with a code at once.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != s.ClientID || q.Get("response_type") != "code" ||
+		q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	redirect, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil {
+		http.Error(w, "invalid redirect uri", http.StatusBadRequest)
+		return
+	}
+
+	code := random()
+
+	s.mu.Lock()
+	s.grants[code] = grant{
+		clientID:    s.ClientID,
+		redirectURI: q.Get("redirect_uri"),
+		challenge:   q.Get("code_challenge"),
+		nonce:       q.Get("nonce"),
+		user:        s.user,
+	}
+	s.mu.Unlock()
+
+	rq := redirect.Query()
+	rq.Set("code", code)
+	rq.Set("state", q.Get("state"))
+	redirect.RawQuery = rq.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// token exchanges a code for an id token once.
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, `{"error": "invalid_request"}`, http.StatusBadRequest)
+		return
+	}
+
+	id, secret, _ := r.BasicAuth()
+	id, _ = url.QueryUnescape(id)
+	secret, _ = url.QueryUnescape(secret)
+	if id != s.ClientID || secret != s.ClientSecret {
+		http.Error(w, `{"error": "invalid_client"}`, http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	g, ok := s.grants[r.PostForm.Get("code")]
+	delete(s.grants, r.PostForm.Get("code"))
+	s.mu.Unlock()
+
+	if !ok || r.PostForm.Get("grant_type") != "authorization_code" ||
+		g.redirectURI != r.PostForm.Get("redirect_uri") ||
+		g.challenge != oidc.Challenge(r.PostForm.Get("code_verifier")) {
+		http.Error(w, `{"error": "invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":                s.URL,
+		"sub":                g.user.Subject,
+		"aud":                []string{g.clientID},
+		"exp":                now.Add(time.Hour).Unix(),
+		"iat":                now.Unix(),
+		"nonce":              g.nonce,
+		"email":              g.user.Email,
+		"email_verified":     g.user.EmailVerified,
+		"preferred_username": g.user.PreferredUsername,
+		"name":               g.user.Name,
+	}
+
+	tkn := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	tkn.Header["kid"] = keyID
+
+	idToken, err := tkn.SignedString(s.key)
+	if err != nil {
+		http.Error(w, `{"error": "server_error"}`, http.StatusInternalServerError)
+		return
+	}
+
+	t := oidc.TokenResponse{
+		AccessToken: random(),
+		TokenType:   "Bearer",
+		IDToken:     idToken,
+	}
+
+	data, _ := t.MarshalJSON()
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func random() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
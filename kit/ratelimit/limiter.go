@@ -0,0 +1,152 @@
+// Package ratelimit limits the rate of requests by key with token buckets.
+package ratelimit
+
+import (
+	"container/list"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Rate is a number of requests allowed per period.
+type Rate struct {
+	Requests int
+	Per      time.Duration
+}
+
+// ParseRate parses a rate of the format `<requests>/<period>`,
+// e.g. `100/1m`. Zero requests disable the limit.
+func ParseRate(s string) (Rate, error) {
+	split := strings.Split(s, "/")
+	if len(split) != 2 {
+		return Rate{}, errors.Errorf("expected rate format: <requests>/<period>, got %q", s)
+	}
+
+	requests, err := strconv.Atoi(split[0])
+	if err != nil || requests < 0 {
+		return Rate{}, errors.Errorf("invalid number of requests %q", split[0])
+	}
+
+	per, err := time.ParseDuration(split[1])
+	if err != nil || per <= 0 {
+		return Rate{}, errors.Errorf("invalid period %q", split[1])
+	}
+
+	return Rate{Requests: requests, Per: per}, nil
+}
+
+// String implements fmt.Stringer interface.
+func (r Rate) String() string {
+	return fmt.Sprintf("%d/%s", r.Requests, r.Per)
+}
+
+// Result is a decision of the limiter about the request.
+type Result struct {
+	Allowed bool
+	// Limit is the size of the bucket.
+	Limit int
+	// Remaining is the number of requests left in the bucket.
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next request
+	// is allowed when this one is not.
+	RetryAfter time.Duration
+}
+
+// Limiter is an in-memory token bucket rate limiter. Every key gets a
+// bucket of Requests tokens which is refilled evenly during the period,
+// so short bursts are allowed and the average rate is limited. It keeps
+// a limited number of keys, so it suits a single instance. The bucket
+// which was used least recently makes room for a new key.
+type Limiter struct {
+	mu      sync.Mutex
+	rate    Rate
+	size    int
+	buckets map[string]*list.Element
+	// recent orders buckets from the most recently used.
+	recent *list.List
+	now    func() time.Time
+}
+
+type bucket struct {
+	key    string
+	tokens float64
+	at     time.Time
+}
+
+// NewLimiter creates a limiter which holds up to size keys.
+func NewLimiter(rate Rate, size int) *Limiter {
+	l := Limiter{
+		rate:    rate,
+		size:    size,
+		buckets: make(map[string]*list.Element),
+		recent:  list.New(),
+		now:     time.Now,
+	}
+
+	return &l
+}
+
+// Allow takes a token from the bucket of the key. The request
+// is allowed when there was one. A limiter with zero requests
+// allows everything.
+func (l *Limiter) Allow(key string) Result {
+	if l.rate.Requests == 0 {
+		return Result{Allowed: true}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	limit := float64(l.rate.Requests)
+	perToken := l.rate.Per / time.Duration(l.rate.Requests)
+
+	var b *bucket
+	if e, ok := l.buckets[key]; ok {
+		l.recent.MoveToFront(e)
+		b = e.Value.(*bucket)
+	} else {
+		if len(l.buckets) >= l.size {
+			l.evict()
+		}
+		b = &bucket{key: key, tokens: limit, at: now}
+		l.buckets[key] = l.recent.PushFront(b)
+	}
+
+	// Refill tokens for the time passed since the last request.
+	b.tokens = math.Min(limit, b.tokens+float64(now.Sub(b.at))/float64(perToken))
+	b.at = now
+
+	res := Result{
+		Limit: l.rate.Requests,
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = time.Duration((1 - b.tokens) * float64(perToken))
+	}
+
+	res.Remaining = int(b.tokens)
+	res.Reset = time.Duration((limit - b.tokens) * float64(perToken))
+
+	return res
+}
+
+// evict removes the bucket which was used least recently.
+func (l *Limiter) evict() {
+	e := l.recent.Back()
+	if e == nil {
+		return
+	}
+	l.recent.Remove(e)
+	delete(l.buckets, e.Value.(*bucket).key)
+}
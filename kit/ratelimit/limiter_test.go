@@ -0,0 +1,125 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rate    string
+		expect  Rate
+		wantErr bool
+	}{
+		{
+			name:   "ok",
+			rate:   "100/1m",
+			expect: Rate{Requests: 100, Per: time.Minute},
+		},
+		{
+			name:   "disabled",
+			rate:   "0/1s",
+			expect: Rate{Per: time.Second},
+		},
+		{
+			name:    "wrong format",
+			rate:    "100",
+			wantErr: true,
+		},
+		{
+			name:    "wrong requests",
+			rate:    "ten/1m",
+			wantErr: true,
+		},
+		{
+			name:    "wrong period",
+			rate:    "100/0s",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseRate(tc.rate)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expect, got)
+		})
+	}
+}
+
+func TestLimiterAllow(t *testing.T) {
+	now := time.Now()
+	l := NewLimiter(Rate{Requests: 2, Per: 2 * time.Second}, 10)
+	l.now = func() time.Time { return now }
+
+	res := l.Allow("a")
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 2, res.Limit)
+	assert.Equal(t, 1, res.Remaining)
+	assert.Equal(t, time.Second, res.Reset)
+
+	res = l.Allow("a")
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+
+	t.Log("\ttest:0\tshould reject requests over the limit.")
+	{
+		res := l.Allow("a")
+		assert.False(t, res.Allowed)
+		assert.Equal(t, time.Second, res.RetryAfter)
+		assert.Equal(t, 2*time.Second, res.Reset)
+	}
+
+	t.Log("\ttest:1\tshould keep buckets of keys apart.")
+	{
+		res := l.Allow("b")
+		assert.True(t, res.Allowed)
+	}
+
+	t.Log("\ttest:2\tshould refill the bucket.")
+	{
+		now = now.Add(time.Second)
+		res := l.Allow("a")
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 0, res.Remaining)
+	}
+}
+
+func TestLimiterDisabled(t *testing.T) {
+	l := NewLimiter(Rate{Per: time.Second}, 10)
+
+	for i := 0; i < 100; i++ {
+		assert.True(t, l.Allow("a").Allowed)
+	}
+}
+
+func TestLimiterEviction(t *testing.T) {
+	now := time.Now()
+	l := NewLimiter(Rate{Requests: 1, Per: time.Minute}, 2)
+	l.now = func() time.Time { return now }
+
+	for _, key := range []string{"a", "b", "c"} {
+		assert.True(t, l.Allow(key).Allowed, key)
+		now = now.Add(time.Second)
+	}
+
+	// The bucket of a was used first, so it was
+	// evicted and a gets a new one.
+	assert.True(t, l.Allow("a").Allowed)
+	assert.False(t, l.Allow("c").Allowed)
+
+	// The bucket of c was created before the one of a,
+	// but used after it, so a makes room for d.
+	assert.True(t, l.Allow("d").Allowed)
+	assert.False(t, l.Allow("c").Allowed)
+	assert.Len(t, l.buckets, 2)
+}
@@ -0,0 +1,105 @@
+// Package totp implements time-based one-time passwords of RFC 6238
+// with the defaults of authenticator apps: HMAC-SHA1, 6 digits and
+// a 30 seconds period.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Digits is the length of codes.
+	Digits = 6
+	// modulus cuts values to Digits.
+	modulus = 1000000
+	// Period is how long a code is valid.
+	Period = 30 * time.Second
+
+	secretSize = 20
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random base32 encoded secret.
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "read random")
+	}
+
+	return encoding.EncodeToString(b), nil
+}
+
+// URI returns the otpauth URI of the secret,
+// which authenticator apps scan as a QR code.
+func URI(issuer, account, secret string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(Digits))
+	v.Set("period", fmt.Sprint(int(Period.Seconds())))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: v.Encode(),
+	}
+
+	return u.String()
+}
+
+// Step returns the number of the period at t.
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period.Seconds())
+}
+
+// Code returns the code of the secret at the step.
+func Code(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", errors.Wrap(err, "decode secret")
+	}
+
+	// HOTP of RFC 4226 with the step as the counter.
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0xf
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", Digits, value%modulus), nil
+}
+
+// Validate checks the code at t, allowing skew periods before and
+// after it for clock drift. It returns the step the code matches,
+// so callers can reject codes which were already used.
+func Validate(secret, code string, t time.Time, skew int) (int64, bool, error) {
+	now := Step(t)
+	for i := -skew; i <= skew; i++ {
+		want, err := Code(secret, now+int64(i))
+		if err != nil {
+			return 0, false, errors.Wrap(err, "code")
+		}
+		if hmac.Equal([]byte(want), []byte(code)) {
+			return now + int64(i), true, nil
+		}
+	}
+
+	return 0, false, nil
+}
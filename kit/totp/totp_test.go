@@ -0,0 +1,63 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCode(t *testing.T) {
+	// Test vectors of RFC 6238 for SHA1 cut to 6 digits.
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		time int64
+		code string
+	}{
+		{time: 59, code: "287082"},
+		{time: 1111111109, code: "081804"},
+		{time: 1111111111, code: "050471"},
+		{time: 1234567890, code: "005924"},
+		{time: 2000000000, code: "279037"},
+	}
+
+	for _, tc := range tests {
+		code, err := Code(secret, Step(time.Unix(tc.time, 0)))
+		assert.Nil(t, err)
+		assert.Equal(t, tc.code, code, tc.time)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.Nil(t, err)
+
+	now := time.Now()
+	code, err := Code(secret, Step(now.Add(-Period)))
+	assert.Nil(t, err)
+
+	step, ok, err := Validate(secret, code, now, 1)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, Step(now)-1, step)
+
+	_, ok, err = Validate(secret, code, now.Add(Period), 1)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	_, _, err = Validate("not base32!", code, now, 1)
+	assert.Error(t, err)
+}
+
+func TestURI(t *testing.T) {
+	u, err := url.Parse(URI("blog", "john", "SECRET"))
+	assert.Nil(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/blog:john", u.Path)
+	assert.Equal(t, "SECRET", u.Query().Get("secret"))
+	assert.Equal(t, "blog", u.Query().Get("issuer"))
+}